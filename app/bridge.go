@@ -17,6 +17,7 @@ import (
 	"github.com/sleuth-io/sx/v2/internal/manifest"
 	"github.com/sleuth-io/sx/v2/internal/metadata"
 	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/stats"
 	"github.com/sleuth-io/sx/v2/internal/utils"
 	vaultpkg "github.com/sleuth-io/sx/v2/internal/vault"
 )
@@ -79,6 +80,8 @@ func (a *App) startup(ctx context.Context) {
 
 func (a *App) shutdown(ctx context.Context) {
 	a.saveWindowState(ctx)
+	// Audit events not delivered by now stay buffered for the next flush
+	stats.WaitAuditExports(2 * time.Second)
 }
 
 // OpenSettings asks the frontend to show the settings view. Wired to the
@@ -137,7 +137,7 @@ export interface ConsolidateResult {
 export interface BenchmarkRecord {
   /** RFC3339 timestamp of the run. */
   at: string;
-  source: "app" | "server";
+  source: "app" | "cli" | "server";
   executor: { provider: string; model: string };
   runs_per_config: number;
   /** Who ran it (vault identity or skills.new user). */
@@ -151,7 +151,7 @@ export interface BenchmarkRecord {
   };
   per_eval?: { eval_key: string; with_pass: number; without_pass: number; status: string }[];
   notes?: string[];
-  /** App records: the content hash the run was benchmarked against. */
+  /** App and CLI records: the content hash the run was benchmarked against. */
   skill_hash?: string;
   /** Server records: the benchmarked version and whether it is still
    * the asset's current one — the staleness signal. */
@@ -170,7 +170,7 @@ export interface QualityRecord {
   /** RFC3339 timestamp of the evaluation (server records: approximated
    * from the asset's update time). */
   at?: string;
-  source: "app" | "server";
+  source: "app" | "cli" | "server";
   /** Who ran it (vault identity or skills.new user). */
   by?: string;
   /** App records: which provider/model evaluated. */
@@ -194,7 +194,7 @@ export interface QualityRecord {
     recommendations: string[];
   };
   stats?: { file_count?: number; word_count?: number };
-  /** App records: the content hash evaluated — the staleness signal. */
+  /** App and CLI records: the content hash evaluated — the staleness signal. */
   skill_hash?: string;
 }
 
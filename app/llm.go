@@ -5,11 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/sleuth-io/sx/v2/internal/llm"
 	"github.com/sleuth-io/sx/v2/internal/logger"
-	"github.com/sleuth-io/sx/v2/internal/utils"
 )
 
 // sx.llm core service (API 1.9.0, docs/app-plugins-spec.md). Sandboxed
@@ -22,9 +20,6 @@ import (
 // library state; API keys live in the OS keyring, never on disk and
 // never in the webview.
 
-// llmConfigFile holds the provider selection under the sx config dir.
-const llmConfigFile = "llm.json"
-
 // llmKeyringAccount namespaces provider API keys in the same keyring
 // service as extension secrets ("sx-app-plugins") so they show up
 // together — and are revocable together — in the OS keychain UI.
@@ -32,7 +27,7 @@ const llmConfigFile = "llm.json"
 // entered once in settings, and refusing to write it to disk beats
 // working headless.
 func llmKeyringAccount(provider string) string {
-	return "llm-provider/" + provider
+	return llm.KeyringAccount(provider)
 }
 
 var llmProviderIDs = map[string]bool{
@@ -50,30 +45,11 @@ func (a *App) llmCtx() context.Context {
 }
 
 func llmConfigPath() (string, error) {
-	dir, err := utils.GetConfigDir()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(dir, llmConfigFile), nil
+	return llm.ConfigPath()
 }
 
 func loadLLMConfig() (llm.Config, error) {
-	path, err := llmConfigPath()
-	if err != nil {
-		return llm.Config{}, err
-	}
-	data, err := os.ReadFile(path) // #nosec G304 -- fixed name under the sx config dir
-	if os.IsNotExist(err) {
-		return llm.Config{}, nil
-	}
-	if err != nil {
-		return llm.Config{}, err
-	}
-	var cfg llm.Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		return llm.Config{}, fmt.Errorf("corrupt %s: %w", llmConfigFile, err)
-	}
-	return cfg, nil
+	return llm.LoadConfig()
 }
 
 // LLMStatusView is everything the settings panel needs in one call.
@@ -158,7 +134,7 @@ func (a *App) llmProvider() (llm.Provider, error) {
 		return nil, err
 	}
 	var apiKey string
-	if cfg.Provider == llm.ProviderAnthropic || cfg.Provider == llm.ProviderOpenAI || cfg.Provider == llm.ProviderGoogle {
+	if llm.NeedsAPIKey(cfg.Provider) {
 		v, ok, kerr := activePluginSecretStore.Get(llmKeyringAccount(cfg.Provider))
 		if kerr != nil {
 			return nil, fmt.Errorf("could not read the API key from the OS keychain: %w", kerr)
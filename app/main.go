@@ -2,6 +2,7 @@ package main
 
 import (
 	"embed"
+	"errors"
 	"log"
 	goruntime "runtime"
 
@@ -12,22 +13,48 @@ import (
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
 	"github.com/wailsapp/wails/v2/pkg/options/mac"
 
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	_ "github.com/sleuth-io/sx/v2/internal/clients/aider"          // Register Aider client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/claude_code"    // Register Claude Code client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/cline"          // Register Cline client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/codex"          // Register Codex client
+	_ "github.com/sleuth-io/sx/v2/internal/clients/continuedev"    // Register Continue client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/cursor"         // Register Cursor client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/gemini"         // Register Gemini Code Assist client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/github_copilot" // Register GitHub Copilot client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/kiro"           // Register Kiro client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/openclaw"       // Register OpenClaw client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/opencode"       // Register OpenCode client
+	_ "github.com/sleuth-io/sx/v2/internal/clients/profile"        // Build clients declared in the config
+	_ "github.com/sleuth-io/sx/v2/internal/clients/windsurf"       // Register Windsurf client
+	_ "github.com/sleuth-io/sx/v2/internal/clients/zed"            // Register Zed client
 	"github.com/sleuth-io/sx/v2/internal/config"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/signing"
+	"github.com/sleuth-io/sx/v2/internal/stats"
 )
 
 //go:embed all:frontend/dist
 var assets embed.FS
 
 func main() {
+	// Register clients declared in the config alongside the built-in ones
+	if err := clients.LoadProfiles(); err != nil {
+		log.Printf("failed to load client profiles: %v", err)
+	}
+
+	// Sign audit events with the user's signing key, if they have one
+	if key, err := signing.LoadKey(); err == nil {
+		mgmt.SetAuditSigner(key)
+	} else if !errors.Is(err, signing.ErrNoKey) {
+		log.Printf("failed to load signing key for audit events: %v", err)
+	}
+
+	// Mirror audit events to the OTLP endpoint, if one is configured
+	if err := stats.RegisterAuditExporter(); err != nil {
+		log.Printf("failed to configure OTLP audit export: %v", err)
+	}
+
 	app := NewApp()
 
 	// Native menu. macOS gets the standard app menu (with Settings… living
@@ -11,6 +11,7 @@ import (
 	"github.com/zalando/go-keyring"
 
 	"github.com/sleuth-io/sx/v2/internal/config"
+	"github.com/sleuth-io/sx/v2/internal/llm"
 	"github.com/sleuth-io/sx/v2/internal/logger"
 )
 
@@ -27,7 +28,7 @@ import (
 // pluginKeyringService is the logical "app" key in the OS keyring.
 // Distinct from the sx-cloud-relay service so extension entries are
 // recognizable (and revocable) in the OS keychain UI.
-const pluginKeyringService = "sx-app-plugins"
+const pluginKeyringService = llm.KeyringService
 
 var pluginSecretNamePattern = regexp.MustCompile(`^[a-z][a-z0-9._-]{0,63}$`)
 
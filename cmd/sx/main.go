@@ -1,28 +1,39 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/sleuth-io/sx/v2/internal/autoupdate"
 	"github.com/sleuth-io/sx/v2/internal/buildinfo"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	_ "github.com/sleuth-io/sx/v2/internal/clients/aider"          // Register Aider client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/claude_code"    // Register Claude Code client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/cline"          // Register Cline client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/codex"          // Register Codex client
+	_ "github.com/sleuth-io/sx/v2/internal/clients/continuedev"    // Register Continue client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/cursor"         // Register Cursor client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/gemini"         // Register Gemini Code Assist client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/github_copilot" // Register GitHub Copilot client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/kiro"           // Register Kiro client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/openclaw"       // Register OpenClaw client
 	_ "github.com/sleuth-io/sx/v2/internal/clients/opencode"       // Register OpenCode client
+	_ "github.com/sleuth-io/sx/v2/internal/clients/profile"        // Build clients declared in the config
+	_ "github.com/sleuth-io/sx/v2/internal/clients/windsurf"       // Register Windsurf client
+	_ "github.com/sleuth-io/sx/v2/internal/clients/zed"            // Register Zed client
 	"github.com/sleuth-io/sx/v2/internal/commands"
 	"github.com/sleuth-io/sx/v2/internal/config"
 	"github.com/sleuth-io/sx/v2/internal/git"
 	"github.com/sleuth-io/sx/v2/internal/logger"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/signing"
+	"github.com/sleuth-io/sx/v2/internal/stats"
 	"github.com/sleuth-io/sx/v2/internal/ui"
 	"github.com/sleuth-io/sx/v2/internal/ui/theme"
 )
@@ -89,6 +100,10 @@ func colorizeFlags(render func(...string) string) func(string) string {
 	}
 }
 
+// auditExportGrace is how long sx waits on exit for audit events still
+// being sent to the OTLP endpoint
+const auditExportGrace = 2 * time.Second
+
 func main() {
 	// Log command invocation with context
 	log := logger.Get()
@@ -145,6 +160,24 @@ Capture what your best AI users have learned and spread it to everyone automatic
 			if profile, _ := cmd.Flags().GetString("profile"); profile != "" {
 				config.SetActiveProfile(profile)
 			}
+
+			// Register clients declared in the config alongside the built-in ones
+			if err := clients.LoadProfiles(); err != nil {
+				log.Warn("failed to load client profiles", "error", err)
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+
+			// Sign audit events with the user's signing key, if they have one
+			if key, err := signing.LoadKey(); err == nil {
+				mgmt.SetAuditSigner(key)
+			} else if !errors.Is(err, signing.ErrNoKey) {
+				log.Warn("failed to load signing key for audit events", "error", err)
+			}
+
+			// Mirror audit events to the OTLP endpoint, if one is configured
+			if err := stats.RegisterAuditExporter(); err != nil {
+				log.Warn("failed to configure OTLP audit export", "error", err)
+			}
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Default command: run install if lock file exists
@@ -230,11 +263,23 @@ Use "{{muted (printf "%s [command] --help" .CommandPath)}}" for more information
 	rootCmd.AddCommand(commands.NewTeamCommand())
 	rootCmd.AddCommand(commands.NewBotCommand())
 	rootCmd.AddCommand(commands.NewOrgCommand())
+	rootCmd.AddCommand(commands.NewChangeCommand())
+	rootCmd.AddCommand(commands.NewChannelCommand())
+	rootCmd.AddCommand(commands.NewSigningCommand())
+	rootCmd.AddCommand(commands.NewLintCommand())
+	rootCmd.AddCommand(commands.NewQualityCommand())
+	rootCmd.AddCommand(commands.NewBenchCommand())
 	rootCmd.AddCommand(commands.NewStatsCommand())
 	rootCmd.AddCommand(commands.NewAuditCommand())
 	rootCmd.AddCommand(commands.NewCloudCommand())
 
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+
+	// Give audit events sent in the background a moment to reach the
+	// OTLP endpoint; the rest stay buffered for the next flush
+	stats.WaitAuditExports(auditExportGrace)
+
+	if err != nil {
 		// Print error with styling
 		styledOut := ui.NewOutput(os.Stdout, os.Stderr)
 		styledOut.Error(err.Error())
@@ -1,16 +1,34 @@
 package assets
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"slices"
+	"sort"
+	"strings"
 
 	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/version"
 )
 
+// VersionLister lists the published versions of an asset and describes
+// any one of them as a lock file row, with its source, content hashes and
+// dependencies.
+type VersionLister interface {
+	GetVersionList(ctx context.Context, name string) ([]string, error)
+	LockAssetVersion(ctx context.Context, name, version string) (*lockfile.Asset, error)
+}
+
+// maxResolveRounds bounds how often moving rows may bring in new
+// dependency ranges before Resolve gives up
+const maxResolveRounds = 10
+
 // DependencyResolver resolves asset dependencies
 type DependencyResolver struct {
-	assets map[string]*lockfile.Asset
+	assets   map[string]*lockfile.Asset
+	versions VersionLister
+	changed  bool
 }
 
 // NewDependencyResolver creates a new dependency resolver
@@ -25,9 +43,46 @@ func NewDependencyResolver(lockFile *lockfile.LockFile) *DependencyResolver {
 	}
 }
 
-// Resolve resolves dependencies and returns assets in topological order
-func (r *DependencyResolver) Resolve(assets []*lockfile.Asset) ([]*lockfile.Asset, error) {
-	// Build dependency graph
+// WithVersions lets Resolve pick a dependency version from the vault's
+// published versions instead of only the version in the lock file.
+func (r *DependencyResolver) WithVersions(versions VersionLister) *DependencyResolver {
+	r.versions = versions
+	return r
+}
+
+// Changed reports whether the last Resolve rewrote the lock file.
+func (r *DependencyResolver) Changed() bool {
+	return r.changed
+}
+
+// Resolve resolves dependencies and returns assets in topological order.
+// Version ranges are resolved first (see resolveVersions). Moving a row to
+// another version can change its dependencies, so the graph is rebuilt and
+// resolved again until no row moves.
+func (r *DependencyResolver) Resolve(ctx context.Context, assets []*lockfile.Asset) ([]*lockfile.Asset, error) {
+	r.changed = false
+	for round := 0; ; round++ {
+		graph, inDegree, assetSet, err := r.buildGraph(assets)
+		if err != nil {
+			return nil, err
+		}
+		moved, err := r.resolveVersions(ctx, assetSet)
+		if err != nil {
+			return nil, err
+		}
+		if moved {
+			if round == maxResolveRounds {
+				return nil, fmt.Errorf("dependency versions did not settle after %d rounds", maxResolveRounds)
+			}
+			continue
+		}
+		return sortByDependencies(graph, inDegree, assetSet)
+	}
+}
+
+// buildGraph collects assets and their transitive dependencies from the
+// lock file, with an edge from each dependency to its dependents
+func (r *DependencyResolver) buildGraph(assets []*lockfile.Asset) (map[string][]string, map[string]int, map[string]*lockfile.Asset, error) {
 	graph := make(map[string][]string)
 	inDegree := make(map[string]int)
 	assetSet := make(map[string]*lockfile.Asset)
@@ -46,7 +101,7 @@ func (r *DependencyResolver) Resolve(assets []*lockfile.Asset) ([]*lockfile.Asse
 			if assetSet[dep.Name] == nil {
 				// Dependency not in the set, try to find it in the full assets map
 				if r.assets[dep.Name] == nil {
-					return nil, fmt.Errorf("dependency not found: %s (required by %s)", dep.Name, asset.Name)
+					return nil, nil, nil, fmt.Errorf("dependency not found: %s (required by %s)", dep.Name, asset.Name)
 				}
 				// Add the dependency to the set
 				depAsset := r.assets[dep.Name]
@@ -56,7 +111,7 @@ func (r *DependencyResolver) Resolve(assets []*lockfile.Asset) ([]*lockfile.Asse
 
 				// Recursively add dependencies of the dependency
 				if err := r.addDependenciesRecursive(depAsset, graph, inDegree, assetSet); err != nil {
-					return nil, err
+					return nil, nil, nil, err
 				}
 			}
 
@@ -66,6 +121,12 @@ func (r *DependencyResolver) Resolve(assets []*lockfile.Asset) ([]*lockfile.Asse
 		}
 	}
 
+	return graph, inDegree, assetSet, nil
+}
+
+// sortByDependencies orders assetSet so dependencies come before their
+// dependents
+func sortByDependencies(graph map[string][]string, inDegree map[string]int, assetSet map[string]*lockfile.Asset) ([]*lockfile.Asset, error) {
 	// Topological sort using Kahn's algorithm
 	var result []*lockfile.Asset
 	var queue []string
@@ -135,6 +196,122 @@ func (r *DependencyResolver) addDependenciesRecursive(asset *lockfile.Asset, gra
 	return nil
 }
 
+// DependencyRequirement is one dependent's version requirement on a dependency.
+type DependencyRequirement struct {
+	From  string // dependent, as name@version
+	Range string
+}
+
+// DependencyConflictError reports a dependency no published version can
+// satisfy: either the dependents' ranges are incompatible with each other,
+// or a single range matches nothing that has been published.
+type DependencyConflictError struct {
+	Name         string
+	Requirements []DependencyRequirement
+	Available    []string
+}
+
+func (e *DependencyConflictError) Error() string {
+	var b strings.Builder
+	if len(e.Requirements) > 1 {
+		fmt.Fprintf(&b, "dependency conflict: no version of %s satisfies every dependent", e.Name)
+	} else {
+		fmt.Fprintf(&b, "dependency conflict: no version of %s satisfies the required range", e.Name)
+	}
+	for _, req := range e.Requirements {
+		fmt.Fprintf(&b, "\n  %s requires %s %s", req.From, e.Name, req.Range)
+	}
+	if len(e.Available) > 0 {
+		fmt.Fprintf(&b, "\n  available versions: %s", strings.Join(e.Available, ", "))
+	} else {
+		b.WriteString("\n  no versions are published")
+	}
+	return b.String()
+}
+
+// resolveVersions picks one version per dependency that satisfies every
+// dependent's version requirement: the highest match among the vault's
+// published versions (when a VersionLister is set) and the lock file's own
+// row. When the pick differs from the row's version, the row is replaced
+// in place by the vault's description of the picked version — source,
+// content hashes, type, clients and dependencies — keeping its scopes.
+// Dependents keep their declared ranges, so a later resolve can move to a
+// newer matching release. It returns whether any row moved.
+func (r *DependencyResolver) resolveVersions(ctx context.Context, assetSet map[string]*lockfile.Asset) (bool, error) {
+	names := make([]string, 0, len(assetSet))
+	for name := range assetSet {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	reqs := make(map[string][]DependencyRequirement)
+	for _, name := range names {
+		a := assetSet[name]
+		for _, dep := range a.Dependencies {
+			if dep.Version == "" || assetSet[dep.Name] == nil {
+				continue
+			}
+			reqs[dep.Name] = append(reqs[dep.Name], DependencyRequirement{From: a.Name + "@" + a.Version, Range: dep.Version})
+		}
+	}
+
+	moved := false
+	for _, name := range names {
+		depReqs := reqs[name]
+		if len(depReqs) == 0 {
+			continue
+		}
+		row := assetSet[name]
+		candidates := []string{row.Version}
+		if r.versions != nil {
+			published, err := r.versions.GetVersionList(ctx, name)
+			if err != nil {
+				return false, fmt.Errorf("failed to list versions of %s: %w", name, err)
+			}
+			for _, v := range published {
+				if !slices.Contains(candidates, v) {
+					candidates = append(candidates, v)
+				}
+			}
+		}
+		candidates = version.Sort(candidates)
+
+		picked := ""
+		for i := len(candidates) - 1; i >= 0 && picked == ""; i-- {
+			if satisfiesAll(candidates[i], depReqs) {
+				picked = candidates[i]
+			}
+		}
+		if picked == "" {
+			return false, &DependencyConflictError{Name: name, Requirements: depReqs, Available: candidates}
+		}
+
+		if picked != row.Version {
+			fresh, err := r.versions.LockAssetVersion(ctx, name, picked)
+			if err != nil {
+				return false, fmt.Errorf("failed to load %s@%s: %w", name, picked, err)
+			}
+			scopes := row.Scopes
+			*row = *fresh
+			row.Name = name
+			row.Version = picked
+			row.Scopes = scopes
+			moved = true
+			r.changed = true
+		}
+	}
+	return moved, nil
+}
+
+func satisfiesAll(v string, reqs []DependencyRequirement) bool {
+	for _, req := range reqs {
+		if !version.Satisfies(req.Range, v) {
+			return false
+		}
+	}
+	return true
+}
+
 // contains checks if a slice contains a string
 func contains(slice []string, item string) bool {
 	return slices.Contains(slice, item)
@@ -157,8 +334,8 @@ func ValidateDependencies(lockFile *lockfile.LockFile) error {
 			// If version is specified, check it matches
 			if dep.Version != "" {
 				foundAsset := assetMap[dep.Name]
-				if foundAsset.Version != dep.Version {
-					return fmt.Errorf("asset %s requires %s@%s, but lock file has %s", asset.Name, dep.Name, dep.Version, foundAsset.Version)
+				if !version.Satisfies(dep.Version, foundAsset.Version) {
+					return fmt.Errorf("asset %s requires %s %s, but lock file has %s", asset.Name, dep.Name, dep.Version, foundAsset.Version)
 				}
 			}
 		}
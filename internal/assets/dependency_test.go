@@ -0,0 +1,227 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+)
+
+type fakeVersions map[string][]string
+
+func (f fakeVersions) GetVersionList(_ context.Context, name string) ([]string, error) {
+	return f[name], nil
+}
+
+func (f fakeVersions) LockAssetVersion(_ context.Context, name, version string) (*lockfile.Asset, error) {
+	return publishedRow(name, version), nil
+}
+
+// fakeVault also gives published versions dependencies, keyed name@version
+type fakeVault struct {
+	fakeVersions
+	deps map[string][]lockfile.Dependency
+}
+
+func (f fakeVault) LockAssetVersion(_ context.Context, name, version string) (*lockfile.Asset, error) {
+	return publishedRow(name, version, f.deps[name+"@"+version]...), nil
+}
+
+func publishedRow(name, version string, deps ...lockfile.Dependency) *lockfile.Asset {
+	return &lockfile.Asset{
+		Name:         name,
+		Version:      version,
+		Type:         asset.TypeSkill,
+		Dependencies: deps,
+		SourceHTTP: &lockfile.SourceHTTP{
+			URL:    "https://vault.example/" + name + "/" + version + ".zip",
+			Hashes: map[string]string{"sha256": "sha-" + name + "-" + version},
+		},
+	}
+}
+
+func depLock(assets ...lockfile.Asset) *lockfile.LockFile {
+	return &lockfile.LockFile{Assets: assets}
+}
+
+func skillRow(name, version string, deps ...lockfile.Dependency) lockfile.Asset {
+	return lockfile.Asset{
+		Name:         name,
+		Version:      version,
+		Type:         asset.TypeSkill,
+		Dependencies: deps,
+		SourcePath:   &lockfile.SourcePath{Path: "./assets/" + name + "/" + version},
+	}
+}
+
+// TestResolve_PicksHighestSatisfyingVersion: a range resolves to the
+// highest published version inside it, not to the lock file's latest row.
+// The pick moves the dependency's row; the dependent keeps its range.
+func TestResolve_PicksHighestSatisfyingVersion(t *testing.T) {
+	lf := depLock(
+		skillRow("formatter", "3.0.0"),
+		skillRow("reviewer", "1.0.0", lockfile.Dependency{Name: "formatter", Version: "^1.2"}),
+	)
+	versions := fakeVersions{"formatter": {"1.0.0", "1.2.0", "1.4.1", "2.0.0", "3.0.0"}}
+
+	sorted, err := NewDependencyResolver(lf).WithVersions(versions).Resolve(context.Background(), []*lockfile.Asset{&lf.Assets[1]})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if len(sorted) != 2 || sorted[0].Name != "formatter" || sorted[1].Name != "reviewer" {
+		t.Fatalf("want formatter before reviewer, got %+v", sorted)
+	}
+	if sorted[0].Version != "1.4.1" {
+		t.Errorf("formatter version = %s, want 1.4.1", sorted[0].Version)
+	}
+	if src := sorted[0].SourceHTTP; src == nil || src.Hashes["sha256"] != "sha-formatter-1.4.1" {
+		t.Errorf("a moved row must take the vault's source and hashes for 1.4.1, got %+v", src)
+	}
+	if got := lf.Assets[1].Dependencies[0].Version; got != "^1.2" {
+		t.Errorf("dependent's requirement = %q, want the declared ^1.2", got)
+	}
+}
+
+// TestResolve_ResolvedLockMovesToNewerRelease: a lock file that was
+// already resolved still follows the range when a newer matching version
+// is published.
+func TestResolve_ResolvedLockMovesToNewerRelease(t *testing.T) {
+	lf := depLock(
+		skillRow("formatter", "1.0.0"),
+		skillRow("reviewer", "1.0.0", lockfile.Dependency{Name: "formatter", Version: "^1.2"}),
+	)
+	versions := fakeVersions{"formatter": {"1.0.0", "1.2.0"}}
+	if _, err := NewDependencyResolver(lf).WithVersions(versions).Resolve(context.Background(), []*lockfile.Asset{&lf.Assets[1]}); err != nil {
+		t.Fatalf("first Resolve: %v", err)
+	}
+	if lf.Assets[0].Version != "1.2.0" {
+		t.Fatalf("formatter = %s after first resolve, want 1.2.0", lf.Assets[0].Version)
+	}
+
+	versions["formatter"] = append(versions["formatter"], "1.3.0", "2.0.0")
+	resolver := NewDependencyResolver(lf).WithVersions(versions)
+	if _, err := resolver.Resolve(context.Background(), []*lockfile.Asset{&lf.Assets[1]}); err != nil {
+		t.Fatalf("second Resolve: %v", err)
+	}
+	if lf.Assets[0].Version != "1.3.0" || !resolver.Changed() {
+		t.Errorf("formatter = %s (changed %v), want it moved to 1.3.0", lf.Assets[0].Version, resolver.Changed())
+	}
+}
+
+// TestResolve_MovedRowTakesVersionDependencies: moving a row across a
+// release that added a dependency pulls in that dependency with its range
+// resolved, and the row's scopes survive the move.
+func TestResolve_MovedRowTakesVersionDependencies(t *testing.T) {
+	lib := skillRow("lib", "1.0.0", lockfile.Dependency{Name: "legacy", Version: "^1"})
+	lib.Scopes = []lockfile.Scope{{Repo: "github.com/acme/app"}}
+	lf := depLock(
+		lib,
+		skillRow("legacy", "1.0.0"),
+		skillRow("util", "1.0.0"),
+		skillRow("app", "1.0.0", lockfile.Dependency{Name: "lib", Version: "^2"}),
+	)
+	versions := fakeVault{
+		fakeVersions: fakeVersions{"lib": {"1.0.0", "2.0.0"}, "util": {"1.0.0", "1.3.0", "2.0.0"}},
+		deps:         map[string][]lockfile.Dependency{"lib@2.0.0": {{Name: "util", Version: "^1"}}},
+	}
+
+	resolver := NewDependencyResolver(lf).WithVersions(versions)
+	sorted, err := resolver.Resolve(context.Background(), []*lockfile.Asset{&lf.Assets[3]})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	var names []string
+	for _, a := range sorted {
+		names = append(names, a.Name+"@"+a.Version)
+	}
+	if strings.Join(names, " ") != "util@1.3.0 lib@2.0.0 app@1.0.0" {
+		t.Fatalf("resolved %v, want util@1.3.0 lib@2.0.0 app@1.0.0", names)
+	}
+	moved := lf.Assets[0]
+	if len(moved.Dependencies) != 1 || moved.Dependencies[0].Name != "util" || moved.Dependencies[0].Version != "^1" {
+		t.Errorf("lib@2.0.0 dependencies = %+v, want util with its ^1 range", moved.Dependencies)
+	}
+	if moved.SourceHTTP == nil || moved.SourceHTTP.Hashes["sha256"] != "sha-lib-2.0.0" || moved.SourcePath != nil {
+		t.Errorf("lib@2.0.0 source = %+v / %+v, want the vault's hashed source", moved.SourceHTTP, moved.SourcePath)
+	}
+	if len(moved.Scopes) != 1 || moved.Scopes[0].Repo != "github.com/acme/app" {
+		t.Errorf("lib scopes = %+v, want them kept", moved.Scopes)
+	}
+	if !resolver.Changed() {
+		t.Error("Changed() = false after moving rows")
+	}
+}
+
+// TestResolve_KeepsLockRowWhenItIsHighest: when the lock file's row is
+// already the best match, it is kept with its source intact.
+func TestResolve_KeepsLockRowWhenItIsHighest(t *testing.T) {
+	lf := depLock(
+		skillRow("formatter", "2.1.0"),
+		skillRow("reviewer", "1.0.0", lockfile.Dependency{Name: "formatter", Version: ">=2.0 <3"}),
+	)
+	versions := fakeVersions{"formatter": {"1.0.0", "2.0.0", "2.1.0"}}
+
+	sorted, err := NewDependencyResolver(lf).WithVersions(versions).Resolve(context.Background(), []*lockfile.Asset{&lf.Assets[1]})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if sorted[0].Version != "2.1.0" || sorted[0].GetSourceType() != "path" {
+		t.Errorf("formatter = %s (%s), want 2.1.0 from its path source", sorted[0].Version, sorted[0].GetSourceType())
+	}
+}
+
+// TestResolve_IncompatibleRangesReportConflict: two dependents whose
+// ranges don't overlap fail with a report naming both.
+func TestResolve_IncompatibleRangesReportConflict(t *testing.T) {
+	lf := depLock(
+		skillRow("formatter", "2.0.0"),
+		skillRow("reviewer", "1.0.0", lockfile.Dependency{Name: "formatter", Version: "^1.2"}),
+		skillRow("linter", "4.0.0", lockfile.Dependency{Name: "formatter", Version: ">=2.0 <3"}),
+	)
+	versions := fakeVersions{"formatter": {"1.2.0", "2.0.0"}}
+
+	_, err := NewDependencyResolver(lf).WithVersions(versions).Resolve(context.Background(), []*lockfile.Asset{&lf.Assets[1], &lf.Assets[2]})
+	var conflict *DependencyConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("err = %v, want DependencyConflictError", err)
+	}
+	msg := err.Error()
+	for _, want := range []string{"reviewer@1.0.0 requires formatter ^1.2", "linter@4.0.0 requires formatter >=2.0 <3", "1.2.0, 2.0.0"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("conflict report missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+// TestResolve_WithoutVersionsUsesLockRow: with no version source, a range
+// is checked against the lock file's row alone.
+func TestResolve_WithoutVersionsUsesLockRow(t *testing.T) {
+	lf := depLock(
+		skillRow("formatter", "2.0.0"),
+		skillRow("reviewer", "1.0.0", lockfile.Dependency{Name: "formatter", Version: "^1.2"}),
+	)
+	_, err := NewDependencyResolver(lf).Resolve(context.Background(), []*lockfile.Asset{&lf.Assets[1]})
+	if err == nil {
+		t.Fatal("formatter 2.0.0 does not satisfy ^1.2; expected a conflict")
+	}
+}
+
+// TestResolve_ExactVersionIsNotAWildcard: a bare "3" pins 3, not 3.x.
+func TestResolve_ExactVersionIsNotAWildcard(t *testing.T) {
+	lf := depLock(
+		skillRow("formatter", "3.1"),
+		skillRow("reviewer", "1.0.0", lockfile.Dependency{Name: "formatter", Version: "3"}),
+	)
+	versions := fakeVersions{"formatter": {"3", "3.1"}}
+
+	sorted, err := NewDependencyResolver(lf).WithVersions(versions).Resolve(context.Background(), []*lockfile.Asset{&lf.Assets[1]})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if sorted[0].Version != "3" {
+		t.Errorf("formatter version = %s, want exactly 3", sorted[0].Version)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/schollz/progressbar/v3"
@@ -11,7 +12,10 @@ import (
 	"github.com/sleuth-io/sx/v2/internal/cache"
 	"github.com/sleuth-io/sx/v2/internal/config"
 	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/logger"
+	"github.com/sleuth-io/sx/v2/internal/manifest"
 	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/signing"
 	"github.com/sleuth-io/sx/v2/internal/utils"
 	vaultpkg "github.com/sleuth-io/sx/v2/internal/vault"
 )
@@ -20,6 +24,14 @@ import (
 type AssetFetcher struct {
 	vault    vaultpkg.Vault
 	vaultKey string
+
+	// The vault's signing config, loaded once per fetcher, and the
+	// warnings its "warn" policy produced (docs/signing.md).
+	signingOnce sync.Once
+	signingCfg  *manifest.Signing
+	signingErr  error
+	warnMu      sync.Mutex
+	warnings    []string
 }
 
 // NewAssetFetcher creates a new asset fetcher. vaultKey, when non-empty,
@@ -45,6 +57,9 @@ func (f *AssetFetcher) FetchAsset(ctx context.Context, asset *lockfile.Asset) (z
 			meta, err = metadata.Parse(metadataBytes)
 			if err == nil && meta.Validate() == nil {
 				// Valid cached asset
+				if err := f.verifySignature(ctx, asset, zipData); err != nil {
+					return nil, nil, err
+				}
 				return zipData, meta, nil
 			}
 		}
@@ -52,7 +67,7 @@ func (f *AssetFetcher) FetchAsset(ctx context.Context, asset *lockfile.Asset) (z
 	}
 
 	// Cache miss or invalid, download asset
-	zipData, err = f.vault.GetAsset(ctx, asset)
+	zipData, err = f.download(ctx, asset)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to download asset: %w", err)
 	}
@@ -78,6 +93,10 @@ func (f *AssetFetcher) FetchAsset(ctx context.Context, asset *lockfile.Asset) (z
 		return nil, nil, fmt.Errorf("metadata validation failed: %w", err)
 	}
 
+	if err := f.verifySignature(ctx, asset, zipData); err != nil {
+		return nil, nil, err
+	}
+
 	// Cache to disk for future use
 	_ = cache.SaveAssetToDisk(asset.Name, asset.Version, f.vaultKey, zipData)
 	// Ignore cache save errors - not critical
@@ -85,6 +104,58 @@ func (f *AssetFetcher) FetchAsset(ctx context.Context, asset *lockfile.Asset) (z
 	return zipData, meta, nil
 }
 
+// download fetches an asset's zip from the vault. A row with no source was
+// moved to another version by dependency range resolution, so it is
+// fetched by name and version.
+func (f *AssetFetcher) download(ctx context.Context, asset *lockfile.Asset) ([]byte, error) {
+	if asset.GetSourceType() == "unknown" {
+		return f.vault.GetAssetByVersion(ctx, asset.Name, asset.Version)
+	}
+	return f.vault.GetAsset(ctx, asset)
+}
+
+// verifySignature applies the vault's signing policy to a fetched asset:
+// "enforce" turns a missing or bad signature into an error, "warn" records
+// a warning (see Warnings) and lets the install proceed.
+func (f *AssetFetcher) verifySignature(ctx context.Context, asset *lockfile.Asset, zipData []byte) error {
+	store, ok := f.vault.(vaultpkg.SigningStore)
+	if !ok {
+		return nil
+	}
+	f.signingOnce.Do(func() {
+		f.signingCfg, f.signingErr = store.SigningConfig(ctx)
+	})
+	if f.signingErr != nil {
+		return fmt.Errorf("failed to read signing policy: %w", f.signingErr)
+	}
+	policy := vaultpkg.SigningPolicyOf(f.signingCfg)
+	if policy == signing.PolicyOff {
+		return nil
+	}
+	sig, err := vaultpkg.VerifyAssetSignature(ctx, store, f.signingCfg, asset.Name, asset.Version, zipData)
+	if err == nil {
+		logger.Get().Debug("asset signature verified", "asset", asset.Name, "version", asset.Version, "signer", sig.Signer, "key", sig.KeyName)
+		return nil
+	}
+	if policy == signing.PolicyEnforce {
+		return fmt.Errorf("signature check failed for %s@%s: %w", asset.Name, asset.Version, err)
+	}
+	msg := fmt.Sprintf("%s@%s: %v", asset.Name, asset.Version, err)
+	logger.Get().Warn("asset signature check failed", "asset", asset.Name, "version", asset.Version, "error", err)
+	f.warnMu.Lock()
+	f.warnings = append(f.warnings, msg)
+	f.warnMu.Unlock()
+	return nil
+}
+
+// Warnings returns the signature warnings collected so far under a "warn"
+// signing policy.
+func (f *AssetFetcher) Warnings() []string {
+	f.warnMu.Lock()
+	defer f.warnMu.Unlock()
+	return slices.Clone(f.warnings)
+}
+
 // FetchAssetWithProgress downloads a single asset with progress bar
 func (f *AssetFetcher) FetchAssetWithProgress(ctx context.Context, asset *lockfile.Asset, bar *progressbar.ProgressBar) (zipData []byte, meta *metadata.Metadata, err error) {
 	// Try disk cache first. Lock-file validation pins every source-git
@@ -96,6 +167,9 @@ func (f *AssetFetcher) FetchAssetWithProgress(ctx context.Context, asset *lockfi
 		if err == nil {
 			meta, err = metadata.Parse(metadataBytes)
 			if err == nil && meta.Validate() == nil {
+				if err := f.verifySignature(ctx, asset, zipData); err != nil {
+					return nil, nil, err
+				}
 				// Valid cached asset - complete progress bar immediately
 				if bar != nil {
 					bar.ChangeMax64(int64(len(zipData)))
@@ -108,7 +182,7 @@ func (f *AssetFetcher) FetchAssetWithProgress(ctx context.Context, asset *lockfi
 	}
 
 	// Download asset through vault (handles auth properly)
-	zipData, err = f.vault.GetAsset(ctx, asset)
+	zipData, err = f.download(ctx, asset)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to download asset: %w", err)
 	}
@@ -140,6 +214,10 @@ func (f *AssetFetcher) FetchAssetWithProgress(ctx context.Context, asset *lockfi
 		return nil, nil, fmt.Errorf("metadata validation failed: %w", err)
 	}
 
+	if err := f.verifySignature(ctx, asset, zipData); err != nil {
+		return nil, nil, err
+	}
+
 	// Cache to disk for future use
 	_ = cache.SaveAssetToDisk(asset.Name, asset.Version, f.vaultKey, zipData)
 	// Ignore cache save errors - not critical
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/sleuth-io/sx/v2/internal/cache"
 	"github.com/sleuth-io/sx/v2/internal/lockfile"
@@ -17,8 +19,17 @@ const TrackerFormatVersion = "3"
 
 // Tracker tracks all installed assets across all scopes
 type Tracker struct {
-	Version string           `json:"version"`
-	Assets  []InstalledAsset `json:"assets"`
+	Version  string           `json:"version"`
+	Assets   []InstalledAsset `json:"assets"`
+	Sections []ManagedSection `json:"sections,omitempty"`
+}
+
+// ManagedSection records what sx last wrote to a marked section of an
+// instruction file, the base for merging hand edits on the next write
+type ManagedSection struct {
+	File    string `json:"file"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
 }
 
 // InstalledAsset represents a single installed asset with its scope
@@ -329,3 +340,40 @@ func DeleteTracker() error {
 
 	return nil
 }
+
+// sectionsMu guards Tracker.Sections, which clients update in parallel
+var sectionsMu sync.Mutex
+
+// SectionBase returns what sx last wrote to the named section of file
+func (t *Tracker) SectionBase(file, name string) (string, bool) {
+	sectionsMu.Lock()
+	defer sectionsMu.Unlock()
+	for _, s := range t.Sections {
+		if s.File == file && s.Name == name {
+			return s.Content, true
+		}
+	}
+	return "", false
+}
+
+// SetSectionBase records what sx wrote to the named section of file
+func (t *Tracker) SetSectionBase(file, name, content string) {
+	sectionsMu.Lock()
+	defer sectionsMu.Unlock()
+	for i, s := range t.Sections {
+		if s.File == file && s.Name == name {
+			t.Sections[i].Content = content
+			return
+		}
+	}
+	t.Sections = append(t.Sections, ManagedSection{File: file, Name: name, Content: content})
+}
+
+// DeleteSectionBase forgets the named section of file
+func (t *Tracker) DeleteSectionBase(file, name string) {
+	sectionsMu.Lock()
+	defer sectionsMu.Unlock()
+	t.Sections = slices.DeleteFunc(t.Sections, func(s ManagedSection) bool {
+		return s.File == file && s.Name == name
+	})
+}
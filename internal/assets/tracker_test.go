@@ -301,3 +301,30 @@ func TestNewAssetKey(t *testing.T) {
 		})
 	}
 }
+
+func TestTrackerSectionBases(t *testing.T) {
+	tracker := &Tracker{Version: TrackerFormatVersion}
+
+	if _, ok := tracker.SectionBase("/repo/AGENTS.md", "style"); ok {
+		t.Fatal("Expected no base in an empty tracker")
+	}
+
+	tracker.SetSectionBase("/repo/AGENTS.md", "style", "v1\n")
+	tracker.SetSectionBase("/repo/AGENTS.md", "review", "r1\n")
+	tracker.SetSectionBase("/repo/AGENTS.md", "style", "v2\n")
+
+	if got, ok := tracker.SectionBase("/repo/AGENTS.md", "style"); !ok || got != "v2\n" {
+		t.Errorf("SectionBase() = %q, %v, want v2", got, ok)
+	}
+	if len(tracker.Sections) != 2 {
+		t.Errorf("Expected 2 sections, got %d", len(tracker.Sections))
+	}
+
+	tracker.DeleteSectionBase("/repo/AGENTS.md", "style")
+	if _, ok := tracker.SectionBase("/repo/AGENTS.md", "style"); ok {
+		t.Error("Expected the base to be deleted")
+	}
+	if _, ok := tracker.SectionBase("/repo/AGENTS.md", "review"); !ok {
+		t.Error("Expected other bases to survive a delete")
+	}
+}
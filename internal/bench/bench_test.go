@@ -0,0 +1,150 @@
+package bench
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/llm"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// stubProvider answers "feat: <prompt>" when the skill is in context and
+// "<prompt>" otherwise; the judge passes answers that mention "feat".
+type stubProvider struct {
+	calls    int
+	sawEvals bool
+}
+
+func (s *stubProvider) ID() string { return "stub" }
+
+func (s *stubProvider) Complete(ctx context.Context, req llm.Request) (llm.Response, error) {
+	s.calls++
+	resp := llm.Response{Provider: "stub", Model: "stub-1"}
+	last := req.Messages[len(req.Messages)-1].Content
+	switch {
+	case len(req.Schema) > 0:
+		resp.Text = `{"pass": false, "reason": "no"}`
+		if strings.Contains(last, "feat") {
+			resp.Text = `{"pass": true, "reason": "yes"}`
+		}
+	case req.Messages[0].Role == "system":
+		s.sawEvals = s.sawEvals || strings.Contains(req.Messages[0].Content, "evals/")
+		resp.Text = "feat: " + last
+	default:
+		resp.Text = last
+	}
+	return resp, nil
+}
+
+func zipOf(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := utils.CreateZip(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestRun_AggregatesPassRates(t *testing.T) {
+	skill := zipOf(t, map[string]string{
+		"SKILL.md": "---\ndescription: Commit messages\n---\n\nPrefix with feat:.\n",
+		// With the skill: 3/3. Without: only not-contains passes, 1/3.
+		"evals/commit.toml": `prompt = "add login"
+
+[[assert]]
+contains = "FEAT:"
+ignore-case = true
+
+[[assert]]
+not-contains = "WIP"
+
+[[assert]]
+judge = "Uses a conventional-commit prefix"
+`,
+		// Passes either way: 1/1 and 1/1.
+		"evals/subject.toml": "key = \"mentions-subject\"\nprompt = \"fix logout\"\n\n[[assert]]\nregex = \"logout$\"\n",
+	})
+	cases, err := LoadCases(skill)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) != 2 || cases[0].Key != "commit" || cases[1].Key != "mentions-subject" {
+		t.Fatalf("cases = %+v", cases)
+	}
+
+	p := &stubProvider{}
+	rec, err := Run(context.Background(), p, skill, cases, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.sawEvals {
+		t.Error("eval cases leaked into the with-skill context")
+	}
+	// 2 cases × 2 runs × 2 configs, plus a judge call per commit run.
+	if p.calls != 12 {
+		t.Errorf("calls = %d, want 12", p.calls)
+	}
+	if rec.RunsPerConfig != 2 || rec.Executor != (Executor{Provider: "stub", Model: "stub-1"}) || rec.SkillHash == "" {
+		t.Errorf("record header = %+v", rec)
+	}
+	with, without := rec.Summary.WithSkill["pass_rate"], rec.Summary.WithoutSkill["pass_rate"]
+	if with != (Stat{Mean: 1, Stddev: 0, Min: 1, Max: 1}) {
+		t.Errorf("with_skill = %+v", with)
+	}
+	if without != (Stat{Mean: 0.667, Stddev: 0.333, Min: 0.333, Max: 1}) {
+		t.Errorf("without_skill = %+v", without)
+	}
+	if d := rec.Summary.Delta["pass_rate"]; d != 0.333 {
+		t.Errorf("delta = %v", d)
+	}
+	want := []EvalResult{
+		{EvalKey: "commit", WithPass: 1, WithoutPass: 0.333, Status: StatusPassing},
+		{EvalKey: "mentions-subject", WithPass: 1, WithoutPass: 1, Status: StatusPassing},
+	}
+	for i, w := range want {
+		if rec.PerEval[i] != w {
+			t.Errorf("per_eval[%d] = %+v, want %+v", i, rec.PerEval[i], w)
+		}
+	}
+}
+
+func TestLoadCases_Validation(t *testing.T) {
+	for name, body := range map[string]string{
+		"no prompt":    "[[assert]]\ncontains = \"x\"\n",
+		"no asserts":   "prompt = \"hi\"\n",
+		"two kinds":    "prompt = \"hi\"\n[[assert]]\ncontains = \"x\"\nregex = \"y\"\n",
+		"bad regex":    "prompt = \"hi\"\n[[assert]]\nregex = \"(\"\n",
+		"invalid toml": "prompt = \n",
+	} {
+		_, err := LoadCases(zipOf(t, map[string]string{"SKILL.md": "x", "evals/a.toml": body}))
+		if err == nil || !strings.HasPrefix(err.Error(), "evals/a.toml:") {
+			t.Errorf("%s: err = %v", name, err)
+		}
+	}
+
+	_, err := LoadCases(zipOf(t, map[string]string{
+		"evals/a.toml": "key = \"same\"\nprompt = \"hi\"\n[[assert]]\ncontains = \"x\"\n",
+		"evals/b.toml": "key = \"same\"\nprompt = \"hi\"\n[[assert]]\ncontains = \"x\"\n",
+	}))
+	if err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Errorf("duplicate key: err = %v", err)
+	}
+
+	cases, err := LoadCases(zipOf(t, map[string]string{"SKILL.md": "x", "docs/evals/a.toml": "junk"}))
+	if err != nil || len(cases) != 0 {
+		t.Errorf("only top-level evals/ counts: %+v, %v", cases, err)
+	}
+}
@@ -0,0 +1,123 @@
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// EvalsDir is where an asset ships its eval cases: one TOML file per
+// case, evals/<key>.toml.
+const EvalsDir = "evals"
+
+// Case is one eval: a prompt, and the assertions the answer must meet.
+type Case struct {
+	// Key identifies the case in per_eval; defaults to the file's stem.
+	Key     string      `toml:"key"`
+	Prompt  string      `toml:"prompt"`
+	Asserts []Assertion `toml:"assert"`
+}
+
+// Assertion is one check on an answer. Exactly one of Contains,
+// NotContains, Regex, or Judge is set.
+type Assertion struct {
+	Contains    string `toml:"contains"`
+	NotContains string `toml:"not-contains"`
+	Regex       string `toml:"regex"`
+	// Judge is a criterion a model grades the answer against, for
+	// properties string matching can't express.
+	Judge      string `toml:"judge"`
+	IgnoreCase bool   `toml:"ignore-case"`
+}
+
+func (a Assertion) regexp() (*regexp.Regexp, error) {
+	if a.IgnoreCase {
+		return regexp.Compile("(?i)" + a.Regex)
+	}
+	return regexp.Compile(a.Regex)
+}
+
+// LoadCases reads and validates the asset's evals/*.toml cases, sorted by
+// file name. An asset without an evals directory has no cases.
+func LoadCases(zipData []byte) ([]Case, error) {
+	names, err := utils.ListZipFiles(zipData)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var cases []Case
+	seen := map[string]string{}
+	for _, name := range names {
+		if path.Dir(name) != EvalsDir || path.Ext(name) != ".toml" {
+			continue
+		}
+		data, err := utils.ReadZipFile(zipData, name)
+		if err != nil {
+			return nil, err
+		}
+		var c Case
+		if _, err := toml.Decode(string(data), &c); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if c.Key == "" {
+			c.Key = strings.TrimSuffix(path.Base(name), ".toml")
+		}
+		if err := c.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if prev, ok := seen[c.Key]; ok {
+			return nil, fmt.Errorf("%s: key %q is already used by %s", name, c.Key, prev)
+		}
+		seen[c.Key] = name
+		cases = append(cases, c)
+	}
+	return cases, nil
+}
+
+func (c *Case) validate() error {
+	if strings.TrimSpace(c.Prompt) == "" {
+		return errors.New("prompt is empty")
+	}
+	if len(c.Asserts) == 0 {
+		return errors.New("no [[assert]] checks")
+	}
+	for i, a := range c.Asserts {
+		set := 0
+		for _, v := range []string{a.Contains, a.NotContains, a.Regex, a.Judge} {
+			if v != "" {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("assert %d: set exactly one of contains, not-contains, regex, judge", i+1)
+		}
+		if a.Regex != "" {
+			if _, err := a.regexp(); err != nil {
+				return fmt.Errorf("assert %d: %w", i+1, err)
+			}
+		}
+	}
+	return nil
+}
+
+// String describes the assertion for reports.
+func (a Assertion) String() string {
+	switch {
+	case a.Contains != "":
+		return fmt.Sprintf("contains %q", a.Contains)
+	case a.NotContains != "":
+		return fmt.Sprintf("doesn't contain %q", a.NotContains)
+	case a.Regex != "":
+		return fmt.Sprintf("matches /%s/", a.Regex)
+	default:
+		return "judge: " + a.Judge
+	}
+}
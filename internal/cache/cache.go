@@ -155,6 +155,24 @@ func GetGitRepoCachePath(repoURL string) (string, error) {
 	return filepath.Join(gitReposDir, urlHash), nil
 }
 
+// GetS3VaultCachePath returns the local mirror directory for an S3 vault
+func GetS3VaultCachePath(repoURL string) (string, error) {
+	cacheDir, err := GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "s3-vaults", utils.URLHash(repoURL)), nil
+}
+
+// GetOCIVaultCachePath returns the local mirror directory for an OCI vault
+func GetOCIVaultCachePath(repoURL string) (string, error) {
+	cacheDir, err := GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "oci-vaults", utils.URLHash(repoURL)), nil
+}
+
 // GetGitSourceCachePath returns the cache path for a git repository
 // fetched as a source-git asset source. Deliberately a different
 // namespace than GetGitRepoCachePath: when the same URL is used both as
@@ -0,0 +1,340 @@
+package aider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/bootstrap"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/aider/handlers"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// Client implements the clients.Client interface for Aider
+type Client struct {
+	clients.BaseClient
+}
+
+// NewClient creates a new Aider client
+func NewClient() *Client {
+	return &Client{
+		BaseClient: clients.NewBaseClient(
+			clients.ClientIDAider,
+			"Aider",
+			[]asset.Type{
+				asset.TypeRule,  // .aider/sx/{name}.md, listed under read:
+				asset.TypeSkill, // flattened to .aider/sx/{name}.md
+			},
+		),
+	}
+}
+
+// RuleCapabilities returns Aider's rule capabilities
+func (c *Client) RuleCapabilities() *clients.RuleCapabilities {
+	return RuleCapabilities()
+}
+
+// IsInstalled checks if Aider is installed: aider in PATH, or a user
+// config in the home directory
+func (c *Client) IsInstalled() bool {
+	if _, err := exec.LookPath("aider"); err == nil {
+		return true
+	}
+
+	home, err := handlers.HomeDir()
+	if err != nil {
+		return false
+	}
+	return utils.FileExists(handlers.ConfigPath(home))
+}
+
+// GetVersion returns the Aider version
+func (c *Client) GetVersion() string {
+	cmd := exec.Command("aider", "--version")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	// Prints e.g. "aider 0.86.1"
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(string(output)), "aider"))
+}
+
+// InstallAssets installs assets to Aider using client-specific handlers
+func (c *Client) InstallAssets(ctx context.Context, req clients.InstallRequest) (clients.InstallResponse, error) {
+	resp := clients.InstallResponse{
+		Results: make([]clients.AssetResult, 0, len(req.Assets)),
+	}
+
+	root, err := c.determineRoot(req.Scope)
+	if err != nil {
+		return resp, fmt.Errorf("cannot determine installation directory: %w", err)
+	}
+
+	for _, bundle := range req.Assets {
+		result := clients.AssetResult{
+			AssetName: bundle.Asset.Name,
+		}
+
+		handler, err := handlers.NewHandler(bundle.Metadata.Asset.Type, bundle.Metadata)
+		if err != nil {
+			result.Status = clients.StatusSkipped
+			result.Message = "Unsupported asset type: " + bundle.Metadata.Asset.Type.Key
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+		err = handler.Install(ctx, bundle.ZipData, root)
+
+		result.Status, result.Message, result.Error = clients.TranslateInstallError(err, "Installed to "+handlers.ConventionPath(root, bundle.Asset.Name))
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+// UninstallAssets removes assets from Aider
+func (c *Client) UninstallAssets(ctx context.Context, req clients.UninstallRequest) (clients.UninstallResponse, error) {
+	resp := clients.UninstallResponse{
+		Results: make([]clients.AssetResult, 0, len(req.Assets)),
+	}
+
+	root, err := c.determineRoot(req.Scope)
+	if err != nil {
+		return resp, fmt.Errorf("cannot determine uninstall directory: %w", err)
+	}
+
+	for _, a := range req.Assets {
+		result := clients.AssetResult{
+			AssetName: a.Name,
+		}
+
+		// Create minimal metadata for removal
+		meta := &metadata.Metadata{
+			Asset: metadata.Asset{
+				Name: a.Name,
+				Type: a.Type,
+			},
+		}
+
+		handler, err := handlers.NewHandler(a.Type, meta)
+		if err != nil {
+			result.Status = clients.StatusSkipped
+			result.Message = "Unsupported asset type: " + a.Type.Key
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		if err := handler.Remove(ctx, root); err != nil {
+			result.Status = clients.StatusFailed
+			result.Error = err
+		} else {
+			result.Status = clients.StatusSuccess
+			result.Message = "Uninstalled successfully"
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+// determineRoot returns the directory holding .aider.conf.yml for a
+// scope: the home directory globally, otherwise the repo or path
+func (c *Client) determineRoot(scope *clients.InstallScope) (string, error) {
+	switch scope.Type {
+	case clients.ScopeRepository:
+		if scope.RepoRoot == "" {
+			return "", errors.New("repo-scoped install requires RepoRoot but none provided (not in a git repository?)")
+		}
+		return scope.RepoRoot, nil
+	case clients.ScopePath:
+		if scope.RepoRoot == "" {
+			return "", errors.New("path-scoped install requires RepoRoot but none provided (not in a git repository?)")
+		}
+		return filepath.Join(scope.RepoRoot, scope.Path), nil
+	default:
+		return handlers.HomeDir()
+	}
+}
+
+// PlanPaths lists what installs at scope may touch: .aider.conf.yml and
+// the conventions files beside it
+func (c *Client) PlanPaths(scope *clients.InstallScope) ([]string, error) {
+	root, err := c.determineRoot(scope)
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		handlers.ConfigPath(root),
+		filepath.Join(root, filepath.FromSlash(handlers.ConventionsDir)),
+	}, nil
+}
+
+// ListAssets returns installed skills. Aider skills are flattened into
+// conventions files, so there's nothing to list.
+func (c *Client) ListAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
+	return []clients.InstalledSkill{}, nil
+}
+
+// ReadSkill reads a skill by name; flattened skills can't be read back
+func (c *Client) ReadSkill(ctx context.Context, name string, scope *clients.InstallScope) (*clients.SkillContent, error) {
+	return nil, fmt.Errorf("skill not found: %s", name)
+}
+
+// EnsureAssetSupport is a no-op for Aider: installing an asset registers
+// it in .aider.conf.yml.
+func (c *Client) EnsureAssetSupport(ctx context.Context, scope *clients.InstallScope) error {
+	return nil
+}
+
+// GetBootstrapOptions returns no options: Aider has neither hooks nor MCP
+func (c *Client) GetBootstrapOptions(ctx context.Context) []bootstrap.Option {
+	return nil
+}
+
+// GetBootstrapPath returns the user's Aider config.
+func (c *Client) GetBootstrapPath() string {
+	home, err := handlers.HomeDir()
+	if err != nil {
+		return ""
+	}
+	return handlers.ConfigPath(home)
+}
+
+// InstallBootstrap is a no-op for Aider.
+func (c *Client) InstallBootstrap(ctx context.Context, opts []bootstrap.Option) error {
+	return nil
+}
+
+// UninstallBootstrap is a no-op for Aider.
+func (c *Client) UninstallBootstrap(ctx context.Context, opts []bootstrap.Option) error {
+	return nil
+}
+
+// ShouldInstall always returns true for Aider, which has no session hook
+// to deduplicate.
+func (c *Client) ShouldInstall(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// VerifyAssets checks if assets are actually installed on the filesystem
+func (c *Client) VerifyAssets(ctx context.Context, assets []*lockfile.Asset, scope *clients.InstallScope) []clients.VerifyResult {
+	results := make([]clients.VerifyResult, 0, len(assets))
+
+	root, err := c.determineRoot(scope)
+	if err != nil {
+		// Can't determine target - mark all assets as not installed
+		for _, a := range assets {
+			results = append(results, clients.VerifyResult{
+				Asset:     a,
+				Installed: false,
+				Message:   fmt.Sprintf("cannot determine target directory: %v", err),
+			})
+		}
+		return results
+	}
+
+	for _, a := range assets {
+		result := clients.VerifyResult{
+			Asset: a,
+		}
+
+		handler, err := handlers.NewHandler(a.Type, &metadata.Metadata{
+			Asset: metadata.Asset{
+				Name:    a.Name,
+				Version: a.Version,
+				Type:    a.Type,
+			},
+		})
+		if err != nil {
+			result.Message = err.Error()
+		} else {
+			result.Installed, result.Message = handler.VerifyInstalled(root)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// ScanInstalledAssets finds conventions files the user listed under
+// read: themselves (CONVENTIONS.md and the like), for import as rules.
+// Files in the sx conventions directory are skipped.
+func (c *Client) ScanInstalledAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledAsset, error) {
+	found, err := c.userConventions(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	assets := make([]clients.InstalledAsset, 0, len(found))
+	for name := range found {
+		assets = append(assets, clients.InstalledAsset{
+			Name:    name,
+			Version: "1.0", // Default version for unmanaged assets
+			Type:    asset.TypeRule,
+		})
+	}
+	return assets, nil
+}
+
+// userConventions maps asset names to the markdown files listed under
+// read: that sx didn't install
+func (c *Client) userConventions(scope *clients.InstallScope) (map[string]string, error) {
+	root, err := c.determineRoot(scope)
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine target directory: %w", err)
+	}
+	entries, err := handlers.ReadEntries(handlers.ConfigPath(root))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Aider config: %w", err)
+	}
+
+	found := make(map[string]string)
+	for _, entry := range entries {
+		path := entry
+		if strings.HasPrefix(path, "~/") {
+			if home, err := handlers.HomeDir(); err == nil {
+				path = filepath.Join(home, path[2:])
+			}
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, filepath.FromSlash(path))
+		}
+		if matchesPath(path) || !strings.EqualFold(filepath.Ext(path), ".md") || !utils.FileExists(path) {
+			continue
+		}
+		name := utils.Slugify(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+		if _, dup := found[name]; !dup {
+			found[name] = path
+		}
+	}
+	return found, nil
+}
+
+// GetAssetPath returns the filesystem path to an installed asset
+func (c *Client) GetAssetPath(ctx context.Context, name string, assetType asset.Type, scope *clients.InstallScope) (string, error) {
+	if assetType != asset.TypeRule {
+		return "", fmt.Errorf("import not supported for type: %s", assetType)
+	}
+	found, err := c.userConventions(scope)
+	if err != nil {
+		return "", err
+	}
+	path, ok := found[name]
+	if !ok {
+		return "", fmt.Errorf("no conventions file for %s in %s", name, handlers.ConfigFile)
+	}
+	return path, nil
+}
+
+func init() {
+	// Auto-register on package import
+	clients.Register(NewClient())
+}
@@ -0,0 +1,181 @@
+package aider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/aider/handlers"
+	"github.com/sleuth-io/sx/v2/internal/clients/clienttest"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+func testAssets(t *testing.T) []*clients.AssetBundle {
+	t.Helper()
+	return []*clients.AssetBundle{
+		clienttest.Bundle(t, &metadata.Metadata{
+			Asset: metadata.Asset{Name: "go-style", Version: "1.0", Type: asset.TypeRule},
+			Rule:  &metadata.RuleConfig{Title: "Go Style"},
+		}, map[string]string{"RULE.md": "Use gofmt."}),
+		clienttest.Bundle(t, &metadata.Metadata{
+			Asset: metadata.Asset{Name: "review", Version: "1.0", Type: asset.TypeSkill, Description: "Review a change"},
+			Skill: &metadata.SkillConfig{PromptFile: "SKILL.md"},
+		}, map[string]string{
+			"SKILL.md":           "---\nname: review\n---\n\nRead the diff first.",
+			"reference/style.md": "Prefer small functions.",
+			"scripts/lint.sh":    "#!/bin/sh\n",
+		}),
+	}
+}
+
+func install(t *testing.T, client *Client, scope *clients.InstallScope, bundles []*clients.AssetBundle) {
+	t.Helper()
+	resp, err := client.InstallAssets(context.Background(), clients.InstallRequest{Assets: bundles, Scope: scope})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range resp.Results {
+		if r.Status != clients.StatusSuccess {
+			t.Fatalf("%s: %s %v", r.AssetName, r.Status, r.Error)
+		}
+	}
+}
+
+func TestInstallAssets_RepoScope(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	repo := t.TempDir()
+	configPath := filepath.Join(repo, handlers.ConfigFile)
+	existing := "# team settings\nmodel: sonnet # pinned\nread: CONVENTIONS.md\n"
+	if err := os.WriteFile(configPath, []byte(existing), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: repo}
+	bundles := testAssets(t)
+
+	// Installing twice must not list the files twice
+	install(t, client, scope, bundles)
+	install(t, client, scope, bundles)
+
+	entries, err := handlers.ReadEntries(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"CONVENTIONS.md", ".aider/sx/go-style.md", ".aider/sx/review.md"}
+	if strings.Join(entries, ",") != strings.Join(want, ",") {
+		t.Errorf("read entries = %v, want %v", entries, want)
+	}
+	config, _ := os.ReadFile(configPath)
+	for _, s := range []string{"# team settings", "model: sonnet # pinned"} {
+		if !strings.Contains(string(config), s) {
+			t.Errorf("config lost %q:\n%s", s, config)
+		}
+	}
+
+	rule, err := os.ReadFile(filepath.Join(repo, ".aider", "sx", "go-style.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(rule) != "# Go Style\n\nUse gofmt.\n" {
+		t.Errorf("rule file = %q", rule)
+	}
+
+	skill, err := os.ReadFile(filepath.Join(repo, ".aider", "sx", "review.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"# review", "Review a change", "Read the diff first.", "## reference/style.md", "Prefer small functions."} {
+		if !strings.Contains(string(skill), s) {
+			t.Errorf("flattened skill missing %q:\n%s", s, skill)
+		}
+	}
+	if strings.Contains(string(skill), "name: review") || strings.Contains(string(skill), "lint.sh") {
+		t.Errorf("flattened skill kept frontmatter or a script:\n%s", skill)
+	}
+
+	var locks []*lockfile.Asset
+	for _, b := range bundles {
+		locks = append(locks, b.Asset)
+	}
+	for _, r := range client.VerifyAssets(context.Background(), locks, scope) {
+		if !r.Installed {
+			t.Errorf("%s not verified: %s", r.Asset.Name, r.Message)
+		}
+	}
+
+	clienttest.Uninstall(t, client, scope, bundles...)
+
+	entries, _ = handlers.ReadEntries(configPath)
+	if len(entries) != 1 || entries[0] != "CONVENTIONS.md" {
+		t.Errorf("read entries after uninstall = %v", entries)
+	}
+	if utils.FileExists(filepath.Join(repo, ".aider", "sx", "go-style.md")) {
+		t.Error("rule file not removed")
+	}
+	for _, r := range client.VerifyAssets(context.Background(), locks, scope) {
+		if r.Installed {
+			t.Errorf("%s still verified after uninstall", r.Asset.Name)
+		}
+	}
+}
+
+func TestInstallAssets_GlobalScope(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeGlobal}
+	bundles := testAssets(t)[:1]
+	install(t, client, scope, bundles)
+
+	configPath := filepath.Join(home, handlers.ConfigFile)
+	entries, err := handlers.ReadEntries(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The global config is read from anywhere, so paths are absolute
+	if len(entries) != 1 || entries[0] != filepath.Join(home, ".aider", "sx", "go-style.md") {
+		t.Errorf("read entries = %v", entries)
+	}
+
+	// A config sx created is removed with the last entry
+	clienttest.Uninstall(t, client, scope, bundles...)
+	if utils.FileExists(configPath) {
+		t.Error("config sx created should be removed once empty")
+	}
+}
+
+func TestScanInstalledAssets(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	repo := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repo, "CONVENTIONS.md"), []byte("Be terse.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config := "read:\n  - CONVENTIONS.md\n  - missing.md\n"
+	if err := os.WriteFile(filepath.Join(repo, handlers.ConfigFile), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: repo}
+	install(t, client, scope, testAssets(t)[:1])
+
+	found, err := client.ScanInstalledAssets(context.Background(), scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].Name != "conventions" || found[0].Type != asset.TypeRule {
+		t.Fatalf("scan = %+v, want only the user's conventions", found)
+	}
+	path, err := client.GetAssetPath(context.Background(), "conventions", asset.TypeRule, scope)
+	if err != nil || path != filepath.Join(repo, "CONVENTIONS.md") {
+		t.Errorf("GetAssetPath = %q, %v", path, err)
+	}
+}
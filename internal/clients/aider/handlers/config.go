@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// ReadEntries returns the read: entries of an Aider config. Aider accepts
+// a single file or a list.
+func ReadEntries(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	doc, err := utils.ParseYAMLDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return readValues(utils.YAMLMapValue(doc.Content[0], readKey)), nil
+}
+
+// AddReadEntry adds entry to the read: list of an Aider config, creating
+// the config if needed. Existing entries, other settings and comments are
+// kept, and an entry already listed isn't added twice.
+func AddReadEntry(path, entry string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read Aider config: %w", err)
+	}
+	doc, err := utils.ParseYAMLDocument(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	root := doc.Content[0]
+	current := utils.YAMLMapValue(root, readKey)
+	if slices.Contains(readValues(current), entry) {
+		return nil
+	}
+
+	item := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: entry}
+	switch {
+	case current != nil && current.Kind == yaml.SequenceNode:
+		current.Content = append(current.Content, item)
+	case current != nil && current.Kind == yaml.ScalarNode && current.Value != "":
+		// A single file becomes a list, keeping the file
+		utils.SetYAMLMapValue(root, readKey, &yaml.Node{
+			Kind:    yaml.SequenceNode,
+			Tag:     "!!seq",
+			Content: []*yaml.Node{current, item},
+		})
+	default:
+		utils.SetYAMLMapValue(root, readKey, &yaml.Node{
+			Kind:    yaml.SequenceNode,
+			Tag:     "!!seq",
+			Content: []*yaml.Node{item},
+		})
+	}
+
+	return writeConfig(path, doc)
+}
+
+// RemoveReadEntry removes entry from the read: list of an Aider config,
+// dropping the key once nothing is left. The file is left alone when the
+// entry isn't listed.
+func RemoveReadEntry(path, entry string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read Aider config: %w", err)
+	}
+	doc, err := utils.ParseYAMLDocument(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	root := doc.Content[0]
+	current := utils.YAMLMapValue(root, readKey)
+	if !slices.Contains(readValues(current), entry) {
+		return nil
+	}
+
+	if current.Kind == yaml.SequenceNode {
+		current.Content = slices.DeleteFunc(current.Content, func(n *yaml.Node) bool {
+			return n.Value == entry
+		})
+	}
+	if current.Kind == yaml.ScalarNode || len(current.Content) == 0 {
+		utils.DeleteYAMLMapKey(root, readKey)
+	}
+
+	if len(root.Content) == 0 && len(doc.HeadComment)+len(doc.FootComment) == 0 {
+		// Nothing left but what sx added
+		return os.Remove(path)
+	}
+	return writeConfig(path, doc)
+}
+
+// readValues returns the file names in a read: value
+func readValues(n *yaml.Node) []string {
+	if n == nil {
+		return nil
+	}
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if n.Value != "" {
+			return []string{n.Value}
+		}
+	case yaml.SequenceNode:
+		var values []string
+		for _, item := range n.Content {
+			if item.Kind == yaml.ScalarNode {
+				values = append(values, item.Value)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func writeConfig(path string, doc *yaml.Node) error {
+	out, err := utils.EncodeYAMLDocument(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode Aider config: %w", err)
+	}
+	if err := utils.WriteFileAtomic(path, out, 0644); err != nil {
+		return fmt.Errorf("failed to write Aider config: %w", err)
+	}
+	return nil
+}
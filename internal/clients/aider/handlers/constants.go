@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// File and directory names for Aider assets
+const (
+	// ConfigFile is Aider's YAML config. Aider reads it from the home
+	// directory, the git root and the working directory.
+	ConfigFile = ".aider.conf.yml"
+
+	// ConventionsDir is the sx-managed directory, under the scope root,
+	// holding one markdown file per installed rule or skill
+	ConventionsDir = ".aider/sx"
+
+	// readKey is the config key listing files Aider loads read-only into
+	// every chat
+	readKey = "read"
+)
+
+// HomeDir returns the user's home directory, the root of the global scope
+func HomeDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return home, nil
+}
+
+// ConventionPath returns the conventions file for an asset under root
+func ConventionPath(root, name string) string {
+	return filepath.Join(root, filepath.FromSlash(ConventionsDir), name+".md")
+}
+
+// ConfigPath returns the .aider.conf.yml under root
+func ConfigPath(root string) string {
+	return filepath.Join(root, ConfigFile)
+}
+
+// readEntry is how a conventions file is listed under read:. In a repo
+// the path is relative, so a committed config works on every checkout
+// (Aider resolves it against the directory it's started in). The global
+// config is read from anywhere, so it gets the absolute path.
+func readEntry(root, name string) string {
+	if isGlobalRoot(root) {
+		return ConventionPath(root, name)
+	}
+	return ConventionsDir + "/" + name + ".md"
+}
+
+// isGlobalRoot reports whether root is the home directory
+func isGlobalRoot(root string) bool {
+	home, err := HomeDir()
+	return err == nil && filepath.Clean(root) == home
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/handlers/rule"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// ConventionHandler installs a rule or skill for Aider as a markdown file
+// in the conventions directory, listed under read: in .aider.conf.yml.
+// Skills are flattened: SKILL.md followed by the skill's other markdown
+// files. Scripts and other files are left out, since Aider can't run them
+// on its own.
+type ConventionHandler struct {
+	metadata *metadata.Metadata
+}
+
+// NewConventionHandler creates a new conventions handler
+func NewConventionHandler(meta *metadata.Metadata) *ConventionHandler {
+	return &ConventionHandler{metadata: meta}
+}
+
+// Install writes the conventions file and registers it in the config
+func (h *ConventionHandler) Install(ctx context.Context, zipData []byte, root string) error {
+	var content string
+	var err error
+	if h.metadata.Asset.Type == asset.TypeSkill {
+		content, err = h.flattenSkill(zipData)
+	} else {
+		content, err = h.ruleContent(zipData)
+	}
+	if err != nil {
+		return err
+	}
+
+	name := h.metadata.Asset.Name
+	filePath := ConventionPath(root, name)
+	if err := utils.EnsureDir(filepath.Dir(filePath)); err != nil {
+		return fmt.Errorf("failed to create conventions directory: %w", err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write conventions file: %w", err)
+	}
+
+	return AddReadEntry(ConfigPath(root), readEntry(root, name))
+}
+
+// Remove unregisters and deletes the conventions file
+func (h *ConventionHandler) Remove(ctx context.Context, root string) error {
+	name := h.metadata.Asset.Name
+	if err := RemoveReadEntry(ConfigPath(root), readEntry(root, name)); err != nil {
+		return err
+	}
+	if err := os.Remove(ConventionPath(root, name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove conventions file: %w", err)
+	}
+	return nil
+}
+
+// VerifyInstalled checks the conventions file exists and is registered
+func (h *ConventionHandler) VerifyInstalled(root string) (bool, string) {
+	name := h.metadata.Asset.Name
+	filePath := ConventionPath(root, name)
+	if !utils.FileExists(filePath) {
+		return false, "conventions file not found"
+	}
+	entries, err := ReadEntries(ConfigPath(root))
+	if err != nil {
+		return false, "failed to read Aider config: " + err.Error()
+	}
+	if !slices.Contains(entries, readEntry(root, name)) {
+		return false, "conventions file not listed under read: in " + ConfigFile
+	}
+	return true, "Found at " + filePath
+}
+
+// ruleContent renders a rule as a titled markdown section
+func (h *ConventionHandler) ruleContent(zipData []byte) (string, error) {
+	promptFile := rule.DefaultPromptFile
+	title := h.metadata.Asset.Name
+	if h.metadata.Rule != nil {
+		if h.metadata.Rule.PromptFile != "" {
+			promptFile = h.metadata.Rule.PromptFile
+		}
+		if h.metadata.Rule.Title != "" {
+			title = h.metadata.Rule.Title
+		}
+	}
+
+	content, err := utils.ReadZipFile(zipData, promptFile)
+	if err != nil {
+		// Try lowercase variant
+		content, err = utils.ReadZipFile(zipData, "rule.md")
+		if err != nil {
+			return "", fmt.Errorf("prompt file not found: %s", promptFile)
+		}
+	}
+
+	return "# " + title + "\n\n" + strings.TrimSpace(string(content)) + "\n", nil
+}
+
+// flattenSkill renders a skill as one markdown document
+func (h *ConventionHandler) flattenSkill(zipData []byte) (string, error) {
+	promptFile := "SKILL.md"
+	if h.metadata.Skill != nil && h.metadata.Skill.PromptFile != "" {
+		promptFile = h.metadata.Skill.PromptFile
+	}
+	prompt, err := utils.ReadZipFile(zipData, promptFile)
+	if err != nil {
+		return "", fmt.Errorf("prompt file not found: %s", promptFile)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# " + h.metadata.Asset.Name + "\n\n")
+	if h.metadata.Asset.Description != "" {
+		sb.WriteString(h.metadata.Asset.Description + "\n\n")
+	}
+	sb.WriteString(strings.TrimSpace(stripFrontmatter(string(prompt))) + "\n")
+
+	files, err := utils.ListZipFiles(zipData)
+	if err != nil {
+		return "", fmt.Errorf("failed to list skill files: %w", err)
+	}
+	slices.Sort(files)
+	for _, f := range files {
+		if f == promptFile || !strings.EqualFold(path.Ext(f), ".md") {
+			continue
+		}
+		data, err := utils.ReadZipFile(zipData, f)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", f, err)
+		}
+		sb.WriteString("\n## " + f + "\n\n" + strings.TrimSpace(stripFrontmatter(string(data))) + "\n")
+	}
+
+	return sb.String(), nil
+}
+
+// stripFrontmatter drops a leading --- frontmatter block
+func stripFrontmatter(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if rest, ok := strings.CutPrefix(content, "---\n"); ok {
+		if _, body, found := strings.Cut(rest, "\n---\n"); found {
+			return body
+		}
+	}
+	return content
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+// Handler defines the interface for asset type handlers
+type Handler interface {
+	// Install installs the asset from zip data under the scope root
+	Install(ctx context.Context, zipData []byte, root string) error
+
+	// Remove removes the asset from under the scope root
+	Remove(ctx context.Context, root string) error
+
+	// VerifyInstalled checks if the asset is properly installed
+	// Returns (installed bool, message string)
+	VerifyInstalled(root string) (bool, string)
+}
+
+// NewHandler creates a handler for the given asset type and metadata.
+// Rules and skills both become conventions files.
+func NewHandler(assetType asset.Type, meta *metadata.Metadata) (Handler, error) {
+	switch assetType {
+	case asset.TypeRule, asset.TypeSkill:
+		return NewConventionHandler(meta), nil
+	default:
+		return nil, fmt.Errorf("unsupported asset type for Aider: %s", assetType.Key)
+	}
+}
@@ -0,0 +1,70 @@
+package aider
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/aider/handlers"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+// RuleCapabilities returns the rule capabilities for Aider
+func RuleCapabilities() *clients.RuleCapabilities {
+	return &clients.RuleCapabilities{
+		ClientName:       clients.ClientIDAider,
+		RulesDirectory:   handlers.ConventionsDir,
+		FileExtension:    ".md",
+		InstructionFiles: []string{"CONVENTIONS.md"},
+		MatchesPath:      matchesPath,
+		MatchesContent:   matchesContent,
+		ParseRuleFile:    parseRuleFile,
+		GenerateRuleFile: generateRuleFile,
+		DetectAssetType:  detectAssetType,
+	}
+}
+
+// detectAssetType claims files in the sx conventions directory as rules
+func detectAssetType(path string, _ []byte) *asset.Type {
+	if matchesPath(path) {
+		return &asset.TypeRule
+	}
+	return nil
+}
+
+// matchesPath checks if a path is in the sx conventions directory
+func matchesPath(path string) bool {
+	return strings.Contains(filepath.ToSlash(path), handlers.ConventionsDir+"/") && strings.HasSuffix(path, ".md")
+}
+
+// matchesContent checks if content appears to be an Aider conventions
+// file. They're plain markdown, so only the location tells.
+func matchesContent(path string, content []byte) bool {
+	return matchesPath(path)
+}
+
+// parseRuleFile parses an Aider conventions file, which is plain markdown
+func parseRuleFile(content []byte) (*clients.ParsedRule, error) {
+	return &clients.ParsedRule{
+		Content:    string(content),
+		ClientName: clients.ClientIDAider,
+	}, nil
+}
+
+// generateRuleFile creates a conventions file for Aider. Aider loads
+// every conventions file into each chat, so globs don't apply.
+func generateRuleFile(cfg *metadata.RuleConfig, body string) []byte {
+	var content strings.Builder
+
+	if cfg != nil && cfg.Title != "" {
+		content.WriteString("# ")
+		content.WriteString(cfg.Title)
+		content.WriteString("\n\n")
+	}
+
+	content.WriteString(strings.TrimSpace(body))
+	content.WriteString("\n")
+
+	return []byte(content.String())
+}
@@ -41,6 +41,11 @@ func (c *Client) RuleCapabilities() *clients.RuleCapabilities {
 	return RuleCapabilities()
 }
 
+// MapHookEvent reports the Claude Code event a hook asset fires on
+func (c *Client) MapHookEvent(cfg *metadata.HookConfig) (string, bool) {
+	return handlers.MapHookEvent(cfg)
+}
+
 // IsInstalled checks if Claude Code is installed by checking for .claude directory
 func (c *Client) IsInstalled() bool {
 	home, err := os.UserHomeDir()
@@ -218,6 +223,35 @@ func (c *Client) determineTargetBase(scope *clients.InstallScope) (string, error
 	}
 }
 
+// PlanPaths lists what installs at scope may touch: the asset directories
+// and settings.json under the scope's .claude directory, its MCP config
+// (~/.claude.json globally, .mcp.json beside .claude otherwise) and the
+// plugin registries
+func (c *Client) PlanPaths(scope *clients.InstallScope) ([]string, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return nil, err
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, name := range []string{
+		handlers.DirSkills, handlers.DirAgents, handlers.DirCommands, handlers.DirMCPServers,
+		"rules", "hooks", "settings.json", ".mcp.json", "plugins/installed_plugins.json",
+	} {
+		paths = append(paths, filepath.Join(targetBase, filepath.FromSlash(name)))
+	}
+	if targetBase == filepath.Join(home, handlers.ConfigDir) {
+		paths = append(paths, filepath.Join(home, ".claude.json"))
+	} else {
+		paths = append(paths, filepath.Join(filepath.Dir(targetBase), ".mcp.json"))
+	}
+	return append(paths, filepath.Join(home, handlers.ConfigDir, "plugins", "known_marketplaces.json")), nil
+}
+
 // ListAssets returns all installed skills for a given scope
 func (c *Client) ListAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
 	targetBase, err := c.determineTargetBase(scope)
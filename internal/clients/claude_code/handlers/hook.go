@@ -157,7 +157,14 @@ func (h *HookHandler) Validate(zipData []byte) error {
 // mapEventToClaudeCode maps a canonical event name to Claude Code native event.
 // If the hook has a [hook.claude-code] event override, that is returned instead.
 func (h *HookHandler) mapEventToClaudeCode() (string, bool) {
-	return hook.MapEvent(h.metadata.Hook.Event, claudeCodeEventMap, h.metadata.Hook.ClaudeCode)
+	return MapHookEvent(h.metadata.Hook)
+}
+
+// MapHookEvent maps a hook's canonical event to the Claude Code native event,
+// honoring a [hook.claude-code] event override. ok is false when Claude Code has no
+// such event.
+func MapHookEvent(cfg *metadata.HookConfig) (string, bool) {
+	return hook.MapEvent(cfg.Event, claudeCodeEventMap, cfg.ClaudeCode)
 }
 
 // updateSettings updates settings.json to register the hook
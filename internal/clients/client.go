@@ -94,6 +94,38 @@ type Client interface {
 	RuleCapabilities() *RuleCapabilities
 }
 
+// HookEventMapper is implemented by clients that install hook assets. It
+// reports the native event a hook fires on — honoring the hook's per-client
+// event override — so callers like `sx lint` can find unsupported events
+// without installing anything. ok is false when InstallAssets would skip
+// the hook with hook.ErrUnsupportedEvent.
+type HookEventMapper interface {
+	MapHookEvent(cfg *metadata.HookConfig) (native string, ok bool)
+}
+
+// ManagedSectionWriter is implemented by clients that install some assets
+// as marked sections of shared instruction files (see the section package).
+// Those installs honor the section options carried in the context, so
+// `sx install --dry-run` can preview them as diffs without writing.
+type ManagedSectionWriter interface {
+	WritesManagedSections(assetType asset.Type, scope *InstallScope) bool
+}
+
+// InstallPlanner is implemented by clients that can list the files and
+// directories InstallAssets and UninstallAssets may touch at a scope
+// (asset directories, MCP and hook config files). `sx install --plan`
+// runs the real install against staged copies of these paths and reports
+// the difference; clients that don't implement it are left out of the
+// plan. Paths that don't exist yet are fine. PlanPaths returns an error
+// wrapping ErrNotPlannable when installs would write somewhere a staged
+// copy can't stand in for.
+type InstallPlanner interface {
+	PlanPaths(scope *InstallScope) ([]string, error)
+}
+
+// ErrNotPlannable marks a client whose installs can't be planned safely
+var ErrNotPlannable = errors.New("cannot be planned")
+
 // InstalledSkill represents a skill that has been installed
 type InstalledSkill struct {
 	Name        string // Skill name
@@ -159,22 +191,30 @@ const (
 	ClientIDOpenClaw      = "openclaw"
 	ClientIDOpenCode      = "opencode"
 	ClientIDKiro          = "kiro"
+	ClientIDWindsurf      = "windsurf"
+	ClientIDZed           = "zed"
+	ClientIDContinue      = "continue"
+	ClientIDAider         = "aider"
 )
 
 // AllClientIDs returns all known client IDs
 func AllClientIDs() []string {
-	return []string{ClientIDClaudeCode, ClientIDCursor, ClientIDCline, ClientIDGemini, ClientIDGitHubCopilot, ClientIDCodex, ClientIDOpenClaw, ClientIDOpenCode, ClientIDKiro}
+	return []string{ClientIDClaudeCode, ClientIDCursor, ClientIDCline, ClientIDGemini, ClientIDGitHubCopilot, ClientIDCodex, ClientIDOpenClaw, ClientIDOpenCode, ClientIDKiro, ClientIDWindsurf, ClientIDZed, ClientIDContinue, ClientIDAider}
 }
 
-// IsValidClientID checks if the given ID is a known client ID
+// IsValidClientID checks if the given ID is a known client ID: a built-in
+// client or a client profile declared in the config
 func IsValidClientID(id string) bool {
-	return slices.Contains(AllClientIDs(), id)
+	return slices.Contains(AllClientIDs(), id) || globalRegistry.hasProfile(id)
 }
 
 // InstallOptions contains optional installation settings
 type InstallOptions struct {
-	Force   bool // Force reinstall even if already installed
-	DryRun  bool // Don't actually install, just validate
+	Force bool // Force reinstall even if already installed
+	// DryRun marks a plan run (`sx install --plan`). The install runs
+	// against staged copies of the paths the client lists through
+	// InstallPlanner, so clients write as usual.
+	DryRun  bool
 	Verbose bool // Verbose output
 }
 
@@ -192,7 +232,7 @@ type UninstallRequest struct {
 
 type UninstallOptions struct {
 	Force   bool // Force uninstall even if dependencies exist
-	DryRun  bool // Don't actually uninstall
+	DryRun  bool // Marks a plan run, as InstallOptions.DryRun does
 	Verbose bool // Verbose output
 }
 
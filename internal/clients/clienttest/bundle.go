@@ -0,0 +1,62 @@
+// Package clienttest holds fixtures shared by the client adapters' tests:
+// asset bundles as the installer hands them to a client, and the lock file
+// rows and uninstall requests that go with them.
+package clienttest
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// Bundle zips meta and files, keyed by path in the zip, into an asset
+// bundle. A config-only asset such as an MCP server passes no files.
+func Bundle(t testing.TB, meta *metadata.Metadata, files map[string]string) *clients.AssetBundle {
+	t.Helper()
+	metaBytes, err := metadata.Marshal(meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zipData, err := utils.CreateZipFromContent("metadata.toml", metaBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		if zipData, err = utils.AddFileToZip(zipData, name, []byte(files[name])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &clients.AssetBundle{
+		Asset:    &lockfile.Asset{Name: meta.Asset.Name, Version: meta.Asset.Version, Type: meta.Asset.Type},
+		Metadata: meta,
+		ZipData:  zipData,
+	}
+}
+
+// LockAssets returns the lock file rows of bundles, for VerifyAssets
+func LockAssets(bundles []*clients.AssetBundle) []*lockfile.Asset {
+	out := make([]*lockfile.Asset, 0, len(bundles))
+	for _, b := range bundles {
+		out = append(out, b.Asset)
+	}
+	return out
+}
+
+// Uninstall removes bundles from scope, failing the test on error
+func Uninstall(t testing.TB, client clients.Client, scope *clients.InstallScope, bundles ...*clients.AssetBundle) {
+	t.Helper()
+	toRemove := make([]asset.Asset, 0, len(bundles))
+	for _, b := range bundles {
+		toRemove = append(toRemove, asset.Asset{Name: b.Asset.Name, Type: b.Asset.Type})
+	}
+	if _, err := client.UninstallAssets(context.Background(), clients.UninstallRequest{Assets: toRemove, Scope: scope}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -48,6 +48,11 @@ func (c *Client) RuleCapabilities() *clients.RuleCapabilities {
 	return RuleCapabilities()
 }
 
+// MapHookEvent reports the Cline event a hook asset fires on
+func (c *Client) MapHookEvent(cfg *metadata.HookConfig) (string, bool) {
+	return handlers.MapHookEvent(cfg)
+}
+
 // IsInstalled checks if Cline is installed by checking for .cline directory
 // or VS Code extension's globalStorage
 func (c *Client) IsInstalled() bool {
@@ -233,6 +238,45 @@ func (c *Client) determineTargetBase(scope *clients.InstallScope) (string, error
 	}
 }
 
+// PlanPaths lists what installs at scope may touch. Besides the scope's
+// .cline directory, Cline keeps rules and workflows in .clinerules beside
+// it (~/.cline/rules and ~/Documents/Cline/Workflows globally), global
+// hooks in ~/Documents/Cline/Hooks, and MCP servers in the CLI or VS Code
+// extension settings. A CLINE_DIR override can't be redirected to a staged
+// copy, so it can't be planned.
+func (c *Client) PlanPaths(scope *clients.InstallScope) ([]string, error) {
+	if os.Getenv("CLINE_DIR") != "" {
+		return nil, fmt.Errorf("%w: CLINE_DIR is set", clients.ErrNotPlannable)
+	}
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return nil, err
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get home directory: %w", err)
+	}
+	mcpConfig, err := handlers.GetMCPConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{
+		filepath.Join(targetBase, handlers.DirSkills),
+		filepath.Join(targetBase, handlers.DirMCPServers),
+		filepath.Join(targetBase, "hooks"),
+		filepath.Join(home, "Documents", "Cline", "Hooks"),
+		mcpConfig,
+	}
+	if targetBase == filepath.Join(home, handlers.ConfigDir) {
+		return append(paths,
+			filepath.Join(targetBase, handlers.DirRules),
+			filepath.Join(home, "Documents", "Cline", "Workflows"),
+		), nil
+	}
+	return append(paths, filepath.Join(filepath.Dir(targetBase), handlers.RulesDir)), nil
+}
+
 // ListAssets returns all installed skills for a given scope
 func (c *Client) ListAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
 	targetBase, err := c.determineTargetBase(scope)
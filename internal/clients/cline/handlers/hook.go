@@ -128,7 +128,14 @@ func (h *HookHandler) VerifyInstalled(targetBase string) (bool, string) {
 // mapEventToCline maps a canonical event name to Cline native event.
 // If the hook has a [hook.cline] event override, that is returned instead.
 func (h *HookHandler) mapEventToCline() (string, bool) {
-	return hook.MapEvent(h.metadata.Hook.Event, clineEventMap, h.metadata.Hook.Cline)
+	return MapHookEvent(h.metadata.Hook)
+}
+
+// MapHookEvent maps a hook's canonical event to the Cline native event,
+// honoring a [hook.cline] event override. ok is false when Cline has no
+// such event.
+func MapHookEvent(cfg *metadata.HookConfig) (string, bool) {
+	return hook.MapEvent(cfg.Event, clineEventMap, cfg.Cline)
 }
 
 // createHookScript creates the hook script in Cline's hooks directory
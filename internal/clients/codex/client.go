@@ -282,6 +282,28 @@ func (c *Client) determineTargetBase(scope *clients.InstallScope, assetType asse
 	}
 }
 
+// PlanPaths lists what installs at scope may touch: skills under .agents,
+// and the commands, agents, MCP servers and config.toml under .codex
+// (plus the legacy ~/.codex/skills copies global installs clean up)
+func (c *Client) PlanPaths(scope *clients.InstallScope) ([]string, error) {
+	skillBase, err := c.determineTargetBase(scope, asset.TypeSkill)
+	if err != nil {
+		return nil, err
+	}
+	targetBase, err := c.determineTargetBase(scope, asset.TypeCommand)
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{filepath.Join(skillBase, handlers.DirSkills)}
+	for _, name := range []string{handlers.DirCommands, handlers.DirAgents, handlers.DirMCPServers, "config.toml"} {
+		paths = append(paths, filepath.Join(targetBase, name))
+	}
+	if scope.Type == clients.ScopeGlobal {
+		paths = append(paths, filepath.Join(targetBase, handlers.DirSkills))
+	}
+	return paths, nil
+}
+
 // ListAssets returns all installed skills for a given scope
 func (c *Client) ListAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
 	targetBase, err := c.determineTargetBase(scope, asset.TypeSkill)
@@ -0,0 +1,392 @@
+package continuedev
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/bootstrap"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/continuedev/handlers"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/logger"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+// Client implements the clients.Client interface for Continue
+type Client struct {
+	clients.BaseClient
+}
+
+// NewClient creates a new Continue client
+func NewClient() *Client {
+	return &Client{
+		BaseClient: clients.NewBaseClient(
+			clients.ClientIDContinue,
+			"Continue",
+			[]asset.Type{
+				asset.TypeCommand, // .continue/prompts/{name}.prompt
+				asset.TypeRule,    // .continue/rules/{name}.md
+				asset.TypeMCP,     // config.yaml mcpServers, or .continue/mcpServers/{name}.yaml
+			},
+		),
+	}
+}
+
+// RuleCapabilities returns Continue's rule capabilities
+func (c *Client) RuleCapabilities() *clients.RuleCapabilities {
+	return RuleCapabilities()
+}
+
+// IsInstalled checks if Continue is installed: the ~/.continue directory
+// the IDE extensions create on first run, or the cn CLI in PATH.
+// Workspace .continue directories don't count, since they can be
+// committed to a repo without Continue being installed.
+func (c *Client) IsInstalled() bool {
+	if _, err := exec.LookPath("cn"); err == nil {
+		return true
+	}
+
+	globalDir, err := handlers.GlobalDir()
+	if err != nil {
+		return false
+	}
+	stat, err := os.Stat(globalDir)
+	return err == nil && stat.IsDir()
+}
+
+// GetVersion returns the Continue CLI version. The IDE extensions don't
+// expose theirs, so it's empty without the CLI.
+func (c *Client) GetVersion() string {
+	cmd := exec.Command("cn", "--version")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// InstallAssets installs assets to Continue using client-specific handlers
+func (c *Client) InstallAssets(ctx context.Context, req clients.InstallRequest) (clients.InstallResponse, error) {
+	resp := clients.InstallResponse{
+		Results: make([]clients.AssetResult, 0, len(req.Assets)),
+	}
+
+	targetBase, err := c.determineTargetBase(req.Scope)
+	if err != nil {
+		return resp, fmt.Errorf("cannot determine installation directory: %w", err)
+	}
+
+	if err := os.MkdirAll(targetBase, 0755); err != nil {
+		return resp, fmt.Errorf("failed to create target directory: %w", err)
+	}
+
+	for _, bundle := range req.Assets {
+		result := clients.AssetResult{
+			AssetName: bundle.Asset.Name,
+		}
+
+		handler, err := handlers.NewHandler(bundle.Metadata.Asset.Type, bundle.Metadata)
+		if err != nil {
+			result.Status = clients.StatusSkipped
+			result.Message = "Unsupported asset type: " + bundle.Metadata.Asset.Type.Key
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+		err = handler.Install(ctx, bundle.ZipData, targetBase)
+
+		result.Status, result.Message, result.Error = clients.TranslateInstallError(err, "Installed to "+targetBase)
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+// UninstallAssets removes assets from Continue
+func (c *Client) UninstallAssets(ctx context.Context, req clients.UninstallRequest) (clients.UninstallResponse, error) {
+	resp := clients.UninstallResponse{
+		Results: make([]clients.AssetResult, 0, len(req.Assets)),
+	}
+
+	targetBase, err := c.determineTargetBase(req.Scope)
+	if err != nil {
+		return resp, fmt.Errorf("cannot determine uninstall directory: %w", err)
+	}
+
+	for _, a := range req.Assets {
+		result := clients.AssetResult{
+			AssetName: a.Name,
+		}
+
+		// Create minimal metadata for removal
+		meta := &metadata.Metadata{
+			Asset: metadata.Asset{
+				Name: a.Name,
+				Type: a.Type,
+			},
+		}
+
+		handler, err := handlers.NewHandler(a.Type, meta)
+		if err != nil {
+			result.Status = clients.StatusSkipped
+			result.Message = "Unsupported asset type: " + a.Type.Key
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		if err := handler.Remove(ctx, targetBase); err != nil {
+			result.Status = clients.StatusFailed
+			result.Error = err
+		} else {
+			result.Status = clients.StatusSuccess
+			result.Message = "Uninstalled successfully"
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+// determineTargetBase returns the installation directory based on scope:
+// ~/.continue globally, otherwise the scope's .continue directory
+func (c *Client) determineTargetBase(scope *clients.InstallScope) (string, error) {
+	switch scope.Type {
+	case clients.ScopeRepository:
+		if scope.RepoRoot == "" {
+			return "", errors.New("repo-scoped install requires RepoRoot but none provided (not in a git repository?)")
+		}
+		return filepath.Join(scope.RepoRoot, handlers.ConfigDir), nil
+	case clients.ScopePath:
+		if scope.RepoRoot == "" {
+			return "", errors.New("path-scoped install requires RepoRoot but none provided (not in a git repository?)")
+		}
+		return filepath.Join(scope.RepoRoot, scope.Path, handlers.ConfigDir), nil
+	default:
+		return handlers.GlobalDir()
+	}
+}
+
+// PlanPaths lists what installs at scope may touch: the rules, prompts
+// and MCP server blocks under the scope's .continue directory, and the
+// global config.yaml
+func (c *Client) PlanPaths(scope *clients.InstallScope) ([]string, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, name := range []string{
+		handlers.DirRules, handlers.DirPrompts, handlers.DirMCPBlocks, handlers.DirMCPServers, handlers.ConfigFile,
+	} {
+		paths = append(paths, filepath.Join(targetBase, name))
+	}
+	return paths, nil
+}
+
+// ListAssets returns installed skills; Continue has no skills
+func (c *Client) ListAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
+	return []clients.InstalledSkill{}, nil
+}
+
+// ReadSkill reads a skill by name; Continue has no skills
+func (c *Client) ReadSkill(ctx context.Context, name string, scope *clients.InstallScope) (*clients.SkillContent, error) {
+	return nil, fmt.Errorf("skill not found: %s", name)
+}
+
+// EnsureAssetSupport is a no-op for Continue: it discovers rules, prompts
+// and MCP blocks from their directories natively.
+func (c *Client) EnsureAssetSupport(ctx context.Context, scope *clients.InstallScope) error {
+	return nil
+}
+
+// GetBootstrapOptions returns bootstrap options for Continue. Continue
+// has no hooks, so only the MCP server is offered.
+func (c *Client) GetBootstrapOptions(ctx context.Context) []bootstrap.Option {
+	return []bootstrap.Option{
+		bootstrap.SleuthAIQueryMCP(),
+	}
+}
+
+// GetBootstrapPath returns the path to Continue's config.yaml.
+func (c *Client) GetBootstrapPath() string {
+	dir, err := handlers.GlobalDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, handlers.ConfigFile)
+}
+
+// InstallBootstrap registers MCP servers from the enabled options in
+// config.yaml.
+func (c *Client) InstallBootstrap(ctx context.Context, opts []bootstrap.Option) error {
+	log := logger.Get()
+	configPath := c.GetBootstrapPath()
+	if configPath == "" {
+		return errors.New("cannot determine Continue config path")
+	}
+
+	for _, opt := range opts {
+		if opt.MCPConfig == nil {
+			continue
+		}
+		server := handlers.MCPServer{
+			Name:    opt.MCPConfig.Name,
+			Command: opt.MCPConfig.Command,
+			Args:    opt.MCPConfig.Args,
+			Env:     opt.MCPConfig.Env,
+		}
+		if err := handlers.AddMCPServer(configPath, server); err != nil {
+			return fmt.Errorf("failed to install MCP server %s: %w", opt.MCPConfig.Name, err)
+		}
+		log.Info("MCP server installed", "server", opt.MCPConfig.Name, "client", clients.ClientIDContinue)
+	}
+
+	return nil
+}
+
+// UninstallBootstrap removes MCP servers installed by InstallBootstrap.
+func (c *Client) UninstallBootstrap(ctx context.Context, opts []bootstrap.Option) error {
+	log := logger.Get()
+	configPath := c.GetBootstrapPath()
+	if configPath == "" {
+		return errors.New("cannot determine Continue config path")
+	}
+
+	for _, opt := range opts {
+		if opt.MCPConfig == nil {
+			continue
+		}
+		if err := handlers.RemoveMCPServer(configPath, opt.MCPConfig.Name); err != nil {
+			return err
+		}
+		log.Info("MCP server uninstalled", "server", opt.MCPConfig.Name, "client", clients.ClientIDContinue)
+	}
+
+	return nil
+}
+
+// ShouldInstall always returns true for Continue, which has no session
+// hook to deduplicate.
+func (c *Client) ShouldInstall(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// VerifyAssets checks if assets are actually installed on the filesystem
+func (c *Client) VerifyAssets(ctx context.Context, assets []*lockfile.Asset, scope *clients.InstallScope) []clients.VerifyResult {
+	results := make([]clients.VerifyResult, 0, len(assets))
+
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		// Can't determine target - mark all assets as not installed
+		for _, a := range assets {
+			results = append(results, clients.VerifyResult{
+				Asset:     a,
+				Installed: false,
+				Message:   fmt.Sprintf("cannot determine target directory: %v", err),
+			})
+		}
+		return results
+	}
+
+	for _, a := range assets {
+		result := clients.VerifyResult{
+			Asset: a,
+		}
+
+		handler, err := handlers.NewHandler(a.Type, &metadata.Metadata{
+			Asset: metadata.Asset{
+				Name:    a.Name,
+				Version: a.Version,
+				Type:    a.Type,
+			},
+		})
+		if err != nil {
+			result.Message = err.Error()
+		} else {
+			result.Installed, result.Message = handler.VerifyInstalled(targetBase)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// ScanInstalledAssets finds rules and prompts for import. Neither carries
+// sx metadata, so init filters out the ones sx installed against the
+// lock file.
+func (c *Client) ScanInstalledAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledAsset, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine target directory: %w", err)
+	}
+
+	var assets []clients.InstalledAsset
+
+	rules, err := scanFiles(filepath.Join(targetBase, handlers.DirRules), ".md", asset.TypeRule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan rules: %w", err)
+	}
+	assets = append(assets, rules...)
+
+	prompts, err := scanFiles(filepath.Join(targetBase, handlers.DirPrompts), handlers.PromptExt, asset.TypeCommand)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan prompts: %w", err)
+	}
+	assets = append(assets, prompts...)
+
+	return assets, nil
+}
+
+// scanFiles lists the files in dir with extension ext as assets of one type
+func scanFiles(dir, ext string, assetType asset.Type) ([]clients.InstalledAsset, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var assets []clients.InstalledAsset
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(name), ext) {
+			continue
+		}
+		assets = append(assets, clients.InstalledAsset{
+			Name:    strings.TrimSuffix(name, filepath.Ext(name)),
+			Version: "1.0", // Default version for unmanaged assets
+			Type:    assetType,
+		})
+	}
+	return assets, nil
+}
+
+// GetAssetPath returns the filesystem path to an installed asset
+func (c *Client) GetAssetPath(ctx context.Context, name string, assetType asset.Type, scope *clients.InstallScope) (string, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return "", fmt.Errorf("cannot determine target directory: %w", err)
+	}
+
+	switch assetType {
+	case asset.TypeCommand:
+		return handlers.PromptPath(targetBase, name), nil
+	case asset.TypeRule:
+		return handlers.RulePath(targetBase, name), nil
+	default:
+		return "", fmt.Errorf("import not supported for type: %s", assetType)
+	}
+}
+
+func init() {
+	// Auto-register on package import
+	clients.Register(NewClient())
+}
@@ -0,0 +1,193 @@
+package continuedev
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/clienttest"
+	"github.com/sleuth-io/sx/v2/internal/clients/continuedev/handlers"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+func testAssets(t *testing.T) []*clients.AssetBundle {
+	t.Helper()
+	return []*clients.AssetBundle{
+		clienttest.Bundle(t, &metadata.Metadata{
+			Asset: metadata.Asset{Name: "go-style", Version: "1.0", Type: asset.TypeRule},
+			Rule:  &metadata.RuleConfig{Title: "Go Style", Globs: []string{"**/*.go"}},
+		}, map[string]string{"RULE.md": "Use gofmt."}),
+		clienttest.Bundle(t, &metadata.Metadata{
+			Asset:   metadata.Asset{Name: "release", Version: "1.0", Type: asset.TypeCommand, Description: "Cut a release"},
+			Command: &metadata.CommandConfig{PromptFile: "COMMAND.md"},
+		}, map[string]string{"COMMAND.md": "---\nallowed-tools: Bash\n---\n\nTag and push."}),
+		clienttest.Bundle(t, &metadata.Metadata{
+			Asset: metadata.Asset{Name: "docs", Version: "1.0", Type: asset.TypeMCP},
+			MCP:   &metadata.MCPConfig{Transport: "sse", URL: "https://docs.example.com/sse"},
+		}, nil),
+	}
+}
+
+func TestInstallAssets_RepoScope(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	repo := t.TempDir()
+	ctx := context.Background()
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: repo}
+	bundles := testAssets(t)
+
+	resp, err := client.InstallAssets(ctx, clients.InstallRequest{Assets: bundles, Scope: scope})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range resp.Results {
+		if r.Status != clients.StatusSuccess {
+			t.Fatalf("%s: %s %v", r.AssetName, r.Status, r.Error)
+		}
+	}
+
+	base := filepath.Join(repo, ".continue")
+	rule, _ := os.ReadFile(filepath.Join(base, "rules", "go-style.md"))
+	if string(rule) != "---\nname: Go Style\nglobs: '**/*.go'\n---\n\nUse gofmt.\n" {
+		t.Errorf("rule file:\n%s", rule)
+	}
+	prompt, _ := os.ReadFile(filepath.Join(base, "prompts", "release.prompt"))
+	if string(prompt) != "name: release\ndescription: Cut a release\ninvokable: true\n---\nTag and push." {
+		t.Errorf("prompt file:\n%q", prompt)
+	}
+	block, _ := os.ReadFile(filepath.Join(base, "mcpServers", "docs.yaml"))
+	if !strings.Contains(string(block), "mcpServers:\n  - name: docs\n    type: sse\n    url: https://docs.example.com/sse\n") {
+		t.Errorf("MCP block:\n%s", block)
+	}
+
+	for _, v := range client.VerifyAssets(ctx, clienttest.LockAssets(bundles), scope) {
+		if !v.Installed {
+			t.Errorf("%s not verified: %s", v.Asset.Name, v.Message)
+		}
+	}
+
+	clienttest.Uninstall(t, client, scope, bundles...)
+	for _, v := range client.VerifyAssets(ctx, clienttest.LockAssets(bundles), scope) {
+		if v.Installed {
+			t.Errorf("%s still installed after uninstall", v.Asset.Name)
+		}
+	}
+}
+
+func TestInstallAssets_GlobalConfigKeepsUserSettings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	ctx := context.Background()
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeGlobal}
+
+	configPath := filepath.Join(home, ".continue", "config.yaml")
+	original := `name: My Assistant
+version: 1.0.0
+schema: v1
+# Models I use daily
+models:
+  - name: Claude
+    provider: anthropic
+    model: claude-sonnet
+mcpServers:
+  - name: sqlite # local database
+    command: npx
+    args: ["-y", "mcp-sqlite"]
+`
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mcp := testAssets(t)[2:]
+	for range 2 { // installing twice must not duplicate the entry
+		if _, err := client.InstallAssets(ctx, clients.InstallRequest{Assets: mcp, Scope: scope}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, _ := os.ReadFile(configPath)
+	for _, want := range []string{"# Models I use daily", "- name: sqlite # local database", "model: claude-sonnet"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("config.yaml lost %q:\n%s", want, data)
+		}
+	}
+	servers, err := handlers.ReadMCPServers(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(servers) != 2 || servers[1].Name != "docs" || servers[1].URL != "https://docs.example.com/sse" {
+		t.Errorf("mcpServers = %+v", servers)
+	}
+
+	clienttest.Uninstall(t, client, scope, mcp...)
+	servers, _ = handlers.ReadMCPServers(configPath)
+	if len(servers) != 1 || servers[0].Name != "sqlite" {
+		t.Errorf("after uninstall mcpServers = %+v", servers)
+	}
+}
+
+func TestInstallBootstrap_CreatesConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	client := NewClient()
+
+	opts := client.GetBootstrapOptions(context.Background())
+	if err := client.InstallBootstrap(context.Background(), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(home, ".continue", "config.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "name: Local Assistant\nversion: 1.0.0\nschema: v1\nmcpServers:\n") {
+		t.Errorf("config.yaml:\n%s", data)
+	}
+}
+
+func TestScanInstalledAssets(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeGlobal}
+	base := filepath.Join(home, ".continue")
+
+	for path, content := range map[string]string{
+		"rules/sql.md":          "Uppercase keywords.",
+		"prompts/triage.prompt": "name: triage\n---\nTriage the issue.",
+		"prompts/notes.txt":     "not a prompt",
+	} {
+		p := filepath.Join(base, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	found, err := client.ScanInstalledAssets(context.Background(), scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, a := range found {
+		got[a.Name] = a.Type.Key
+	}
+	if len(got) != 2 || got["sql"] != asset.TypeRule.Key || got["triage"] != asset.TypeCommand.Key {
+		t.Errorf("ScanInstalledAssets = %v", got)
+	}
+
+	path, err := client.GetAssetPath(context.Background(), "triage", asset.TypeCommand, scope)
+	if err != nil || path != filepath.Join(base, "prompts", "triage.prompt") {
+		t.Errorf("GetAssetPath = %q, %v", path, err)
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// promptPreamble is the YAML preamble of a .prompt file. invokable makes
+// the prompt a /{name} slash command.
+type promptPreamble struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Invokable   bool   `yaml:"invokable"`
+}
+
+// CommandHandler handles command asset installation for Continue.
+// Commands become invokable prompt files at {base}/prompts/{name}.prompt.
+type CommandHandler struct {
+	metadata *metadata.Metadata
+}
+
+// NewCommandHandler creates a new command handler
+func NewCommandHandler(meta *metadata.Metadata) *CommandHandler {
+	return &CommandHandler{metadata: meta}
+}
+
+// PromptPath returns the prompt file for a command name
+func PromptPath(targetBase, name string) string {
+	return filepath.Join(targetBase, DirPrompts, name+PromptExt)
+}
+
+// Install writes the command as a prompt file
+func (h *CommandHandler) Install(ctx context.Context, zipData []byte, targetBase string) error {
+	promptFile := h.getPromptFile()
+	if promptFile == "" {
+		return errors.New("no prompt file specified in metadata")
+	}
+	promptContent, err := utils.ReadZipFile(zipData, promptFile)
+	if err != nil {
+		return fmt.Errorf("failed to read prompt file: %w", err)
+	}
+
+	promptPath := PromptPath(targetBase, h.metadata.Asset.Name)
+	if err := utils.EnsureDir(filepath.Dir(promptPath)); err != nil {
+		return fmt.Errorf("failed to create prompts directory: %w", err)
+	}
+	if err := os.WriteFile(promptPath, h.buildPrompt(string(promptContent)), 0644); err != nil {
+		return fmt.Errorf("failed to write prompt file: %w", err)
+	}
+	return nil
+}
+
+// Remove removes the prompt file
+func (h *CommandHandler) Remove(ctx context.Context, targetBase string) error {
+	if err := os.Remove(PromptPath(targetBase, h.metadata.Asset.Name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove prompt file: %w", err)
+	}
+	return nil
+}
+
+// VerifyInstalled checks if the prompt file exists
+func (h *CommandHandler) VerifyInstalled(targetBase string) (bool, string) {
+	promptPath := PromptPath(targetBase, h.metadata.Asset.Name)
+	if !utils.FileExists(promptPath) {
+		return false, "prompt file not found"
+	}
+	return true, "Found at " + promptPath
+}
+
+// buildPrompt writes the preamble, a --- line, then the prompt body.
+// A markdown frontmatter block on the command is dropped, since Continue
+// would read it as part of the body.
+func (h *CommandHandler) buildPrompt(prompt string) []byte {
+	prompt = strings.ReplaceAll(prompt, "\r\n", "\n")
+	if rest, ok := strings.CutPrefix(prompt, "---\n"); ok {
+		if _, body, found := strings.Cut(rest, "\n---\n"); found {
+			prompt = strings.TrimLeft(body, "\n")
+		}
+	}
+
+	var buf bytes.Buffer
+	// Marshalling a struct of strings can't fail
+	preamble, _ := yaml.Marshal(promptPreamble{
+		Name:        h.metadata.Asset.Name,
+		Description: h.metadata.Asset.Description,
+		Invokable:   true,
+	})
+	buf.Write(preamble)
+	buf.WriteString("---\n")
+	buf.WriteString(prompt)
+	return buf.Bytes()
+}
+
+func (h *CommandHandler) getPromptFile() string {
+	// Check both Command and Skill metadata sections
+	if h.metadata.Command != nil && h.metadata.Command.PromptFile != "" {
+		return h.metadata.Command.PromptFile
+	}
+	if h.metadata.Skill != nil && h.metadata.Skill.PromptFile != "" {
+		return h.metadata.Skill.PromptFile
+	}
+	return ""
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ConfigDir is Continue's directory: ~/.continue for the user, and
+// .continue in a workspace
+const ConfigDir = ".continue"
+
+// Directory and file names for Continue assets
+const (
+	DirRules   = "rules"   // {base}/rules/{name}.md
+	DirPrompts = "prompts" // {base}/prompts/{name}.prompt
+
+	// DirMCPBlocks holds workspace MCP server blocks, one YAML file per
+	// server; Continue only reads config.yaml from ~/.continue
+	DirMCPBlocks = "mcpServers"
+
+	// DirMCPServers holds extracted packaged MCP servers
+	DirMCPServers = "mcp-servers"
+
+	// ConfigFile is Continue's user config
+	ConfigFile = "config.yaml"
+
+	PromptExt = ".prompt"
+)
+
+// GlobalDir returns ~/.continue
+func GlobalDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, ConfigDir), nil
+}
+
+// isGlobalBase reports whether targetBase is ~/.continue rather than a
+// workspace's .continue
+func isGlobalBase(targetBase string) bool {
+	global, err := GlobalDir()
+	return err == nil && filepath.Clean(targetBase) == global
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+// Handler defines the interface for asset type handlers
+type Handler interface {
+	// Install installs the asset from zip data to the target base directory
+	Install(ctx context.Context, zipData []byte, targetBase string) error
+
+	// Remove removes the asset from the target base directory
+	Remove(ctx context.Context, targetBase string) error
+
+	// VerifyInstalled checks if the asset is properly installed
+	// Returns (installed bool, message string)
+	VerifyInstalled(targetBase string) (bool, string)
+}
+
+// NewHandler creates a handler for the given asset type and metadata
+func NewHandler(assetType asset.Type, meta *metadata.Metadata) (Handler, error) {
+	switch assetType {
+	case asset.TypeCommand:
+		return NewCommandHandler(meta), nil
+	case asset.TypeRule:
+		return NewRuleHandler(meta), nil
+	case asset.TypeMCP:
+		return NewMCPHandler(meta), nil
+	default:
+		return nil, fmt.Errorf("unsupported asset type for Continue: %s", assetType.Key)
+	}
+}
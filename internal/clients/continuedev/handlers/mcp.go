@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/handlers/dirasset"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+var mcpOps = dirasset.NewOperations(DirMCPServers, &asset.TypeMCP)
+
+// mcpServersKey is the config key Continue reads MCP servers from
+const mcpServersKey = "mcpServers"
+
+// MCPServer is one entry of a Continue mcpServers list
+type MCPServer struct {
+	Name    string            `yaml:"name"`
+	Type    string            `yaml:"type,omitempty"` // sse or streamable-http for remote servers
+	URL     string            `yaml:"url,omitempty"`
+	Command string            `yaml:"command,omitempty"`
+	Args    []string          `yaml:"args,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty"`
+}
+
+// mcpBlock is a standalone workspace block file holding one server
+type mcpBlock struct {
+	Name       string      `yaml:"name"`
+	Version    string      `yaml:"version"`
+	Schema     string      `yaml:"schema"`
+	MCPServers []MCPServer `yaml:"mcpServers"`
+}
+
+// MCPHandler handles MCP asset installation for Continue. Global servers
+// are added to the mcpServers list in ~/.continue/config.yaml, edited in
+// place so the rest of the config survives. Continue doesn't read a
+// workspace config.yaml, so workspace servers are written as block files
+// in .continue/mcpServers/{name}.yaml.
+type MCPHandler struct {
+	metadata *metadata.Metadata
+}
+
+// NewMCPHandler creates a new MCP handler
+func NewMCPHandler(meta *metadata.Metadata) *MCPHandler {
+	return &MCPHandler{metadata: meta}
+}
+
+// Install installs an MCP asset
+func (h *MCPHandler) Install(ctx context.Context, zipData []byte, targetBase string) error {
+	hasContent, err := utils.HasContentFiles(zipData)
+	if err != nil {
+		return fmt.Errorf("failed to inspect zip contents: %w", err)
+	}
+
+	var server MCPServer
+	if hasContent {
+		// Packaged mode: extract MCP server files
+		serverDir := filepath.Join(targetBase, DirMCPServers, h.metadata.Asset.Name)
+		if err := utils.ExtractZip(zipData, serverDir); err != nil {
+			return fmt.Errorf("failed to extract MCP server: %w", err)
+		}
+		server = h.packagedServer(serverDir)
+	} else {
+		// Config-only mode: no extraction needed
+		server = h.configOnlyServer()
+	}
+
+	if isGlobalBase(targetBase) {
+		return AddMCPServer(filepath.Join(targetBase, ConfigFile), server)
+	}
+	return writeMCPBlock(BlockPath(targetBase, h.metadata.Asset.Name), server)
+}
+
+// Remove removes the MCP server
+func (h *MCPHandler) Remove(ctx context.Context, targetBase string) error {
+	if isGlobalBase(targetBase) {
+		if err := RemoveMCPServer(filepath.Join(targetBase, ConfigFile), h.metadata.Asset.Name); err != nil {
+			return err
+		}
+	} else if err := os.Remove(BlockPath(targetBase, h.metadata.Asset.Name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove MCP block: %w", err)
+	}
+
+	// Remove server directory if it exists (packaged mode)
+	serverDir := filepath.Join(targetBase, DirMCPServers, h.metadata.Asset.Name)
+	os.RemoveAll(serverDir) // Ignore errors if doesn't exist
+
+	return nil
+}
+
+// VerifyInstalled checks if the MCP server is properly installed
+func (h *MCPHandler) VerifyInstalled(targetBase string) (bool, string) {
+	// Check if install directory exists (packaged mode)
+	installDir := filepath.Join(targetBase, DirMCPServers, h.metadata.Asset.Name)
+	if utils.IsDirectory(installDir) {
+		return mcpOps.VerifyInstalled(targetBase, h.metadata.Asset.Name, h.metadata.Asset.Version)
+	}
+
+	if !isGlobalBase(targetBase) {
+		blockPath := BlockPath(targetBase, h.metadata.Asset.Name)
+		if !utils.FileExists(blockPath) {
+			return false, "MCP block not found"
+		}
+		return true, "Found at " + blockPath
+	}
+
+	servers, err := ReadMCPServers(filepath.Join(targetBase, ConfigFile))
+	if err != nil {
+		return false, "failed to read Continue config: " + err.Error()
+	}
+	for _, s := range servers {
+		if s.Name == h.metadata.Asset.Name {
+			return true, "installed"
+		}
+	}
+	return false, "MCP server not registered"
+}
+
+func (h *MCPHandler) packagedServer(serverDir string) MCPServer {
+	mcpConfig := h.metadata.MCP
+
+	return MCPServer{
+		Name:    h.metadata.Asset.Name,
+		Command: utils.ResolveCommand(mcpConfig.Command, serverDir),
+		Args:    utils.ResolveArgs(mcpConfig.Args, serverDir),
+		Env:     mcpConfig.Env,
+	}
+}
+
+func (h *MCPHandler) configOnlyServer() MCPServer {
+	mcpConfig := h.metadata.MCP
+
+	if mcpConfig.IsRemote() {
+		serverType := "streamable-http"
+		if mcpConfig.Transport == "sse" {
+			serverType = "sse"
+		}
+		return MCPServer{
+			Name: h.metadata.Asset.Name,
+			Type: serverType,
+			URL:  mcpConfig.URL,
+		}
+	}
+
+	return MCPServer{
+		Name:    h.metadata.Asset.Name,
+		Command: mcpConfig.Command,
+		Args:    mcpConfig.Args,
+		Env:     mcpConfig.Env,
+	}
+}
+
+// BlockPath returns the workspace block file for an MCP server
+func BlockPath(targetBase, name string) string {
+	return filepath.Join(targetBase, DirMCPBlocks, name+".yaml")
+}
+
+func writeMCPBlock(path string, server MCPServer) error {
+	node, err := utils.YAMLNode(mcpBlock{
+		Name:       server.Name,
+		Version:    "0.0.1",
+		Schema:     "v1",
+		MCPServers: []MCPServer{server},
+	})
+	if err != nil {
+		return err
+	}
+	data, err := utils.EncodeYAMLDocument(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{node}})
+	if err != nil {
+		return err
+	}
+	if err := utils.EnsureDir(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("failed to create MCP blocks directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write MCP block: %w", err)
+	}
+	return nil
+}
+
+// ReadMCPServers returns the mcpServers list of a Continue config file;
+// a missing file has none
+func ReadMCPServers(path string) ([]MCPServer, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var config struct {
+		MCPServers []MCPServer `yaml:"mcpServers"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	return config.MCPServers, nil
+}
+
+// AddMCPServer adds or replaces a server, matched by name, in the
+// mcpServers list of a Continue config file. A missing config is created
+// with the fields Continue requires.
+func AddMCPServer(path string, server MCPServer) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		data = []byte("name: Local Assistant\nversion: 1.0.0\nschema: v1\n")
+	} else if err != nil {
+		return fmt.Errorf("failed to read Continue config: %w", err)
+	}
+
+	doc, err := utils.ParseYAMLDocument(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse Continue config %s: %w", path, err)
+	}
+	entry, err := utils.YAMLNode(server)
+	if err != nil {
+		return err
+	}
+
+	root := doc.Content[0]
+	list := utils.YAMLMapValue(root, mcpServersKey)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		list = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		utils.SetYAMLMapValue(root, mcpServersKey, list)
+	}
+	if i := serverIndex(list, server.Name); i >= 0 {
+		list.Content[i] = entry
+	} else {
+		list.Content = append(list.Content, entry)
+	}
+
+	return writeConfig(path, doc)
+}
+
+// RemoveMCPServer removes a server by name from a Continue config file.
+// The file is left alone when the server isn't there.
+func RemoveMCPServer(path, name string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read Continue config: %w", err)
+	}
+
+	doc, err := utils.ParseYAMLDocument(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse Continue config %s: %w", path, err)
+	}
+	list := utils.YAMLMapValue(doc.Content[0], mcpServersKey)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return nil
+	}
+	i := serverIndex(list, name)
+	if i < 0 {
+		return nil
+	}
+	list.Content = append(list.Content[:i], list.Content[i+1:]...)
+
+	return writeConfig(path, doc)
+}
+
+// serverIndex returns the index of the server named name, or -1
+func serverIndex(list *yaml.Node, name string) int {
+	for i, item := range list.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		if n := utils.YAMLMapValue(item, "name"); n != nil && n.Value == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func writeConfig(path string, doc *yaml.Node) error {
+	out, err := utils.EncodeYAMLDocument(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode Continue config: %w", err)
+	}
+	if err := utils.EnsureDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := utils.WriteFileAtomic(path, out, 0644); err != nil {
+		return fmt.Errorf("failed to write Continue config: %w", err)
+	}
+	return nil
+}
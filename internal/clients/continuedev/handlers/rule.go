@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sleuth-io/sx/v2/internal/handlers/rule"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// ruleFrontmatter is the frontmatter of a Continue rule, in the order
+// Continue's docs list it
+type ruleFrontmatter struct {
+	Name        string `yaml:"name,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	Globs       any    `yaml:"globs,omitempty"`
+	Regex       string `yaml:"regex,omitempty"`
+	AlwaysApply *bool  `yaml:"alwaysApply,omitempty"`
+}
+
+// RenderRuleFile renders a Continue rule. Without globs or alwaysApply,
+// Continue includes the rule in every request, matching sx's default.
+// [rule.continue] may set always-apply and regex.
+func RenderRuleFile(name string, cfg *metadata.RuleConfig, body string) []byte {
+	fm := ruleFrontmatter{Name: name}
+	if cfg != nil {
+		fm.Description = cfg.Description
+		switch len(cfg.Globs) {
+		case 0:
+		case 1:
+			fm.Globs = cfg.Globs[0]
+		default:
+			fm.Globs = cfg.Globs
+		}
+		if v, ok := cfg.Continue["always-apply"].(bool); ok {
+			fm.AlwaysApply = &v
+		}
+		if v, ok := cfg.Continue["regex"].(string); ok {
+			fm.Regex = v
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	// Marshalling a struct of strings can't fail
+	fmData, _ := yaml.Marshal(fm)
+	buf.Write(fmData)
+	buf.WriteString("---\n\n")
+	buf.WriteString(body)
+	return buf.Bytes()
+}
+
+// RuleHandler handles rule asset installation for Continue. Rules are
+// written to {base}/rules/{name}.md with Continue frontmatter.
+type RuleHandler struct {
+	metadata *metadata.Metadata
+}
+
+// NewRuleHandler creates a new rule handler
+func NewRuleHandler(meta *metadata.Metadata) *RuleHandler {
+	return &RuleHandler{metadata: meta}
+}
+
+// RulePath returns the rule file for a rule name
+func RulePath(targetBase, name string) string {
+	return filepath.Join(targetBase, DirRules, name+".md")
+}
+
+// Install writes the rule file
+func (h *RuleHandler) Install(ctx context.Context, zipData []byte, targetBase string) error {
+	content, err := h.readRuleContent(zipData)
+	if err != nil {
+		return fmt.Errorf("failed to read rule content: %w", err)
+	}
+
+	filePath := RulePath(targetBase, h.metadata.Asset.Name)
+	if err := utils.EnsureDir(filepath.Dir(filePath)); err != nil {
+		return fmt.Errorf("failed to create rules directory: %w", err)
+	}
+	data := RenderRuleFile(h.getTitle(), h.metadata.Rule, strings.TrimSpace(content)+"\n")
+	if err := os.WriteFile(filePath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write rule file: %w", err)
+	}
+	return nil
+}
+
+// Remove removes the rule file
+func (h *RuleHandler) Remove(ctx context.Context, targetBase string) error {
+	if err := os.Remove(RulePath(targetBase, h.metadata.Asset.Name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove rule file: %w", err)
+	}
+	return nil
+}
+
+// VerifyInstalled checks if the rule file exists
+func (h *RuleHandler) VerifyInstalled(targetBase string) (bool, string) {
+	filePath := RulePath(targetBase, h.metadata.Asset.Name)
+	if utils.FileExists(filePath) {
+		return true, "Found at " + filePath
+	}
+	return false, "Rule file not found"
+}
+
+// getTitle returns the rule title, defaulting to asset name
+func (h *RuleHandler) getTitle() string {
+	if h.metadata.Rule != nil && h.metadata.Rule.Title != "" {
+		return h.metadata.Rule.Title
+	}
+	return h.metadata.Asset.Name
+}
+
+// getPromptFile returns the prompt file, using the shared default
+func (h *RuleHandler) getPromptFile() string {
+	if h.metadata.Rule != nil && h.metadata.Rule.PromptFile != "" {
+		return h.metadata.Rule.PromptFile
+	}
+	return rule.DefaultPromptFile
+}
+
+// readRuleContent reads the rule content from the zip
+func (h *RuleHandler) readRuleContent(zipData []byte) (string, error) {
+	promptFile := h.getPromptFile()
+
+	content, err := utils.ReadZipFile(zipData, promptFile)
+	if err != nil {
+		// Try lowercase variant
+		content, err = utils.ReadZipFile(zipData, "rule.md")
+		if err != nil {
+			return "", fmt.Errorf("prompt file not found: %s", promptFile)
+		}
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,146 @@
+package continuedev
+
+import (
+	"errors"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/continuedev/handlers"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+// RuleCapabilities returns the rule capabilities for Continue
+func RuleCapabilities() *clients.RuleCapabilities {
+	return &clients.RuleCapabilities{
+		ClientName:       clients.ClientIDContinue,
+		RulesDirectory:   ".continue/rules",
+		FileExtension:    ".md",
+		InstructionFiles: []string{},
+		MatchesPath:      matchesPath,
+		MatchesContent:   matchesContent,
+		ParseRuleFile:    parseRuleFile,
+		GenerateRuleFile: generateRuleFile,
+		DetectAssetType:  detectAssetType,
+	}
+}
+
+// detectAssetType determines the asset type for Continue paths
+func detectAssetType(path string, _ []byte) *asset.Type {
+	lower := strings.ToLower(path)
+
+	if strings.Contains(lower, ".continue/rules/") && strings.HasSuffix(lower, ".md") {
+		return &asset.TypeRule
+	}
+	if strings.Contains(lower, ".continue/prompts/") && strings.HasSuffix(lower, handlers.PromptExt) {
+		return &asset.TypeCommand
+	}
+
+	return nil
+}
+
+// matchesPath checks if a path belongs to Continue rules
+func matchesPath(path string) bool {
+	return strings.Contains(path, ".continue/rules/") && strings.HasSuffix(path, ".md")
+}
+
+// matchesContent checks if content appears to be a Continue rule file.
+// Its frontmatter overlaps Cursor's (globs, description, alwaysApply), so
+// only the location tells them apart.
+func matchesContent(path string, content []byte) bool {
+	return matchesPath(path)
+}
+
+// parseRuleFile parses a Continue rule file and returns the canonical format
+func parseRuleFile(content []byte) (*clients.ParsedRule, error) {
+	fm, body, err := extractYAMLFrontmatter(content)
+	if err != nil {
+		// No frontmatter - just return raw content
+		return &clients.ParsedRule{
+			Content:    string(content),
+			ClientName: clients.ClientIDContinue,
+		}, nil
+	}
+
+	result := &clients.ParsedRule{
+		ClientName:   clients.ClientIDContinue,
+		Content:      body,
+		ClientFields: make(map[string]any),
+	}
+
+	// Known fields that we handle explicitly
+	knownFields := map[string]bool{"name": true, "globs": true, "description": true, "alwaysApply": true}
+
+	if globs, ok := fm["globs"]; ok {
+		result.Globs = toStringSlice(globs)
+	}
+	if desc, ok := fm["description"].(string); ok {
+		result.Description = desc
+	}
+	// alwaysApply is stored under the key [rule.continue] uses
+	if alwaysApply, ok := fm["alwaysApply"].(bool); ok {
+		result.ClientFields["always-apply"] = alwaysApply
+	}
+
+	// Preserve unknown fields (regex among them) for lossless round-trip
+	for key, value := range fm {
+		if !knownFields[key] {
+			result.ClientFields[key] = value
+		}
+	}
+
+	return result, nil
+}
+
+// generateRuleFile creates a complete rule file for Continue
+func generateRuleFile(cfg *metadata.RuleConfig, body string) []byte {
+	name := ""
+	if cfg != nil {
+		name = cfg.Title
+	}
+	return handlers.RenderRuleFile(name, cfg, body)
+}
+
+// extractYAMLFrontmatter extracts YAML frontmatter from markdown content
+func extractYAMLFrontmatter(content []byte) (map[string]any, string, error) {
+	str := strings.ReplaceAll(string(content), "\r\n", "\n")
+
+	if !strings.HasPrefix(str, "---\n") {
+		return nil, "", errors.New("no frontmatter found")
+	}
+
+	fmContent, body, found := strings.Cut(str[4:], "\n---")
+	if !found {
+		return nil, "", errors.New("unclosed frontmatter")
+	}
+	body = strings.TrimLeft(body, "\n")
+
+	var fm map[string]any
+	if err := yaml.Unmarshal([]byte(fmContent), &fm); err != nil {
+		return nil, "", err
+	}
+	if fm == nil {
+		fm = map[string]any{}
+	}
+	return fm, body, nil
+}
+
+// toStringSlice reads globs as a single pattern or a list
+func toStringSlice(v any) []string {
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []any:
+		result := make([]string, 0, len(val))
+		for _, item := range val {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,84 @@
+package continuedev
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+func TestDetectAssetType(t *testing.T) {
+	tests := []struct {
+		path string
+		want *asset.Type
+	}{
+		{".continue/rules/go.md", &asset.TypeRule},
+		{"/home/me/.continue/rules/go.md", &asset.TypeRule},
+		{".continue/prompts/release.prompt", &asset.TypeCommand},
+		{".continue/prompts/notes.md", nil},
+		{".continue/mcpServers/db.yaml", nil},
+		{".cursor/rules/go.mdc", nil},
+	}
+
+	for _, tt := range tests {
+		got := detectAssetType(tt.path, nil)
+		if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+			t.Errorf("detectAssetType(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestParseRuleFile(t *testing.T) {
+	content := "---\nname: SQL style\ndescription: How we write SQL\nglobs: \"**/*.sql\"\nalwaysApply: false\nregex: SELECT\n---\n\nUppercase keywords.\n"
+
+	parsed, err := parseRuleFile([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(parsed.Globs, []string{"**/*.sql"}) {
+		t.Errorf("Globs = %v", parsed.Globs)
+	}
+	if parsed.Description != "How we write SQL" {
+		t.Errorf("Description = %q", parsed.Description)
+	}
+	if parsed.ClientFields["always-apply"] != false || parsed.ClientFields["regex"] != "SELECT" {
+		t.Errorf("ClientFields = %v", parsed.ClientFields)
+	}
+	if parsed.Content != "Uppercase keywords.\n" {
+		t.Errorf("Content = %q", parsed.Content)
+	}
+}
+
+func TestGenerateRuleFile_RoundTrip(t *testing.T) {
+	alwaysApply := true
+	cfg := &metadata.RuleConfig{
+		Title:       "Go: style",
+		Description: "Go conventions",
+		Globs:       []string{"**/*.go", "go.mod"},
+		Continue:    map[string]any{"always-apply": alwaysApply},
+	}
+
+	generated := generateRuleFile(cfg, "Use gofmt.\n")
+	if !strings.Contains(string(generated), "name: 'Go: style'\n") {
+		t.Errorf("name not quoted safely:\n%s", generated)
+	}
+
+	parsed, err := parseRuleFile(generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(parsed.Globs, cfg.Globs) {
+		t.Errorf("Globs = %v, want %v", parsed.Globs, cfg.Globs)
+	}
+	if parsed.Description != cfg.Description {
+		t.Errorf("Description = %q", parsed.Description)
+	}
+	if parsed.ClientFields["always-apply"] != true {
+		t.Errorf("always-apply = %v", parsed.ClientFields["always-apply"])
+	}
+	if parsed.Content != "Use gofmt.\n" {
+		t.Errorf("Content = %q", parsed.Content)
+	}
+}
@@ -50,6 +50,11 @@ func (c *Client) RuleCapabilities() *clients.RuleCapabilities {
 	return RuleCapabilities()
 }
 
+// MapHookEvent reports the Cursor event a hook asset fires on
+func (c *Client) MapHookEvent(cfg *metadata.HookConfig) (string, bool) {
+	return handlers.MapHookEvent(cfg)
+}
+
 // IsInstalled checks if Cursor is installed by checking for .cursor directory
 func (c *Client) IsInstalled() bool {
 	home, err := os.UserHomeDir()
@@ -216,6 +221,23 @@ func (c *Client) determineTargetBase(scope *clients.InstallScope) (string, error
 	}
 }
 
+// PlanPaths lists what installs at scope may touch: the asset directories,
+// mcp.json and hooks.json under the scope's .cursor directory
+func (c *Client) PlanPaths(scope *clients.InstallScope) ([]string, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, name := range []string{
+		handlers.DirSkills, handlers.DirCommands, handlers.DirMCPServers,
+		"rules", "hooks", "mcp.json", "hooks.json",
+	} {
+		paths = append(paths, filepath.Join(targetBase, name))
+	}
+	return paths, nil
+}
+
 // EnsureAssetSupport ensures asset infrastructure is set up for the current context.
 // This scans skills from all applicable scopes (global, repo, path) and creates
 // a local .cursor/rules/skills.md file listing all available skills.
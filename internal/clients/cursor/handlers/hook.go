@@ -92,7 +92,14 @@ type HooksConfig struct {
 // mapEventToCursor maps a canonical event name to Cursor native event.
 // If the hook has a [hook.cursor] event override, that is returned instead.
 func (h *HookHandler) mapEventToCursor() (string, bool) {
-	return hook.MapEvent(h.metadata.Hook.Event, cursorEventMap, h.metadata.Hook.Cursor)
+	return MapHookEvent(h.metadata.Hook)
+}
+
+// MapHookEvent maps a hook's canonical event to the Cursor native event,
+// honoring a [hook.cursor] event override. ok is false when Cursor has no
+// such event.
+func MapHookEvent(cfg *metadata.HookConfig) (string, bool) {
+	return hook.MapEvent(cfg.Event, cursorEventMap, cfg.Cursor)
 }
 
 func (h *HookHandler) updateHooksJSON(targetBase string) error {
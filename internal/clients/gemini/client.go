@@ -45,6 +45,16 @@ func (c *Client) RuleCapabilities() *clients.RuleCapabilities {
 	return RuleCapabilities()
 }
 
+// WritesManagedSections reports that rules are sections of GEMINI.md
+func (c *Client) WritesManagedSections(assetType asset.Type, _ *clients.InstallScope) bool {
+	return assetType == asset.TypeRule
+}
+
+// MapHookEvent reports the Gemini event a hook asset fires on
+func (c *Client) MapHookEvent(cfg *metadata.HookConfig) (string, bool) {
+	return handlers.MapHookEvent(cfg)
+}
+
 // IsInstalled checks if Gemini is installed (CLI, VS Code extension, or JetBrains plugin)
 func (c *Client) IsInstalled() bool {
 	home, err := os.UserHomeDir()
@@ -231,6 +241,33 @@ func (c *Client) determineTargetBase(scope *clients.InstallScope) (string, error
 	}
 }
 
+// PlanPaths lists what installs at scope may touch: GEMINI.md, the
+// commands, hooks, MCP servers and settings.json under .gemini, and the
+// mcp.json of each JetBrains IDE the Gemini plugin runs in
+func (c *Client) PlanPaths(scope *clients.InstallScope) ([]string, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return nil, err
+	}
+	geminiDir := targetBase
+	if filepath.Base(targetBase) != handlers.ConfigDir {
+		geminiDir = filepath.Join(targetBase, handlers.ConfigDir)
+	}
+
+	paths := []string{filepath.Join(targetBase, handlers.GeminiRuleFile)}
+	for _, name := range []string{handlers.DirCommands, handlers.DirHooks, handlers.DirMCPServers, handlers.SettingsFile} {
+		paths = append(paths, filepath.Join(geminiDir, name))
+	}
+	products, err := handlers.FindJetBrainsConfigDirs()
+	if err != nil {
+		return nil, err
+	}
+	for _, product := range products {
+		paths = append(paths, filepath.Join(product.Path, handlers.JetBrainsMCPFile))
+	}
+	return paths, nil
+}
+
 // EnsureAssetSupport ensures asset infrastructure is set up for the current context.
 // For Gemini, no additional setup is needed.
 func (c *Client) EnsureAssetSupport(ctx context.Context, scope *clients.InstallScope) error {
@@ -114,7 +114,14 @@ func (h *HookHandler) Validate(zipData []byte) error {
 // mapEventToGemini maps a canonical event name to Gemini native event.
 // If the hook has a [hook.gemini] event override, that is returned instead.
 func (h *HookHandler) mapEventToGemini() (string, bool) {
-	return hook.MapEvent(h.metadata.Hook.Event, geminiEventMap, h.metadata.Hook.Gemini)
+	return MapHookEvent(h.metadata.Hook)
+}
+
+// MapHookEvent maps a hook's canonical event to the Gemini native event,
+// honoring a [hook.gemini] event override. ok is false when Gemini has no
+// such event.
+func MapHookEvent(cfg *metadata.HookConfig) (string, bool) {
+	return hook.MapEvent(cfg.Event, geminiEventMap, cfg.Gemini)
 }
 
 // updateSettings updates settings.json to register the hook
@@ -297,6 +297,37 @@ func (c *Client) determineTargetBase(scope *clients.InstallScope) (string, error
 	}
 }
 
+// PlanPaths lists what installs at scope may touch: the asset directories
+// under .github (~/.copilot globally), VS Code's .vscode MCP servers and
+// mcp.json, and the Copilot CLI MCP config they are mirrored into
+func (c *Client) PlanPaths(scope *clients.InstallScope) ([]string, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return nil, err
+	}
+	mcpBase, err := c.determineMCPTargetBase(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, name := range []string{
+		handlers.DirSkills, handlers.DirInstructions, handlers.DirPrompts,
+		handlers.DirAgents, handlers.DirMCPServers, handlers.DirHooks,
+	} {
+		paths = append(paths, filepath.Join(targetBase, name))
+	}
+	paths = append(paths,
+		filepath.Join(targetBase, handlers.DirHooks, handlers.FileHooks),
+		filepath.Join(mcpBase, handlers.DirMCPServers),
+		filepath.Join(mcpBase, "mcp.json"),
+	)
+	if cliConfig, _ := c.determineCLIMCPConfig(scope); cliConfig != "" {
+		paths = append(paths, cliConfig)
+	}
+	return paths, nil
+}
+
 // determineMCPTargetBase returns the installation directory for MCP servers.
 // MCP servers use .vscode/ for VS Code integration, not .github/.
 func (c *Client) determineMCPTargetBase(scope *clients.InstallScope) (string, error) {
@@ -47,6 +47,11 @@ func (c *Client) RuleCapabilities() *clients.RuleCapabilities {
 	return RuleCapabilities()
 }
 
+// MapHookEvent reports the Kiro event a hook asset fires on
+func (c *Client) MapHookEvent(cfg *metadata.HookConfig) (string, bool) {
+	return handlers.MapHookEvent(cfg)
+}
+
 // IsInstalled checks if Kiro is installed.
 // We check for actual installation indicators:
 // 1. The kiro-cli binary in PATH (most reliable)
@@ -229,6 +234,23 @@ func (c *Client) determineTargetBase(scope *clients.InstallScope) (string, error
 	}
 }
 
+// PlanPaths lists what installs at scope may touch: the asset directories
+// and settings/mcp.json under the scope's .kiro directory
+func (c *Client) PlanPaths(scope *clients.InstallScope) ([]string, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{filepath.Join(targetBase, handlers.DirSettings, "mcp.json")}
+	for _, name := range []string{
+		handlers.DirSkills, handlers.DirSteering, handlers.DirMCPServers,
+		handlers.DirPrompts, handlers.DirHooks, handlers.DirAgents,
+	} {
+		paths = append(paths, filepath.Join(targetBase, name))
+	}
+	return paths, nil
+}
+
 // EnsureAssetSupport ensures asset infrastructure is set up for the current context.
 // For Kiro, this registers the sx MCP server (for the query tool) and cleans up
 // legacy steering files. Kiro auto-discovers skills from .kiro/skills/ natively.
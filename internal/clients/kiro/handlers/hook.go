@@ -136,7 +136,14 @@ func (h *HookHandler) VerifyInstalled(targetBase string) (bool, string) {
 // mapEventToKiro maps a canonical event name to Kiro native event.
 // If the hook has a [hook.kiro] event override, that is returned instead.
 func (h *HookHandler) mapEventToKiro() (string, bool) {
-	return hook.MapEvent(h.metadata.Hook.Event, kiroEventMap, h.metadata.Hook.Kiro)
+	return MapHookEvent(h.metadata.Hook)
+}
+
+// MapHookEvent maps a hook's canonical event to the Kiro native event,
+// honoring a [hook.kiro] event override. ok is false when Kiro has no
+// such event.
+func MapHookEvent(cfg *metadata.HookConfig) (string, bool) {
+	return hook.MapEvent(cfg.Event, kiroEventMap, cfg.Kiro)
 }
 
 // writeHookFile creates the .kiro.hook JSON file
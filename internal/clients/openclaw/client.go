@@ -190,6 +190,19 @@ func (c *Client) determineTargetBase(scope *clients.InstallScope, _ asset.Type)
 	}
 }
 
+// PlanPaths lists what installs may touch: the skills and hooks under
+// ~/.openclaw
+func (c *Client) PlanPaths(scope *clients.InstallScope) ([]string, error) {
+	targetBase, err := c.determineTargetBase(scope, asset.TypeSkill)
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		filepath.Join(targetBase, handlers.DirSkills),
+		filepath.Join(targetBase, handlers.DirHooks),
+	}, nil
+}
+
 // ListAssets returns all installed skills for a given scope
 func (c *Client) ListAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
 	targetBase, err := c.determineTargetBase(scope, asset.TypeSkill)
@@ -206,6 +206,23 @@ func (c *Client) determineTargetBase(scope *clients.InstallScope) (string, error
 	}
 }
 
+// PlanPaths lists what installs at scope may touch: the asset directories
+// and opencode.json (or opencode.jsonc) in the scope's config directory
+func (c *Client) PlanPaths(scope *clients.InstallScope) ([]string, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, name := range []string{
+		handlers.DirSkills, handlers.DirCommands, handlers.DirAgents, handlers.DirRules,
+		handlers.DirMCPServers, handlers.ConfigFile, handlers.ConfigFileJSONC,
+	} {
+		paths = append(paths, filepath.Join(targetBase, name))
+	}
+	return paths, nil
+}
+
 // ListAssets returns installed skills for the given scope.
 func (c *Client) ListAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
 	targetBase, err := c.determineTargetBase(scope)
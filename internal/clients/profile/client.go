@@ -0,0 +1,414 @@
+// Package profile implements clients declared in the sx config rather
+// than in code: agents that read AGENTS.md-style instruction files and an
+// MCP config file, such as Goose, Amp, Jules or Junie.
+package profile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/bootstrap"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/profile/handlers"
+	"github.com/sleuth-io/sx/v2/internal/config"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/logger"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// errNoTarget is returned when a profile declares no file for an asset
+// type in a scope; the asset is skipped rather than failed
+var errNoTarget = errors.New("no target file declared")
+
+// Client implements the clients.Client interface for a client profile
+type Client struct {
+	clients.BaseClient
+	profile config.ClientProfile
+}
+
+// NewClient creates a client from a profile declared in the sx config
+func NewClient(profile config.ClientProfile) (*Client, error) {
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	var types []asset.Type
+	if len(profile.InstructionFiles) > 0 || profile.GlobalInstructionFile != "" {
+		types = append(types, asset.TypeRule) // marked sections of the instruction file
+	}
+	if profile.MCP != nil {
+		types = append(types, asset.TypeMCP) // entries under the MCP pointer
+	}
+
+	return &Client{
+		BaseClient: clients.NewBaseClient(profile.ID, profile.DisplayName(), types),
+		profile:    profile,
+	}, nil
+}
+
+// RuleCapabilities returns the profile's rule capabilities
+func (c *Client) RuleCapabilities() *clients.RuleCapabilities {
+	return ruleCapabilities(&c.profile)
+}
+
+// WritesManagedSections reports that rules are sections of the profile's
+// instruction file
+func (c *Client) WritesManagedSections(assetType asset.Type, _ *clients.InstallScope) bool {
+	return assetType == asset.TypeRule
+}
+
+// IsInstalled checks the profile's detect command and paths
+func (c *Client) IsInstalled() bool {
+	if c.profile.DetectCommand != "" {
+		if _, err := exec.LookPath(c.profile.DetectCommand); err == nil {
+			return true
+		}
+	}
+	for _, p := range c.profile.DetectPaths {
+		if path, err := utils.ExpandTilde(p); err == nil && utils.FileExists(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetVersion runs the detect command with --version
+func (c *Client) GetVersion() string {
+	if c.profile.DetectCommand == "" {
+		return ""
+	}
+	output, err := exec.Command(c.profile.DetectCommand, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// InstallAssets installs assets using the profile's handlers
+func (c *Client) InstallAssets(ctx context.Context, req clients.InstallRequest) (clients.InstallResponse, error) {
+	resp := clients.InstallResponse{
+		Results: make([]clients.AssetResult, 0, len(req.Assets)),
+	}
+
+	for _, bundle := range req.Assets {
+		result := clients.AssetResult{
+			AssetName: bundle.Asset.Name,
+		}
+
+		handler, err := handlers.NewHandler(bundle.Metadata.Asset.Type, bundle.Metadata, &c.profile)
+		if err != nil {
+			result.Status = clients.StatusSkipped
+			result.Message = "Unsupported asset type: " + bundle.Metadata.Asset.Type.Key
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		target, err := c.targetFile(bundle.Metadata.Asset.Type, req.Scope)
+		if errors.Is(err, errNoTarget) {
+			result.Status = clients.StatusSkipped
+			result.Message = err.Error()
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+		if err == nil {
+			err = handler.Install(ctx, bundle.ZipData, target)
+		}
+
+		result.Status, result.Message, result.Error = clients.TranslateInstallError(err, "Installed to "+target)
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+// UninstallAssets removes assets using the profile's handlers
+func (c *Client) UninstallAssets(ctx context.Context, req clients.UninstallRequest) (clients.UninstallResponse, error) {
+	resp := clients.UninstallResponse{
+		Results: make([]clients.AssetResult, 0, len(req.Assets)),
+	}
+
+	for _, a := range req.Assets {
+		result := clients.AssetResult{
+			AssetName: a.Name,
+		}
+
+		// Create minimal metadata for removal
+		meta := &metadata.Metadata{
+			Asset: metadata.Asset{
+				Name: a.Name,
+				Type: a.Type,
+			},
+		}
+
+		handler, err := handlers.NewHandler(a.Type, meta, &c.profile)
+		if err != nil {
+			result.Status = clients.StatusSkipped
+			result.Message = "Unsupported asset type: " + a.Type.Key
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		target, err := c.targetFile(a.Type, req.Scope)
+		if errors.Is(err, errNoTarget) {
+			result.Status = clients.StatusSkipped
+			result.Message = err.Error()
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+		if err == nil {
+			err = handler.Remove(ctx, target)
+		}
+
+		if err != nil {
+			result.Status = clients.StatusFailed
+			result.Error = err
+		} else {
+			result.Status = clients.StatusSuccess
+			result.Message = "Uninstalled successfully"
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+// targetFile returns the file an asset type is written to in a scope:
+// the first instruction file for rules, the MCP config for MCP servers
+func (c *Client) targetFile(assetType asset.Type, scope *clients.InstallScope) (string, error) {
+	switch assetType {
+	case asset.TypeRule:
+		if scope.Type == clients.ScopeGlobal {
+			if c.profile.GlobalInstructionFile == "" {
+				return "", fmt.Errorf("%w: %s has no global instruction file", errNoTarget, c.DisplayName())
+			}
+			return utils.NormalizePath(c.profile.GlobalInstructionFile)
+		}
+		if len(c.profile.InstructionFiles) == 0 {
+			return "", fmt.Errorf("%w: %s has no repository instruction file", errNoTarget, c.DisplayName())
+		}
+		root, err := scopeRoot(scope)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(root, filepath.FromSlash(c.profile.InstructionFiles[0])), nil
+	case asset.TypeMCP:
+		return c.mcpConfigPath(scope)
+	}
+	return "", fmt.Errorf("%w for %s", errNoTarget, assetType.Key)
+}
+
+// PlanPaths lists what installs at scope may touch: the instruction file
+// rules go to, and the MCP config with the server directory beside it.
+// Absolute paths in the profile can't be redirected to staged copies, so
+// such profiles can't be planned.
+func (c *Client) PlanPaths(scope *clients.InstallScope) ([]string, error) {
+	var paths []string
+	for _, t := range []asset.Type{asset.TypeRule, asset.TypeMCP} {
+		if !c.SupportsAssetType(t) {
+			continue
+		}
+		if t == asset.TypeRule && scope.Type == clients.ScopeGlobal && filepath.IsAbs(c.profile.GlobalInstructionFile) {
+			return nil, fmt.Errorf("%w: global instruction file %s is an absolute path", clients.ErrNotPlannable, c.profile.GlobalInstructionFile)
+		}
+		if t == asset.TypeMCP && filepath.IsAbs(c.profile.MCP.File) {
+			return nil, fmt.Errorf("%w: MCP file %s is an absolute path", clients.ErrNotPlannable, c.profile.MCP.File)
+		}
+		target, err := c.targetFile(t, scope)
+		if errors.Is(err, errNoTarget) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, target)
+		if t == asset.TypeMCP {
+			paths = append(paths, filepath.Join(filepath.Dir(target), handlers.DirMCPServers))
+		}
+	}
+	return paths, nil
+}
+
+// mcpConfigPath resolves the profile's MCP config file for a scope. A
+// relative file is under the scope root; an absolute or ~/ one is fixed.
+func (c *Client) mcpConfigPath(scope *clients.InstallScope) (string, error) {
+	file := c.profile.MCP.File
+	if filepath.IsAbs(file) || strings.HasPrefix(file, "~") {
+		return utils.NormalizePath(file)
+	}
+	root, err := scopeRoot(scope)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(root, filepath.FromSlash(file)), nil
+}
+
+// scopeRoot returns the directory a scope installs under: the home
+// directory globally, otherwise the repository or path
+func scopeRoot(scope *clients.InstallScope) (string, error) {
+	switch scope.Type {
+	case clients.ScopeRepository:
+		if scope.RepoRoot == "" {
+			return "", errors.New("repo-scoped install requires RepoRoot but none provided (not in a git repository?)")
+		}
+		return scope.RepoRoot, nil
+	case clients.ScopePath:
+		if scope.RepoRoot == "" {
+			return "", errors.New("path-scoped install requires RepoRoot but none provided (not in a git repository?)")
+		}
+		return filepath.Join(scope.RepoRoot, scope.Path), nil
+	default:
+		return utils.ExpandTilde("~")
+	}
+}
+
+// ListAssets returns installed skills; profile clients have none
+func (c *Client) ListAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
+	return []clients.InstalledSkill{}, nil
+}
+
+// ReadSkill reads a skill by name; profile clients have none
+func (c *Client) ReadSkill(ctx context.Context, name string, scope *clients.InstallScope) (*clients.SkillContent, error) {
+	return nil, fmt.Errorf("skill not found: %s", name)
+}
+
+// EnsureAssetSupport is a no-op: the instruction and MCP config files are
+// the client's own.
+func (c *Client) EnsureAssetSupport(ctx context.Context, scope *clients.InstallScope) error {
+	return nil
+}
+
+// GetBootstrapOptions offers the MCP server when the profile declares an
+// MCP config
+func (c *Client) GetBootstrapOptions(ctx context.Context) []bootstrap.Option {
+	if c.profile.MCP == nil {
+		return nil
+	}
+	return []bootstrap.Option{
+		bootstrap.SleuthAIQueryMCP(),
+	}
+}
+
+// GetBootstrapPath returns the global MCP config file.
+func (c *Client) GetBootstrapPath() string {
+	if c.profile.MCP == nil {
+		return ""
+	}
+	path, err := c.mcpConfigPath(&clients.InstallScope{Type: clients.ScopeGlobal})
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// InstallBootstrap registers MCP servers from the enabled options in the
+// global MCP config.
+func (c *Client) InstallBootstrap(ctx context.Context, opts []bootstrap.Option) error {
+	return c.editBootstrap(opts, func(path, format string, pointer []string, opt *bootstrap.MCPServerConfig) error {
+		return handlers.AddMCPServer(path, format, pointer, opt.Name, handlers.MCPServerEntry{
+			Command: opt.Command,
+			Args:    opt.Args,
+			Env:     opt.Env,
+		})
+	}, "MCP server installed")
+}
+
+// UninstallBootstrap removes MCP servers installed by InstallBootstrap.
+func (c *Client) UninstallBootstrap(ctx context.Context, opts []bootstrap.Option) error {
+	return c.editBootstrap(opts, func(path, format string, pointer []string, opt *bootstrap.MCPServerConfig) error {
+		return handlers.RemoveMCPServer(path, format, pointer, opt.Name)
+	}, "MCP server uninstalled")
+}
+
+func (c *Client) editBootstrap(opts []bootstrap.Option, edit func(path, format string, pointer []string, opt *bootstrap.MCPServerConfig) error, done string) error {
+	if c.profile.MCP == nil {
+		return nil
+	}
+	log := logger.Get()
+
+	path := c.GetBootstrapPath()
+	format, err := c.profile.MCP.FileFormat()
+	if err != nil {
+		return err
+	}
+	pointer, err := c.profile.MCP.PointerPath()
+	if err != nil {
+		return err
+	}
+
+	for _, opt := range opts {
+		if opt.MCPConfig == nil {
+			continue
+		}
+		if err := edit(path, format, pointer, opt.MCPConfig); err != nil {
+			return fmt.Errorf("MCP server %s: %w", opt.MCPConfig.Name, err)
+		}
+		log.Info(done, "server", opt.MCPConfig.Name, "client", c.ID())
+	}
+	return nil
+}
+
+// ShouldInstall always returns true: profile clients have no session hook
+// to deduplicate.
+func (c *Client) ShouldInstall(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// VerifyAssets checks if assets are actually installed on the filesystem
+func (c *Client) VerifyAssets(ctx context.Context, assets []*lockfile.Asset, scope *clients.InstallScope) []clients.VerifyResult {
+	results := make([]clients.VerifyResult, 0, len(assets))
+
+	for _, a := range assets {
+		result := clients.VerifyResult{
+			Asset: a,
+		}
+
+		handler, err := handlers.NewHandler(a.Type, &metadata.Metadata{
+			Asset: metadata.Asset{
+				Name:    a.Name,
+				Version: a.Version,
+				Type:    a.Type,
+			},
+		}, &c.profile)
+		if err != nil {
+			result.Message = err.Error()
+			results = append(results, result)
+			continue
+		}
+
+		target, err := c.targetFile(a.Type, scope)
+		if err != nil {
+			result.Message = fmt.Sprintf("cannot determine target file: %v", err)
+		} else {
+			result.Installed, result.Message = handler.VerifyInstalled(target)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// ScanInstalledAssets returns nothing: the instruction files are imported
+// through the registry's instruction-file parsing instead
+func (c *Client) ScanInstalledAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledAsset, error) {
+	return nil, nil
+}
+
+// GetAssetPath is not supported for profile clients
+func (c *Client) GetAssetPath(ctx context.Context, name string, assetType asset.Type, scope *clients.InstallScope) (string, error) {
+	return "", fmt.Errorf("import not supported for client profile %s", c.ID())
+}
+
+func init() {
+	// Build config-declared clients through this package
+	clients.SetProfileFactory(func(profile config.ClientProfile) (clients.Client, error) {
+		return NewClient(profile)
+	})
+}
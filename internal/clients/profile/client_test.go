@@ -0,0 +1,188 @@
+package profile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/clienttest"
+	"github.com/sleuth-io/sx/v2/internal/config"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+func testAssets(t *testing.T) []*clients.AssetBundle {
+	t.Helper()
+	return []*clients.AssetBundle{
+		clienttest.Bundle(t, &metadata.Metadata{
+			Asset: metadata.Asset{Name: "go-style", Version: "1.0", Type: asset.TypeRule},
+			Rule:  &metadata.RuleConfig{Title: "Go Style"},
+		}, map[string]string{"RULE.md": "Use gofmt."}),
+		clienttest.Bundle(t, &metadata.Metadata{
+			Asset: metadata.Asset{Name: "docs", Version: "1.0", Type: asset.TypeMCP},
+			MCP:   &metadata.MCPConfig{Transport: "sse", URL: "https://docs.example.com/sse"},
+		}, nil),
+	}
+}
+
+func newTestClient(t *testing.T, mcp *config.ClientProfileMCP) *Client {
+	t.Helper()
+	client, err := NewClient(config.ClientProfile{
+		ID:               "goose",
+		Name:             "Goose",
+		InstructionFiles: []string{"AGENTS.md", ".goosehints"},
+		MCP:              mcp,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func install(t *testing.T, client *Client, scope *clients.InstallScope, bundles []*clients.AssetBundle) clients.InstallResponse {
+	t.Helper()
+	resp, err := client.InstallAssets(context.Background(), clients.InstallRequest{Assets: bundles, Scope: scope})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestInstallAssets_MCPFormats(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		pointer  string
+		existing string
+		want     []string
+	}{
+		{
+			name:     "json",
+			file:     ".junie/mcp/mcp.json",
+			pointer:  "/mcpServers",
+			existing: "{\n  // team servers\n  \"mcpServers\": {}\n}\n",
+			want:     []string{"// team servers", `"docs": {`, `"url": "https://docs.example.com/sse"`},
+		},
+		{
+			name:     "yaml",
+			file:     ".goose/config.yaml",
+			pointer:  "/extensions",
+			existing: "# goose settings\nGOOSE_MODEL: gpt-4o\n",
+			want:     []string{"# goose settings", "GOOSE_MODEL: gpt-4o", "extensions:\n  docs:\n    type: sse"},
+		},
+		{
+			name:     "toml",
+			file:     ".agent/config.toml",
+			pointer:  "/mcp/servers",
+			existing: "model = \"o3\"\n",
+			want:     []string{`model = "o3"`, "[mcp.servers.docs]", `url = "https://docs.example.com/sse"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			repo := t.TempDir()
+			configPath := filepath.Join(repo, filepath.FromSlash(tt.file))
+			if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(configPath, []byte(tt.existing), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			client := newTestClient(t, &config.ClientProfileMCP{File: tt.file, Pointer: tt.pointer})
+			scope := &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: repo}
+			bundles := testAssets(t)[1:]
+			for _, r := range install(t, client, scope, bundles).Results {
+				if r.Status != clients.StatusSuccess {
+					t.Fatalf("%s: %s %v", r.AssetName, r.Status, r.Error)
+				}
+			}
+
+			data, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, s := range tt.want {
+				if !strings.Contains(string(data), s) {
+					t.Errorf("config missing %q:\n%s", s, data)
+				}
+			}
+			if r := client.VerifyAssets(context.Background(), []*lockfile.Asset{bundles[0].Asset}, scope)[0]; !r.Installed {
+				t.Errorf("not verified: %s", r.Message)
+			}
+
+			clienttest.Uninstall(t, client, scope, bundles...)
+			data, _ = os.ReadFile(configPath)
+			if strings.Contains(string(data), "docs.example.com") {
+				t.Errorf("server not removed:\n%s", data)
+			}
+			if r := client.VerifyAssets(context.Background(), []*lockfile.Asset{bundles[0].Asset}, scope)[0]; r.Installed {
+				t.Error("still verified after uninstall")
+			}
+		})
+	}
+}
+
+func TestInstallAssets_RuleSections(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	repo := t.TempDir()
+	agentsPath := filepath.Join(repo, "AGENTS.md")
+	if err := os.WriteFile(agentsPath, []byte("# Project\n\nRun make test.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := newTestClient(t, nil)
+	scope := &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: repo}
+	bundles := testAssets(t)
+
+	resp := install(t, client, scope, bundles)
+	if resp.Results[0].Status != clients.StatusSuccess {
+		t.Fatalf("rule: %s %v", resp.Results[0].Status, resp.Results[0].Error)
+	}
+	// Without an MCP config the profile doesn't take MCP servers
+	if resp.Results[1].Status != clients.StatusSkipped {
+		t.Errorf("mcp status = %s, want skipped", resp.Results[1].Status)
+	}
+
+	// Reinstalling replaces the section rather than adding another
+	install(t, client, scope, bundles[:1])
+	data, _ := os.ReadFile(agentsPath)
+	want := "# Project\n\nRun make test.\n\n<!-- sx:go-style -->\n## Go Style\n\nUse gofmt.\n<!-- /sx:go-style -->\n"
+	if string(data) != want {
+		t.Errorf("AGENTS.md = %q, want %q", data, want)
+	}
+
+	clienttest.Uninstall(t, client, scope, bundles[:1]...)
+	data, _ = os.ReadFile(agentsPath)
+	if string(data) != "# Project\n\nRun make test.\n" {
+		t.Errorf("AGENTS.md after uninstall = %q", data)
+	}
+}
+
+func TestInstallAssets_GlobalRuleWithoutGlobalFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	client := newTestClient(t, nil)
+	resp := install(t, client, &clients.InstallScope{Type: clients.ScopeGlobal}, testAssets(t)[:1])
+	if resp.Results[0].Status != clients.StatusSkipped {
+		t.Errorf("status = %s, want skipped", resp.Results[0].Status)
+	}
+}
+
+func TestRuleCapabilities_InstructionFiles(t *testing.T) {
+	reg := clients.NewRegistry()
+	reg.Register(newTestClient(t, nil))
+
+	if !reg.IsInstructionFile("repo/.goosehints") {
+		t.Error(".goosehints should be an importable instruction file")
+	}
+	if reg.IsRuleFile("repo/.goosehints") {
+		t.Error("instruction files are not rule files")
+	}
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/config"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+// DirMCPServers is the directory, next to the MCP config file, packaged
+// MCP servers are extracted into
+const DirMCPServers = "mcp-servers"
+
+// Handler defines the interface for asset type handlers. Profile clients
+// write each asset into a single file, so handlers take that file's path
+// rather than a target directory.
+type Handler interface {
+	// Install installs the asset from zip data into the target file
+	Install(ctx context.Context, zipData []byte, target string) error
+
+	// Remove removes the asset from the target file
+	Remove(ctx context.Context, target string) error
+
+	// VerifyInstalled checks if the asset is properly installed
+	// Returns (installed bool, message string)
+	VerifyInstalled(target string) (bool, string)
+}
+
+// NewHandler creates a handler for the given asset type and metadata
+func NewHandler(assetType asset.Type, meta *metadata.Metadata, profile *config.ClientProfile) (Handler, error) {
+	switch assetType {
+	case asset.TypeRule:
+		return NewRuleHandler(meta), nil
+	case asset.TypeMCP:
+		if profile.MCP == nil {
+			return nil, fmt.Errorf("client profile %s has no MCP config", profile.ID)
+		}
+		return NewMCPHandler(meta, profile.MCP)
+	default:
+		return nil, fmt.Errorf("unsupported asset type for client profile %s: %s", profile.ID, assetType.Key)
+	}
+}
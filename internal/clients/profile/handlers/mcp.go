@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/config"
+	"github.com/sleuth-io/sx/v2/internal/handlers/dirasset"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+var mcpOps = dirasset.NewOperations(DirMCPServers, &asset.TypeMCP)
+
+// MCPServerEntry is the server entry written under the profile's pointer:
+// the mcpServers shape most agents share
+type MCPServerEntry struct {
+	Type    string            `json:"type,omitempty" yaml:"type,omitempty" toml:"type,omitempty"` // sse or http for remote servers
+	URL     string            `json:"url,omitempty" yaml:"url,omitempty" toml:"url,omitempty"`
+	Command string            `json:"command,omitempty" yaml:"command,omitempty" toml:"command,omitempty"`
+	Args    []string          `json:"args,omitempty" yaml:"args,omitempty" toml:"args,omitempty"`
+	Env     map[string]string `json:"env,omitempty" yaml:"env,omitempty" toml:"env,omitempty"`
+}
+
+// MCPHandler handles MCP asset installation for a profile client. The
+// server is added under the profile's JSON pointer in its MCP config,
+// keyed by asset name. JSON and YAML configs are edited in place so other
+// settings and comments survive; TOML is re-encoded, which drops comments.
+type MCPHandler struct {
+	metadata *metadata.Metadata
+	format   string
+	pointer  []string
+}
+
+// NewMCPHandler creates a new MCP handler for a profile's MCP config
+func NewMCPHandler(meta *metadata.Metadata, mcp *config.ClientProfileMCP) (*MCPHandler, error) {
+	format, err := mcp.FileFormat()
+	if err != nil {
+		return nil, err
+	}
+	pointer, err := mcp.PointerPath()
+	if err != nil {
+		return nil, err
+	}
+	return &MCPHandler{metadata: meta, format: format, pointer: pointer}, nil
+}
+
+// Install installs an MCP asset into the config file at target
+func (h *MCPHandler) Install(ctx context.Context, zipData []byte, target string) error {
+	hasContent, err := utils.HasContentFiles(zipData)
+	if err != nil {
+		return fmt.Errorf("failed to inspect zip contents: %w", err)
+	}
+
+	var entry MCPServerEntry
+	if hasContent {
+		// Packaged mode: extract MCP server files next to the config
+		serverDir := filepath.Join(filepath.Dir(target), DirMCPServers, h.metadata.Asset.Name)
+		if err := utils.ExtractZip(zipData, serverDir); err != nil {
+			return fmt.Errorf("failed to extract MCP server: %w", err)
+		}
+		entry = h.packagedEntry(serverDir)
+	} else {
+		// Config-only mode: no extraction needed
+		entry = h.configOnlyEntry()
+	}
+
+	return AddMCPServer(target, h.format, h.pointer, h.metadata.Asset.Name, entry)
+}
+
+// Remove removes the MCP server from the config file at target
+func (h *MCPHandler) Remove(ctx context.Context, target string) error {
+	if err := RemoveMCPServer(target, h.format, h.pointer, h.metadata.Asset.Name); err != nil {
+		return err
+	}
+
+	// Remove server directory if it exists (packaged mode)
+	serverDir := filepath.Join(filepath.Dir(target), DirMCPServers, h.metadata.Asset.Name)
+	os.RemoveAll(serverDir) // Ignore errors if doesn't exist
+
+	return nil
+}
+
+// VerifyInstalled checks if the MCP server is properly installed
+func (h *MCPHandler) VerifyInstalled(target string) (bool, string) {
+	// Check if install directory exists (packaged mode)
+	base := filepath.Dir(target)
+	if utils.IsDirectory(filepath.Join(base, DirMCPServers, h.metadata.Asset.Name)) {
+		return mcpOps.VerifyInstalled(base, h.metadata.Asset.Name, h.metadata.Asset.Version)
+	}
+
+	found, err := HasMCPServer(target, h.format, h.pointer, h.metadata.Asset.Name)
+	if err != nil {
+		return false, "failed to read MCP config: " + err.Error()
+	}
+	if !found {
+		return false, "MCP server not registered"
+	}
+	return true, "installed"
+}
+
+func (h *MCPHandler) packagedEntry(serverDir string) MCPServerEntry {
+	mcpConfig := h.metadata.MCP
+
+	return MCPServerEntry{
+		Command: utils.ResolveCommand(mcpConfig.Command, serverDir),
+		Args:    utils.ResolveArgs(mcpConfig.Args, serverDir),
+		Env:     mcpConfig.Env,
+	}
+}
+
+func (h *MCPHandler) configOnlyEntry() MCPServerEntry {
+	mcpConfig := h.metadata.MCP
+
+	if mcpConfig.IsRemote() {
+		return MCPServerEntry{
+			Type: mcpConfig.Transport,
+			URL:  mcpConfig.URL,
+		}
+	}
+
+	return MCPServerEntry{
+		Command: mcpConfig.Command,
+		Args:    mcpConfig.Args,
+		Env:     mcpConfig.Env,
+	}
+}
+
+// AddMCPServer adds or replaces the server named name in the object at
+// pointer, creating the file and any missing objects along the way
+func AddMCPServer(path, format string, pointer []string, name string, entry MCPServerEntry) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read MCP config: %w", err)
+	}
+
+	var out []byte
+	switch format {
+	case config.MCPFormatJSON:
+		out, err = utils.SetJSONCValue(data, slices.Concat(pointer, []string{name}), entry)
+	case config.MCPFormatYAML:
+		out, err = editYAML(data, pointer, true, func(servers *yaml.Node) error {
+			node, err := utils.YAMLNode(entry)
+			if err != nil {
+				return err
+			}
+			utils.SetYAMLMapValue(servers, name, node)
+			return nil
+		})
+	case config.MCPFormatTOML:
+		out, err = editTOML(data, pointer, true, func(servers map[string]any) {
+			servers[name] = entry
+		})
+	default:
+		err = fmt.Errorf("unsupported MCP config format: %s", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", path, err)
+	}
+	return writeConfig(path, out)
+}
+
+// RemoveMCPServer removes the server named name from the object at
+// pointer. The file is left alone when the server isn't there.
+func RemoveMCPServer(path, format string, pointer []string, name string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read MCP config: %w", err)
+	}
+
+	found, err := HasMCPServer(path, format, pointer, name)
+	if err != nil || !found {
+		return err
+	}
+
+	var out []byte
+	switch format {
+	case config.MCPFormatJSON:
+		out, _, err = utils.DeleteJSONCValue(data, slices.Concat(pointer, []string{name}))
+	case config.MCPFormatYAML:
+		out, err = editYAML(data, pointer, false, func(servers *yaml.Node) error {
+			utils.DeleteYAMLMapKey(servers, name)
+			return nil
+		})
+	case config.MCPFormatTOML:
+		out, err = editTOML(data, pointer, false, func(servers map[string]any) {
+			delete(servers, name)
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", path, err)
+	}
+	return writeConfig(path, out)
+}
+
+// HasMCPServer reports whether the object at pointer has a server named
+// name; a missing file has none
+func HasMCPServer(path, format string, pointer []string, name string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var doc map[string]any
+	switch format {
+	case config.MCPFormatJSON:
+		if len(data) > 0 {
+			err = utils.UnmarshalJSONC(data, &doc)
+		}
+	case config.MCPFormatYAML:
+		err = yaml.Unmarshal(data, &doc)
+	case config.MCPFormatTOML:
+		_, err = toml.Decode(string(data), &doc)
+	default:
+		err = fmt.Errorf("unsupported MCP config format: %s", format)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	servers := lookupMap(doc, pointer, false)
+	_, found := servers[name]
+	return found, nil
+}
+
+// editYAML applies edit to the mapping at pointer, keeping the rest of
+// the document and its comments. With create, missing mappings are added.
+func editYAML(data []byte, pointer []string, create bool, edit func(servers *yaml.Node) error) ([]byte, error) {
+	doc, err := utils.ParseYAMLDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	node := doc.Content[0]
+	for _, key := range pointer {
+		next := utils.YAMLMapValue(node, key)
+		if next == nil || next.Kind != yaml.MappingNode {
+			if !create {
+				return nil, errors.New("no servers to remove")
+			}
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			utils.SetYAMLMapValue(node, key, next)
+		}
+		node = next
+	}
+	if err := edit(node); err != nil {
+		return nil, err
+	}
+	return utils.EncodeYAMLDocument(doc)
+}
+
+// editTOML applies edit to the table at pointer. With create, missing
+// tables are added.
+func editTOML(data []byte, pointer []string, create bool, edit func(servers map[string]any)) ([]byte, error) {
+	doc := map[string]any{}
+	if _, err := toml.Decode(string(data), &doc); err != nil {
+		return nil, err
+	}
+	servers := lookupMap(doc, pointer, create)
+	if servers == nil {
+		return nil, errors.New("no servers to remove")
+	}
+	edit(servers)
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// lookupMap walks pointer through nested maps. With create, missing maps
+// are added; otherwise a missing one returns nil.
+func lookupMap(doc map[string]any, pointer []string, create bool) map[string]any {
+	node := doc
+	for _, key := range pointer {
+		next, ok := node[key].(map[string]any)
+		if !ok {
+			if !create || node == nil {
+				return nil
+			}
+			next = map[string]any{}
+			node[key] = next
+		}
+		node = next
+	}
+	return node
+}
+
+func writeConfig(path string, data []byte) error {
+	if err := utils.EnsureDir(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := utils.WriteFileAtomic(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write MCP config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/handlers/rule"
+	"github.com/sleuth-io/sx/v2/internal/handlers/section"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// RuleHandler writes a rule as a marked section of an instruction file
+// (AGENTS.md and the like) through the section package, which leaves
+// content outside the section alone and merges hand edits inside it.
+type RuleHandler struct {
+	metadata *metadata.Metadata
+}
+
+// NewRuleHandler creates a new rule handler
+func NewRuleHandler(meta *metadata.Metadata) *RuleHandler {
+	return &RuleHandler{metadata: meta}
+}
+
+// Install adds or replaces the rule's section of the instruction file
+func (h *RuleHandler) Install(ctx context.Context, zipData []byte, target string) error {
+	content, err := h.readRuleContent(zipData)
+	if err != nil {
+		return fmt.Errorf("failed to read rule content: %w", err)
+	}
+
+	body := "## " + h.getTitle() + "\n\n" + strings.TrimSpace(content) + "\n"
+	return section.Write(ctx, target, h.metadata.Asset.Name, body)
+}
+
+// Remove removes the rule's section, deleting the file once it's empty
+func (h *RuleHandler) Remove(ctx context.Context, target string) error {
+	return section.Remove(ctx, target, h.metadata.Asset.Name)
+}
+
+// VerifyInstalled checks if the rule's section is present
+func (h *RuleHandler) VerifyInstalled(target string) (bool, string) {
+	if !utils.FileExists(target) {
+		return false, filepath.Base(target) + " not found"
+	}
+	if section.Contains(target, h.metadata.Asset.Name) {
+		return true, "Found in " + target
+	}
+	return false, "Rule section not found in " + filepath.Base(target)
+}
+
+// getTitle returns the rule title, defaulting to asset name
+func (h *RuleHandler) getTitle() string {
+	if h.metadata.Rule != nil && h.metadata.Rule.Title != "" {
+		return h.metadata.Rule.Title
+	}
+	return h.metadata.Asset.Name
+}
+
+// getPromptFile returns the prompt file, using the shared default
+func (h *RuleHandler) getPromptFile() string {
+	if h.metadata.Rule != nil && h.metadata.Rule.PromptFile != "" {
+		return h.metadata.Rule.PromptFile
+	}
+	return rule.DefaultPromptFile
+}
+
+// readRuleContent reads the rule content from the zip
+func (h *RuleHandler) readRuleContent(zipData []byte) (string, error) {
+	promptFile := h.getPromptFile()
+
+	content, err := utils.ReadZipFile(zipData, promptFile)
+	if err != nil {
+		// Try lowercase variant
+		content, err = utils.ReadZipFile(zipData, "rule.md")
+		if err != nil {
+			return "", fmt.Errorf("prompt file not found: %s", promptFile)
+		}
+	}
+
+	return string(content), nil
+}
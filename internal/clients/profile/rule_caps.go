@@ -0,0 +1,38 @@
+package profile
+
+import (
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/config"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+// ruleCapabilities returns the rule capabilities of a profile client.
+// Rules live only in instruction files, which the registry already parses
+// for rule sections, so there's no rules directory to match.
+func ruleCapabilities(profile *config.ClientProfile) *clients.RuleCapabilities {
+	return &clients.RuleCapabilities{
+		ClientName:       profile.ID,
+		FileExtension:    ".md",
+		InstructionFiles: profile.InstructionFiles,
+		MatchesPath:      func(string) bool { return false },
+		MatchesContent:   func(string, []byte) bool { return false },
+		ParseRuleFile: func(content []byte) (*clients.ParsedRule, error) {
+			return &clients.ParsedRule{
+				Content:    string(content),
+				ClientName: profile.ID,
+			}, nil
+		},
+		GenerateRuleFile: generateRuleFile,
+		DetectAssetType:  func(string, []byte) *asset.Type { return nil },
+	}
+}
+
+// generateRuleFile creates a rule as plain markdown: instruction files
+// have no frontmatter, so globs don't apply
+func generateRuleFile(cfg *metadata.RuleConfig, body string) []byte {
+	if cfg != nil && cfg.Title != "" {
+		return []byte("# " + cfg.Title + "\n\n" + body)
+	}
+	return []byte(body)
+}
@@ -1,6 +1,7 @@
 package clients
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"sort"
@@ -8,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/config"
 )
 
 // sortClientsByID returns the clients sorted by their ID. Used at every
@@ -26,14 +28,30 @@ func sortClientsByID(clients []Client) []Client {
 type Registry struct {
 	mu      sync.RWMutex
 	clients map[string]Client
+
+	// profiles are the IDs of clients built from config-declared profiles,
+	// which a later RegisterProfiles call replaces
+	profiles map[string]bool
 }
 
 var globalRegistry = NewRegistry()
 
+// ProfileFactory builds a client from a profile declared in the sx config
+type ProfileFactory func(profile config.ClientProfile) (Client, error)
+
+var profileFactory ProfileFactory
+
+// SetProfileFactory sets how config-declared client profiles are built.
+// The profile client package calls it from init().
+func SetProfileFactory(factory ProfileFactory) {
+	profileFactory = factory
+}
+
 // NewRegistry creates a new client registry
 func NewRegistry() *Registry {
 	return &Registry{
-		clients: make(map[string]Client),
+		clients:  make(map[string]Client),
+		profiles: make(map[string]bool),
 	}
 }
 
@@ -44,6 +62,50 @@ func (r *Registry) Register(client Client) {
 	r.clients[client.ID()] = client
 }
 
+// RegisterProfiles builds a client for each profile declared in the sx
+// config and registers it alongside the built-in clients, replacing the
+// clients of any earlier call. A profile that fails to build or whose ID
+// belongs to a built-in client is skipped; the rest are still registered.
+func (r *Registry) RegisterProfiles(profiles []config.ClientProfile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id := range r.profiles {
+		delete(r.clients, id)
+	}
+	r.profiles = make(map[string]bool)
+
+	if len(profiles) == 0 {
+		return nil
+	}
+	if profileFactory == nil {
+		return errors.New("client profiles are not supported in this build")
+	}
+
+	var errs []error
+	for _, profile := range profiles {
+		if _, exists := r.clients[profile.ID]; exists {
+			errs = append(errs, fmt.Errorf("client profile %s: a client with that ID already exists", profile.ID))
+			continue
+		}
+		client, err := profileFactory(profile)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		r.clients[client.ID()] = client
+		r.profiles[client.ID()] = true
+	}
+	return errors.Join(errs...)
+}
+
+// hasProfile reports whether id is a client built from a config profile
+func (r *Registry) hasProfile(id string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.profiles[id]
+}
+
 // Get retrieves a client by ID
 func (r *Registry) Get(id string) (Client, error) {
 	r.mu.RLock()
@@ -108,6 +170,19 @@ func Register(client Client) {
 	globalRegistry.Register(client)
 }
 
+// LoadProfiles registers the client profiles declared in the sx config in
+// the global registry. Without a config there are none to load.
+func LoadProfiles() error {
+	if !config.Exists() {
+		return nil
+	}
+	mpc, err := config.LoadMultiProfile()
+	if err != nil {
+		return err
+	}
+	return globalRegistry.RegisterProfiles(mpc.ClientProfiles)
+}
+
 // Rule detection functions using client capabilities
 
 // DetectClientFromPath asks each client if it owns this path.
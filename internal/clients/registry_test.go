@@ -2,10 +2,13 @@ package clients
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/sleuth-io/sx/v2/internal/asset"
 	"github.com/sleuth-io/sx/v2/internal/bootstrap"
+	"github.com/sleuth-io/sx/v2/internal/config"
 	"github.com/sleuth-io/sx/v2/internal/lockfile"
 	"github.com/sleuth-io/sx/v2/internal/metadata"
 )
@@ -273,3 +276,64 @@ func TestRuleCapabilitiesGenerateRuleFile(t *testing.T) {
 		t.Errorf("expected 'test', got %q", string(result))
 	}
 }
+
+func TestRegisterProfiles(t *testing.T) {
+	saved := profileFactory
+	t.Cleanup(func() { profileFactory = saved })
+	SetProfileFactory(func(p config.ClientProfile) (Client, error) {
+		if p.ID == "broken" {
+			return nil, errors.New("broken profile")
+		}
+		return newMockClient(p.ID, &RuleCapabilities{InstructionFiles: p.InstructionFiles}), nil
+	})
+
+	reg := NewRegistry()
+	reg.Register(newMockClient("cursor", nil))
+
+	err := reg.RegisterProfiles([]config.ClientProfile{
+		{ID: "goose", InstructionFiles: []string{".goosehints"}},
+		{ID: "cursor"},
+		{ID: "broken"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "cursor") || !strings.Contains(err.Error(), "broken profile") {
+		t.Errorf("expected errors for the taken ID and the broken profile, got %v", err)
+	}
+	if _, err := reg.Get("goose"); err != nil {
+		t.Errorf("goose not registered: %v", err)
+	}
+	if !reg.IsInstructionFile("repo/.goosehints") {
+		t.Error("profile instruction file not recognized")
+	}
+
+	// A later call replaces earlier profiles but keeps built-in clients
+	if err := reg.RegisterProfiles([]config.ClientProfile{{ID: "amp"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reg.Get("goose"); err == nil {
+		t.Error("goose should be gone after re-registering profiles")
+	}
+	for _, id := range []string{"amp", "cursor"} {
+		if _, err := reg.Get(id); err != nil {
+			t.Errorf("%s not registered: %v", id, err)
+		}
+	}
+}
+
+func TestIsValidClientIDIncludesProfiles(t *testing.T) {
+	savedFactory, savedRegistry := profileFactory, globalRegistry
+	t.Cleanup(func() { profileFactory, globalRegistry = savedFactory, savedRegistry })
+	SetProfileFactory(func(p config.ClientProfile) (Client, error) {
+		return newMockClient(p.ID, nil), nil
+	})
+	globalRegistry = NewRegistry()
+
+	if IsValidClientID("goose") {
+		t.Fatal("goose should not be valid before its profile is registered")
+	}
+	if err := globalRegistry.RegisterProfiles([]config.ClientProfile{{ID: "goose"}}); err != nil {
+		t.Fatal(err)
+	}
+	if !IsValidClientID("goose") || !IsValidClientID(ClientIDCursor) {
+		t.Error("expected both the profile and built-in IDs to be valid")
+	}
+}
@@ -0,0 +1,477 @@
+package windsurf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/bootstrap"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/windsurf/handlers"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/logger"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+// Client implements the clients.Client interface for Windsurf
+type Client struct {
+	clients.BaseClient
+}
+
+// NewClient creates a new Windsurf client
+func NewClient() *Client {
+	return &Client{
+		BaseClient: clients.NewBaseClient(
+			clients.ClientIDWindsurf,
+			"Windsurf",
+			[]asset.Type{
+				asset.TypeSkill,   // .windsurf/skills/{name}/
+				asset.TypeCommand, // .windsurf/workflows/{name}.md
+				asset.TypeRule,    // .windsurf/rules/{name}.md
+				asset.TypeMCP,     // ~/.codeium/windsurf/mcp_config.json
+			},
+		),
+	}
+}
+
+// RuleCapabilities returns Windsurf's rule capabilities
+func (c *Client) RuleCapabilities() *clients.RuleCapabilities {
+	return RuleCapabilities()
+}
+
+// WritesManagedSections reports that global rules are sections of
+// global_rules.md; workspace rules are files of their own
+func (c *Client) WritesManagedSections(assetType asset.Type, scope *clients.InstallScope) bool {
+	return assetType == asset.TypeRule && scope.Type == clients.ScopeGlobal
+}
+
+// IsInstalled checks if Windsurf is installed: the windsurf launcher in
+// PATH, or the ~/.codeium/windsurf directory the editor creates on first
+// run. Workspace .windsurf directories don't count, since they can be
+// committed to a repo without Windsurf being installed.
+func (c *Client) IsInstalled() bool {
+	if _, err := exec.LookPath("windsurf"); err == nil {
+		return true
+	}
+
+	globalDir, err := handlers.GlobalDir()
+	if err != nil {
+		return false
+	}
+	stat, err := os.Stat(globalDir)
+	return err == nil && stat.IsDir()
+}
+
+// GetVersion returns the Windsurf version
+func (c *Client) GetVersion() string {
+	cmd := exec.Command("windsurf", "--version")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	// The launcher prints the version, then the commit and architecture
+	version, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n")
+	return version
+}
+
+// InstallAssets installs assets to Windsurf using client-specific handlers
+func (c *Client) InstallAssets(ctx context.Context, req clients.InstallRequest) (clients.InstallResponse, error) {
+	resp := clients.InstallResponse{
+		Results: make([]clients.AssetResult, 0, len(req.Assets)),
+	}
+
+	targetBase, err := c.determineTargetBase(req.Scope)
+	if err != nil {
+		return resp, fmt.Errorf("cannot determine installation directory: %w", err)
+	}
+
+	if err := os.MkdirAll(targetBase, 0755); err != nil {
+		return resp, fmt.Errorf("failed to create target directory: %w", err)
+	}
+
+	for _, bundle := range req.Assets {
+		result := clients.AssetResult{
+			AssetName: bundle.Asset.Name,
+		}
+
+		handler, err := handlers.NewHandler(bundle.Metadata.Asset.Type, bundle.Metadata)
+		if err != nil {
+			result.Status = clients.StatusSkipped
+			result.Message = "Unsupported asset type: " + bundle.Metadata.Asset.Type.Key
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+		err = handler.Install(ctx, bundle.ZipData, targetBase)
+
+		result.Status, result.Message, result.Error = clients.TranslateInstallError(err, "Installed to "+targetBase)
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+// UninstallAssets removes assets from Windsurf
+func (c *Client) UninstallAssets(ctx context.Context, req clients.UninstallRequest) (clients.UninstallResponse, error) {
+	resp := clients.UninstallResponse{
+		Results: make([]clients.AssetResult, 0, len(req.Assets)),
+	}
+
+	targetBase, err := c.determineTargetBase(req.Scope)
+	if err != nil {
+		return resp, fmt.Errorf("cannot determine uninstall directory: %w", err)
+	}
+
+	for _, a := range req.Assets {
+		result := clients.AssetResult{
+			AssetName: a.Name,
+		}
+
+		// Create minimal metadata for removal
+		meta := &metadata.Metadata{
+			Asset: metadata.Asset{
+				Name: a.Name,
+				Type: a.Type,
+			},
+		}
+
+		handler, err := handlers.NewHandler(a.Type, meta)
+		if err != nil {
+			result.Status = clients.StatusSkipped
+			result.Message = "Unsupported asset type: " + a.Type.Key
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		if err := handler.Remove(ctx, targetBase); err != nil {
+			result.Status = clients.StatusFailed
+			result.Error = err
+		} else {
+			result.Status = clients.StatusSuccess
+			result.Message = "Uninstalled successfully"
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+// determineTargetBase returns the installation directory based on scope:
+// ~/.codeium/windsurf globally, otherwise the scope's .windsurf directory
+func (c *Client) determineTargetBase(scope *clients.InstallScope) (string, error) {
+	switch scope.Type {
+	case clients.ScopeRepository:
+		if scope.RepoRoot == "" {
+			return "", errors.New("repo-scoped install requires RepoRoot but none provided (not in a git repository?)")
+		}
+		return filepath.Join(scope.RepoRoot, handlers.ConfigDir), nil
+	case clients.ScopePath:
+		if scope.RepoRoot == "" {
+			return "", errors.New("path-scoped install requires RepoRoot but none provided (not in a git repository?)")
+		}
+		return filepath.Join(scope.RepoRoot, scope.Path, handlers.ConfigDir), nil
+	default:
+		return handlers.GlobalDir()
+	}
+}
+
+// PlanPaths lists what installs at scope may touch: the asset directories
+// under the scope's base (including global_rules.md under memories) and
+// the global mcp_config.json
+func (c *Client) PlanPaths(scope *clients.InstallScope) ([]string, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return nil, err
+	}
+	mcpConfig, err := handlers.GetMCPConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{mcpConfig}
+	for _, name := range []string{
+		handlers.DirSkills, handlers.DirRules, handlers.DirWorkflows, handlers.DirGlobalWorkflows,
+		handlers.DirMemories, handlers.DirMCPServers,
+	} {
+		paths = append(paths, filepath.Join(targetBase, name))
+	}
+	return paths, nil
+}
+
+// ListAssets returns all installed skills for a given scope
+func (c *Client) ListAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine target directory: %w", err)
+	}
+
+	installed, err := handlers.SkillOps.ScanInstalled(targetBase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan installed skills: %w", err)
+	}
+
+	skills := make([]clients.InstalledSkill, 0, len(installed))
+	for _, info := range installed {
+		skills = append(skills, clients.InstalledSkill{
+			Name:        info.Name,
+			Description: info.Description,
+			Version:     info.Version,
+		})
+	}
+
+	return skills, nil
+}
+
+// ReadSkill reads the content of a specific skill by name
+func (c *Client) ReadSkill(ctx context.Context, name string, scope *clients.InstallScope) (*clients.SkillContent, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine target directory: %w", err)
+	}
+
+	result, err := handlers.SkillOps.ReadPromptContent(targetBase, name, "SKILL.md", func(m *metadata.Metadata) string { return m.Skill.PromptFile })
+	if err != nil {
+		return nil, err
+	}
+
+	return &clients.SkillContent{
+		Name:        name,
+		Description: result.Description,
+		Version:     result.Version,
+		Content:     result.Content,
+		BaseDir:     result.BaseDir,
+	}, nil
+}
+
+// EnsureAssetSupport is a no-op for Windsurf: Cascade discovers skills,
+// workflows, and rules from their directories natively.
+func (c *Client) EnsureAssetSupport(ctx context.Context, scope *clients.InstallScope) error {
+	return nil
+}
+
+// GetBootstrapOptions returns bootstrap options for Windsurf. sx doesn't
+// install Cascade hooks yet, so only the MCP server is offered.
+func (c *Client) GetBootstrapOptions(ctx context.Context) []bootstrap.Option {
+	return []bootstrap.Option{
+		bootstrap.SleuthAIQueryMCP(),
+	}
+}
+
+// GetBootstrapPath returns the path to Windsurf's MCP config file.
+func (c *Client) GetBootstrapPath() string {
+	path, err := handlers.GetMCPConfigPath()
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// InstallBootstrap registers MCP servers from the enabled options.
+func (c *Client) InstallBootstrap(ctx context.Context, opts []bootstrap.Option) error {
+	log := logger.Get()
+
+	for _, opt := range opts {
+		if opt.MCPConfig == nil {
+			continue
+		}
+		serverConfig := map[string]any{
+			"command": opt.MCPConfig.Command,
+			"args":    opt.MCPConfig.Args,
+		}
+		if len(opt.MCPConfig.Env) > 0 {
+			serverConfig["env"] = opt.MCPConfig.Env
+		}
+		if err := handlers.AddMCPServer(opt.MCPConfig.Name, serverConfig); err != nil {
+			return fmt.Errorf("failed to install MCP server %s: %w", opt.MCPConfig.Name, err)
+		}
+		log.Info("MCP server installed", "server", opt.MCPConfig.Name, "client", clients.ClientIDWindsurf)
+	}
+
+	return nil
+}
+
+// UninstallBootstrap removes MCP servers installed by InstallBootstrap.
+func (c *Client) UninstallBootstrap(ctx context.Context, opts []bootstrap.Option) error {
+	log := logger.Get()
+
+	for _, opt := range opts {
+		if opt.MCPConfig == nil {
+			continue
+		}
+		if err := handlers.RemoveMCPServer(opt.MCPConfig.Name); err != nil {
+			return err
+		}
+		log.Info("MCP server uninstalled", "server", opt.MCPConfig.Name, "client", clients.ClientIDWindsurf)
+	}
+
+	return nil
+}
+
+// ShouldInstall always returns true for Windsurf, which has no session
+// hook to deduplicate.
+func (c *Client) ShouldInstall(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// VerifyAssets checks if assets are actually installed on the filesystem
+func (c *Client) VerifyAssets(ctx context.Context, assets []*lockfile.Asset, scope *clients.InstallScope) []clients.VerifyResult {
+	results := make([]clients.VerifyResult, 0, len(assets))
+
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		// Can't determine target - mark all assets as not installed
+		for _, a := range assets {
+			results = append(results, clients.VerifyResult{
+				Asset:     a,
+				Installed: false,
+				Message:   fmt.Sprintf("cannot determine target directory: %v", err),
+			})
+		}
+		return results
+	}
+
+	for _, a := range assets {
+		result := clients.VerifyResult{
+			Asset: a,
+		}
+
+		handler, err := handlers.NewHandler(a.Type, &metadata.Metadata{
+			Asset: metadata.Asset{
+				Name:    a.Name,
+				Version: a.Version,
+				Type:    a.Type,
+			},
+		})
+		if err != nil {
+			result.Message = err.Error()
+		} else {
+			result.Installed, result.Message = handler.VerifyInstalled(targetBase)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// ScanInstalledAssets finds skills, workflows, and workspace rules that
+// weren't installed by sx. Skills sx installs carry a metadata.toml;
+// workflows and rules don't, so init filters those against the lock file.
+func (c *Client) ScanInstalledAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledAsset, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return nil, fmt.Errorf("cannot determine target directory: %w", err)
+	}
+
+	var assets []clients.InstalledAsset
+
+	skills, err := scanUnmanagedSkills(filepath.Join(targetBase, handlers.DirSkills))
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan skills: %w", err)
+	}
+	assets = append(assets, skills...)
+
+	workflows, err := scanMarkdownFiles(handlers.WorkflowsDir(targetBase), asset.TypeCommand)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan workflows: %w", err)
+	}
+	assets = append(assets, workflows...)
+
+	rules, err := scanMarkdownFiles(filepath.Join(targetBase, handlers.DirRules), asset.TypeRule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan rules: %w", err)
+	}
+	assets = append(assets, rules...)
+
+	return assets, nil
+}
+
+// scanUnmanagedSkills finds skill directories that have a SKILL.md but no
+// metadata.toml
+func scanUnmanagedSkills(skillsDir string) ([]clients.InstalledAsset, error) {
+	entries, err := os.ReadDir(skillsDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var assets []clients.InstalledAsset
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dirPath := filepath.Join(skillsDir, entry.Name())
+		if _, err := os.Stat(filepath.Join(dirPath, "metadata.toml")); err == nil {
+			continue // already managed by sx
+		}
+		if _, err := os.Stat(filepath.Join(dirPath, "SKILL.md")); err != nil {
+			if _, err := os.Stat(filepath.Join(dirPath, "skill.md")); err != nil {
+				continue
+			}
+		}
+		assets = append(assets, clients.InstalledAsset{
+			Name:    entry.Name(),
+			Version: "1.0", // Default version for unmanaged assets
+			Type:    asset.TypeSkill,
+		})
+	}
+	return assets, nil
+}
+
+// scanMarkdownFiles lists the .md files in dir as assets of one type
+func scanMarkdownFiles(dir string, assetType asset.Type) ([]clients.InstalledAsset, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var assets []clients.InstalledAsset
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(name), ".md") {
+			continue
+		}
+		assets = append(assets, clients.InstalledAsset{
+			Name:    strings.TrimSuffix(name, filepath.Ext(name)),
+			Version: "1.0", // Default version for unmanaged assets
+			Type:    assetType,
+		})
+	}
+	return assets, nil
+}
+
+// GetAssetPath returns the filesystem path to an installed asset
+func (c *Client) GetAssetPath(ctx context.Context, name string, assetType asset.Type, scope *clients.InstallScope) (string, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return "", fmt.Errorf("cannot determine target directory: %w", err)
+	}
+
+	switch assetType {
+	case asset.TypeSkill:
+		return filepath.Join(targetBase, handlers.DirSkills, name), nil
+	case asset.TypeCommand:
+		return handlers.WorkflowPath(targetBase, name), nil
+	case asset.TypeRule:
+		if scope.Type == clients.ScopeGlobal {
+			return "", errors.New("global Windsurf rules live in one file and can't be imported individually")
+		}
+		return filepath.Join(targetBase, handlers.DirRules, name+".md"), nil
+	default:
+		return "", fmt.Errorf("import not supported for type: %s", assetType)
+	}
+}
+
+func init() {
+	// Auto-register on package import
+	clients.Register(NewClient())
+}
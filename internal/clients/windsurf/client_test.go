@@ -0,0 +1,194 @@
+package windsurf
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/clienttest"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+func testAssets(t *testing.T) []*clients.AssetBundle {
+	t.Helper()
+	return []*clients.AssetBundle{
+		clienttest.Bundle(t, &metadata.Metadata{
+			Asset: metadata.Asset{Name: "go-style", Version: "1.0", Type: asset.TypeRule},
+			Rule:  &metadata.RuleConfig{Title: "Go Style", Globs: []string{"**/*.go"}},
+		}, map[string]string{"RULE.md": "Use gofmt."}),
+		clienttest.Bundle(t, &metadata.Metadata{
+			Asset:   metadata.Asset{Name: "release", Version: "1.0", Type: asset.TypeCommand, Description: "Cut a release"},
+			Command: &metadata.CommandConfig{PromptFile: "COMMAND.md"},
+		}, map[string]string{"COMMAND.md": "Tag and push."}),
+		clienttest.Bundle(t, &metadata.Metadata{
+			Asset: metadata.Asset{Name: "pdf", Version: "1.0", Type: asset.TypeSkill},
+			Skill: &metadata.SkillConfig{PromptFile: "SKILL.md"},
+		}, map[string]string{"SKILL.md": "Read PDFs."}),
+		clienttest.Bundle(t, &metadata.Metadata{
+			Asset: metadata.Asset{Name: "docs", Version: "1.0", Type: asset.TypeMCP},
+			MCP:   &metadata.MCPConfig{Transport: "http", URL: "https://docs.example.com/mcp"},
+		}, nil),
+	}
+}
+
+func TestInstallAssets_RepoScope(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	repo := t.TempDir()
+	ctx := context.Background()
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: repo}
+	bundles := testAssets(t)
+
+	resp, err := client.InstallAssets(ctx, clients.InstallRequest{Assets: bundles, Scope: scope})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range resp.Results {
+		if r.Status != clients.StatusSuccess {
+			t.Fatalf("%s: %s %v", r.AssetName, r.Status, r.Error)
+		}
+	}
+
+	rule, err := os.ReadFile(filepath.Join(repo, ".windsurf", "rules", "go-style.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(rule), "---\ntrigger: glob\nglobs: \"**/*.go\"\n---\n\nUse gofmt.") {
+		t.Errorf("rule file:\n%s", rule)
+	}
+	workflow, err := os.ReadFile(filepath.Join(repo, ".windsurf", "workflows", "release.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(workflow), `description: "Cut a release"`) {
+		t.Errorf("workflow file:\n%s", workflow)
+	}
+
+	mcpPath := filepath.Join(os.Getenv("HOME"), ".codeium", "windsurf", "mcp_config.json")
+	var cfg struct {
+		MCPServers map[string]map[string]any `json:"mcpServers"`
+	}
+	data, err := os.ReadFile(mcpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MCPServers["docs"]["serverUrl"] != "https://docs.example.com/mcp" {
+		t.Errorf("mcp_config.json = %s", data)
+	}
+
+	for _, v := range client.VerifyAssets(ctx, clienttest.LockAssets(bundles), scope) {
+		if !v.Installed {
+			t.Errorf("%s not verified: %s", v.Asset.Name, v.Message)
+		}
+	}
+
+	var toRemove []asset.Asset
+	for _, b := range bundles {
+		toRemove = append(toRemove, asset.Asset{Name: b.Asset.Name, Type: b.Asset.Type})
+	}
+	if _, err := client.UninstallAssets(ctx, clients.UninstallRequest{Assets: toRemove, Scope: scope}); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range client.VerifyAssets(ctx, clienttest.LockAssets(bundles), scope) {
+		if v.Installed {
+			t.Errorf("%s still installed after uninstall", v.Asset.Name)
+		}
+	}
+}
+
+// Global rules share global_rules.md with whatever the user wrote there
+func TestInstallAssets_GlobalRulesFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	ctx := context.Background()
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeGlobal}
+
+	rulesFile := filepath.Join(home, ".codeium", "windsurf", "memories", "global_rules.md")
+	if err := os.MkdirAll(filepath.Dir(rulesFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(rulesFile, []byte("Always answer in English.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rule := testAssets(t)[0]
+	if _, err := client.InstallAssets(ctx, clients.InstallRequest{Assets: []*clients.AssetBundle{rule}, Scope: scope}); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(rulesFile)
+	want := "Always answer in English.\n\n<!-- sx:go-style -->\n## Go Style\n\nUse gofmt.\n<!-- /sx:go-style -->\n"
+	if string(data) != want {
+		t.Errorf("global_rules.md =\n%q\nwant\n%q", data, want)
+	}
+
+	// Reinstalling replaces the section rather than appending another
+	if _, err := client.InstallAssets(ctx, clients.InstallRequest{Assets: []*clients.AssetBundle{rule}, Scope: scope}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(rulesFile); string(data) != want {
+		t.Errorf("after reinstall:\n%q", data)
+	}
+
+	if _, err := client.UninstallAssets(ctx, clients.UninstallRequest{Assets: []asset.Asset{{Name: "go-style", Type: asset.TypeRule}}, Scope: scope}); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(rulesFile); string(data) != "Always answer in English.\n" {
+		t.Errorf("after uninstall:\n%q", data)
+	}
+}
+
+func TestScanInstalledAssets(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	ctx := context.Background()
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeGlobal}
+	base := filepath.Join(home, ".codeium", "windsurf")
+
+	// An sx-managed skill is not offered for import
+	if _, err := client.InstallAssets(ctx, clients.InstallRequest{Assets: testAssets(t)[2:3], Scope: scope}); err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range map[string]string{
+		"skills/handmade/SKILL.md":      "Do things.",
+		"global_workflows/triage.md":    "Triage the issue.",
+		"global_workflows/notes.txt":    "not a workflow",
+		"memories/global_rules.md":      "Be brief.",
+		"skills/not-a-skill/README.txt": "no prompt",
+	} {
+		p := filepath.Join(base, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	found, err := client.ScanInstalledAssets(ctx, scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, a := range found {
+		got[a.Name] = a.Type.Key
+	}
+	want := map[string]string{"handmade": asset.TypeSkill.Key, "triage": asset.TypeCommand.Key}
+	if len(got) != len(want) || got["handmade"] != want["handmade"] || got["triage"] != want["triage"] {
+		t.Errorf("ScanInstalledAssets = %v, want %v", got, want)
+	}
+
+	path, err := client.GetAssetPath(ctx, "triage", asset.TypeCommand, scope)
+	if err != nil || path != filepath.Join(base, "global_workflows", "triage.md") {
+		t.Errorf("GetAssetPath = %q, %v", path, err)
+	}
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// CommandHandler handles command asset installation for Windsurf.
+// Commands are installed as Windsurf workflows, which Cascade runs as
+// /{name} slash commands:
+// - Global scope: ~/.codeium/windsurf/global_workflows/{name}.md
+// - Workspace scope: .windsurf/workflows/{name}.md
+type CommandHandler struct {
+	metadata *metadata.Metadata
+}
+
+// NewCommandHandler creates a new command handler
+func NewCommandHandler(meta *metadata.Metadata) *CommandHandler {
+	return &CommandHandler{metadata: meta}
+}
+
+// Install installs a command as a Windsurf workflow
+func (h *CommandHandler) Install(ctx context.Context, zipData []byte, targetBase string) error {
+	promptFile := h.getPromptFile()
+	if promptFile == "" {
+		return errors.New("no prompt file specified in metadata")
+	}
+	promptContent, err := utils.ReadZipFile(zipData, promptFile)
+	if err != nil {
+		return fmt.Errorf("failed to read prompt file: %w", err)
+	}
+
+	workflowPath := WorkflowPath(targetBase, h.metadata.Asset.Name)
+	if err := utils.EnsureDir(filepath.Dir(workflowPath)); err != nil {
+		return fmt.Errorf("failed to create workflows directory: %w", err)
+	}
+	if err := os.WriteFile(workflowPath, h.buildWorkflow(string(promptContent)), 0644); err != nil {
+		return fmt.Errorf("failed to write workflow file: %w", err)
+	}
+	return nil
+}
+
+// Remove removes a workflow from Windsurf
+func (h *CommandHandler) Remove(ctx context.Context, targetBase string) error {
+	if err := os.Remove(WorkflowPath(targetBase, h.metadata.Asset.Name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove workflow file: %w", err)
+	}
+	return nil
+}
+
+// VerifyInstalled checks if the workflow is properly installed
+func (h *CommandHandler) VerifyInstalled(targetBase string) (bool, string) {
+	workflowPath := WorkflowPath(targetBase, h.metadata.Asset.Name)
+	if !utils.FileExists(workflowPath) {
+		return false, "workflow file not found"
+	}
+	return true, "Found at " + workflowPath
+}
+
+// WorkflowsDir returns the workflows directory for a target base
+func WorkflowsDir(targetBase string) string {
+	if isGlobalBase(targetBase) {
+		return filepath.Join(targetBase, DirGlobalWorkflows)
+	}
+	return filepath.Join(targetBase, DirWorkflows)
+}
+
+// WorkflowPath returns the workflow file for a command
+func WorkflowPath(targetBase, name string) string {
+	return filepath.Join(WorkflowsDir(targetBase), name+".md")
+}
+
+// buildWorkflow adds a description frontmatter, which Windsurf shows in
+// the slash-command picker, unless the prompt already has frontmatter
+func (h *CommandHandler) buildWorkflow(prompt string) []byte {
+	description := h.metadata.Asset.Description
+	if description == "" || strings.HasPrefix(prompt, "---\n") || strings.HasPrefix(prompt, "---\r\n") {
+		return []byte(prompt)
+	}
+	return fmt.Appendf(nil, "---\ndescription: %q\n---\n\n%s", description, prompt)
+}
+
+func (h *CommandHandler) getPromptFile() string {
+	// Check both Command and Skill metadata sections
+	if h.metadata.Command != nil && h.metadata.Command.PromptFile != "" {
+		return h.metadata.Command.PromptFile
+	}
+	if h.metadata.Skill != nil && h.metadata.Skill.PromptFile != "" {
+		return h.metadata.Skill.PromptFile
+	}
+	return ""
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Configuration directories
+const (
+	// ConfigDir is the Windsurf workspace directory name (in a repo)
+	ConfigDir = ".windsurf"
+
+	// GlobalConfigDir is Windsurf's user-level directory, relative to home
+	GlobalConfigDir = ".codeium/windsurf"
+)
+
+// Directory and file names for Windsurf assets
+const (
+	DirSkills          = "skills"           // {base}/skills/{name}/
+	DirRules           = "rules"            // .windsurf/rules/{name}.md (workspace only)
+	DirWorkflows       = "workflows"        // .windsurf/workflows/{name}.md
+	DirGlobalWorkflows = "global_workflows" // ~/.codeium/windsurf/global_workflows/{name}.md
+	DirMemories        = "memories"         // ~/.codeium/windsurf/memories/
+	DirMCPServers      = "mcp-servers"
+
+	// GlobalRulesFile holds Windsurf's global rules; it has no per-file
+	// global rules directory
+	GlobalRulesFile = "global_rules.md"
+
+	// MCPConfigFile is Windsurf's MCP config, global only
+	MCPConfigFile = "mcp_config.json"
+)
+
+// GlobalDir returns ~/.codeium/windsurf
+func GlobalDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, GlobalConfigDir), nil
+}
+
+// isGlobalBase reports whether targetBase is the user-level directory
+// rather than a workspace's .windsurf
+func isGlobalBase(targetBase string) bool {
+	global, err := GlobalDir()
+	return err == nil && filepath.Clean(targetBase) == global
+}
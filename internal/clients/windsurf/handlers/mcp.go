@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/handlers/dirasset"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+var mcpOps = dirasset.NewOperations(DirMCPServers, &asset.TypeMCP)
+
+// MCPHandler handles MCP asset installation for Windsurf. Windsurf only
+// reads ~/.codeium/windsurf/mcp_config.json, so every scope registers
+// there; packaged servers are extracted under the scope's target base.
+type MCPHandler struct {
+	metadata *metadata.Metadata
+}
+
+// NewMCPHandler creates a new MCP handler
+func NewMCPHandler(meta *metadata.Metadata) *MCPHandler {
+	return &MCPHandler{metadata: meta}
+}
+
+// Install installs an MCP asset by updating mcp_config.json
+func (h *MCPHandler) Install(ctx context.Context, zipData []byte, targetBase string) error {
+	hasContent, err := utils.HasContentFiles(zipData)
+	if err != nil {
+		return fmt.Errorf("failed to inspect zip contents: %w", err)
+	}
+
+	var entry map[string]any
+	if hasContent {
+		// Packaged mode: extract MCP server files
+		serverDir := filepath.Join(targetBase, DirMCPServers, h.metadata.Asset.Name)
+		if err := utils.ExtractZip(zipData, serverDir); err != nil {
+			return fmt.Errorf("failed to extract MCP server: %w", err)
+		}
+		entry = h.generatePackagedMCPEntry(serverDir)
+	} else {
+		// Config-only mode: no extraction needed
+		entry = h.generateConfigOnlyMCPEntry()
+	}
+
+	return AddMCPServer(h.metadata.Asset.Name, entry)
+}
+
+// Remove removes an MCP entry from mcp_config.json
+func (h *MCPHandler) Remove(ctx context.Context, targetBase string) error {
+	if err := RemoveMCPServer(h.metadata.Asset.Name); err != nil {
+		return err
+	}
+
+	// Remove server directory if it exists (packaged mode)
+	serverDir := filepath.Join(targetBase, DirMCPServers, h.metadata.Asset.Name)
+	os.RemoveAll(serverDir) // Ignore errors if doesn't exist
+
+	return nil
+}
+
+// VerifyInstalled checks if the MCP server is properly installed
+func (h *MCPHandler) VerifyInstalled(targetBase string) (bool, string) {
+	// Check if install directory exists (packaged mode)
+	installDir := filepath.Join(targetBase, DirMCPServers, h.metadata.Asset.Name)
+	if utils.IsDirectory(installDir) {
+		return mcpOps.VerifyInstalled(targetBase, h.metadata.Asset.Name, h.metadata.Asset.Version)
+	}
+
+	// Config-only mode: check MCP config for server entry
+	mcpConfigPath, err := GetMCPConfigPath()
+	if err != nil {
+		return false, "failed to get MCP config path: " + err.Error()
+	}
+	config, err := ReadMCPConfig(mcpConfigPath)
+	if err != nil {
+		return false, "failed to read MCP config: " + err.Error()
+	}
+	if _, exists := config.MCPServers[h.metadata.Asset.Name]; !exists {
+		return false, "MCP server not registered"
+	}
+	return true, "installed"
+}
+
+func (h *MCPHandler) generatePackagedMCPEntry(serverDir string) map[string]any {
+	mcpConfig := h.metadata.MCP
+
+	command, args := utils.ResolveCommandAndArgs(mcpConfig.Command, mcpConfig.Args, serverDir)
+
+	entry := map[string]any{
+		"command": command,
+		"args":    args,
+	}
+
+	if len(mcpConfig.Env) > 0 {
+		entry["env"] = mcpConfig.Env
+	}
+
+	return entry
+}
+
+func (h *MCPHandler) generateConfigOnlyMCPEntry() map[string]any {
+	mcpConfig := h.metadata.MCP
+
+	if mcpConfig.IsRemote() {
+		// Windsurf names the remote endpoint serverUrl, for both
+		// streamable HTTP and SSE
+		entry := map[string]any{
+			"serverUrl": mcpConfig.URL,
+		}
+		if len(mcpConfig.Env) > 0 {
+			entry["env"] = mcpConfig.Env
+		}
+		return entry
+	}
+
+	entry := map[string]any{
+		"command": mcpConfig.Command,
+		"args":    utils.StringsToAny(mcpConfig.Args),
+	}
+
+	if len(mcpConfig.Env) > 0 {
+		entry["env"] = mcpConfig.Env
+	}
+
+	return entry
+}
+
+// MCPConfig represents Windsurf's mcp_config.json structure
+type MCPConfig struct {
+	MCPServers map[string]any `json:"mcpServers"`
+}
+
+// GetMCPConfigPath returns ~/.codeium/windsurf/mcp_config.json
+func GetMCPConfigPath() (string, error) {
+	dir, err := GlobalDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, MCPConfigFile), nil
+}
+
+// ReadMCPConfig reads Windsurf's mcp_config.json file
+func ReadMCPConfig(path string) (*MCPConfig, error) {
+	config := &MCPConfig{
+		MCPServers: make(map[string]any),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil // Return empty config
+		}
+		return nil, err
+	}
+
+	if err := utils.UnmarshalJSONC(data, config); err != nil {
+		return nil, err
+	}
+
+	if config.MCPServers == nil {
+		config.MCPServers = make(map[string]any)
+	}
+
+	return config, nil
+}
+
+// WriteMCPConfig writes Windsurf's mcp_config.json file
+func WriteMCPConfig(path string, config *MCPConfig) error {
+	if err := utils.EnsureDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// AddMCPServer adds or updates an MCP server entry in Windsurf's config
+func AddMCPServer(serverName string, serverConfig map[string]any) error {
+	mcpConfigPath, err := GetMCPConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to get MCP config path: %w", err)
+	}
+
+	config, err := ReadMCPConfig(mcpConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read MCP config: %w", err)
+	}
+
+	config.MCPServers[serverName] = serverConfig
+
+	if err := WriteMCPConfig(mcpConfigPath, config); err != nil {
+		return fmt.Errorf("failed to write MCP config: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveMCPServer removes an MCP server entry from Windsurf's config
+func RemoveMCPServer(serverName string) error {
+	mcpConfigPath, err := GetMCPConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to get MCP config path: %w", err)
+	}
+
+	config, err := ReadMCPConfig(mcpConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read MCP config: %w", err)
+	}
+
+	if _, ok := config.MCPServers[serverName]; !ok {
+		return nil
+	}
+	delete(config.MCPServers, serverName)
+
+	if err := WriteMCPConfig(mcpConfigPath, config); err != nil {
+		return fmt.Errorf("failed to write MCP config: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/handlers/rule"
+	"github.com/sleuth-io/sx/v2/internal/handlers/section"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// Windsurf rule activation modes, set by the trigger: frontmatter field
+const (
+	TriggerAlwaysOn      = "always_on"
+	TriggerManual        = "manual"
+	TriggerModelDecision = "model_decision"
+	TriggerGlob          = "glob"
+)
+
+// IsValidTrigger reports whether t is a Windsurf activation mode
+func IsValidTrigger(t string) bool {
+	switch t {
+	case TriggerAlwaysOn, TriggerManual, TriggerModelDecision, TriggerGlob:
+		return true
+	}
+	return false
+}
+
+// RuleTrigger maps a rule config to its Windsurf activation mode: glob
+// when the rule has globs, else [rule.windsurf] trigger, else always_on.
+func RuleTrigger(cfg *metadata.RuleConfig) string {
+	if cfg == nil {
+		return TriggerAlwaysOn
+	}
+	if len(cfg.Globs) > 0 {
+		return TriggerGlob
+	}
+	if t, ok := cfg.Windsurf["trigger"].(string); ok && IsValidTrigger(t) && t != TriggerGlob {
+		return t
+	}
+	return TriggerAlwaysOn
+}
+
+// RenderRuleFile renders a workspace rule with Windsurf frontmatter.
+// Windsurf reads globs as one comma-separated string.
+func RenderRuleFile(trigger, description string, globs []string, body string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	fmt.Fprintf(&buf, "trigger: %s\n", trigger)
+	if description != "" {
+		fmt.Fprintf(&buf, "description: %q\n", description)
+	}
+	if trigger == TriggerGlob && len(globs) > 0 {
+		fmt.Fprintf(&buf, "globs: %q\n", strings.Join(globs, ","))
+	}
+	buf.WriteString("---\n\n")
+	buf.WriteString(body)
+	return buf.Bytes()
+}
+
+// RuleHandler handles rule asset installation for Windsurf.
+// Workspace rules are written to .windsurf/rules/{name}.md with trigger
+// frontmatter. Windsurf has a single global rules file, so global rules
+// become marked sections of ~/.codeium/windsurf/memories/global_rules.md
+// and are always on.
+type RuleHandler struct {
+	metadata *metadata.Metadata
+}
+
+// NewRuleHandler creates a new rule handler
+func NewRuleHandler(meta *metadata.Metadata) *RuleHandler {
+	return &RuleHandler{metadata: meta}
+}
+
+// Install writes the rule file, or the rule's section of global_rules.md
+func (h *RuleHandler) Install(ctx context.Context, zipData []byte, targetBase string) error {
+	content, err := h.readRuleContent(zipData)
+	if err != nil {
+		return fmt.Errorf("failed to read rule content: %w", err)
+	}
+	content = strings.TrimSpace(content) + "\n"
+
+	if isGlobalBase(targetBase) {
+		return h.installGlobal(ctx, targetBase, content)
+	}
+
+	rulesDir := filepath.Join(targetBase, DirRules)
+	if err := utils.EnsureDir(rulesDir); err != nil {
+		return fmt.Errorf("failed to create rules directory: %w", err)
+	}
+	var globs []string
+	if h.metadata.Rule != nil {
+		globs = h.metadata.Rule.Globs
+	}
+	data := RenderRuleFile(RuleTrigger(h.metadata.Rule), h.getDescription(), globs, content)
+	if err := os.WriteFile(filepath.Join(rulesDir, h.metadata.Asset.Name+".md"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write rule file: %w", err)
+	}
+	return nil
+}
+
+// Remove removes the rule file, or the rule's section of global_rules.md
+func (h *RuleHandler) Remove(ctx context.Context, targetBase string) error {
+	if isGlobalBase(targetBase) {
+		return h.removeGlobal(ctx, targetBase)
+	}
+	filePath := filepath.Join(targetBase, DirRules, h.metadata.Asset.Name+".md")
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove rule file: %w", err)
+	}
+	return nil
+}
+
+// VerifyInstalled checks if the rule file or section exists
+func (h *RuleHandler) VerifyInstalled(targetBase string) (bool, string) {
+	if isGlobalBase(targetBase) {
+		filePath := filepath.Join(targetBase, DirMemories, GlobalRulesFile)
+		if !utils.FileExists(filePath) {
+			return false, GlobalRulesFile + " not found"
+		}
+		if section.Contains(filePath, h.metadata.Asset.Name) {
+			return true, "Found in " + filePath
+		}
+		return false, "Rule section not found in " + GlobalRulesFile
+	}
+
+	filePath := filepath.Join(targetBase, DirRules, h.metadata.Asset.Name+".md")
+	if utils.FileExists(filePath) {
+		return true, "Found at " + filePath
+	}
+	return false, "Rule file not found"
+}
+
+func (h *RuleHandler) installGlobal(ctx context.Context, targetBase, content string) error {
+	filePath := filepath.Join(targetBase, DirMemories, GlobalRulesFile)
+	return section.Write(ctx, filePath, h.metadata.Asset.Name, "## "+h.getTitle()+"\n\n"+content)
+}
+
+func (h *RuleHandler) removeGlobal(ctx context.Context, targetBase string) error {
+	return section.Remove(ctx, filepath.Join(targetBase, DirMemories, GlobalRulesFile), h.metadata.Asset.Name)
+}
+
+// getTitle returns the rule title, defaulting to asset name
+func (h *RuleHandler) getTitle() string {
+	if h.metadata.Rule != nil && h.metadata.Rule.Title != "" {
+		return h.metadata.Rule.Title
+	}
+	return h.metadata.Asset.Name
+}
+
+// getDescription returns the description for the frontmatter
+func (h *RuleHandler) getDescription() string {
+	if h.metadata.Rule != nil && h.metadata.Rule.Description != "" {
+		return h.metadata.Rule.Description
+	}
+	return h.metadata.Asset.Description
+}
+
+// getPromptFile returns the prompt file, using the shared default
+func (h *RuleHandler) getPromptFile() string {
+	if h.metadata.Rule != nil && h.metadata.Rule.PromptFile != "" {
+		return h.metadata.Rule.PromptFile
+	}
+	return rule.DefaultPromptFile
+}
+
+// readRuleContent reads the rule content from the zip
+func (h *RuleHandler) readRuleContent(zipData []byte) (string, error) {
+	promptFile := h.getPromptFile()
+
+	content, err := utils.ReadZipFile(zipData, promptFile)
+	if err != nil {
+		// Try lowercase variant
+		content, err = utils.ReadZipFile(zipData, "rule.md")
+		if err != nil {
+			return "", fmt.Errorf("prompt file not found: %s", promptFile)
+		}
+	}
+
+	return string(content), nil
+}
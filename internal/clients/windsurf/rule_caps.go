@@ -0,0 +1,173 @@
+package windsurf
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/windsurf/handlers"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+// RuleCapabilities returns the rule capabilities for Windsurf
+func RuleCapabilities() *clients.RuleCapabilities {
+	return &clients.RuleCapabilities{
+		ClientName:       clients.ClientIDWindsurf,
+		RulesDirectory:   ".windsurf/rules",
+		FileExtension:    ".md",
+		InstructionFiles: []string{".windsurfrules", "AGENTS.md"},
+		MatchesPath:      matchesPath,
+		MatchesContent:   matchesContent,
+		ParseRuleFile:    parseRuleFile,
+		GenerateRuleFile: generateRuleFile,
+		DetectAssetType:  detectAssetType,
+	}
+}
+
+// detectAssetType determines the asset type for Windsurf paths
+func detectAssetType(path string, _ []byte) *asset.Type {
+	lower := strings.ToLower(path)
+
+	if strings.Contains(lower, ".windsurf/rules/") && strings.HasSuffix(lower, ".md") {
+		return &asset.TypeRule
+	}
+	if (strings.Contains(lower, ".windsurf/workflows/") || strings.Contains(lower, "windsurf/global_workflows/")) &&
+		strings.HasSuffix(lower, ".md") {
+		return &asset.TypeCommand
+	}
+	if strings.Contains(lower, ".windsurf/skills/") || strings.Contains(lower, ".codeium/windsurf/skills/") {
+		return &asset.TypeSkill
+	}
+
+	return nil
+}
+
+// matchesPath checks if a path belongs to Windsurf rules
+func matchesPath(path string) bool {
+	return strings.Contains(path, ".windsurf/rules/") && strings.HasSuffix(path, ".md")
+}
+
+// matchesContent checks if content appears to be a Windsurf rule file:
+// frontmatter with one of Windsurf's trigger modes
+func matchesContent(path string, content []byte) bool {
+	for _, t := range []string{handlers.TriggerAlwaysOn, handlers.TriggerManual, handlers.TriggerModelDecision, handlers.TriggerGlob} {
+		if bytes.Contains(content, []byte("trigger: "+t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRuleFile parses a Windsurf rule file and returns the canonical format
+func parseRuleFile(content []byte) (*clients.ParsedRule, error) {
+	fm, body, err := extractFrontmatter(content)
+	if err != nil {
+		// No frontmatter - just return raw content
+		return &clients.ParsedRule{
+			Content:    string(content),
+			ClientName: clients.ClientIDWindsurf,
+		}, nil
+	}
+
+	result := &clients.ParsedRule{
+		ClientName:   clients.ClientIDWindsurf,
+		Content:      body,
+		ClientFields: make(map[string]any),
+	}
+
+	// Known fields that we handle explicitly
+	knownFields := map[string]bool{"trigger": true, "globs": true, "description": true}
+
+	if globs, ok := fm["globs"]; ok {
+		result.Globs = splitGlobs(globs)
+	}
+	if desc, ok := fm["description"].(string); ok {
+		result.Description = desc
+	}
+	// The trigger is kept so an imported rule keeps its activation mode
+	// (manual and model_decision have no canonical equivalent)
+	if trigger, ok := fm["trigger"].(string); ok {
+		result.ClientFields["trigger"] = trigger
+	}
+
+	// Preserve unknown fields for lossless round-trip
+	for key, value := range fm {
+		if !knownFields[key] {
+			result.ClientFields[key] = value
+		}
+	}
+
+	return result, nil
+}
+
+// generateRuleFile creates a complete rule file for Windsurf
+func generateRuleFile(cfg *metadata.RuleConfig, body string) []byte {
+	var description string
+	var globs []string
+	if cfg != nil {
+		description = cfg.Description
+		globs = cfg.Globs
+	}
+	return handlers.RenderRuleFile(handlers.RuleTrigger(cfg), description, globs, body)
+}
+
+// extractFrontmatter extracts frontmatter from a rule file. Windsurf
+// itself writes globs unquoted (globs: **/*.ts), which isn't valid YAML,
+// so when YAML parsing fails each line is read as a plain key: value.
+func extractFrontmatter(content []byte) (map[string]any, string, error) {
+	str := strings.ReplaceAll(string(content), "\r\n", "\n")
+
+	if !strings.HasPrefix(str, "---\n") {
+		return nil, "", errors.New("no frontmatter found")
+	}
+
+	fmContent, body, found := strings.Cut(str[4:], "\n---")
+	if !found {
+		return nil, "", errors.New("unclosed frontmatter")
+	}
+	body = strings.TrimLeft(body, "\n")
+
+	var fm map[string]any
+	if err := yaml.Unmarshal([]byte(fmContent), &fm); err == nil {
+		if fm == nil {
+			fm = map[string]any{}
+		}
+		return fm, body, nil
+	}
+
+	fm = map[string]any{}
+	for line := range strings.SplitSeq(fmContent, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(key) == "" {
+			continue
+		}
+		fm[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	return fm, body, nil
+}
+
+// splitGlobs reads globs as Windsurf's comma-separated string or a list
+func splitGlobs(v any) []string {
+	var raw []string
+	switch val := v.(type) {
+	case string:
+		raw = strings.Split(val, ",")
+	case []any:
+		for _, item := range val {
+			if s, ok := item.(string); ok {
+				raw = append(raw, s)
+			}
+		}
+	}
+	var globs []string
+	for _, g := range raw {
+		if g = strings.TrimSpace(g); g != "" {
+			globs = append(globs, g)
+		}
+	}
+	return globs
+}
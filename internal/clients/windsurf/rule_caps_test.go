@@ -0,0 +1,113 @@
+package windsurf
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+func TestMatchesPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{".windsurf/rules/go-style.md", true},
+		{"/repo/services/api/.windsurf/rules/test.md", true},
+		{".windsurf/workflows/deploy.md", false},
+		{".windsurfrules", false},
+		{".clinerules/test.md", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchesPath(tt.path); got != tt.expected {
+			t.Errorf("matchesPath(%q) = %v, want %v", tt.path, got, tt.expected)
+		}
+	}
+}
+
+func TestDetectAssetType(t *testing.T) {
+	tests := []struct {
+		path string
+		want *asset.Type
+	}{
+		{".windsurf/rules/go.md", &asset.TypeRule},
+		{".windsurf/workflows/deploy.md", &asset.TypeCommand},
+		{"/home/me/.codeium/windsurf/global_workflows/review.md", &asset.TypeCommand},
+		{".windsurf/skills/pdf/SKILL.md", &asset.TypeSkill},
+		{"/home/me/.codeium/windsurf/skills/pdf", &asset.TypeSkill},
+		{".cursor/rules/go.mdc", nil},
+	}
+
+	for _, tt := range tests {
+		got := detectAssetType(tt.path, nil)
+		if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+			t.Errorf("detectAssetType(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+// Windsurf writes globs unquoted, which isn't valid YAML
+func TestParseRuleFile_WindsurfAuthored(t *testing.T) {
+	content := "---\ntrigger: glob\ndescription: Go conventions\nglobs: **/*.go, cmd/**\n---\n\nUse gofmt.\n"
+
+	parsed, err := parseRuleFile([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(parsed.Globs, []string{"**/*.go", "cmd/**"}) {
+		t.Errorf("Globs = %v", parsed.Globs)
+	}
+	if parsed.Description != "Go conventions" {
+		t.Errorf("Description = %q", parsed.Description)
+	}
+	if parsed.ClientFields["trigger"] != "glob" {
+		t.Errorf("trigger = %v", parsed.ClientFields["trigger"])
+	}
+	if parsed.Content != "Use gofmt.\n" {
+		t.Errorf("Content = %q", parsed.Content)
+	}
+}
+
+func TestGenerateRuleFile_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *metadata.RuleConfig
+		wantTrigger string
+	}{
+		{"default", &metadata.RuleConfig{}, "always_on"},
+		{"globs", &metadata.RuleConfig{Globs: []string{"**/*.ts", "web/**"}}, "glob"},
+		{"model decision", &metadata.RuleConfig{Description: "When writing SQL", Windsurf: map[string]any{"trigger": "model_decision"}}, "model_decision"},
+		{"manual", &metadata.RuleConfig{Windsurf: map[string]any{"trigger": "manual"}}, "manual"},
+		{"globs win over trigger", &metadata.RuleConfig{Globs: []string{"*.py"}, Windsurf: map[string]any{"trigger": "manual"}}, "glob"},
+		{"unknown trigger", &metadata.RuleConfig{Windsurf: map[string]any{"trigger": "sometimes"}}, "always_on"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generated := generateRuleFile(tt.cfg, "Body text.\n")
+			if !matchesContent(".windsurf/rules/x.md", generated) {
+				t.Errorf("generated rule not recognized as Windsurf:\n%s", generated)
+			}
+
+			parsed, err := parseRuleFile(generated)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed.ClientFields["trigger"] != tt.wantTrigger {
+				t.Errorf("trigger = %v, want %s\n%s", parsed.ClientFields["trigger"], tt.wantTrigger, generated)
+			}
+			if !slices.Equal(parsed.Globs, tt.cfg.Globs) {
+				t.Errorf("Globs = %v, want %v", parsed.Globs, tt.cfg.Globs)
+			}
+			if parsed.Description != tt.cfg.Description {
+				t.Errorf("Description = %q, want %q", parsed.Description, tt.cfg.Description)
+			}
+			if strings.TrimSpace(parsed.Content) != "Body text." {
+				t.Errorf("Content = %q", parsed.Content)
+			}
+		})
+	}
+}
@@ -0,0 +1,357 @@
+package zed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/bootstrap"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/zed/handlers"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/logger"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+// Client implements the clients.Client interface for the Zed editor
+type Client struct {
+	clients.BaseClient
+}
+
+// NewClient creates a new Zed client
+func NewClient() *Client {
+	return &Client{
+		BaseClient: clients.NewBaseClient(
+			clients.ClientIDZed,
+			"Zed",
+			[]asset.Type{
+				asset.TypeRule, // .rules at the worktree root
+				asset.TypeMCP,  // context_servers in settings.json
+			},
+		),
+	}
+}
+
+// RuleCapabilities returns Zed's rule capabilities
+func (c *Client) RuleCapabilities() *clients.RuleCapabilities {
+	return RuleCapabilities()
+}
+
+// WritesManagedSections reports that repo and path rules are sections of
+// .rules; global rules are skipped
+func (c *Client) WritesManagedSections(assetType asset.Type, scope *clients.InstallScope) bool {
+	return assetType == asset.TypeRule && scope.Type != clients.ScopeGlobal
+}
+
+// IsInstalled checks if Zed is installed: the zed CLI (zeditor on some
+// Linux packages) in PATH, or the ~/.config/zed directory the editor
+// creates on first run. Project .zed directories don't count, since they
+// can be committed to a repo without Zed being installed.
+func (c *Client) IsInstalled() bool {
+	for _, bin := range []string{"zed", "zeditor"} {
+		if _, err := exec.LookPath(bin); err == nil {
+			return true
+		}
+	}
+
+	globalDir, err := handlers.GlobalDir()
+	if err != nil {
+		return false
+	}
+	stat, err := os.Stat(globalDir)
+	return err == nil && stat.IsDir()
+}
+
+// GetVersion returns the Zed version
+func (c *Client) GetVersion() string {
+	cmd := exec.Command("zed", "--version")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	// Prints e.g. "Zed 0.203.4 1a2b3c4"
+	return strings.TrimSpace(string(output))
+}
+
+// InstallAssets installs assets to Zed using client-specific handlers
+func (c *Client) InstallAssets(ctx context.Context, req clients.InstallRequest) (clients.InstallResponse, error) {
+	resp := clients.InstallResponse{
+		Results: make([]clients.AssetResult, 0, len(req.Assets)),
+	}
+
+	targetBase, err := c.determineTargetBase(req.Scope)
+	if err != nil {
+		return resp, fmt.Errorf("cannot determine installation directory: %w", err)
+	}
+
+	for _, bundle := range req.Assets {
+		result := clients.AssetResult{
+			AssetName: bundle.Asset.Name,
+		}
+
+		handler, err := handlers.NewHandler(bundle.Metadata.Asset.Type, bundle.Metadata)
+		if err != nil {
+			result.Status = clients.StatusSkipped
+			result.Message = "Unsupported asset type: " + bundle.Metadata.Asset.Type.Key
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		// Note what a new .rules hides before Install creates it
+		success := "Installed to " + targetBase
+		if bundle.Metadata.Asset.Type == asset.TypeRule && req.Scope.Type != clients.ScopeGlobal {
+			success = "Installed to " + handlers.RuleFilePath(targetBase)
+			if shadowed := handlers.ShadowedRuleFile(targetBase); shadowed != "" {
+				success += "; Zed no longer reads " + shadowed + " here"
+			}
+		}
+
+		err = handler.Install(ctx, bundle.ZipData, targetBase)
+		if errors.Is(err, handlers.ErrGlobalRulesUnsupported) {
+			result.Status = clients.StatusSkipped
+			result.Message = err.Error()
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		result.Status, result.Message, result.Error = clients.TranslateInstallError(err, success)
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+// UninstallAssets removes assets from Zed
+func (c *Client) UninstallAssets(ctx context.Context, req clients.UninstallRequest) (clients.UninstallResponse, error) {
+	resp := clients.UninstallResponse{
+		Results: make([]clients.AssetResult, 0, len(req.Assets)),
+	}
+
+	targetBase, err := c.determineTargetBase(req.Scope)
+	if err != nil {
+		return resp, fmt.Errorf("cannot determine uninstall directory: %w", err)
+	}
+
+	for _, a := range req.Assets {
+		result := clients.AssetResult{
+			AssetName: a.Name,
+		}
+
+		// Create minimal metadata for removal
+		meta := &metadata.Metadata{
+			Asset: metadata.Asset{
+				Name: a.Name,
+				Type: a.Type,
+			},
+		}
+
+		handler, err := handlers.NewHandler(a.Type, meta)
+		if err != nil {
+			result.Status = clients.StatusSkipped
+			result.Message = "Unsupported asset type: " + a.Type.Key
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		if err := handler.Remove(ctx, targetBase); err != nil {
+			result.Status = clients.StatusFailed
+			result.Error = err
+		} else {
+			result.Status = clients.StatusSuccess
+			result.Message = "Uninstalled successfully"
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+// determineTargetBase returns the settings directory for a scope:
+// ~/.config/zed globally, otherwise the scope's .zed directory (whose
+// parent is the worktree root that holds .rules)
+func (c *Client) determineTargetBase(scope *clients.InstallScope) (string, error) {
+	switch scope.Type {
+	case clients.ScopeRepository:
+		if scope.RepoRoot == "" {
+			return "", errors.New("repo-scoped install requires RepoRoot but none provided (not in a git repository?)")
+		}
+		return filepath.Join(scope.RepoRoot, handlers.ConfigDir), nil
+	case clients.ScopePath:
+		if scope.RepoRoot == "" {
+			return "", errors.New("path-scoped install requires RepoRoot but none provided (not in a git repository?)")
+		}
+		return filepath.Join(scope.RepoRoot, scope.Path, handlers.ConfigDir), nil
+	default:
+		return handlers.GlobalDir()
+	}
+}
+
+// PlanPaths lists what installs at scope may touch: settings.json and MCP
+// servers under the settings directory, and the worktree's rule file
+func (c *Client) PlanPaths(scope *clients.InstallScope) ([]string, error) {
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{
+		handlers.SettingsPath(targetBase),
+		filepath.Join(targetBase, handlers.DirMCPServers),
+	}
+	if scope.Type != clients.ScopeGlobal {
+		paths = append(paths, handlers.RuleFilePath(targetBase))
+	}
+	return paths, nil
+}
+
+// ListAssets returns installed skills; Zed has no skills
+func (c *Client) ListAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
+	return []clients.InstalledSkill{}, nil
+}
+
+// ReadSkill reads a skill by name; Zed has no skills
+func (c *Client) ReadSkill(ctx context.Context, name string, scope *clients.InstallScope) (*clients.SkillContent, error) {
+	return nil, fmt.Errorf("skill not found: %s", name)
+}
+
+// EnsureAssetSupport is a no-op for Zed: it reads .rules and
+// context_servers natively.
+func (c *Client) EnsureAssetSupport(ctx context.Context, scope *clients.InstallScope) error {
+	return nil
+}
+
+// GetBootstrapOptions returns bootstrap options for Zed. Zed has no
+// hooks, so only the MCP server is offered.
+func (c *Client) GetBootstrapOptions(ctx context.Context) []bootstrap.Option {
+	return []bootstrap.Option{
+		bootstrap.SleuthAIQueryMCP(),
+	}
+}
+
+// GetBootstrapPath returns the path to Zed's user settings file.
+func (c *Client) GetBootstrapPath() string {
+	dir, err := handlers.GlobalDir()
+	if err != nil {
+		return ""
+	}
+	return handlers.SettingsPath(dir)
+}
+
+// InstallBootstrap registers MCP servers from the enabled options in the
+// user settings.
+func (c *Client) InstallBootstrap(ctx context.Context, opts []bootstrap.Option) error {
+	log := logger.Get()
+	settingsPath := c.GetBootstrapPath()
+	if settingsPath == "" {
+		return errors.New("cannot determine Zed settings path")
+	}
+
+	for _, opt := range opts {
+		if opt.MCPConfig == nil {
+			continue
+		}
+		serverConfig := map[string]any{
+			"command": opt.MCPConfig.Command,
+			"args":    opt.MCPConfig.Args,
+		}
+		if len(opt.MCPConfig.Env) > 0 {
+			serverConfig["env"] = opt.MCPConfig.Env
+		}
+		if err := handlers.AddContextServer(settingsPath, opt.MCPConfig.Name, serverConfig); err != nil {
+			return fmt.Errorf("failed to install MCP server %s: %w", opt.MCPConfig.Name, err)
+		}
+		log.Info("MCP server installed", "server", opt.MCPConfig.Name, "client", clients.ClientIDZed)
+	}
+
+	return nil
+}
+
+// UninstallBootstrap removes MCP servers installed by InstallBootstrap.
+func (c *Client) UninstallBootstrap(ctx context.Context, opts []bootstrap.Option) error {
+	log := logger.Get()
+	settingsPath := c.GetBootstrapPath()
+	if settingsPath == "" {
+		return errors.New("cannot determine Zed settings path")
+	}
+
+	for _, opt := range opts {
+		if opt.MCPConfig == nil {
+			continue
+		}
+		if err := handlers.RemoveContextServer(settingsPath, opt.MCPConfig.Name); err != nil {
+			return err
+		}
+		log.Info("MCP server uninstalled", "server", opt.MCPConfig.Name, "client", clients.ClientIDZed)
+	}
+
+	return nil
+}
+
+// ShouldInstall always returns true for Zed, which has no session hook
+// to deduplicate.
+func (c *Client) ShouldInstall(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// VerifyAssets checks if assets are actually installed on the filesystem
+func (c *Client) VerifyAssets(ctx context.Context, assets []*lockfile.Asset, scope *clients.InstallScope) []clients.VerifyResult {
+	results := make([]clients.VerifyResult, 0, len(assets))
+
+	targetBase, err := c.determineTargetBase(scope)
+	if err != nil {
+		// Can't determine target - mark all assets as not installed
+		for _, a := range assets {
+			results = append(results, clients.VerifyResult{
+				Asset:     a,
+				Installed: false,
+				Message:   fmt.Sprintf("cannot determine target directory: %v", err),
+			})
+		}
+		return results
+	}
+
+	for _, a := range assets {
+		result := clients.VerifyResult{
+			Asset: a,
+		}
+
+		handler, err := handlers.NewHandler(a.Type, &metadata.Metadata{
+			Asset: metadata.Asset{
+				Name:    a.Name,
+				Version: a.Version,
+				Type:    a.Type,
+			},
+		})
+		if err != nil {
+			result.Message = err.Error()
+		} else {
+			result.Installed, result.Message = handler.VerifyInstalled(targetBase)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// ScanInstalledAssets returns nothing for Zed: its rules share one .rules
+// file, which sx add imports section by section, and context servers
+// aren't importable assets.
+func (c *Client) ScanInstalledAssets(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledAsset, error) {
+	return []clients.InstalledAsset{}, nil
+}
+
+// GetAssetPath returns the filesystem path to an installed asset
+func (c *Client) GetAssetPath(ctx context.Context, name string, assetType asset.Type, scope *clients.InstallScope) (string, error) {
+	return "", fmt.Errorf("import not supported for type: %s", assetType)
+}
+
+func init() {
+	// Auto-register on package import
+	clients.Register(NewClient())
+}
@@ -0,0 +1,253 @@
+package zed
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/clienttest"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+func ruleBundle(t *testing.T, name, title, body string) *clients.AssetBundle {
+	t.Helper()
+	return clienttest.Bundle(t, &metadata.Metadata{
+		Asset: metadata.Asset{Name: name, Version: "1.0", Type: asset.TypeRule},
+		Rule:  &metadata.RuleConfig{Title: title},
+	}, map[string]string{"RULE.md": body})
+}
+
+func mcpBundle(t *testing.T, name string, mcp *metadata.MCPConfig) *clients.AssetBundle {
+	t.Helper()
+	return clienttest.Bundle(t, &metadata.Metadata{
+		Asset: metadata.Asset{Name: name, Version: "1.0", Type: asset.TypeMCP},
+		MCP:   mcp,
+	}, nil)
+}
+
+func install(t *testing.T, client *Client, scope *clients.InstallScope, bundles ...*clients.AssetBundle) clients.InstallResponse {
+	t.Helper()
+	resp, err := client.InstallAssets(context.Background(), clients.InstallRequest{Assets: bundles, Scope: scope})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestInstallAssets_ContextServersKeepComments(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeGlobal}
+
+	settingsPath := filepath.Join(home, ".config", "zed", "settings.json")
+	original := `// Zed settings
+//
+// For information on how to configure Zed, see the Zed
+// documentation: https://zed.dev/docs/configuring-zed
+{
+  "theme": "One Dark", // my favourite
+  "buffer_font_size": 15,
+}
+`
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(settingsPath, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	docs := mcpBundle(t, "docs", &metadata.MCPConfig{Transport: "http", URL: "https://docs.example.com/mcp"})
+	local := mcpBundle(t, "db", &metadata.MCPConfig{Command: "db-mcp", Args: []string{"--read-only"}, Env: map[string]string{"DB": "prod"}})
+	for _, r := range install(t, client, scope, docs, local).Results {
+		if r.Status != clients.StatusSuccess {
+			t.Fatalf("%s: %s %v", r.AssetName, r.Status, r.Error)
+		}
+	}
+
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"// documentation: https://zed.dev/docs/configuring-zed", `"theme": "One Dark", // my favourite`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("settings.json lost %q:\n%s", want, data)
+		}
+	}
+
+	servers, err := readServers(settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if servers["docs"]["url"] != "https://docs.example.com/mcp" {
+		t.Errorf("docs = %v", servers["docs"])
+	}
+	if servers["db"]["command"] != "db-mcp" {
+		t.Errorf("db = %v", servers["db"])
+	}
+
+	lock := []*lockfile.Asset{docs.Asset, local.Asset}
+	for _, v := range client.VerifyAssets(context.Background(), lock, scope) {
+		if !v.Installed {
+			t.Errorf("%s not verified: %s", v.Asset.Name, v.Message)
+		}
+	}
+
+	clienttest.Uninstall(t, client, scope, docs, local)
+	data, _ = os.ReadFile(settingsPath)
+	if strings.Contains(string(data), "docs.example.com") || strings.Contains(string(data), "db-mcp") {
+		t.Errorf("servers left after uninstall:\n%s", data)
+	}
+	if !strings.Contains(string(data), "// my favourite") {
+		t.Errorf("comments lost on uninstall:\n%s", data)
+	}
+	for _, v := range client.VerifyAssets(context.Background(), lock, scope) {
+		if v.Installed {
+			t.Errorf("%s still verified after uninstall", v.Asset.Name)
+		}
+	}
+}
+
+func TestInstallAssets_RulesFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	repo := t.TempDir()
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: repo}
+	rulesPath := filepath.Join(repo, ".rules")
+
+	if err := os.WriteFile(rulesPath, []byte("Written by hand.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	goStyle := ruleBundle(t, "go-style", "Go Style", "Use gofmt.")
+	tests := ruleBundle(t, "tests", "Tests", "Table-driven, please.")
+	install(t, client, scope, goStyle, tests)
+
+	want := "Written by hand.\n\n" +
+		"<!-- sx:go-style -->\n## Go Style\n\nUse gofmt.\n<!-- /sx:go-style -->\n\n" +
+		"<!-- sx:tests -->\n## Tests\n\nTable-driven, please.\n<!-- /sx:tests -->\n"
+	if data, _ := os.ReadFile(rulesPath); string(data) != want {
+		t.Errorf(".rules =\n%q\nwant\n%q", data, want)
+	}
+
+	// Reinstalling replaces the section in place
+	install(t, client, scope, ruleBundle(t, "go-style", "Go Style", "Use gofmt and vet."))
+	if data, _ := os.ReadFile(rulesPath); !strings.Contains(string(data), "<!-- sx:go-style -->\n## Go Style\n\nUse gofmt and vet.\n<!-- /sx:go-style -->\n\n<!-- sx:tests -->") {
+		t.Errorf("after reinstall:\n%s", data)
+	}
+
+	lock := []*lockfile.Asset{goStyle.Asset, tests.Asset}
+	for _, v := range client.VerifyAssets(context.Background(), lock, scope) {
+		if !v.Installed {
+			t.Errorf("%s not verified: %s", v.Asset.Name, v.Message)
+		}
+	}
+
+	clienttest.Uninstall(t, client, scope, goStyle, tests)
+	if data, _ := os.ReadFile(rulesPath); string(data) != "Written by hand.\n" {
+		t.Errorf("after uninstall:\n%q", data)
+	}
+}
+
+func TestInstallAssets_RemovesEmptyRulesFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	repo := t.TempDir()
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: repo}
+
+	rule := ruleBundle(t, "go-style", "Go Style", "Use gofmt.")
+	install(t, client, scope, rule)
+	clienttest.Uninstall(t, client, scope, rule)
+
+	if _, err := os.Stat(filepath.Join(repo, ".rules")); !os.IsNotExist(err) {
+		t.Errorf(".rules should be removed once empty, stat err = %v", err)
+	}
+}
+
+// TestInstallAssets_SharedRuleFile: with no .rules, a rule goes into the
+// AGENTS.md Zed already reads rather than a new .rules that would hide it
+func TestInstallAssets_SharedRuleFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	repo := t.TempDir()
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: repo}
+	agentsPath := filepath.Join(repo, "AGENTS.md")
+	if err := os.WriteFile(agentsPath, []byte("# Agents\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rule := ruleBundle(t, "go-style", "Go Style", "Use gofmt.")
+	install(t, client, scope, rule)
+	if _, err := os.Stat(filepath.Join(repo, ".rules")); !os.IsNotExist(err) {
+		t.Errorf(".rules should not be created, stat err = %v", err)
+	}
+	if data, _ := os.ReadFile(agentsPath); !strings.Contains(string(data), "<!-- sx:go-style -->") {
+		t.Errorf("AGENTS.md =\n%s", data)
+	}
+	for _, v := range client.VerifyAssets(context.Background(), []*lockfile.Asset{rule.Asset}, scope) {
+		if !v.Installed {
+			t.Errorf("%s not verified: %s", v.Asset.Name, v.Message)
+		}
+	}
+
+	clienttest.Uninstall(t, client, scope, rule)
+	if data, _ := os.ReadFile(agentsPath); string(data) != "# Agents\n" {
+		t.Errorf("after uninstall:\n%q", data)
+	}
+}
+
+// TestInstallAssets_ShadowedRuleFileReported: creating .rules over another
+// tool's rule file says so in the install result
+func TestInstallAssets_ShadowedRuleFileReported(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	repo := t.TempDir()
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: repo}
+	if err := os.WriteFile(filepath.Join(repo, ".cursorrules"), []byte("Be terse.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := install(t, client, scope, ruleBundle(t, "go-style", "Go Style", "Use gofmt."))
+	if len(resp.Results) != 1 || !strings.Contains(resp.Results[0].Message, "no longer reads .cursorrules") {
+		t.Fatalf("results = %+v, want a note about .cursorrules", resp.Results)
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".rules")); err != nil {
+		t.Errorf(".rules should be created: %v", err)
+	}
+
+	// Once .rules exists there's nothing left to hide
+	resp = install(t, client, scope, ruleBundle(t, "tests", "Tests", "Table-driven, please."))
+	if strings.Contains(resp.Results[0].Message, "no longer reads") {
+		t.Errorf("message = %q, want no note", resp.Results[0].Message)
+	}
+}
+
+func TestInstallAssets_GlobalRuleSkipped(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	client := NewClient()
+
+	resp := install(t, client, &clients.InstallScope{Type: clients.ScopeGlobal}, ruleBundle(t, "go-style", "Go Style", "Use gofmt."))
+	if len(resp.Results) != 1 || resp.Results[0].Status != clients.StatusSkipped {
+		t.Fatalf("results = %+v, want one skipped", resp.Results)
+	}
+}
+
+func readServers(path string) (map[string]map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var settings struct {
+		ContextServers map[string]map[string]any `json:"context_servers"`
+	}
+	if err := utils.UnmarshalJSONC(data, &settings); err != nil {
+		return nil, err
+	}
+	return settings.ContextServers, nil
+}
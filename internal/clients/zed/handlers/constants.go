@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Configuration directories
+const (
+	// ConfigDir is Zed's project settings directory (in a repo)
+	ConfigDir = ".zed"
+
+	// GlobalConfigDir is Zed's user settings directory, relative to home
+	// (the same on macOS and Linux)
+	GlobalConfigDir = ".config/zed"
+)
+
+// File and directory names for Zed assets
+const (
+	// SettingsFile holds Zed settings, including context_servers. Zed
+	// reads it as JSONC, so users often keep comments in it.
+	SettingsFile = "settings.json"
+
+	// RulesFile is the project rules file Zed loads into every agent
+	// thread, at the worktree root
+	RulesFile = ".rules"
+
+	DirMCPServers = "mcp-servers"
+)
+
+// ShadowedRuleFiles are the other rule files Zed falls back to, in its
+// order of preference, when a worktree has no .rules. Zed loads only the
+// first one it finds, so creating .rules hides them.
+var ShadowedRuleFiles = []string{
+	".cursorrules",
+	".windsurfrules",
+	".clinerules",
+	".github/copilot-instructions.md",
+	"AGENT.md",
+	"AGENTS.md",
+	"CLAUDE.md",
+	"GEMINI.md",
+}
+
+// SharedRuleFiles are the fallbacks other agents read too. When one of
+// them is the file Zed reads today, rules go into it rather than into a
+// new .rules that would hide it.
+var SharedRuleFiles = []string{
+	"AGENT.md",
+	"AGENTS.md",
+	"CLAUDE.md",
+	"GEMINI.md",
+}
+
+// GlobalDir returns ~/.config/zed
+func GlobalDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(home, GlobalConfigDir), nil
+}
+
+// isGlobalBase reports whether targetBase is the user settings directory
+// rather than a project's .zed
+func isGlobalBase(targetBase string) bool {
+	global, err := GlobalDir()
+	return err == nil && filepath.Clean(targetBase) == global
+}
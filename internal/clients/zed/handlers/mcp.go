@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/handlers/dirasset"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+var mcpOps = dirasset.NewOperations(DirMCPServers, &asset.TypeMCP)
+
+// contextServersKey is the settings.json member Zed reads MCP servers from
+const contextServersKey = "context_servers"
+
+// MCPHandler handles MCP asset installation for Zed. Servers are
+// registered under context_servers in the scope's settings.json, which is
+// edited in place so the user's comments and formatting survive.
+type MCPHandler struct {
+	metadata *metadata.Metadata
+}
+
+// NewMCPHandler creates a new MCP handler
+func NewMCPHandler(meta *metadata.Metadata) *MCPHandler {
+	return &MCPHandler{metadata: meta}
+}
+
+// Install installs an MCP asset by adding it to settings.json
+func (h *MCPHandler) Install(ctx context.Context, zipData []byte, targetBase string) error {
+	hasContent, err := utils.HasContentFiles(zipData)
+	if err != nil {
+		return fmt.Errorf("failed to inspect zip contents: %w", err)
+	}
+
+	var entry map[string]any
+	if hasContent {
+		// Packaged mode: extract MCP server files
+		serverDir := filepath.Join(targetBase, DirMCPServers, h.metadata.Asset.Name)
+		if err := utils.ExtractZip(zipData, serverDir); err != nil {
+			return fmt.Errorf("failed to extract MCP server: %w", err)
+		}
+		entry = h.generatePackagedMCPEntry(serverDir)
+	} else {
+		// Config-only mode: no extraction needed
+		entry = h.generateConfigOnlyMCPEntry()
+	}
+
+	return AddContextServer(SettingsPath(targetBase), h.metadata.Asset.Name, entry)
+}
+
+// Remove removes the context server from settings.json
+func (h *MCPHandler) Remove(ctx context.Context, targetBase string) error {
+	if err := RemoveContextServer(SettingsPath(targetBase), h.metadata.Asset.Name); err != nil {
+		return err
+	}
+
+	// Remove server directory if it exists (packaged mode)
+	serverDir := filepath.Join(targetBase, DirMCPServers, h.metadata.Asset.Name)
+	os.RemoveAll(serverDir) // Ignore errors if doesn't exist
+
+	return nil
+}
+
+// VerifyInstalled checks if the MCP server is properly installed
+func (h *MCPHandler) VerifyInstalled(targetBase string) (bool, string) {
+	// Check if install directory exists (packaged mode)
+	installDir := filepath.Join(targetBase, DirMCPServers, h.metadata.Asset.Name)
+	if utils.IsDirectory(installDir) {
+		return mcpOps.VerifyInstalled(targetBase, h.metadata.Asset.Name, h.metadata.Asset.Version)
+	}
+
+	// Config-only mode: check settings.json for the server entry
+	servers, err := ReadContextServers(SettingsPath(targetBase))
+	if err != nil {
+		return false, "failed to read Zed settings: " + err.Error()
+	}
+	if _, exists := servers[h.metadata.Asset.Name]; !exists {
+		return false, "context server not registered"
+	}
+	return true, "installed"
+}
+
+func (h *MCPHandler) generatePackagedMCPEntry(serverDir string) map[string]any {
+	mcpConfig := h.metadata.MCP
+
+	command, args := utils.ResolveCommandAndArgs(mcpConfig.Command, mcpConfig.Args, serverDir)
+
+	entry := map[string]any{
+		"command": command,
+		"args":    args,
+	}
+
+	if len(mcpConfig.Env) > 0 {
+		entry["env"] = mcpConfig.Env
+	}
+
+	return entry
+}
+
+func (h *MCPHandler) generateConfigOnlyMCPEntry() map[string]any {
+	mcpConfig := h.metadata.MCP
+
+	if mcpConfig.IsRemote() {
+		// Zed connects to remote servers by url, for both streamable
+		// HTTP and SSE
+		return map[string]any{
+			"url": mcpConfig.URL,
+		}
+	}
+
+	entry := map[string]any{
+		"command": mcpConfig.Command,
+		"args":    utils.StringsToAny(mcpConfig.Args),
+	}
+
+	if len(mcpConfig.Env) > 0 {
+		entry["env"] = mcpConfig.Env
+	}
+
+	return entry
+}
+
+// SettingsPath returns the settings.json under a target base
+func SettingsPath(targetBase string) string {
+	return filepath.Join(targetBase, SettingsFile)
+}
+
+// ReadContextServers returns the context_servers entries of a Zed
+// settings file; a missing file has none
+func ReadContextServers(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return map[string]any{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var settings struct {
+		ContextServers map[string]any `json:"context_servers"`
+	}
+	if err := utils.UnmarshalJSONC(data, &settings); err != nil {
+		return nil, err
+	}
+	if settings.ContextServers == nil {
+		settings.ContextServers = map[string]any{}
+	}
+	return settings.ContextServers, nil
+}
+
+// AddContextServer adds or updates a context server in a Zed settings
+// file, leaving the rest of the file untouched
+func AddContextServer(path, serverName string, serverConfig map[string]any) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read Zed settings: %w", err)
+	}
+
+	updated, err := utils.SetJSONCValue(data, []string{contextServersKey, serverName}, serverConfig)
+	if err != nil {
+		return fmt.Errorf("failed to update Zed settings %s: %w", path, err)
+	}
+
+	if err := utils.EnsureDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := utils.WriteFileAtomic(path, updated, 0644); err != nil {
+		return fmt.Errorf("failed to write Zed settings: %w", err)
+	}
+	return nil
+}
+
+// RemoveContextServer removes a context server from a Zed settings file.
+// The file is left alone when the server isn't registered.
+func RemoveContextServer(path, serverName string) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read Zed settings: %w", err)
+	}
+
+	updated, removed, err := utils.DeleteJSONCValue(data, []string{contextServersKey, serverName})
+	if err != nil {
+		return fmt.Errorf("failed to update Zed settings %s: %w", path, err)
+	}
+	if !removed {
+		return nil
+	}
+	if err := utils.WriteFileAtomic(path, updated, 0644); err != nil {
+		return fmt.Errorf("failed to write Zed settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/handlers/rule"
+	"github.com/sleuth-io/sx/v2/internal/handlers/section"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// ErrGlobalRulesUnsupported is returned when installing a rule globally:
+// Zed keeps user-level rules in its Rules Library database, not in a file
+var ErrGlobalRulesUnsupported = errors.New("zed has no file-based global rules; install the rule to a repository instead")
+
+// RuleHandler handles rule asset installation for Zed. Each rule is a
+// marked section of the rule file at the worktree root (the parent of the
+// scope's .zed directory); see RuleFilePath.
+type RuleHandler struct {
+	metadata *metadata.Metadata
+}
+
+// NewRuleHandler creates a new rule handler
+func NewRuleHandler(meta *metadata.Metadata) *RuleHandler {
+	return &RuleHandler{metadata: meta}
+}
+
+// RulesPath returns the .rules file for a project target base
+func RulesPath(targetBase string) string {
+	return filepath.Join(filepath.Dir(targetBase), RulesFile)
+}
+
+// RuleFilePath returns the file rules for a project target base go into:
+// .rules when it exists, else the shared instruction file Zed reads today
+// (AGENTS.md and the like), else a new .rules
+func RuleFilePath(targetBase string) string {
+	root := filepath.Dir(targetBase)
+	if name := activeRuleFile(root); name == RulesFile || slices.Contains(SharedRuleFiles, name) {
+		return filepath.Join(root, filepath.FromSlash(name))
+	}
+	return RulesPath(targetBase)
+}
+
+// ShadowedRuleFile returns the rule file Zed reads today that installing a
+// rule at a project target base would hide by creating .rules, or ""
+func ShadowedRuleFile(targetBase string) string {
+	name := activeRuleFile(filepath.Dir(targetBase))
+	if name == RulesFile || slices.Contains(SharedRuleFiles, name) {
+		return ""
+	}
+	return name
+}
+
+// Install adds or replaces the rule's section of the rule file
+func (h *RuleHandler) Install(ctx context.Context, zipData []byte, targetBase string) error {
+	if isGlobalBase(targetBase) {
+		return ErrGlobalRulesUnsupported
+	}
+
+	content, err := h.readRuleContent(zipData)
+	if err != nil {
+		return fmt.Errorf("failed to read rule content: %w", err)
+	}
+
+	body := "## " + h.getTitle() + "\n\n" + strings.TrimSpace(content) + "\n"
+	return section.Write(ctx, RuleFilePath(targetBase), h.metadata.Asset.Name, body)
+}
+
+// Remove removes the rule's section, deleting the file once it's empty
+func (h *RuleHandler) Remove(ctx context.Context, targetBase string) error {
+	if isGlobalBase(targetBase) {
+		return nil
+	}
+
+	return section.Remove(ctx, RuleFilePath(targetBase), h.metadata.Asset.Name)
+}
+
+// VerifyInstalled checks if the rule's section is present in the rule file
+func (h *RuleHandler) VerifyInstalled(targetBase string) (bool, string) {
+	if isGlobalBase(targetBase) {
+		return false, ErrGlobalRulesUnsupported.Error()
+	}
+
+	filePath := RuleFilePath(targetBase)
+	if !utils.FileExists(filePath) {
+		return false, filepath.Base(filePath) + " not found"
+	}
+	if section.Contains(filePath, h.metadata.Asset.Name) {
+		return true, "Found in " + filePath
+	}
+	return false, "Rule section not found in " + filepath.Base(filePath)
+}
+
+// activeRuleFile returns the rule file Zed reads in root today, if any
+func activeRuleFile(root string) string {
+	for _, name := range append([]string{RulesFile}, ShadowedRuleFiles...) {
+		if utils.FileExists(filepath.Join(root, filepath.FromSlash(name))) {
+			return name
+		}
+	}
+	return ""
+}
+
+// getTitle returns the rule title, defaulting to asset name
+func (h *RuleHandler) getTitle() string {
+	if h.metadata.Rule != nil && h.metadata.Rule.Title != "" {
+		return h.metadata.Rule.Title
+	}
+	return h.metadata.Asset.Name
+}
+
+// getPromptFile returns the prompt file, using the shared default
+func (h *RuleHandler) getPromptFile() string {
+	if h.metadata.Rule != nil && h.metadata.Rule.PromptFile != "" {
+		return h.metadata.Rule.PromptFile
+	}
+	return rule.DefaultPromptFile
+}
+
+// readRuleContent reads the rule content from the zip
+func (h *RuleHandler) readRuleContent(zipData []byte) (string, error) {
+	promptFile := h.getPromptFile()
+
+	content, err := utils.ReadZipFile(zipData, promptFile)
+	if err != nil {
+		// Try lowercase variant
+		content, err = utils.ReadZipFile(zipData, "rule.md")
+		if err != nil {
+			return "", fmt.Errorf("prompt file not found: %s", promptFile)
+		}
+	}
+
+	return string(content), nil
+}
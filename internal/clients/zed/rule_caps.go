@@ -0,0 +1,69 @@
+package zed
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/zed/handlers"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+)
+
+// RuleCapabilities returns the rule capabilities for Zed
+func RuleCapabilities() *clients.RuleCapabilities {
+	return &clients.RuleCapabilities{
+		ClientName:       clients.ClientIDZed,
+		RulesDirectory:   "", // Zed uses .rules at the worktree root, not a directory
+		FileExtension:    "",
+		InstructionFiles: []string{handlers.RulesFile},
+		MatchesPath:      matchesPath,
+		MatchesContent:   matchesContent,
+		ParseRuleFile:    parseRuleFile,
+		GenerateRuleFile: generateRuleFile,
+		DetectAssetType:  detectAssetType,
+	}
+}
+
+// detectAssetType never claims a file: .rules holds many rules and is
+// imported as an instruction file, section by section
+func detectAssetType(path string, _ []byte) *asset.Type {
+	return nil
+}
+
+// matchesPath reports whether path is a Zed .rules file
+func matchesPath(path string) bool {
+	return filepath.Base(path) == handlers.RulesFile
+}
+
+// matchesContent checks if content appears to be a Zed rule file.
+// Zed rules are plain markdown, so only the file name tells.
+func matchesContent(path string, content []byte) bool {
+	return matchesPath(path)
+}
+
+// parseRuleFile parses a Zed rule file and returns the canonical format
+// Zed rules are plain markdown without frontmatter
+func parseRuleFile(content []byte) (*clients.ParsedRule, error) {
+	return &clients.ParsedRule{
+		Content:    string(content),
+		ClientName: clients.ClientIDZed,
+	}, nil
+}
+
+// generateRuleFile creates a rule for Zed as plain markdown; Zed has no
+// globs or activation settings for project rules
+func generateRuleFile(cfg *metadata.RuleConfig, body string) []byte {
+	var content strings.Builder
+
+	if cfg != nil && cfg.Title != "" {
+		content.WriteString("# ")
+		content.WriteString(cfg.Title)
+		content.WriteString("\n\n")
+	}
+
+	content.WriteString(strings.TrimSpace(body))
+	content.WriteString("\n")
+
+	return []byte(content.String())
+}
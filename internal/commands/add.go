@@ -18,6 +18,7 @@ import (
 	"github.com/sleuth-io/sx/v2/internal/lockfile"
 	"github.com/sleuth-io/sx/v2/internal/metadata"
 	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/publish"
 	"github.com/sleuth-io/sx/v2/internal/ui/components"
 	"github.com/sleuth-io/sx/v2/internal/ui/theme"
 	vaultpkg "github.com/sleuth-io/sx/v2/internal/vault"
@@ -330,7 +331,7 @@ func addFromZipSource(ctx context.Context, cmd *cobra.Command, out *outputHelper
 	// Handle install: auto-run if --yes, prompt if interactive, skip if --no-install
 	if opts.Yes && !opts.NoInstall {
 		out.println()
-		if err := runInstall(cmd, nil, false, "", false, "", "", false, false); err != nil {
+		if err := runInstall(cmd, nil, false, "", false, "", "", false, false, false, ""); err != nil {
 			out.printfErr("Install failed: %v\n", err)
 		}
 	} else if !opts.NoInstall && !opts.isNonInteractive() {
@@ -452,7 +453,7 @@ func promptRunInstall(cmd *cobra.Command, ctx context.Context, out *outputHelper
 	}
 
 	out.println()
-	if err := runInstall(cmd, nil, false, "", false, "", "", false, false); err != nil {
+	if err := runInstall(cmd, nil, false, "", false, "", "", false, false, false, ""); err != nil {
 		out.printfErr("Install failed: %v\n", err)
 	}
 }
@@ -621,10 +622,11 @@ func addNewAsset(ctx context.Context, out *outputHelper, status *components.Stat
 	// the lockfile so isAssetApplicable / MatchesClient see the restriction
 	// without re-reading the metadata.toml at install time.
 	lockAsset := &lockfile.Asset{
-		Name:    meta.Asset.Name,
-		Version: meta.Asset.Version,
-		Type:    meta.Asset.Type,
-		Clients: append([]string(nil), meta.Asset.Clients...),
+		Name:         meta.Asset.Name,
+		Version:      meta.Asset.Version,
+		Type:         meta.Asset.Type,
+		Clients:      append([]string(nil), meta.Asset.Clients...),
+		Dependencies: publish.LockDependencies(meta),
 		SourcePath: &lockfile.SourcePath{
 			Path: assetSourcePath(vault, meta.Asset.Name, meta.Asset.Version),
 		},
@@ -642,10 +644,15 @@ func addNewAsset(ctx context.Context, out *outputHelper, status *components.Stat
 		return true, addViaPullRequest(ctx, out, status, prv, vault, lockAsset, zipData)
 	}
 
+	key, err := loadPublishingKey(vault)
+	if err != nil {
+		return false, err
+	}
+
 	// Upload asset files to vault
 	out.println()
 	status.Start("Adding asset to vault")
-	if err := vault.AddAsset(ctx, lockAsset, zipData); err != nil {
+	if err := addAssetVersion(ctx, vault, lockAsset, zipData, key); err != nil {
 		// Sleuth vault enforces the edit gate server-side: a blocked publish
 		// comes back as an AssetEditPermissionError (a 403). When the vault can
 		// propose a PR, offer that instead of failing — the same fallback the
@@ -668,6 +675,7 @@ func addNewAsset(ctx context.Context, out *outputHelper, status *components.Stat
 	status.Done("")
 
 	out.printf("✓ Successfully added %s@%s\n", meta.Asset.Name, meta.Asset.Version)
+	reportPublishSignature(ctx, out, vault, meta.Asset.Name, meta.Asset.Version, key)
 
 	// --no-install: write lock file but skip install prompt. Honors any
 	// scope flags so batch flows can pin assets to a target repo/path/scope.
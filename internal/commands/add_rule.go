@@ -72,6 +72,22 @@ func createZipFromRuleFile(filePath string) ([]byte, error) {
 			Description: parsed.Description,
 		},
 	}
+	// Windsurf's manual and model_decision triggers have no canonical
+	// equivalent; keep them so the rule installs the way it was authored.
+	if trigger, ok := parsed.ClientFields["trigger"].(string); ok && parsed.ClientName == clients.ClientIDWindsurf {
+		meta.Rule.Windsurf = map[string]any{"trigger": trigger}
+	}
+	// Likewise Continue's alwaysApply and regex activation
+	if parsed.ClientName == clients.ClientIDContinue {
+		for _, key := range []string{"always-apply", "regex"} {
+			if v, ok := parsed.ClientFields[key]; ok {
+				if meta.Rule.Continue == nil {
+					meta.Rule.Continue = map[string]any{}
+				}
+				meta.Rule.Continue[key] = v
+			}
+		}
+	}
 
 	// Store clean content (without frontmatter) in RULE.md
 	cleanContent := strings.TrimSpace(parsed.Content)
@@ -397,14 +413,20 @@ func uploadRuleZip(ctx context.Context, status *components.Status, vault vaultpk
 		},
 	}
 
+	key, err := loadPublishingKey(vault)
+	if err != nil {
+		return err
+	}
+
 	status.Start("Adding " + name + " to vault")
-	if err := vault.AddAsset(ctx, lockAsset, zipData); err != nil {
+	if err := addAssetVersion(ctx, vault, lockAsset, zipData, key); err != nil {
 		status.Fail("Failed")
 		return fmt.Errorf("failed to add asset: %w", err)
 	}
 	status.Done("")
 
 	out.printf("✓ Added %s@%s\n", name, version)
+	reportPublishSignature(ctx, out, vault, name, version, key)
 	return nil
 }
 
@@ -68,6 +68,7 @@ first. Use --json to emit machine-readable output.`,
 	cmd.Flags().StringVar(&sinceStr, "since", "all", "Time range (7d, 30d, 90d, all)")
 	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of rows to return")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.AddCommand(newAuditVerifyCommand(), newAuditExportCommand(), newAuditTailCommand())
 	return cmd
 }
 
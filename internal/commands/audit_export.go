@@ -0,0 +1,443 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/sx/v2/internal/buildinfo"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/vault"
+)
+
+// Audit export formats
+const (
+	auditFormatText  = "text"
+	auditFormatJSONL = "jsonl"
+	auditFormatCSV   = "csv"
+	auditFormatCEF   = "cef"
+)
+
+// auditQueryTimeout bounds each query against the vault. Exports of a
+// hosted vault's whole history page through many requests.
+const auditQueryTimeout = 5 * time.Minute
+
+// auditFilterFlags are the filters export and tail share with sx audit
+type auditFilterFlags struct {
+	actor       string
+	eventPrefix string
+	target      string
+}
+
+func (f *auditFilterFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.actor, "actor", "", "Filter by actor email")
+	cmd.Flags().StringVar(&f.eventPrefix, "event", "", "Filter by event name prefix (e.g. team. or asset.removed)")
+	cmd.Flags().StringVar(&f.target, "target", "", "Filter by target (team name, asset name, etc.)")
+}
+
+func (f *auditFilterFlags) filter() mgmt.AuditFilter {
+	return mgmt.AuditFilter{Actor: f.actor, EventPrefix: f.eventPrefix, Target: f.target}
+}
+
+// auditSinkFlags choose where export and tail send events
+type auditSinkFlags struct {
+	target  string
+	headers []string
+}
+
+func (f *auditSinkFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.target, "sink", "", "Send events to a SIEM instead of stdout: syslog://host:port (also syslog+tcp://, syslog+tls://) or an http(s):// URL")
+	cmd.Flags().StringArrayVar(&f.headers, "sink-header", nil, "Header for an HTTP sink, as 'Name: value' (repeatable)")
+}
+
+func newAuditExportCommand() *cobra.Command {
+	var filters auditFilterFlags
+	var sinkFlags auditSinkFlags
+	var format, sinceStr, untilStr, cursorStr string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export audit events as JSONL, CSV or CEF",
+		Long: `Export audit events oldest first, for archiving or loading into a SIEM.
+
+--since and --until take a number of days (30d), a date (2026-05-01) or
+an RFC 3339 time; a date for --until includes that whole day. With
+--limit, events are exported in pages: the cursor for the next page is
+printed to stderr, and --cursor continues from it.`,
+		Example: `  sx audit export --since 30d > audit.jsonl
+  sx audit export --format csv --since 2026-04-01 --until 2026-04-30
+  sx audit export --limit 1000 --cursor <cursor from the previous page>
+  sx audit export --format cef --sink syslog+tcp://siem.example.com:601`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateAuditFormat(format, false); err != nil {
+				return err
+			}
+			if sinkFlags.target != "" && format == auditFormatCSV {
+				return errors.New("--sink sends one event per message, so it doesn't support --format csv")
+			}
+			if limit < 0 {
+				return errors.New("--limit must not be negative")
+			}
+			now := time.Now().UTC()
+			filter := filters.filter()
+			var err error
+			if filter.Since, err = parseAuditTime("--since", sinceStr, now, false); err != nil {
+				return err
+			}
+			if filter.Until, err = parseAuditTime("--until", untilStr, now, true); err != nil {
+				return err
+			}
+			var after *mgmt.AuditCursor
+			if cursorStr != "" {
+				c, err := mgmt.ParseAuditCursor(cursorStr)
+				if err != nil {
+					return fmt.Errorf("--cursor: %w", err)
+				}
+				after = &c
+				if c.Timestamp.After(filter.Since) {
+					// Nothing before the cursor is on this page
+					filter.Since = c.Timestamp
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), auditQueryTimeout)
+			defer cancel()
+			v, err := loadVault()
+			if err != nil {
+				return err
+			}
+			events, err := v.QueryAuditEvents(ctx, filter)
+			if err != nil {
+				return err
+			}
+			mgmt.SortAuditEventsAscending(events)
+			page, next := mgmt.PageAuditEvents(events, after, limit)
+
+			if sinkFlags.target != "" {
+				sink, err := openAuditSink(sinkFlags.target, sinkFlags.headers, format)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = sink.Close() }()
+				if err := sendAuditEvents(ctx, sink, format, page); err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "Sent %d events to %s\n", len(page), sinkFlags.target)
+			} else if err := writeAuditEvents(cmd.OutOrStdout(), format, page, true); err != nil {
+				return err
+			}
+			if next != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Next page: --cursor %s\n", next)
+			}
+			return nil
+		},
+	}
+
+	filters.register(cmd)
+	sinkFlags.register(cmd)
+	cmd.Flags().StringVar(&format, "format", auditFormatJSONL, "Output format: jsonl, csv, or cef")
+	cmd.Flags().StringVar(&sinceStr, "since", "all", "Export events from this time (30d, 2026-05-01, RFC 3339, or all)")
+	cmd.Flags().StringVar(&untilStr, "until", "", "Export events up to this time (same forms as --since)")
+	cmd.Flags().IntVar(&limit, "limit", 0, "Events per page; 0 exports everything")
+	cmd.Flags().StringVar(&cursorStr, "cursor", "", "Continue from the cursor a previous page printed")
+	return cmd
+}
+
+func newAuditTailCommand() *cobra.Command {
+	var filters auditFilterFlags
+	var sinkFlags auditSinkFlags
+	var format string
+	var lines int
+	var follow bool
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Show the latest audit events, optionally following new ones",
+		Long: `Show the latest audit events, oldest first. With --follow, keep checking
+the vault every --interval and print new events as they are recorded:
+local audit files are re-read, a git vault is fetched, and a skills.new
+vault is polled. Stop with Ctrl-C.
+
+Events are followed by timestamp, so an event recorded with an earlier
+time (e.g. by sx vault copy) isn't streamed; sx audit export finds it.`,
+		Example: `  sx audit tail -n 20
+  sx audit tail --follow --event team.
+  sx audit tail --follow --format cef --sink syslog://siem.example.com:514
+  sx audit tail --follow --sink https://siem.example.com/ingest --sink-header "Authorization: Bearer $TOKEN"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateAuditFormat(format, true); err != nil {
+				return err
+			}
+			if lines < 0 {
+				return errors.New("--lines must not be negative")
+			}
+			if interval <= 0 {
+				return errors.New("--interval must be positive")
+			}
+			v, err := loadVault()
+			if err != nil {
+				return err
+			}
+
+			emit := func(ctx context.Context, events []mgmt.AuditEvent) error {
+				return writeAuditEvents(cmd.OutOrStdout(), format, events, false)
+			}
+			if sinkFlags.target != "" {
+				sink, err := openAuditSink(sinkFlags.target, sinkFlags.headers, format)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = sink.Close() }()
+				emit = func(ctx context.Context, events []mgmt.AuditEvent) error {
+					return sendAuditEvents(ctx, sink, format, events)
+				}
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			cursor, err := tailAuditEvents(ctx, v, filters.filter(), lines, emit)
+			if err != nil || !follow {
+				return err
+			}
+			return followAuditEvents(ctx, v, filters.filter(), cursor, interval, emit, cmd.ErrOrStderr())
+		},
+	}
+
+	filters.register(cmd)
+	sinkFlags.register(cmd)
+	cmd.Flags().StringVar(&format, "format", auditFormatText, "Output format: text, jsonl, or cef")
+	cmd.Flags().IntVarP(&lines, "lines", "n", 10, "Number of recent events to show first")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep streaming new events")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "How often --follow checks for new events")
+	return cmd
+}
+
+// tailAuditEvents emits the last n matching events and returns the cursor
+// after the newest event in the log, which is nil for an empty log
+func tailAuditEvents(ctx context.Context, v vault.Vault, filter mgmt.AuditFilter, n int, emit func(context.Context, []mgmt.AuditEvent) error) (*mgmt.AuditCursor, error) {
+	qctx, cancel := context.WithTimeout(ctx, auditQueryTimeout)
+	defer cancel()
+	events, err := v.QueryAuditEvents(qctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+	mgmt.SortAuditEventsAscending(events)
+	cursor := mgmt.CursorFor(events[len(events)-1])
+	if n < len(events) {
+		events = events[len(events)-n:]
+	}
+	if len(events) > 0 {
+		if err := emit(ctx, events); err != nil {
+			return nil, err
+		}
+	}
+	return &cursor, nil
+}
+
+// followAuditEvents polls the vault every interval and emits events after
+// cursor until ctx is done. When emit fails (a sink is down), the events
+// are retried on the next poll rather than skipped; warnings go to errOut.
+func followAuditEvents(ctx context.Context, v vault.Vault, filter mgmt.AuditFilter, cursor *mgmt.AuditCursor, interval time.Duration, emit func(context.Context, []mgmt.AuditEvent) error, errOut io.Writer) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		f := filter
+		if cursor != nil {
+			f.Since = cursor.Timestamp
+		}
+		qctx, cancel := context.WithTimeout(ctx, auditQueryTimeout)
+		events, err := v.QueryAuditEvents(qctx, f)
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			fmt.Fprintf(errOut, "Warning: failed to read audit events: %v\n", err)
+			continue
+		}
+		mgmt.SortAuditEventsAscending(events)
+		page, _ := mgmt.PageAuditEvents(events, cursor, 0)
+		if len(page) == 0 {
+			continue
+		}
+		if err := emit(ctx, page); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			fmt.Fprintf(errOut, "Warning: %v (retrying)\n", err)
+			continue
+		}
+		next := mgmt.CursorFor(page[len(page)-1])
+		cursor = &next
+	}
+}
+
+func validateAuditFormat(format string, tail bool) error {
+	switch format {
+	case auditFormatJSONL, auditFormatCEF:
+		return nil
+	case auditFormatCSV:
+		if !tail {
+			return nil
+		}
+	case auditFormatText:
+		if tail {
+			return nil
+		}
+	}
+	if tail {
+		return fmt.Errorf("invalid --format %q (use text, jsonl, or cef)", format)
+	}
+	return fmt.Errorf("invalid --format %q (use jsonl, csv, or cef)", format)
+}
+
+// parseAuditTime parses --since and --until: Nd (days ago), a date, an
+// RFC 3339 time, or all/empty for no bound. With endOfDay, a date means
+// the end of that day, so --until includes it.
+func parseAuditTime(flag, s string, now time.Time, endOfDay bool) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.EqualFold(s, "all") {
+		return time.Time{}, nil
+	}
+	if days, ok := strings.CutSuffix(strings.ToLower(s), "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		if endOfDay {
+			return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+		}
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s value %q (use 30d, 2026-05-01, an RFC 3339 time, or all)", flag, s)
+}
+
+// writeAuditEvents writes events to w, one per line. CSV gets a header
+// row when withHeader is set.
+func writeAuditEvents(w io.Writer, format string, events []mgmt.AuditEvent, withHeader bool) error {
+	if format == auditFormatCSV {
+		cw := csv.NewWriter(w)
+		if withHeader {
+			if err := cw.Write(auditCSVHeader); err != nil {
+				return err
+			}
+		}
+		for _, ev := range events {
+			if err := cw.Write(auditCSVRecord(ev)); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	for _, ev := range events {
+		line, err := formatAuditEvent(format, ev)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatAuditEvent renders one event as a single line
+func formatAuditEvent(format string, ev mgmt.AuditEvent) (string, error) {
+	switch format {
+	case auditFormatCEF:
+		return formatAuditCEF(ev), nil
+	case auditFormatText:
+		line := fmt.Sprintf("%s %s %s %s", ev.Timestamp.Local().Format("2006-01-02 15:04:05"), ev.Actor, ev.Event, ev.Target)
+		if len(ev.Data) > 0 {
+			data, _ := json.Marshal(ev.Data)
+			line += " " + string(data)
+		}
+		return line, nil
+	}
+	data, err := json.Marshal(ev)
+	return string(data), err
+}
+
+var auditCSVHeader = []string{"ts", "actor", "event", "target_type", "target", "profile", "data", "hash"}
+
+func auditCSVRecord(ev mgmt.AuditEvent) []string {
+	data := ""
+	if len(ev.Data) > 0 {
+		b, _ := json.Marshal(ev.Data)
+		data = string(b)
+	}
+	return []string{ev.Timestamp.UTC().Format(time.RFC3339Nano), ev.Actor, ev.Event, ev.TargetType, ev.Target, ev.Profile, data, ev.Hash}
+}
+
+// formatAuditCEF renders an event in ArcSight's Common Event Format, which
+// most SIEMs parse natively
+func formatAuditCEF(ev mgmt.AuditEvent) string {
+	ext := []string{
+		"rt=" + strconv.FormatInt(ev.Timestamp.UnixMilli(), 10),
+		"suser=" + cefValue(ev.Actor),
+		"cs1Label=targetType", "cs1=" + cefValue(ev.TargetType),
+		"cs2Label=target", "cs2=" + cefValue(ev.Target),
+	}
+	if ev.Profile != "" {
+		ext = append(ext, "cs3Label=profile", "cs3="+cefValue(ev.Profile))
+	}
+	if len(ev.Data) > 0 {
+		data, _ := json.Marshal(ev.Data)
+		ext = append(ext, "cs4Label=data", "cs4="+cefValue(string(data)))
+	}
+	if ev.Hash != "" {
+		ext = append(ext, "cs5Label=hash", "cs5="+cefValue(ev.Hash))
+	}
+	return fmt.Sprintf("CEF:0|Sleuth|sx|%s|%s|%s|%d|%s",
+		cefHeader(buildinfo.Version), cefHeader(ev.Event), cefHeader(ev.Event), auditSeverity(ev.Event), strings.Join(ext, " "))
+}
+
+// auditSeverity rates events on CEF's 0-10 scale: changes to who
+// administers the vault or how it verifies assets rank above routine ones
+func auditSeverity(event string) int {
+	switch {
+	case strings.HasPrefix(event, "org."), strings.HasPrefix(event, "signing."),
+		event == mgmt.EventTeamAdminSet, event == mgmt.EventTeamAdminUnset:
+		return 7
+	case strings.HasSuffix(event, ".deleted"), strings.HasSuffix(event, ".removed"):
+		return 5
+	}
+	return 3
+}
+
+var (
+	cefHeaderEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	cefValueEscaper  = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+func cefHeader(s string) string { return cefHeaderEscaper.Replace(s) }
+
+func cefValue(s string) string { return cefValueEscaper.Replace(s) }
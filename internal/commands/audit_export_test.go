@@ -0,0 +1,225 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+)
+
+// seedAuditLog records one event per day from 2026-04-01
+func seedAuditLog(t *testing.T, vaultDir string, targets ...string) {
+	t.Helper()
+	var events []mgmt.AuditEvent
+	for i, target := range targets {
+		events = append(events, mgmt.AuditEvent{
+			Timestamp:  time.Date(2026, 4, 1+i, 12, 0, 0, 0, time.UTC),
+			Actor:      "test@example.com",
+			Event:      mgmt.EventTeamCreated,
+			TargetType: mgmt.TargetTypeTeam,
+			Target:     target,
+			Data:       map[string]any{"note": "a=b|c"},
+		})
+	}
+	if err := mgmt.AppendAuditEvents(vaultDir, events); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runAudit(t *testing.T, args ...string) (stdout, stderr string, err error) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	cmd := NewAuditCommand()
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	cmd.SetArgs(args)
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	err = cmd.Execute()
+	return out.String(), errOut.String(), err
+}
+
+func TestAuditExportPages(t *testing.T) {
+	_, vaultDir := seedScopeVault(t)
+	seedAuditLog(t, vaultDir, "a", "b", "c", "d", "e")
+
+	var targets []string
+	args := []string{"export", "--limit", "2", "--since", "2026-04-02", "--until", "2026-04-05"}
+	for range 5 {
+		stdout, stderr, err := runAudit(t, args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for line := range strings.SplitSeq(strings.TrimSpace(stdout), "\n") {
+			var ev mgmt.AuditEvent
+			if err := json.Unmarshal([]byte(line), &ev); err != nil {
+				t.Fatalf("bad JSONL line %q: %v", line, err)
+			}
+			targets = append(targets, ev.Target)
+		}
+		_, cursor, ok := strings.Cut(strings.TrimSpace(stderr), "--cursor ")
+		if !ok {
+			break
+		}
+		args = []string{"export", "--limit", "2", "--since", "2026-04-02", "--until", "2026-04-05", "--cursor", cursor}
+	}
+	if got := strings.Join(targets, ","); got != "b,c,d,e" {
+		t.Errorf("expected b,c,d,e across pages, got %s", got)
+	}
+}
+
+func TestAuditExportFormats(t *testing.T) {
+	_, vaultDir := seedScopeVault(t)
+	seedAuditLog(t, vaultDir, "platform")
+
+	stdout, _, err := runAudit(t, "export", "--format", "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0][0] != "ts" || rows[1][4] != "platform" || rows[1][7] == "" {
+		t.Errorf("unexpected CSV: %q", rows)
+	}
+
+	stdout, _, err = runAudit(t, "export", "--format", "cef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	line := strings.TrimSpace(stdout)
+	if !strings.HasPrefix(line, "CEF:0|Sleuth|sx|") || !strings.Contains(line, "|team.created|team.created|3|") ||
+		!strings.Contains(line, "suser=test@example.com") || !strings.Contains(line, `cs4={"note":"a\=b|c"}`) {
+		t.Errorf("unexpected CEF: %s", line)
+	}
+
+	if _, _, err := runAudit(t, "export", "--format", "xml"); err == nil {
+		t.Error("expected an unknown format to fail")
+	}
+}
+
+func TestAuditExportHTTPSink(t *testing.T) {
+	_, vaultDir := seedScopeVault(t)
+	seedAuditLog(t, vaultDir, "a", "b")
+
+	var body, auth, contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body, auth, contentType = string(data), r.Header.Get("Authorization"), r.Header.Get("Content-Type")
+	}))
+	defer srv.Close()
+
+	stdout, _, err := runAudit(t, "export", "--sink", srv.URL, "--sink-header", "Authorization: Bearer secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stdout != "" {
+		t.Errorf("expected nothing on stdout with a sink, got %q", stdout)
+	}
+	if strings.Count(body, "\n") != 2 || auth != "Bearer secret" || contentType != "application/x-ndjson" {
+		t.Errorf("unexpected request: %q %q %q", body, auth, contentType)
+	}
+
+	if _, _, err := runAudit(t, "export", "--format", "csv", "--sink", srv.URL); err == nil {
+		t.Error("expected CSV to be rejected for a sink")
+	}
+}
+
+func TestAuditTailSyslogSink(t *testing.T) {
+	_, vaultDir := seedScopeVault(t)
+	seedAuditLog(t, vaultDir, "a", "b", "c")
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if _, _, err := runAudit(t, "tail", "-n", "2", "--format", "cef", "--sink", "syslog://"+conn.LocalAddr().String()); err != nil {
+		t.Fatal(err)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 4096)
+	for _, want := range []string{"cs2=b", "cs2=c"} {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg := string(buf[:n])
+		if !strings.HasPrefix(msg, "<110>1 ") || !strings.Contains(msg, " sx ") || !strings.Contains(msg, " audit - CEF:0|") || !strings.Contains(msg, want) {
+			t.Errorf("unexpected syslog message: %s", msg)
+		}
+	}
+}
+
+// syncBuffer lets the test read output while tail --follow writes it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestAuditTailFollow(t *testing.T) {
+	_, vaultDir := seedScopeVault(t)
+	seedAuditLog(t, vaultDir, "a", "b")
+
+	var out syncBuffer
+	cmd := NewAuditCommand()
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"tail", "--follow", "-n", "1", "--format", "jsonl", "--interval", "10ms"})
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- cmd.ExecuteContext(ctx) }()
+
+	waitFor := func(target string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !strings.Contains(out.String(), `"target":"`+target+`"`) {
+			if time.Now().After(deadline) {
+				cancel()
+				t.Fatalf("timed out waiting for %s, got:\n%s", target, out.String())
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	waitFor("b")
+	if err := mgmt.AppendAuditEvent(vaultDir, mgmt.AuditEvent{Actor: "test@example.com", Event: mgmt.EventTeamDeleted, TargetType: mgmt.TargetTypeTeam, Target: "c"}); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("c")
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	got := out.String()
+	if strings.Contains(got, `"target":"a"`) || strings.Count(got, "\n") != 2 {
+		t.Errorf("expected the last event then the new one, got:\n%s", got)
+	}
+}
@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+)
+
+// auditSink forwards formatted audit events to a SIEM
+type auditSink interface {
+	Send(ctx context.Context, lines []string) error
+	Close() error
+}
+
+// Syslog framing for audit events: facility 13 (log audit), severity 6
+// (informational)
+const (
+	syslogPriority = 13*8 + 6
+	syslogAppName  = "sx"
+	syslogMsgID    = "audit"
+)
+
+const auditSinkTimeout = 30 * time.Second
+
+// openAuditSink parses a --sink target: syslog:// (UDP), syslog+udp://,
+// syslog+tcp:// or syslog+tls:// with host:port, or an http(s):// URL
+func openAuditSink(target string, headers []string, format string) (auditSink, error) {
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid --sink %q", target)
+	}
+	switch u.Scheme {
+	case "syslog", "syslog+udp":
+		return newSyslogSink("udp", withDefaultPort(u.Host, "514"))
+	case "syslog+tcp":
+		return newSyslogSink("tcp", withDefaultPort(u.Host, "514"))
+	case "syslog+tls":
+		return newSyslogSink("tls", withDefaultPort(u.Host, "6514"))
+	case "http", "https":
+		return newHTTPSink(u.String(), headers, format)
+	}
+	return nil, fmt.Errorf("unsupported --sink scheme %q (use syslog, syslog+tcp, syslog+tls, http or https)", u.Scheme)
+}
+
+func withDefaultPort(host, port string) string {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// sendAuditEvents formats events and hands them to the sink in one batch
+func sendAuditEvents(ctx context.Context, sink auditSink, format string, events []mgmt.AuditEvent) error {
+	lines := make([]string, 0, len(events))
+	for _, ev := range events {
+		line, err := formatAuditEvent(format, ev)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, line)
+	}
+	ctx, cancel := context.WithTimeout(ctx, auditSinkTimeout)
+	defer cancel()
+	return sink.Send(ctx, lines)
+}
+
+// syslogSink sends RFC 5424 messages, one per event. Stream transports use
+// octet-counting framing (RFC 6587) and reconnect after a failed write.
+type syslogSink struct {
+	network  string
+	addr     string
+	hostname string
+	conn     net.Conn
+}
+
+func newSyslogSink(network, addr string) (*syslogSink, error) {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &syslogSink{network: network, addr: addr, hostname: hostname}, nil
+}
+
+func (s *syslogSink) dial(ctx context.Context) (net.Conn, error) {
+	if s.conn != nil {
+		return s.conn, nil
+	}
+	var conn net.Conn
+	var err error
+	if s.network == "tls" {
+		d := &tls.Dialer{}
+		conn, err = d.DialContext(ctx, "tcp", s.addr)
+	} else {
+		var d net.Dialer
+		conn, err = d.DialContext(ctx, s.network, s.addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog at %s: %w", s.addr, err)
+	}
+	s.conn = conn
+	return conn, nil
+}
+
+func (s *syslogSink) Send(ctx context.Context, lines []string) error {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetWriteDeadline(deadline)
+	}
+	for _, line := range lines {
+		msg := s.message(line, time.Now())
+		if s.network != "udp" {
+			msg = fmt.Sprintf("%d %s", len(msg), msg)
+		}
+		if _, err := io.WriteString(conn, msg); err != nil {
+			_ = conn.Close()
+			s.conn = nil
+			return fmt.Errorf("failed to send to syslog at %s: %w", s.addr, err)
+		}
+	}
+	return nil
+}
+
+// message builds an RFC 5424 message: <PRI>1 TIMESTAMP HOST APP PROCID MSGID SD MSG
+func (s *syslogSink) message(line string, now time.Time) string {
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s - %s",
+		syslogPriority, now.UTC().Format(time.RFC3339Nano), s.hostname, syslogAppName, os.Getpid(), syslogMsgID, line)
+}
+
+func (s *syslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// httpSink POSTs each batch as newline-delimited events
+type httpSink struct {
+	url         string
+	headers     http.Header
+	contentType string
+	client      *http.Client
+}
+
+func newHTTPSink(target string, headers []string, format string) (*httpSink, error) {
+	h := make(http.Header)
+	for _, raw := range headers {
+		name, value, ok := strings.Cut(raw, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid --sink-header %q (use 'Name: value')", raw)
+		}
+		h.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	contentType := "text/plain; charset=utf-8"
+	if format == auditFormatJSONL {
+		contentType = "application/x-ndjson"
+	}
+	return &httpSink{url: target, headers: h, contentType: contentType, client: &http.Client{}}, nil
+}
+
+func (s *httpSink) Send(ctx context.Context, lines []string) error {
+	body := strings.Join(lines, "\n") + "\n"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewBufferString(body))
+	if err != nil {
+		return err
+	}
+	req.Header = s.headers.Clone()
+	req.Header.Set("Content-Type", s.contentType)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send to %s: %w", s.url, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to send to %s: %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+func (s *httpSink) Close() error { return nil }
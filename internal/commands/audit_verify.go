@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/ui"
+	vaultpkg "github.com/sleuth-io/sx/v2/internal/vault"
+)
+
+func newAuditVerifyCommand() *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Check the audit log for tampering",
+		Long: `Walk the hash chain through the vault's .sx/audit/ files and report the
+first event that was modified, deleted, inserted or reordered. Signed
+events are checked against the vault's trusted signing keys.
+
+Exits non-zero when the chain is broken.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			v, err := loadVault()
+			if err != nil {
+				return err
+			}
+			verifier, ok := v.(vaultpkg.AuditVerifier)
+			if !ok {
+				return errors.New("this vault type does not keep a local audit log to verify")
+			}
+			result, err := verifier.VerifyAuditLog(ctx)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				data, err := json.MarshalIndent(result, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), string(data))
+			} else {
+				printAuditVerification(cmd, result)
+			}
+			if b := result.Broken; b != nil {
+				return fmt.Errorf("audit log is broken at %s:%d", b.File, b.Line)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+func printAuditVerification(cmd *cobra.Command, v *mgmt.AuditVerification) {
+	out := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+	if b := v.Broken; b != nil {
+		out.Error(fmt.Sprintf("Audit log broken at %s:%d", b.File, b.Line))
+		if b.Event != nil {
+			out.Muted(fmt.Sprintf("  %s by %s at %s", b.Event.Event, b.Event.Actor, b.Event.Timestamp.Format("2006-01-02 15:04:05")))
+		}
+		out.Println("  " + b.Reason)
+		out.Muted(fmt.Sprintf("  %d events before it verified", v.Events))
+		return
+	}
+
+	if v.Events == 0 {
+		out.Muted("The audit log is empty.")
+		return
+	}
+	out.Success(fmt.Sprintf("Audit log intact: %d events in %d files", v.Events, v.Files))
+	if v.Head != "" {
+		out.KeyValue("  Head", v.Head)
+	}
+	chained := v.Events - v.Unchained
+	out.Muted(fmt.Sprintf("  %d signed by trusted keys, %d by unknown keys, %d unsigned",
+		v.Signed, v.UntrustedSigned, chained-v.Signed-v.UntrustedSigned))
+	if v.Unchained > 0 {
+		out.Warning(fmt.Sprintf("%d events predate the hash chain and aren't protected", v.Unchained))
+	}
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+)
+
+func TestAuditVerify(t *testing.T) {
+	_, vaultDir := seedScopeVault(t)
+	events := []mgmt.AuditEvent{
+		{Actor: "test@example.com", Event: mgmt.EventTeamCreated, TargetType: mgmt.TargetTypeTeam, Target: "platform"},
+		{Actor: "test@example.com", Event: mgmt.EventTeamMemberAdded, TargetType: mgmt.TargetTypeTeam, Target: "platform", Data: map[string]any{"member": "bob@example.com"}},
+	}
+	if err := mgmt.AppendAuditEvents(vaultDir, events); err != nil {
+		t.Fatal(err)
+	}
+
+	verify := func() (*mgmt.AuditVerification, error) {
+		var stdout bytes.Buffer
+		cmd := NewAuditCommand()
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs([]string{"verify", "--json"})
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		err := cmd.Execute()
+		var v mgmt.AuditVerification
+		if jerr := json.Unmarshal(stdout.Bytes(), &v); jerr != nil {
+			t.Fatalf("verify JSON: %v\n%s", jerr, stdout.String())
+		}
+		return &v, err
+	}
+
+	v, err := verify()
+	if err != nil || v.Events != 2 || v.Broken != nil {
+		t.Fatalf("expected an intact log, got %+v, %v", v, err)
+	}
+
+	// Drop the first event
+	files, _ := filepath.Glob(filepath.Join(vaultDir, mgmt.AuditDirName, "*.jsonl"))
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, rest, _ := strings.Cut(string(data), "\n")
+	if err := os.WriteFile(files[0], []byte(rest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	v, err = verify()
+	if err == nil || v.Broken == nil || v.Broken.Line != 1 || v.Broken.Event.Event != mgmt.EventTeamMemberAdded {
+		t.Errorf("expected the chain to break at the remaining event, got %+v, %v", v.Broken, err)
+	}
+}
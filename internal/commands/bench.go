@@ -0,0 +1,236 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/bench"
+	"github.com/sleuth-io/sx/v2/internal/llm"
+	"github.com/sleuth-io/sx/v2/internal/ui"
+	vaultpkg "github.com/sleuth-io/sx/v2/internal/vault"
+)
+
+// NewBenchCommand returns the `sx bench` command group
+// (docs/benchmarks-spec.md).
+func NewBenchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Benchmark skills against their eval cases",
+		Long: `A benchmark runs the eval cases a skill ships in evals/*.toml with and
+without the skill, and records how much the skill moves the pass rate.
+The desktop app shows the records; 'sx bench run' writes them from the
+command line or CI.
+
+Examples:
+
+  sx bench run commit-messages
+  sx bench run --all --runs 3 --json
+  sx bench run commit-messages --provider ollama --model qwen3`,
+	}
+	cmd.AddCommand(newBenchRunCommand())
+	return cmd
+}
+
+// benchRunOptions are the flags of `sx bench run`.
+type benchRunOptions struct {
+	all        bool
+	runs       int
+	jsonOutput bool
+	llm        llm.Config
+}
+
+func newBenchRunCommand() *cobra.Command {
+	var opts benchRunOptions
+	cmd := &cobra.Command{
+		Use:   "run [skill[@version]]",
+		Short: "Run a skill's eval cases and record the benchmark",
+		Long: `Run a skill's eval cases (its latest version unless one is given), or
+those of every skill that has some with --all, through an AI provider:
+each case --runs times with the skill in context and --runs times
+without. The pass rates and their delta are added to the vault's
+benchmark records.
+
+` + llmFlagsHelp,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.all == (len(args) == 1) {
+				return errors.New("name one skill, or pass --all")
+			}
+			if opts.runs < 1 || opts.runs > 20 {
+				return errors.New("--runs must be between 1 and 20")
+			}
+			return runBench(cmd, optionalArg(args, 0), opts)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Benchmark every skill that ships eval cases")
+	cmd.Flags().IntVar(&opts.runs, "runs", 1, "Runs per case and configuration")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "Output results as JSON")
+	addLLMFlags(cmd, &opts.llm)
+	return cmd
+}
+
+// Per-skill outcomes of `sx bench run`.
+const (
+	benchRecorded = "recorded"
+	benchNoEvals  = "no-evals"
+	benchFailed   = "failed"
+)
+
+// benchRunResult is one skill's outcome; --json prints a list of them.
+type benchRunResult struct {
+	Asset   string        `json:"asset"`
+	Version string        `json:"version,omitempty"`
+	Status  string        `json:"status"`
+	Record  *bench.Record `json:"record,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
+
+func runBench(cmd *cobra.Command, arg string, opts benchRunOptions) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Hour)
+	defer cancel()
+
+	v, err := createVault()
+	if err != nil {
+		return err
+	}
+	store, ok := v.(vaultpkg.BenchmarkStore)
+	if !ok {
+		return vaultpkg.ErrBenchmarksUnsupported
+	}
+	cfg, err := llmConfigFromFlags(opts.llm)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	if opts.all {
+		list, err := v.ListAssets(ctx, vaultpkg.ListAssetsOptions{Type: asset.TypeSkill.Key})
+		if err != nil {
+			return fmt.Errorf("failed to list skills: %w", err)
+		}
+		for _, a := range list.Assets {
+			names = append(names, a.Name)
+		}
+	} else {
+		names = []string{arg}
+	}
+
+	var out *ui.Output
+	if !opts.jsonOutput {
+		out = ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+	}
+	var provider llm.Provider
+	var by *string
+	results := make([]*benchRunResult, 0, len(names))
+	for _, name := range names {
+		name, version, _ := strings.Cut(name, "@")
+		res := &benchRunResult{Asset: name}
+		err := func() error {
+			var err error
+			if res.Version, err = resolveStoredVersion(ctx, v, name, version); err != nil {
+				return err
+			}
+			zipData, err := v.GetAssetByVersion(ctx, name, res.Version)
+			if err != nil {
+				return err
+			}
+			cases, err := bench.LoadCases(zipData)
+			if err != nil {
+				return err
+			}
+			if len(cases) == 0 {
+				if !opts.all {
+					return fmt.Errorf("%s has no eval cases (%s/*.toml)", name, bench.EvalsDir)
+				}
+				res.Status = benchNoEvals
+				return nil
+			}
+			if provider == nil {
+				if provider, err = newLLMProvider(cfg); err != nil {
+					return err
+				}
+			}
+			if out != nil {
+				out.Println(out.MutedText(fmt.Sprintf("Running %s × %s for %s…",
+					pluralCount(len(cases), "case"), pluralCount(opts.runs, "run"), name)))
+			}
+			rec, err := bench.Run(ctx, provider, zipData, cases, opts.runs)
+			if err != nil {
+				return err
+			}
+			if by == nil {
+				by = new(string)
+				if a, err := v.CurrentActor(ctx); err == nil {
+					*by = a.Email
+				}
+			}
+			rec.By = *by
+			rec.SkillVersion = res.Version
+			data, err := json.Marshal(rec)
+			if err != nil {
+				return err
+			}
+			if err := store.AddBenchmark(ctx, name, string(data)); err != nil {
+				return err
+			}
+			res.Status = benchRecorded
+			res.Record = rec
+			return nil
+		}()
+		if err != nil {
+			res.Status = benchFailed
+			res.Error = err.Error()
+		}
+		results = append(results, res)
+		if out != nil {
+			printBenchResult(out, res)
+		}
+	}
+
+	counts := map[string]int{}
+	for _, res := range results {
+		counts[res.Status]++
+	}
+	if opts.jsonOutput {
+		if err := emitLintJSON(cmd, map[string]any{
+			"results":  results,
+			"recorded": counts[benchRecorded],
+			"failed":   counts[benchFailed],
+		}); err != nil {
+			return err
+		}
+	} else if counts[benchRecorded]+counts[benchFailed] == 0 {
+		out.Println("No skills with eval cases in the vault.")
+	}
+
+	if n := counts[benchFailed]; n > 0 {
+		return fmt.Errorf("bench run failed for %s", pluralCount(n, "skill"))
+	}
+	return nil
+}
+
+func printBenchResult(out *ui.Output, res *benchRunResult) {
+	sym := out.Theme().Symbols()
+	switch res.Status {
+	case benchRecorded:
+		s := res.Record.Summary
+		out.Println(fmt.Sprintf("%s %s pass rate %.2f with the skill, %.2f without (%+.2f)",
+			out.SuccessText(sym.Success), out.BoldText(res.Asset),
+			s.WithSkill["pass_rate"].Mean, s.WithoutSkill["pass_rate"].Mean, s.Delta["pass_rate"]))
+		for _, e := range res.Record.PerEval {
+			out.Println(out.MutedText(fmt.Sprintf("  %-24s %.2f / %.2f  %s", e.EvalKey, e.WithPass, e.WithoutPass, e.Status)))
+		}
+		for _, note := range res.Record.Notes {
+			out.Println("  " + sym.Warning + " " + note)
+		}
+	case benchFailed:
+		out.Println(out.ErrorText(sym.Error + " " + res.Asset + ": " + res.Error))
+	}
+}
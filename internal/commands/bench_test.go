@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/bench"
+	"github.com/sleuth-io/sx/v2/internal/llm"
+	vaultpkg "github.com/sleuth-io/sx/v2/internal/vault"
+)
+
+// echoModel answers with the prompt, prefixed with "feat: " when a skill
+// is in context.
+type echoModel struct{}
+
+func (echoModel) ID() string { return "echo" }
+
+func (echoModel) Complete(ctx context.Context, req llm.Request) (llm.Response, error) {
+	text := req.Messages[len(req.Messages)-1].Content
+	if req.Messages[0].Role == "system" {
+		text = "feat: " + text
+	}
+	return llm.Response{Text: text, Provider: "echo", Model: "echo-1"}, nil
+}
+
+func execBenchRun(args ...string) (string, error) {
+	var stdout bytes.Buffer
+	cmd := NewBenchCommand()
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stdout)
+	cmd.SetArgs(append([]string{"run"}, args...))
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestBenchRun_RecordsBenchmark(t *testing.T) {
+	env := NewTestEnv(t)
+	vaultDir := env.SetupPathVault()
+	src := env.MkdirAll(filepath.Join(env.TempDir, "commits"))
+	env.WriteFile(filepath.Join(src, "SKILL.md"), "---\ndescription: Commit messages\n---\n\nPrefix with feat:.\n")
+	env.MkdirAll(filepath.Join(src, "evals"))
+	env.WriteFile(filepath.Join(src, "evals", "login.toml"), "prompt = \"add login\"\n\n[[assert]]\ncontains = \"feat:\"\n")
+	if out, err := execAdd(src, "--yes", "--no-install"); err != nil {
+		t.Fatalf("add: %v\n%s", err, out)
+	}
+	plain := env.MkdirAll(filepath.Join(env.TempDir, "plain"))
+	env.WriteFile(filepath.Join(plain, "SKILL.md"), "---\ndescription: No evals\n---\n\nHi.\n")
+	if out, err := execAdd(plain, "--yes", "--no-install"); err != nil {
+		t.Fatalf("add: %v\n%s", err, out)
+	}
+
+	orig := newLLMProvider
+	newLLMProvider = func(cfg llm.Config) (llm.Provider, error) { return echoModel{}, nil }
+	defer func() { newLLMProvider = orig }()
+
+	out, err := execBenchRun("--all", "--json", "--runs", "2")
+	if err != nil {
+		t.Fatalf("bench: %v\n%s", err, out)
+	}
+	var result struct {
+		Results  []benchRunResult `json:"results"`
+		Recorded int              `json:"recorded"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result.Recorded != 1 || len(result.Results) != 2 || result.Results[1].Status != benchNoEvals {
+		t.Fatalf("result = %+v", result)
+	}
+
+	stored, err := vaultpkg.NewPathVault(vaultDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := stored.ListBenchmarks(context.Background(), "commits")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []bench.Record
+	if err := json.Unmarshal([]byte(list), &records); err != nil || len(records) != 1 {
+		t.Fatalf("stored records = %s (%v)", list, err)
+	}
+	rec := records[0]
+	if rec.Summary.Delta["pass_rate"] != 1 || rec.RunsPerConfig != 2 || rec.SkillVersion != "1" || rec.By != "test@example.com" {
+		t.Errorf("stored record = %+v", rec)
+	}
+
+	if _, err := execBenchRun("plain"); err == nil || !strings.Contains(err.Error(), "failed") {
+		t.Errorf("named skill without evals: err = %v", err)
+	}
+}
@@ -0,0 +1,367 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/publish"
+	"github.com/sleuth-io/sx/v2/internal/ui"
+	"github.com/sleuth-io/sx/v2/internal/ui/components"
+	vaultpkg "github.com/sleuth-io/sx/v2/internal/vault"
+)
+
+// NewChangeCommand returns the `sx change` command group: the gated
+// propose → review → merge flow for file-backed vaults
+// (docs/change-requests.md). Sleuth vaults review changes server-side, so
+// the group reports that it is unsupported there.
+func NewChangeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "change",
+		Short: "Propose and review asset changes before they publish",
+		Long: `Change requests let anyone propose a new asset version that only
+publishes once an org-admin, or an admin of a team the asset is scoped to,
+approves it. Pending changes live under .sx/changes/ in the vault.`,
+	}
+	cmd.AddCommand(newChangeProposeCommand())
+	cmd.AddCommand(newChangeListCommand())
+	cmd.AddCommand(newChangeShowCommand())
+	cmd.AddCommand(newChangeApproveCommand())
+	cmd.AddCommand(newChangeRejectCommand())
+	cmd.AddCommand(newChangeMergeCommand())
+	return cmd
+}
+
+// loadChangeStore returns the active vault as a ChangeRequestStore.
+func loadChangeStore() (vaultpkg.Vault, vaultpkg.ChangeRequestStore, error) {
+	v, err := createVault()
+	if err != nil {
+		return nil, nil, err
+	}
+	store, ok := v.(vaultpkg.ChangeRequestStore)
+	if !ok {
+		return nil, nil, errors.New("this vault type does not support change requests — use 'sx add' (skills.new reviews changes in the web app)")
+	}
+	return v, store, nil
+}
+
+func newChangeProposeCommand() *cobra.Command {
+	var opts addOptions
+	var message string
+	cmd := &cobra.Command{
+		Use:   "propose <zip-file|directory|url>",
+		Short: "Propose a new asset version for review",
+		Long: `Package an asset exactly as 'sx add' would, but store it as a pending
+change instead of publishing it. The version becomes visible to installs
+only after the change is approved and merged.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+			opts.Yes = true
+			return runChangePropose(ctx, cmd, args[0], opts, message)
+		},
+	}
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Override detected asset name")
+	cmd.Flags().StringVar(&opts.Type, "type", "", "Override detected asset type")
+	cmd.Flags().StringVar(&opts.Version, "version", "", "Version to propose (default: next suggested version)")
+	cmd.Flags().StringVarP(&message, "message", "m", "", "Describe the change for reviewers")
+	return cmd
+}
+
+func runChangePropose(ctx context.Context, cmd *cobra.Command, input string, opts addOptions, message string) error {
+	out := newOutputHelper(cmd)
+	status := components.NewStatus(cmd.OutOrStdout())
+
+	zipFile, zipData, err := loadZipFile(out, status, input)
+	if err != nil {
+		return err
+	}
+	name, assetType, metadataExists, err := detectAssetInfo(out, status, zipFile, zipData, opts)
+	if err != nil {
+		return err
+	}
+	zipData, err = normalizePromptFileCase(zipData, assetType)
+	if err != nil {
+		return err
+	}
+
+	vault, store, err := loadChangeStore()
+	if err != nil {
+		return err
+	}
+	version, identical, err := checkVersionAndContents(ctx, status, vault, name, zipData)
+	if err != nil {
+		return err
+	}
+	if opts.Version != "" {
+		version = opts.Version
+	} else if identical {
+		return fmt.Errorf("%s@%s already has identical contents — nothing to propose", name, version)
+	}
+
+	meta := createMetadata(name, version, assetType, zipFile, zipData)
+	zipData, err = updateMetadataInZip(meta, zipData, metadataExists)
+	if err != nil {
+		return err
+	}
+	if err := metadata.ValidateZip(zipData, &assetType); err != nil {
+		return err
+	}
+
+	cr, err := store.ProposeChange(ctx, &lockfile.Asset{
+		Name:         meta.Asset.Name,
+		Version:      meta.Asset.Version,
+		Type:         meta.Asset.Type,
+		Clients:      append([]string(nil), meta.Asset.Clients...),
+		Dependencies: publish.LockDependencies(meta),
+	}, zipData, message)
+	if err != nil {
+		return err
+	}
+
+	out.println()
+	out.printf("✓ Proposed %s@%s as change %s\n", cr.AssetName, cr.Version, cr.ID)
+	out.printf("  Ask a reviewer to run: sx change approve %s\n", cr.ID)
+	return nil
+}
+
+func newChangeListCommand() *cobra.Command {
+	var statusFilter string
+	var all bool
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List change requests",
+		Long:  "List change requests, newest first. Shows open (pending and approved) changes unless --status or --all is given.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			_, store, err := loadChangeStore()
+			if err != nil {
+				return err
+			}
+			status := vaultpkg.ChangeStatus(statusFilter)
+			switch status {
+			case "", vaultpkg.ChangeStatusPending, vaultpkg.ChangeStatusApproved,
+				vaultpkg.ChangeStatusRejected, vaultpkg.ChangeStatusMerged:
+			default:
+				return fmt.Errorf("invalid --status %q (valid: pending, approved, rejected, merged)", statusFilter)
+			}
+			changes, err := store.ListChanges(ctx, status)
+			if err != nil {
+				return err
+			}
+			if status == "" && !all {
+				open := changes[:0]
+				for _, c := range changes {
+					if c.Status == vaultpkg.ChangeStatusPending || c.Status == vaultpkg.ChangeStatusApproved {
+						open = append(open, c)
+					}
+				}
+				changes = open
+			}
+			if jsonOutput {
+				return emitChangeJSON(cmd, changes)
+			}
+			printChangeList(cmd, changes)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&statusFilter, "status", "", "Only show changes in this state (pending, approved, rejected, merged)")
+	cmd.Flags().BoolVar(&all, "all", false, "Include rejected and merged changes")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+func printChangeList(cmd *cobra.Command, changes []vaultpkg.ChangeRequest) {
+	out := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+	if len(changes) == 0 {
+		out.Muted("No change requests. Propose one with 'sx change propose <path>'.")
+		return
+	}
+	out.Header("Change requests")
+	out.Newline()
+	for _, c := range changes {
+		line := fmt.Sprintf("  %s %s %s %s",
+			out.BoldText(c.ID),
+			out.EmphasisText(string(c.Status)),
+			fmt.Sprintf("%s@%s", c.AssetName, c.Version),
+			out.MutedText("by "+c.Proposer+" "+c.CreatedAt.Format("2006-01-02 15:04")),
+		)
+		out.Println(line)
+		if c.Message != "" {
+			out.Muted("    " + c.Message)
+		}
+	}
+	out.Newline()
+}
+
+func newChangeShowCommand() *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "show <id>",
+		Short: "Show a change request and its reviews",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			_, store, err := loadChangeStore()
+			if err != nil {
+				return err
+			}
+			cr, zipData, err := store.GetChange(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				return emitChangeJSON(cmd, cr)
+			}
+			printChange(cmd, cr, zipData)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+func printChange(cmd *cobra.Command, cr *vaultpkg.ChangeRequest, zipData []byte) {
+	out := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+	out.Header(fmt.Sprintf("Change %s", cr.ID))
+	out.Newline()
+	out.KeyValue("Asset", fmt.Sprintf("%s@%s (%s)", cr.AssetName, cr.Version, cr.Type.Label))
+	out.KeyValue("Status", string(cr.Status))
+	out.KeyValue("Proposer", cr.Proposer)
+	out.KeyValue("Created", cr.CreatedAt.Format("2006-01-02 15:04"))
+	if cr.MergedBy != "" {
+		out.KeyValue("Merged by", cr.MergedBy)
+	}
+	if len(zipData) > 0 {
+		out.KeyValue("Size", fmt.Sprintf("%d bytes", len(zipData)))
+	}
+	if cr.Message != "" {
+		out.Newline()
+		out.Println("  " + cr.Message)
+	}
+	if len(cr.Reviews) > 0 {
+		out.Newline()
+		out.SubHeader("Reviews")
+		for _, r := range cr.Reviews {
+			line := fmt.Sprintf("  %s %s %s",
+				out.MutedText(r.Timestamp.Format("2006-01-02 15:04")),
+				out.BoldText(r.Actor),
+				out.EmphasisText(r.Decision),
+			)
+			if r.Comment != "" {
+				line += " — " + r.Comment
+			}
+			out.Println(line)
+		}
+	}
+	out.Newline()
+}
+
+func newChangeApproveCommand() *cobra.Command {
+	var comment string
+	cmd := &cobra.Command{
+		Use:   "approve <id>",
+		Short: "Approve a pending change",
+		Long:  "Approve a pending change. Only org-admins and admins of a team the asset is scoped to may approve, and never the proposer.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			_, store, err := loadChangeStore()
+			if err != nil {
+				return err
+			}
+			cr, err := store.ApproveChange(ctx, args[0], comment)
+			if err != nil {
+				return err
+			}
+			out := newOutputHelper(cmd)
+			out.printf("✓ Approved change %s (%s@%s)\n", cr.ID, cr.AssetName, cr.Version)
+			out.printf("  Publish it with: sx change merge %s\n", cr.ID)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&comment, "message", "m", "", "Review comment")
+	return cmd
+}
+
+func newChangeRejectCommand() *cobra.Command {
+	var comment string
+	cmd := &cobra.Command{
+		Use:   "reject <id>",
+		Short: "Reject a change without publishing it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			_, store, err := loadChangeStore()
+			if err != nil {
+				return err
+			}
+			cr, err := store.RejectChange(ctx, args[0], comment)
+			if err != nil {
+				return err
+			}
+			newOutputHelper(cmd).printf("✓ Rejected change %s (%s@%s)\n", cr.ID, cr.AssetName, cr.Version)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&comment, "message", "m", "", "Reason for rejecting")
+	return cmd
+}
+
+func newChangeMergeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "merge <id>",
+		Short: "Publish an approved change",
+		Long: `Publish an approved change through the same path as 'sx add': the
+version is stored and the asset keeps its existing scopes. The proposer or
+any reviewer may merge.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+
+			v, store, err := loadChangeStore()
+			if err != nil {
+				return err
+			}
+			key, err := loadPublishingKey(v)
+			if err != nil {
+				return err
+			}
+			cr, err := store.MergeChange(ctx, args[0], key)
+			if err != nil {
+				return err
+			}
+			out := newOutputHelper(cmd)
+			out.printf("✓ Merged change %s — %s@%s is published\n", cr.ID, cr.AssetName, cr.Version)
+			reportPublishSignature(ctx, out, v, cr.AssetName, cr.Version, key)
+			return nil
+		},
+	}
+}
+
+func emitChangeJSON(cmd *cobra.Command, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	return err
+}
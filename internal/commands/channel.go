@@ -0,0 +1,272 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/sx/v2/internal/ui"
+	vaultpkg "github.com/sleuth-io/sx/v2/internal/vault"
+)
+
+// NewChannelCommand returns the `sx channel` command group: named release
+// channels per asset and the scopes that follow them (docs/channels.md).
+func NewChannelCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "channel",
+		Short: "Manage release channels for assets",
+		Long: `A release channel names one published version of an asset, e.g.
+stable = 3 and beta = 4. Teams, users, bots, and repos that follow a channel
+install that version; everyone else installs the latest version.
+
+Examples:
+
+  sx channel set my-skill stable 3
+  sx channel promote my-skill latest beta
+  sx channel follow my-skill beta --team platform
+  sx channel promote my-skill beta stable
+  sx channel unfollow my-skill --team platform`,
+	}
+	cmd.AddCommand(newChannelListCommand())
+	cmd.AddCommand(newChannelSetCommand())
+	cmd.AddCommand(newChannelPromoteCommand())
+	cmd.AddCommand(newChannelFollowCommand())
+	cmd.AddCommand(newChannelUnfollowCommand())
+	return cmd
+}
+
+// loadChannelStore returns the active vault as a ChannelStore.
+func loadChannelStore() (vaultpkg.Vault, vaultpkg.ChannelStore, error) {
+	v, err := createVault()
+	if err != nil {
+		return nil, nil, err
+	}
+	store, ok := v.(vaultpkg.ChannelStore)
+	if !ok {
+		return nil, nil, errors.New("this vault type does not support release channels")
+	}
+	return v, store, nil
+}
+
+func newChannelListCommand() *cobra.Command {
+	var jsonOutput bool
+	cmd := &cobra.Command{
+		Use:   "list <asset>",
+		Short: "List an asset's channels and who follows them",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			_, store, err := loadChannelStore()
+			if err != nil {
+				return err
+			}
+			info, err := store.ListChannels(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			if jsonOutput {
+				return emitChannelJSON(cmd, info)
+			}
+			printChannels(cmd, info)
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	return cmd
+}
+
+func printChannels(cmd *cobra.Command, info *vaultpkg.AssetChannels) {
+	out := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+	out.Header("Channels for " + info.Asset)
+	out.Newline()
+	out.KeyValue(vaultpkg.LatestChannel, info.Latest)
+	names := make([]string, 0, len(info.Channels))
+	for name := range info.Channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		out.KeyValue(name, info.Channels[name])
+	}
+	if len(info.Followers) > 0 {
+		out.Newline()
+		out.SubHeader("Followers")
+		for _, f := range info.Followers {
+			out.Println(fmt.Sprintf("  %s %s", out.BoldText(f.Channel), f.Target.Describe()))
+		}
+	}
+	out.Newline()
+}
+
+func emitChannelJSON(cmd *cobra.Command, info *vaultpkg.AssetChannels) error {
+	type follower struct {
+		Channel string         `json:"channel"`
+		Target  string         `json:"target"`
+		Scope   map[string]any `json:"scope"`
+	}
+	payload := struct {
+		Asset     string            `json:"asset"`
+		Latest    string            `json:"latest"`
+		Channels  map[string]string `json:"channels"`
+		Followers []follower        `json:"followers"`
+	}{
+		Asset:     info.Asset,
+		Latest:    info.Latest,
+		Channels:  info.Channels,
+		Followers: []follower{},
+	}
+	for _, f := range info.Followers {
+		payload.Followers = append(payload.Followers, follower{
+			Channel: f.Channel,
+			Target:  f.Target.Describe(),
+			Scope:   f.Target.AuditData(),
+		})
+	}
+	return emitChangeJSON(cmd, payload)
+}
+
+func newChannelSetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set <asset> <channel> <version>",
+		Short: "Point a channel at a published version",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			_, store, err := loadChannelStore()
+			if err != nil {
+				return err
+			}
+			if err := store.SetChannel(ctx, args[0], args[1], args[2]); err != nil {
+				return err
+			}
+			newOutputHelper(cmd).printf("✓ %s %s → %s\n", args[0], args[1], args[2])
+			return nil
+		},
+	}
+}
+
+func newChannelPromoteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "promote <asset> <from> <to>",
+		Short: "Move a channel forward to the version another channel points at",
+		Long: `Point <to> at the version <from> currently points at, e.g.
+'sx channel promote my-skill beta stable' once beta has proven itself.
+Use 'latest' as <from> to promote the most recently published version.
+Every promotion is recorded in the vault's audit log.`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			_, store, err := loadChannelStore()
+			if err != nil {
+				return err
+			}
+			version, err := store.PromoteChannel(ctx, args[0], args[1], args[2])
+			if err != nil {
+				return err
+			}
+			newOutputHelper(cmd).printf("✓ Promoted %s %s → %s (%s)\n", args[0], args[1], args[2], version)
+			return nil
+		},
+	}
+}
+
+// channelTargetFlags are the scope flags follow/unfollow accept. Org is
+// absent on purpose: org-wide installs have no scope row to carry a
+// channel.
+type channelTargetFlags struct {
+	repos, paths, teams, users, bots []string
+}
+
+func (f *channelTargetFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&f.repos, "repo", nil, "Scope: a repository URL (repeatable)")
+	cmd.Flags().StringArrayVar(&f.paths, "path", nil, "Scope: a repo subpath set (repo_url#path1,path2; repeatable)")
+	cmd.Flags().StringArrayVar(&f.teams, "team", nil, "Scope: a team, by name (repeatable)")
+	cmd.Flags().StringArrayVar(&f.users, "user", nil, "Scope: a user email, or 'me' (repeatable)")
+	cmd.Flags().StringArrayVar(&f.bots, "bot", nil, "Scope: a bot identity, by name (repeatable)")
+}
+
+// targets resolves the flags through the same resolver `sx install` uses,
+// then expands the "me" alias against the vault's identity.
+func (f *channelTargetFlags) targets(ctx context.Context, v vaultpkg.Vault) ([]vaultpkg.InstallTarget, error) {
+	change, err := resolveScopeFlags(scopeFlags{
+		Repos: f.repos,
+		Paths: f.paths,
+		Teams: f.teams,
+		Users: f.users,
+		Bots:  f.bots,
+	})
+	if err != nil {
+		return nil, err
+	}
+	targets, _, err := resolveSelfUserScopes(ctx, v, change.Targets)
+	return targets, err
+}
+
+func newChannelFollowCommand() *cobra.Command {
+	var flags channelTargetFlags
+	cmd := &cobra.Command{
+		Use:   "follow <asset> <channel> [--repo|--path|--team|--user|--bot]...",
+		Short: "Make installed scopes follow a channel",
+		Long: `Point one or more of the asset's existing install scopes at a channel.
+The asset must already be installed to each scope (see 'sx install --team').
+In a repository a repo/path scope decides, elsewhere the caller's own user or
+bot scope, then a team, then org. Scopes at the same level that follow
+different channels are a conflict that fails the caller's install.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChannelFollow(cmd, &flags, args[0], args[1])
+		},
+	}
+	flags.register(cmd)
+	return cmd
+}
+
+func newChannelUnfollowCommand() *cobra.Command {
+	var flags channelTargetFlags
+	cmd := &cobra.Command{
+		Use:   "unfollow <asset> [--repo|--path|--team|--user|--bot]...",
+		Short: "Return installed scopes to the latest version",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runChannelFollow(cmd, &flags, args[0], "")
+		},
+	}
+	flags.register(cmd)
+	return cmd
+}
+
+func runChannelFollow(cmd *cobra.Command, flags *channelTargetFlags, assetName, channel string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	v, store, err := loadChannelStore()
+	if err != nil {
+		return err
+	}
+	targets, err := flags.targets(ctx, v)
+	if err != nil {
+		return err
+	}
+	out := newOutputHelper(cmd)
+	for _, t := range targets {
+		if err := store.FollowChannel(ctx, assetName, t, channel); err != nil {
+			return fmt.Errorf("%s: %w", t.Describe(), err)
+		}
+		if channel == "" {
+			out.printf("✓ %s for %s now follows the latest version\n", assetName, t.Describe())
+		} else {
+			out.printf("✓ %s for %s now follows %s\n", assetName, t.Describe(), channel)
+		}
+	}
+	return nil
+}
@@ -10,6 +10,10 @@ import (
 	"testing"
 
 	"github.com/sleuth-io/sx/v2/internal/clients"
+	_ "github.com/sleuth-io/sx/v2/internal/clients/aider"       // Auto-registers via init()
+	_ "github.com/sleuth-io/sx/v2/internal/clients/continuedev" // Auto-registers via init()
+	_ "github.com/sleuth-io/sx/v2/internal/clients/windsurf"    // Auto-registers via init()
+	_ "github.com/sleuth-io/sx/v2/internal/clients/zed"         // Auto-registers via init()
 	"github.com/sleuth-io/sx/v2/internal/config"
 )
 
@@ -70,7 +70,7 @@ func NewInitCommand() *cobra.Command {
 		Use:   "init",
 		Short: "Initialize configuration (local path, Git repo, or Skills.new)",
 		Long: `Initialize sx configuration using a local directory, Git repository,
-or Skills.new as the asset source.
+S3 bucket, OCI registry, static site, or Skills.new as the asset source.
 
 By default, runs in interactive mode with local path as the default option.
 Use flags for non-interactive mode.`,
@@ -79,9 +79,9 @@ Use flags for non-interactive mode.`,
 		},
 	}
 
-	cmd.Flags().StringVar(&repoType, "type", "", "Repository type: 'path', 'git', or 'sleuth'")
+	cmd.Flags().StringVar(&repoType, "type", "", "Repository type: 'path', 'git', 's3', 'oci', 'http', or 'sleuth'")
 	cmd.Flags().StringVar(&serverURL, "server-url", "", "Skills.new server URL (for type=sleuth)")
-	cmd.Flags().StringVar(&repoURL, "repo-url", "", "Repository URL (git URL, file:// URL, or directory path)")
+	cmd.Flags().StringVar(&repoURL, "repo-url", "", "Repository URL (git URL, s3:// URL, oci:// URL, https:// static vault URL, file:// URL, or directory path)")
 	cmd.Flags().StringVar(&pathFlag, "path", "", "Vault directory (for type=path)")
 	cmd.Flags().StringVar(&clientsFlag, "clients", "", "Comma-separated client IDs (e.g., 'claude-code,cursor') or 'all'")
 
@@ -420,8 +420,26 @@ func runInitNonInteractive(cmd *cobra.Command, ctx context.Context, repoType, se
 		}
 		return configurePathRepo(cmd, ctx, target, enabledClients)
 
+	case "s3":
+		if repoURL == "" {
+			return errors.New("--repo-url is required for type=s3 (s3://bucket/prefix)")
+		}
+		return configureS3Repo(cmd, repoURL, enabledClients)
+
+	case "oci":
+		if repoURL == "" {
+			return errors.New("--repo-url is required for type=oci (oci://registry/repository)")
+		}
+		return configureOCIRepo(cmd, repoURL, enabledClients)
+
+	case "http":
+		if repoURL == "" {
+			return errors.New("--repo-url is required for type=http (https://host/path)")
+		}
+		return configureHTTPRepo(cmd, repoURL, enabledClients)
+
 	default:
-		return fmt.Errorf("invalid repository type: %s (must be 'path', 'git', or 'sleuth')", repoType)
+		return fmt.Errorf("invalid repository type: %s (must be 'path', 'git', 's3', 'oci', 'http', or 'sleuth')", repoType)
 	}
 }
 
@@ -623,6 +641,83 @@ func configureGitRepo(cmd *cobra.Command, ctx context.Context, repoURL string, e
 	return nil
 }
 
+// configureS3Repo configures an S3 bucket vault. Credentials are not
+// stored: they come from the AWS_* environment variables unless the
+// profile is edited to carry an access key pair.
+func configureS3Repo(cmd *cobra.Command, repoURL string, enabledClients []string) error {
+	styledOut := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+
+	if _, err := vault.NewS3Vault(repoURL, "", ""); err != nil {
+		return err
+	}
+
+	cfg := &config.Config{
+		Type:                 config.RepositoryTypeS3,
+		RepositoryURL:        repoURL,
+		ForceDisabledClients: computeDisabledClients(enabledClients),
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	configPath, _ := utils.GetConfigFile()
+	styledOut.SuccessItem("Saved configuration to " + configPath)
+
+	return nil
+}
+
+// configureOCIRepo configures an OCI registry vault. Credentials are not
+// stored: they come from SX_OCI_USERNAME / SX_OCI_PASSWORD unless the
+// profile is edited to carry them.
+func configureOCIRepo(cmd *cobra.Command, repoURL string, enabledClients []string) error {
+	styledOut := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+
+	if _, err := vault.NewOCIVault(repoURL, "", ""); err != nil {
+		return err
+	}
+
+	cfg := &config.Config{
+		Type:                 config.RepositoryTypeOCI,
+		RepositoryURL:        repoURL,
+		ForceDisabledClients: computeDisabledClients(enabledClients),
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	configPath, _ := utils.GetConfigFile()
+	styledOut.SuccessItem("Saved configuration to " + configPath)
+
+	return nil
+}
+
+// configureHTTPRepo configures a read-only static vault served over
+// HTTP(S), as published by sx vault export-static.
+func configureHTTPRepo(cmd *cobra.Command, repoURL string, enabledClients []string) error {
+	styledOut := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+
+	if _, err := vault.NewHTTPVault(repoURL, ""); err != nil {
+		return err
+	}
+
+	cfg := &config.Config{
+		Type:                 config.RepositoryTypeHTTP,
+		RepositoryURL:        repoURL,
+		ForceDisabledClients: computeDisabledClients(enabledClients),
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	configPath, _ := utils.GetConfigFile()
+	styledOut.SuccessItem("Saved configuration to " + configPath)
+
+	return nil
+}
+
 // configurePathRepo configures a local path repository
 func configurePathRepo(cmd *cobra.Command, ctx context.Context, repoPath string, enabledClients []string) error {
 	styledOut := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
@@ -19,6 +19,7 @@ import (
 	"github.com/sleuth-io/sx/v2/internal/config"
 	"github.com/sleuth-io/sx/v2/internal/gitutil"
 	"github.com/sleuth-io/sx/v2/internal/handlers/hook"
+	"github.com/sleuth-io/sx/v2/internal/handlers/section"
 	"github.com/sleuth-io/sx/v2/internal/lockfile"
 	"github.com/sleuth-io/sx/v2/internal/logger"
 	"github.com/sleuth-io/sx/v2/internal/metadata"
@@ -38,6 +39,9 @@ func NewInstallCommand() *cobra.Command {
 	var clientsFlag string
 	var dryRun bool
 	var strict bool
+	var force bool
+	var plan bool
+	var jsonOutput bool
 
 	// Installation targeting flags — when any of these is set together
 	// with a positional asset name, sx install enters "set installation
@@ -51,6 +55,7 @@ func NewInstallCommand() *cobra.Command {
 	var userFlags []string
 	var botFlags []string
 	var replaceScopeFlag bool
+	var pinFlag string
 	var setTargetYes bool
 
 	cmd := &cobra.Command{
@@ -82,9 +87,20 @@ Examples:
   sx install --repo https://github.com/acme/infra.git my-skill
   sx install --path https://github.com/acme/infra.git#services/api my-skill
 
+Add --pin <version> to keep the named scopes on a stored version while the
+rest of the org moves forward ('--pin latest' removes a pin). Git and path
+vaults only; see docs/channels.md.
+
+  sx install --bot python-backend --pin 3 my-skill
+
 Use --dry-run to preview the resolved asset list for the current
 context without downloading or touching client directories — the
-equivalent of 'pip freeze' against the vault's manifest.`,
+equivalent of 'pip freeze' against the vault's manifest.
+
+Use --plan (with --json for machine-readable output) to see the change
+an install would make before making it: per client, the files created,
+modified or deleted, the MCP server and hook entries added or removed in
+config files, and the assets cleanup would remove.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			targetFlags := scopeFlags{
 				Org:     orgFlag,
@@ -94,17 +110,33 @@ equivalent of 'pip freeze' against the vault's manifest.`,
 				Users:   userFlags,
 				Bots:    botFlags,
 				Replace: replaceScopeFlag,
+				Pin:     pinFlag,
+			}
+			if pinFlag != "" && !targetFlags.hasTarget() {
+				return errors.New("--pin requires a scope flag (--repo, --path, --team, --user, or --bot) and an asset name")
 			}
 			if targetFlags.hasTarget() {
 				if len(args) != 1 {
 					return errors.New("installation target flags require an asset name as a positional argument")
 				}
-				if dryRun {
-					return errors.New("--dry-run cannot be combined with install-target flags")
+				if dryRun || plan {
+					return errors.New("--dry-run and --plan cannot be combined with install-target flags")
 				}
 				return runInstallSetTarget(cmd, args[0], targetFlags, setTargetYes)
 			}
-			return runInstall(cmd, args, hookMode, clientID, fixMode, targetDir, clientsFlag, dryRun, strict)
+			planFormat := ""
+			if plan {
+				if dryRun || fixMode || hookMode {
+					return errors.New("--plan cannot be combined with --dry-run, --repair or --hook-mode")
+				}
+				planFormat = "text"
+				if jsonOutput {
+					planFormat = "json"
+				}
+			} else if jsonOutput {
+				return errors.New("--json requires --plan")
+			}
+			return runInstall(cmd, args, hookMode, clientID, fixMode, targetDir, clientsFlag, dryRun, strict, force, planFormat)
 		},
 	}
 
@@ -113,8 +145,11 @@ equivalent of 'pip freeze' against the vault's manifest.`,
 	cmd.Flags().BoolVar(&fixMode, "repair", false, "Verify assets are actually installed and fix any discrepancies")
 	cmd.Flags().StringVar(&targetDir, "target", "", "Install as if running from this directory")
 	cmd.Flags().StringVar(&clientsFlag, "clients", "", "Install to multiple clients (e.g., 'claude-code,cursor')")
-	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the resolved asset list for the current context and exit without downloading or installing")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the resolved asset list for the current context, and diffs of the instruction-file sections it would change, without installing")
 	cmd.Flags().BoolVar(&strict, "strict", false, "Treat hook installs that soft-skip (event not supported by client) as failures (also via SX_STRICT=1)")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite hand edits to sx-managed sections of instruction files (GEMINI.md, AGENTS.md, .rules)")
+	cmd.Flags().BoolVar(&plan, "plan", false, "Download the pending assets and run every client's install against staged copies of its files, then print the files, MCP and hook entries it would change, without installing")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the --plan result as JSON")
 
 	cmd.Flags().BoolVar(&orgFlag, "org", false, "Scope: install org-wide (global, exclusive)")
 	cmd.Flags().StringArrayVar(&repoFlags, "repo", nil, "Scope: a repository URL (repeatable)")
@@ -123,6 +158,7 @@ equivalent of 'pip freeze' against the vault's manifest.`,
 	cmd.Flags().StringArrayVar(&userFlags, "user", nil, "Scope: a user email, or 'me' (repeatable)")
 	cmd.Flags().StringArrayVar(&botFlags, "bot", nil, "Scope: a bot identity, by name (repeatable)")
 	cmd.Flags().BoolVar(&replaceScopeFlag, "replace-scope", false, "Replace the asset's whole scope set with the named scopes (default is to append)")
+	cmd.Flags().StringVar(&pinFlag, "pin", "", "Pin the named scopes to a stored version ('latest' removes the pin)")
 	cmd.Flags().BoolVarP(&setTargetYes, "yes", "y", false, "Skip the scope-change confirmation prompt")
 
 	_ = cmd.Flags().MarkHidden("hook-mode") // Hide from help output since it's internal
@@ -298,7 +334,7 @@ func describeAssetScopeForDryRun(a *lockfile.Asset) string {
 }
 
 // runInstall executes the install command
-func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID string, repairMode bool, targetDir string, clientsFlag string, dryRun bool, strict bool) error {
+func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID string, repairMode bool, targetDir string, clientsFlag string, dryRun bool, strict bool, force bool, planFormat string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
 	defer cancel()
 
@@ -309,10 +345,10 @@ func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID s
 
 	log := logger.Get()
 	styledOut := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
-	styledOut.SetSilent(hookMode)
+	styledOut.SetSilent(hookMode || planFormat == "json")
 
 	status := components.NewStatus(cmd.OutOrStdout())
-	status.SetSilent(hookMode)
+	status.SetSilent(hookMode || planFormat == "json")
 
 	out := newOutputHelper(cmd)
 	out.silent = hookMode
@@ -378,7 +414,7 @@ func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID s
 	// for "first-active" is whichever profile appears first in
 	// profileLocks (already ordered per config.GetActiveProfileNames).
 	matcherScope := scope.NewMatcher(env.CurrentScope)
-	sortedAssets, assetOrigin, conflicts, skips, err := mergeApplicableAssets(profileLocks, env.Clients, matcherScope)
+	sortedAssets, assetOrigin, conflicts, skips, err := mergeApplicableAssets(ctx, profileLocks, env.Clients, matcherScope)
 	if err != nil {
 		return err
 	}
@@ -400,20 +436,29 @@ func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID s
 	mgmt.SetIdentityOverride(primaryCfg.Identity)
 	mgmt.SetAuditProfileTag(primaryCfg.ProfileName)
 
-	// --dry-run: print the resolved asset list and exit before we touch
-	// the tracker, download anything, or write to client directories.
+	// --dry-run: print the resolved asset list, then diffs of the
+	// instruction-file sections pending rules would change, and exit
+	// before we save the tracker or write to client directories.
 	if dryRun {
 		printDryRunPreview(cmd.OutOrStdout(), sortedAssets, env, assetOrigin, len(profileOrder) > 1, skips)
 		printDryRunSkippedLockAssets(cmd.OutOrStdout(), profileLocks)
-		return nil
+		return previewSectionChanges(ctx, cmd.OutOrStdout(), sortedAssets, env, assetOrigin, profileMeta, profileOrder, force, status, styledOut, out)
+	}
+
+	// --plan: run the install against staged copies of each client's
+	// files and report what would change
+	if planFormat != "" {
+		return runInstallPlan(ctx, cmd.OutOrStdout(), planFormat == "json", sortedAssets, env, profileLocks, assetOrigin, profileMeta, profileOrder, force, status, styledOut, out)
 	}
 
 	reportScopeSkips(skips, styledOut)
 	reportSkippedLockAssets(profileLocks, styledOut)
 
-	// Load tracker
+	// Load tracker. It also holds what sx last wrote to each managed
+	// section of an instruction file, the base for merging hand edits.
 	tracker := loadTracker(out)
 	targetClientIDs := getTargetClientIDs(env.Clients)
+	ctx = section.WithOptions(ctx, section.Options{Store: tracker, Force: force})
 
 	// In repair mode, verify assets against filesystem and update tracker
 	if repairMode {
@@ -525,6 +570,36 @@ func assetKeyForInstall(asset *lockfile.Asset, currentScope *scope.Scope) assets
 
 // cleanupRemovedAssets removes assets that are no longer in the lock file from all clients
 func cleanupRemovedAssets(ctx context.Context, tracker *assets.Tracker, sortedAssets []*lockfile.Asset, skippedNames map[string]bool, gitContext *gitutil.GitContext, currentScope *scope.Scope, targetClients []clients.Client, styledOut *ui.Output) {
+	removedAssets := findRemovedAssets(tracker, sortedAssets, skippedNames, currentScope)
+	if len(removedAssets) == 0 {
+		return
+	}
+
+	styledOut.Newline()
+	styledOut.Header(fmt.Sprintf("Cleaning up %d removed asset(s)...", len(removedAssets)))
+
+	// Group assets by scope and uninstall with appropriate scope
+	globalAssets, scopedAssets := separateGlobalAndScopedAssets(removedAssets)
+
+	if len(globalAssets) > 0 {
+		globalScope := &clients.InstallScope{Type: clients.ScopeGlobal}
+		uninstallAssetsWithScope(ctx, globalAssets, globalScope, targetClients, styledOut)
+	}
+
+	if len(scopedAssets) > 0 {
+		uninstallScope := buildInstallScope(currentScope, gitContext)
+		uninstallAssetsWithScope(ctx, scopedAssets, uninstallScope, targetClients, styledOut)
+	}
+
+	// Remove from tracker
+	for _, removed := range removedAssets {
+		tracker.RemoveAsset(removed.Key())
+	}
+}
+
+// findRemovedAssets returns the tracked assets for the current scope and
+// the global scope that are no longer in the lock file
+func findRemovedAssets(tracker *assets.Tracker, sortedAssets []*lockfile.Asset, skippedNames map[string]bool, currentScope *scope.Scope) []assets.InstalledAsset {
 	// Find assets in tracker for this scope that are no longer in lock file
 	key := assets.NewAssetKey("", currentScope.Type, currentScope.RepoURL, currentScope.RepoPath)
 	currentInScope := tracker.FindByScope(key.Repository, key.Path)
@@ -546,37 +621,17 @@ func cleanupRemovedAssets(ctx context.Context, tracker *assets.Tracker, sortedAs
 		lockFileNames[name] = true
 	}
 
+	// Outside a repository the current scope is global, so the two
+	// lookups overlap
 	var removedAssets []assets.InstalledAsset
+	seen := make(map[assets.AssetKey]bool)
 	for _, installed := range allRelevantAssets {
-		if !lockFileNames[installed.Name] {
+		if !lockFileNames[installed.Name] && !seen[installed.Key()] {
+			seen[installed.Key()] = true
 			removedAssets = append(removedAssets, installed)
 		}
 	}
-
-	if len(removedAssets) == 0 {
-		return
-	}
-
-	styledOut.Newline()
-	styledOut.Header(fmt.Sprintf("Cleaning up %d removed asset(s)...", len(removedAssets)))
-
-	// Group assets by scope and uninstall with appropriate scope
-	globalAssets, scopedAssets := separateGlobalAndScopedAssets(removedAssets)
-
-	if len(globalAssets) > 0 {
-		globalScope := &clients.InstallScope{Type: clients.ScopeGlobal}
-		uninstallAssetsWithScope(ctx, globalAssets, globalScope, targetClients, styledOut)
-	}
-
-	if len(scopedAssets) > 0 {
-		uninstallScope := buildInstallScope(currentScope, gitContext)
-		uninstallAssetsWithScope(ctx, scopedAssets, uninstallScope, targetClients, styledOut)
-	}
-
-	// Remove from tracker
-	for _, removed := range removedAssets {
-		tracker.RemoveAsset(removed.Key())
-	}
+	return removedAssets
 }
 
 // repairTracker verifies assets against the filesystem and updates the tracker to match reality
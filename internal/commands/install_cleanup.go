@@ -24,22 +24,7 @@ func separateGlobalAndScopedAssets(installedAssets []assets.InstalledAsset) (glo
 
 // uninstallAssetsWithScope uninstalls a list of assets from all clients using the given scope
 func uninstallAssetsWithScope(ctx context.Context, installedAssets []assets.InstalledAsset, scope *clients.InstallScope, targetClients []clients.Client, styledOut *ui.Output) {
-	// Convert InstalledAsset to asset.Asset
-	assetsToRemove := make([]asset.Asset, len(installedAssets))
-	for i, installed := range installedAssets {
-		assetsToRemove[i] = asset.Asset{
-			Name:    installed.Name,
-			Version: installed.Version,
-			Type:    asset.FromString(installed.Type),
-			Config:  installed.Config,
-		}
-	}
-
-	uninstallReq := clients.UninstallRequest{
-		Assets:  assetsToRemove,
-		Scope:   scope,
-		Options: clients.UninstallOptions{},
-	}
+	uninstallReq := buildUninstallRequest(installedAssets, scope)
 
 	log := logger.Get()
 	for _, client := range targetClients {
@@ -64,3 +49,23 @@ func uninstallAssetsWithScope(ctx context.Context, installedAssets []assets.Inst
 		}
 	}
 }
+
+// buildUninstallRequest converts tracked assets into an uninstall request
+// at scope
+func buildUninstallRequest(installedAssets []assets.InstalledAsset, scope *clients.InstallScope) clients.UninstallRequest {
+	assetsToRemove := make([]asset.Asset, len(installedAssets))
+	for i, installed := range installedAssets {
+		assetsToRemove[i] = asset.Asset{
+			Name:    installed.Name,
+			Version: installed.Version,
+			Type:    asset.FromString(installed.Type),
+			Config:  installed.Config,
+		}
+	}
+
+	return clients.UninstallRequest{
+		Assets:  assetsToRemove,
+		Scope:   scope,
+		Options: clients.UninstallOptions{},
+	}
+}
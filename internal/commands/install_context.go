@@ -302,17 +302,27 @@ func loadConfigAndVault() (*config.Config, vaultpkg.Vault, error) {
 	return cfg, vault, nil
 }
 
-// resolveAssetDependencies resolves dependencies for applicable assets
-func resolveAssetDependencies(lf *lockfile.LockFile, applicableAssets []*lockfile.Asset) ([]*lockfile.Asset, error) {
+// resolveAssetDependencies resolves dependencies for applicable assets.
+// Version ranges are resolved against the vault's published versions when
+// the vault can describe them; the picked versions are recorded in lf and,
+// when that changed anything, in the cached lock file under cacheKey so
+// later installs reuse the picks until the vault's lock file changes.
+func resolveAssetDependencies(ctx context.Context, vault vaultpkg.Vault, lf *lockfile.LockFile, applicableAssets []*lockfile.Asset, cacheKey string) ([]*lockfile.Asset, error) {
 	if len(applicableAssets) == 0 {
 		return nil, nil
 	}
 
 	resolver := assets.NewDependencyResolver(lf)
-	sortedAssets, err := resolver.Resolve(applicableAssets)
+	if versions, ok := vault.(assets.VersionLister); ok {
+		resolver.WithVersions(versions)
+	}
+	sortedAssets, err := resolver.Resolve(ctx, applicableAssets)
 	if err != nil {
 		return nil, fmt.Errorf("dependency resolution failed: %w", err)
 	}
+	if resolver.Changed() && cacheKey != "" {
+		saveResolvedLockFile(cacheKey, lf)
+	}
 	return sortedAssets, nil
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/sleuth-io/sx/v2/internal/cache"
 	"github.com/sleuth-io/sx/v2/internal/config"
@@ -73,3 +74,23 @@ func saveLockFileToCache(repoURL, etag string, data []byte) {
 		log.Error("failed to cache lock file", "error", err)
 	}
 }
+
+// saveResolvedLockFile replaces the cached lock file with lf after
+// dependency resolution pinned its ranges. The ETag is kept, so the cache
+// is reused until the vault's lock file changes and is resolved afresh.
+// Skipped assets go back in: they are dropped again when the cache is
+// parsed, and leaving them out would read as removals.
+func saveResolvedLockFile(cacheKey string, lf *lockfile.LockFile) {
+	log := logger.Get()
+	resolved := *lf
+	resolved.Assets = append(slices.Clone(lf.Assets), lf.SkippedAssets...)
+	resolved.SkippedAssets = nil
+	data, err := lockfile.Marshal(&resolved)
+	if err != nil {
+		log.Error("failed to marshal resolved lock file", "error", err)
+		return
+	}
+	if err := cache.SaveLockFile(cacheKey, data); err != nil {
+		log.Error("failed to cache resolved lock file", "error", err)
+	}
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/cache"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+)
+
+// TestSaveResolvedLockFile_KeepsSkippedAssets: the cached resolution
+// records the picks and still lists skipped assets, so the next install
+// neither re-resolves nor mistakes them for removals.
+func TestSaveResolvedLockFile_KeepsSkippedAssets(t *testing.T) {
+	t.Setenv("SX_CACHE_DIR", t.TempDir())
+
+	lf := &lockfile.LockFile{
+		LockVersion: "1.0",
+		Version:     "1",
+		CreatedBy:   "test",
+		Assets: []lockfile.Asset{{
+			Name:       "lib",
+			Version:    "2.0.0",
+			Type:       asset.TypeSkill,
+			SourceHTTP: &lockfile.SourceHTTP{URL: "https://vault.example/lib-2.0.0.zip", Hashes: map[string]string{"sha256": "abc"}},
+		}},
+		SkippedAssets: []lockfile.Asset{{
+			Name:      "broken",
+			Version:   "1.0.0",
+			Type:      asset.TypeSkill,
+			SourceGit: &lockfile.SourceGit{URL: "https://example.com/repo.git", Ref: "main"},
+		}},
+	}
+	saveResolvedLockFile("vault-id", lf)
+
+	data, err := cache.LoadLockFile("vault-id")
+	if err != nil {
+		t.Fatalf("LoadLockFile: %v", err)
+	}
+	cached, err := lockfile.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(cached.Assets) != 2 || cached.Assets[0].Name != "lib" || cached.Assets[1].Name != "broken" {
+		t.Fatalf("cached assets = %+v, want lib and broken", cached.Assets)
+	}
+	if cached.Assets[0].SourceHTTP == nil || cached.Assets[0].SourceHTTP.Hashes["sha256"] != "abc" {
+		t.Errorf("lib source = %+v, want its hash kept", cached.Assets[0].SourceHTTP)
+	}
+	if len(lf.Assets) != 1 || len(lf.SkippedAssets) != 1 {
+		t.Errorf("saving must not change the in-memory lock file, got %d assets, %d skipped", len(lf.Assets), len(lf.SkippedAssets))
+	}
+}
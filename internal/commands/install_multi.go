@@ -209,6 +209,7 @@ type assetConflict struct {
 // loadActiveLockFiles; the resolver only reads lockfile bytes, so it
 // doesn't touch the identity override here.
 func mergeApplicableAssets(
+	ctx context.Context,
 	profileLocks []profileLockFile,
 	targetClients []clients.Client,
 	matcherScope *scope.Matcher,
@@ -222,7 +223,11 @@ func mergeApplicableAssets(
 			continue
 		}
 		applicable := filterAssetsByScope(pl.LockFile, targetClients, matcherScope, skips)
-		sorted, resolveErr := resolveAssetDependencies(pl.LockFile, applicable)
+		cacheKey := ""
+		if pl.Config != nil {
+			cacheKey = pl.Config.VaultIdentifier()
+		}
+		sorted, resolveErr := resolveAssetDependencies(ctx, pl.Vault, pl.LockFile, applicable, cacheKey)
 		if resolveErr != nil {
 			return nil, nil, nil, skips, fmt.Errorf("dependency resolution for profile %s: %w", pl.ProfileName, resolveErr)
 		}
@@ -423,6 +428,7 @@ func downloadAssetsMultiVault(
 
 	var merged []assets.DownloadResult
 	var groupErrs []error
+	var signatureWarnings []string
 	for _, g := range orderedGroups {
 		meta := profileMeta[g.profile]
 		mgmt.SetIdentityOverride(meta.Identity)
@@ -430,6 +436,7 @@ func downloadAssetsMultiVault(
 
 		fetcher := assets.NewAssetFetcher(meta.Vault, meta.VaultKey)
 		results, err := fetcher.FetchAssets(ctx, g.assets, 10)
+		signatureWarnings = append(signatureWarnings, fetcher.Warnings()...)
 		if err != nil {
 			groupErrs = append(groupErrs, fmt.Errorf("profile %s: %w", g.profile, err))
 			continue
@@ -445,6 +452,9 @@ func downloadAssetsMultiVault(
 	for _, err := range groupErrs {
 		styledOut.Warning(err.Error())
 	}
+	for _, w := range signatureWarnings {
+		styledOut.Warning("unverified signature: " + w)
+	}
 
 	result := processDownloadResults(merged, styledOut)
 
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"slices"
 	"strings"
@@ -51,7 +52,7 @@ func TestMergeApplicableAssets_DefaultFirstWins(t *testing.T) {
 		buildProfileLock("work", "shared", "work-only"),
 		buildProfileLock("personal", "shared", "personal-only"),
 	}
-	sortedAssets, origin, conflicts, _, err := mergeApplicableAssets(locks, clientList, matcher)
+	sortedAssets, origin, conflicts, _, err := mergeApplicableAssets(context.Background(), locks, clientList, matcher)
 	if err != nil {
 		t.Fatalf("mergeApplicableAssets: %v", err)
 	}
@@ -87,7 +88,7 @@ func TestMergeApplicableAssets_ThreeWayConflict(t *testing.T) {
 		buildProfileLock("b", "dup"),
 		buildProfileLock("c", "dup"),
 	}
-	_, _, conflicts, skips, err := mergeApplicableAssets(locks, clientList, matcher)
+	_, _, conflicts, skips, err := mergeApplicableAssets(context.Background(), locks, clientList, matcher)
 	if err != nil {
 		t.Fatalf("merge: %v", err)
 	}
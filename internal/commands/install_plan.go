@@ -0,0 +1,438 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/assets"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/handlers/section"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/staging"
+	"github.com/sleuth-io/sx/v2/internal/ui"
+	"github.com/sleuth-io/sx/v2/internal/ui/components"
+)
+
+// Actions in an install plan
+const (
+	planInstall = "install"
+	planUpdate  = "update"
+	planRemove  = "remove"
+)
+
+// installPlan is what `sx install --plan` reports: per client, the asset
+// installs and removals it would run and the files they would change,
+// plus the removed assets cleanup would uninstall
+type installPlan struct {
+	Clients        []clientPlan     `json:"clients"`
+	Cleanup        []plannedRemoval `json:"cleanup,omitempty"`
+	CleanupSkipped string           `json:"cleanup_skipped,omitempty"`
+	DownloadErrors []string         `json:"download_errors,omitempty"`
+}
+
+type clientPlan struct {
+	Client  string               `json:"client"`
+	Name    string               `json:"name"`
+	Skipped string               `json:"skipped,omitempty"`
+	Assets  []plannedAsset       `json:"assets,omitempty"`
+	Files   []staging.FileChange `json:"files,omitempty"`
+}
+
+type plannedAsset struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Action  string `json:"action"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type plannedRemoval struct {
+	Name    string   `json:"name"`
+	Version string   `json:"version"`
+	Scope   string   `json:"scope"`
+	Clients []string `json:"clients,omitempty"`
+}
+
+// runInstallPlan downloads the assets an install would install, then runs
+// each client's install, and the cleanup of removed assets, against a
+// staged copy of the files the client touches (see the staging package).
+// Nothing outside the staging directory is written and the tracker isn't
+// saved. Client hooks and EnsureAssetSupport aren't run.
+func runInstallPlan(
+	ctx context.Context,
+	w io.Writer,
+	jsonOutput bool,
+	sortedAssets []*lockfile.Asset,
+	env *installEnvironment,
+	profileLocks []profileLockFile,
+	assetOrigin map[string]string,
+	profileMeta map[string]profileMetadata,
+	profileOrder []string,
+	force bool,
+	status *components.Status,
+	styledOut *ui.Output,
+	out *outputHelper,
+) error {
+	tracker := loadTracker(out)
+	assetsToInstall := determineAssetsToInstall(tracker, sortedAssets, env.CurrentScope, getTargetClientIDs(env.Clients), out)
+
+	plan := &installPlan{}
+	var removed []assets.InstalledAsset
+	if hadHardFetchFailure(profileLocks) {
+		plan.CleanupSkipped = "one or more profiles failed to fetch"
+	} else {
+		removed = findRemovedAssets(tracker, sortedAssets, skippedAssetNames(profileLocks), env.CurrentScope)
+	}
+	for _, r := range removed {
+		plan.Cleanup = append(plan.Cleanup, plannedRemoval{
+			Name:    r.Name,
+			Version: r.Version,
+			Scope:   describeTrackedScope(r),
+			Clients: r.Clients,
+		})
+	}
+
+	downloadResult, err := downloadAssetsMultiVault(ctx, assetsToInstall, assetOrigin, profileMeta, profileOrder, status, styledOut)
+	if err != nil {
+		return err
+	}
+	for _, e := range downloadResult.Errors {
+		plan.DownloadErrors = append(plan.DownloadErrors, e.Error())
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home directory: %w", err)
+	}
+	var repoRoot string
+	if env.GitContext.IsRepo {
+		repoRoot = env.GitContext.RepoRoot
+	}
+
+	p := &installPlanner{
+		tracker:   tracker,
+		downloads: downloadResult.Downloads,
+		actions:   map[string]string{},
+		force:     force,
+		home:      home,
+		repoRoot:  repoRoot,
+	}
+	for _, art := range assetsToInstall {
+		p.actions[art.Name] = planInstall
+		if tracker.FindAsset(assetKeyForInstall(art, env.CurrentScope)) != nil {
+			p.actions[art.Name] = planUpdate
+		}
+	}
+	p.removedGlobal, p.removedScoped = separateGlobalAndScopedAssets(removed)
+	p.cleanupScope = buildInstallScope(env.CurrentScope, env.GitContext)
+	for _, d := range p.downloads {
+		p.installScopes = append(p.installScopes, buildInstallScopesForAsset(d.Asset, env.GitContext))
+	}
+
+	// Clients run one at a time: each points HOME at its own sandbox
+	for _, client := range env.Clients {
+		plan.Clients = append(plan.Clients, p.planClient(ctx, client))
+	}
+
+	if jsonOutput {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+	printInstallPlan(w, plan, env, home, repoRoot)
+	return nil
+}
+
+// installPlanner holds what every client's plan run shares
+type installPlanner struct {
+	tracker       *assets.Tracker
+	downloads     []*assets.AssetWithMetadata
+	installScopes [][]*clients.InstallScope // per download
+	actions       map[string]string         // install or update, by asset name
+	removedGlobal []assets.InstalledAsset
+	removedScoped []assets.InstalledAsset
+	cleanupScope  *clients.InstallScope
+	force         bool
+	home          string
+	repoRoot      string
+}
+
+// planClient stages the client's files, runs the cleanup and installs
+// against them, and diffs the result
+func (p *installPlanner) planClient(ctx context.Context, client clients.Client) clientPlan {
+	cp := clientPlan{Client: client.ID(), Name: client.DisplayName()}
+	planner, ok := client.(clients.InstallPlanner)
+	if !ok {
+		cp.Skipped = "planning is not supported for this client"
+		return cp
+	}
+
+	sandbox, err := staging.New(p.home, p.repoRoot)
+	if err != nil {
+		cp.Skipped = err.Error()
+		return cp
+	}
+	defer sandbox.Close()
+
+	for _, scope := range p.scopes() {
+		paths, err := planner.PlanPaths(scope)
+		if errors.Is(err, clients.ErrNotPlannable) {
+			cp.Skipped = err.Error()
+			return cp
+		}
+		if err != nil {
+			// The install fails the same way for this scope, before
+			// writing anything
+			continue
+		}
+		for _, path := range paths {
+			if err := sandbox.Stage(path); err != nil {
+				cp.Skipped = "cannot stage " + err.Error()
+				return cp
+			}
+		}
+	}
+
+	before, err := sandbox.Snapshot()
+	if err != nil {
+		cp.Skipped = err.Error()
+		return cp
+	}
+
+	restore := sandbox.Enter()
+	ctx = section.WithOptions(ctx, section.Options{
+		Store: planSectionStore{tracker: p.tracker, sandbox: sandbox},
+		Force: p.force,
+	})
+	cp.Assets = p.run(ctx, client, sandbox)
+	restore()
+
+	after, err := sandbox.Snapshot()
+	if err != nil {
+		cp.Skipped = err.Error()
+		return cp
+	}
+	cp.Files = sandbox.Diff(before, after)
+	return cp
+}
+
+// run uninstalls the removed assets and installs the downloads, as a real
+// install would, with scopes pointed at the sandbox's repository root
+func (p *installPlanner) run(ctx context.Context, client clients.Client, sandbox *staging.Sandbox) []plannedAsset {
+	var planned []plannedAsset
+	record := func(action string, version func(name string) string, results []clients.AssetResult) {
+		for _, r := range results {
+			if r.AssetName == "" {
+				continue // "No compatible assets"
+			}
+			a := plannedAsset{
+				Name:    r.AssetName,
+				Version: version(r.AssetName),
+				Action:  action,
+				Status:  string(r.Status),
+				Message: sandbox.RealText(r.Message),
+			}
+			if r.Error != nil {
+				a.Message = sandbox.RealText(r.Error.Error())
+			}
+			planned = append(planned, a)
+		}
+	}
+
+	for _, group := range []struct {
+		removed []assets.InstalledAsset
+		scope   *clients.InstallScope
+	}{
+		{p.removedGlobal, &clients.InstallScope{Type: clients.ScopeGlobal}},
+		{p.removedScoped, p.cleanupScope},
+	} {
+		if len(group.removed) == 0 {
+			continue
+		}
+		// Cleanup runs on every client, but only report assets the
+		// tracker has installed for this one
+		versions := map[string]string{}
+		for _, r := range group.removed {
+			if slices.Contains(r.Clients, client.ID()) {
+				versions[r.Name] = r.Version
+			}
+		}
+		req := buildUninstallRequest(group.removed, stagedScope(group.scope, sandbox))
+		req.Options.DryRun = true
+		resp, err := client.UninstallAssets(ctx, req)
+		if err != nil {
+			for name, version := range versions {
+				planned = append(planned, plannedAsset{Name: name, Version: version, Action: planRemove, Status: string(clients.StatusFailed), Message: sandbox.RealText(err.Error())})
+			}
+			continue
+		}
+		var results []clients.AssetResult
+		for _, r := range resp.Results {
+			if _, ok := versions[r.AssetName]; ok {
+				results = append(results, r)
+			}
+		}
+		record(planRemove, func(name string) string { return versions[name] }, results)
+	}
+
+	orchestrator := clients.NewOrchestrator(clients.Global())
+	options := clients.InstallOptions{Force: p.force, DryRun: true}
+	for i, d := range p.downloads {
+		bundle := &clients.AssetBundle{Asset: d.Asset, Metadata: d.Metadata, ZipData: d.ZipData}
+		for _, scope := range p.installScopes[i] {
+			results := orchestrator.InstallToClients(ctx, []*clients.AssetBundle{bundle}, stagedScope(scope, sandbox), options, []clients.Client{client})
+			record(p.actions[d.Asset.Name], func(string) string { return d.Asset.Version }, results[client.ID()].Results)
+		}
+	}
+	return planned
+}
+
+// scopes returns every scope the plan installs or uninstalls at
+func (p *installPlanner) scopes() []*clients.InstallScope {
+	var all []*clients.InstallScope
+	seen := map[string]bool{}
+	add := func(s *clients.InstallScope) {
+		key := string(s.Type) + "|" + s.Path
+		if !seen[key] {
+			seen[key] = true
+			all = append(all, s)
+		}
+	}
+	if len(p.removedGlobal) > 0 {
+		add(&clients.InstallScope{Type: clients.ScopeGlobal})
+	}
+	if len(p.removedScoped) > 0 {
+		add(p.cleanupScope)
+	}
+	for _, scopes := range p.installScopes {
+		for _, s := range scopes {
+			add(s)
+		}
+	}
+	return all
+}
+
+// stagedScope returns a copy of scope whose repository root is the
+// sandbox's mirror of it
+func stagedScope(scope *clients.InstallScope, sandbox *staging.Sandbox) *clients.InstallScope {
+	staged := *scope
+	if staged.RepoRoot != "" {
+		staged.RepoRoot = sandbox.RepoRoot()
+	}
+	return &staged
+}
+
+// planSectionStore reads managed-section bases from the tracker, where
+// they're keyed by real path, for sections written in a sandbox. Writes
+// are dropped: a plan doesn't save the tracker.
+type planSectionStore struct {
+	tracker *assets.Tracker
+	sandbox *staging.Sandbox
+}
+
+func (s planSectionStore) SectionBase(path, name string) (string, bool) {
+	return s.tracker.SectionBase(s.sandbox.Real(path), name)
+}
+
+func (s planSectionStore) SetSectionBase(path, name, content string) {}
+
+func (s planSectionStore) DeleteSectionBase(path, name string) {}
+
+// describeTrackedScope returns "global", the repository, or repo#path for
+// a tracked asset
+func describeTrackedScope(a assets.InstalledAsset) string {
+	switch {
+	case a.Repository == "":
+		return "global"
+	case a.Path != "":
+		return a.Repository + "#" + a.Path
+	default:
+		return a.Repository
+	}
+}
+
+// printInstallPlan prints a plan in the commented style of --dry-run
+func printInstallPlan(w io.Writer, plan *installPlan, env *installEnvironment, home, repoRoot string) {
+	fmt.Fprintln(w, "# sx install --plan")
+	fmt.Fprintln(w, "# Clients:", strings.Join(getTargetClientIDs(env.Clients), ", "))
+	printDryRunCurrentScope(w, env.CurrentScope)
+
+	if len(plan.Cleanup) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "# Cleanup (no longer in the lock file):")
+		for _, r := range plan.Cleanup {
+			fmt.Fprintf(w, "-%s==%s  # scope=%s\n", r.Name, r.Version, r.Scope)
+		}
+	}
+	if plan.CleanupSkipped != "" {
+		fmt.Fprintf(w, "\n# Cleanup skipped: %s\n", plan.CleanupSkipped)
+	}
+	for _, e := range plan.DownloadErrors {
+		fmt.Fprintf(w, "\n# Download failed: %s\n", e)
+	}
+
+	for _, cp := range plan.Clients {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "## %s\n", cp.Name)
+		if cp.Skipped != "" {
+			fmt.Fprintf(w, "# skipped: %s\n", cp.Skipped)
+			continue
+		}
+		if len(cp.Assets) == 0 && len(cp.Files) == 0 {
+			fmt.Fprintln(w, "# no changes")
+			continue
+		}
+		for _, a := range cp.Assets {
+			line := fmt.Sprintf("%s %s", a.Action, a.Name)
+			if a.Version != "" {
+				line += "==" + a.Version
+			}
+			if a.Status != string(clients.StatusSuccess) {
+				line += "  # " + a.Status
+				if a.Message != "" {
+					line += ": " + a.Message
+				}
+			}
+			fmt.Fprintln(w, line)
+		}
+		for _, f := range cp.Files {
+			fmt.Fprintf(w, "  %s %s\n", fileChangeMark(f.Change), displayPlanPath(f.Path, home, repoRoot))
+			for _, e := range f.Entries {
+				fmt.Fprintf(w, "      %s %s\n", fileChangeMark(e.Change), e.Key)
+			}
+		}
+	}
+}
+
+func fileChangeMark(c staging.Change) string {
+	switch c {
+	case staging.Created, staging.Added:
+		return "+"
+	case staging.Deleted, staging.Removed:
+		return "-"
+	}
+	return "~"
+}
+
+// displayPlanPath shortens a path to repo-relative, or ~/ under home
+func displayPlanPath(path, home, repoRoot string) string {
+	if repoRoot != "" {
+		if rel, err := filepath.Rel(repoRoot, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	if rel, err := filepath.Rel(home, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.Join("~", rel)
+	}
+	return path
+}
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/staging"
+)
+
+// runPlan runs `sx install --plan --json` for Claude Code and decodes it
+func runPlan(t *testing.T) installPlan {
+	t.Helper()
+	var stdout bytes.Buffer
+	cmd := NewInstallCommand()
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--plan", "--json", "--clients", "claude-code"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("install --plan: %v", err)
+	}
+	var plan installPlan
+	if err := json.Unmarshal(stdout.Bytes(), &plan); err != nil {
+		t.Fatalf("plan is not JSON: %v\n%s", err, stdout.String())
+	}
+	if len(plan.Clients) != 1 || plan.Clients[0].Client != "claude-code" {
+		t.Fatalf("plan clients = %+v", plan.Clients)
+	}
+	return plan
+}
+
+func findFileChange(files []staging.FileChange, path string) *staging.FileChange {
+	i := slices.IndexFunc(files, func(f staging.FileChange) bool { return f.Path == path })
+	if i < 0 {
+		return nil
+	}
+	return &files[i]
+}
+
+func TestInstallPlan(t *testing.T) {
+	env, vaultDir := seedScopeVault(t)
+	skillFile := filepath.Join(env.GlobalClaudeDir(), "skills", "my-skill", "SKILL.md")
+
+	plan := runPlan(t)
+	cp := plan.Clients[0]
+	if cp.Skipped != "" {
+		t.Fatalf("client skipped: %s", cp.Skipped)
+	}
+	if len(cp.Assets) != 1 || cp.Assets[0].Name != "my-skill" || cp.Assets[0].Action != planInstall || cp.Assets[0].Status != "success" {
+		t.Errorf("assets = %+v", cp.Assets)
+	}
+	if f := findFileChange(cp.Files, skillFile); f == nil || f.Change != staging.Created {
+		t.Errorf("expected %s to be created, files = %+v", skillFile, cp.Files)
+	}
+	if strings.Contains(cp.Assets[0].Message, "sx-plan-") {
+		t.Errorf("message leaks the staging directory: %s", cp.Assets[0].Message)
+	}
+
+	var text bytes.Buffer
+	cmd := NewInstallCommand()
+	cmd.SetOut(&text)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--plan", "--clients", "claude-code"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("install --plan: %v", err)
+	}
+	for _, want := range []string{"## Claude Code", "install my-skill==1.0.0", "+ " + filepath.Join("~", ".claude", "skills", "my-skill", "SKILL.md")} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text plan missing %q:\n%s", want, text.String())
+		}
+	}
+
+	// Nothing was installed
+	env.AssertFileNotExists(skillFile)
+
+	// Install for real, then drop the asset from the lock file: the plan
+	// shows cleanup removing it
+	inst := NewInstallCommand()
+	inst.SetOut(&bytes.Buffer{})
+	inst.SetArgs([]string{"--clients", "claude-code"})
+	if err := inst.Execute(); err != nil {
+		t.Fatalf("install: %v", err)
+	}
+	env.AssertFileExists(skillFile)
+	env.WriteLockFile(vaultDir, `lock-version = "1"
+version = "1.0.1"
+created-by = "test"
+`)
+
+	plan = runPlan(t)
+	if len(plan.Cleanup) != 1 || plan.Cleanup[0].Name != "my-skill" || plan.Cleanup[0].Scope != "global" {
+		t.Errorf("cleanup = %+v", plan.Cleanup)
+	}
+	cp = plan.Clients[0]
+	if len(cp.Assets) != 1 || cp.Assets[0].Action != planRemove {
+		t.Errorf("assets = %+v", cp.Assets)
+	}
+	if f := findFileChange(cp.Files, skillFile); f == nil || f.Change != staging.Deleted {
+		t.Errorf("expected %s to be deleted, files = %+v", skillFile, cp.Files)
+	}
+	env.AssertFileExists(skillFile)
+}
+
+func TestInstallPlanFlagValidation(t *testing.T) {
+	for _, args := range [][]string{
+		{"--plan", "--dry-run"},
+		{"--plan", "--repair"},
+		{"--json"},
+	} {
+		cmd := NewInstallCommand()
+		cmd.SetArgs(args)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		cmd.SetOut(&bytes.Buffer{})
+		if err := cmd.Execute(); err == nil {
+			t.Errorf("expected an error for %v", args)
+		}
+	}
+}
+
+func TestDisplayPlanPath(t *testing.T) {
+	home := filepath.Join(string(os.PathSeparator), "home", "u")
+	repo := filepath.Join(home, "src", "repo")
+	tests := map[string]string{
+		filepath.Join(repo, ".claude", "skills"):            filepath.Join(".claude", "skills"),
+		filepath.Join(home, ".claude.json"):                 filepath.Join("~", ".claude.json"),
+		filepath.Join(string(os.PathSeparator), "etc", "x"): filepath.Join(string(os.PathSeparator), "etc", "x"),
+	}
+	for path, want := range tests {
+		if got := displayPlanPath(path, home, repo); got != want {
+			t.Errorf("displayPlanPath(%s) = %s, want %s", path, got, want)
+		}
+	}
+}
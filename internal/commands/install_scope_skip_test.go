@@ -2,6 +2,7 @@ package commands
 
 import (
 	"bytes"
+	"context"
 	"slices"
 	"strings"
 	"testing"
@@ -177,7 +178,7 @@ func TestMergeApplicableAssets_DependencyClearsSkip(t *testing.T) {
 		Type:    scope.TypeRepo,
 		RepoURL: "git@sx-test-unresolvable.invalid:acme/app.git",
 	})
-	sorted, _, _, skips, err := mergeApplicableAssets(locks, clientList, matcher)
+	sorted, _, _, skips, err := mergeApplicableAssets(context.Background(), locks, clientList, matcher)
 	if err != nil {
 		t.Fatalf("merge: %v", err)
 	}
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/handlers/section"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/ui"
+	"github.com/sleuth-io/sx/v2/internal/ui/components"
+)
+
+// previewSectionChanges prints, for --dry-run, unified diffs of the
+// sx-managed instruction-file sections the pending rule installs would
+// change (GEMINI.md, .rules, a profile's AGENTS.md). Only those rules are
+// downloaded, and they run through the clients with section writes
+// switched to dry-run, so nothing on disk changes. Sections that were
+// edited by hand in a way that can't be merged are listed as conflicts.
+func previewSectionChanges(
+	ctx context.Context,
+	w io.Writer,
+	sortedAssets []*lockfile.Asset,
+	env *installEnvironment,
+	assetOrigin map[string]string,
+	profileMeta map[string]profileMetadata,
+	profileOrder []string,
+	force bool,
+	status *components.Status,
+	styledOut *ui.Output,
+	out *outputHelper,
+) error {
+	var writers []clients.Client
+	for _, c := range env.Clients {
+		if _, ok := c.(clients.ManagedSectionWriter); ok {
+			writers = append(writers, c)
+		}
+	}
+	if len(writers) == 0 {
+		return nil
+	}
+
+	tracker := loadTracker(out)
+	var rules []*lockfile.Asset
+	for _, art := range determineAssetsToInstall(tracker, sortedAssets, env.CurrentScope, getTargetClientIDs(env.Clients), out) {
+		if art.Type == asset.TypeRule {
+			rules = append(rules, art)
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	downloadResult, err := downloadAssetsMultiVault(ctx, rules, assetOrigin, profileMeta, profileOrder, status, styledOut)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "# Instruction-file changes:")
+
+	ctx = section.WithOptions(ctx, section.Options{Store: tracker, Force: force, DryRun: true, Diff: w})
+	orchestrator := clients.NewOrchestrator(clients.Global())
+	options := clients.InstallOptions{Force: force, DryRun: true}
+	for _, download := range downloadResult.Downloads {
+		bundle := &clients.AssetBundle{
+			Asset:    download.Asset,
+			Metadata: download.Metadata,
+			ZipData:  download.ZipData,
+		}
+		for _, installScope := range buildInstallScopesForAsset(download.Asset, env.GitContext) {
+			var targets []clients.Client
+			for _, c := range writers {
+				if c.(clients.ManagedSectionWriter).WritesManagedSections(download.Metadata.Asset.Type, installScope) {
+					targets = append(targets, c)
+				}
+			}
+			results := orchestrator.InstallToClients(ctx, []*clients.AssetBundle{bundle}, installScope, options, targets)
+			for _, c := range targets {
+				for _, r := range results[c.ID()].Results {
+					if r.Status == clients.StatusFailed {
+						fmt.Fprintf(w, "# %s (%s): %v\n", r.AssetName, c.DisplayName(), r.Error)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
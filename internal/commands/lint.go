@@ -0,0 +1,282 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/sx/v2/internal/buildinfo"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/config"
+	"github.com/sleuth-io/sx/v2/internal/lint"
+	"github.com/sleuth-io/sx/v2/internal/ui"
+	"github.com/sleuth-io/sx/v2/internal/ui/theme"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// NewLintCommand returns `sx lint` (docs/lint.md).
+func NewLintCommand() *cobra.Command {
+	var (
+		format    string
+		clientIDs []string
+		strict    bool
+	)
+	cmd := &cobra.Command{
+		Use:   "lint [path|asset]...",
+		Short: "Check assets against every client's constraints",
+		Long: `Check assets the way install would, for every enabled client, without
+installing anything: metadata, skill frontmatter, hook events each client
+can fire, and how each client reads a rule. Exits nonzero when any asset
+has errors, so it can gate CI.
+
+Each argument is an asset directory, zip, or single-file asset (a rule,
+command, or agent file), or an asset in the vault as name or name@version.
+With no arguments, lints the current directory.
+
+Examples:
+
+  sx lint skills/review-pr
+  sx lint rules/*/ --format sarif > sx-lint.sarif
+  sx lint my-hook@3 --client cursor --strict`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLint(cmd, args, format, clientIDs, strict)
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text, json, or sarif")
+	cmd.Flags().StringSliceVar(&clientIDs, "client", nil, "Check only these clients (repeatable; default: every enabled client)")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Treat warnings as errors")
+	return cmd
+}
+
+func runLint(cmd *cobra.Command, args []string, format string, clientIDs []string, strict bool) error {
+	switch format {
+	case "text", "json", "sarif":
+	default:
+		return fmt.Errorf("invalid format %q (text|json|sarif)", format)
+	}
+	targets, err := lintTargets(clientIDs)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	reports := make([]*lint.Report, 0, len(args))
+	for _, arg := range args {
+		rep, err := lintArg(ctx, arg, targets)
+		if err != nil {
+			return fmt.Errorf("%s: %w", arg, err)
+		}
+		reports = append(reports, rep)
+	}
+
+	errorCount, warningCount := 0, 0
+	for _, rep := range reports {
+		errorCount += rep.Count(lint.SeverityError)
+		warningCount += rep.Count(lint.SeverityWarning)
+	}
+
+	switch format {
+	case "json":
+		err = emitLintJSON(cmd, map[string]any{"assets": reports, "errors": errorCount, "warnings": warningCount})
+	case "sarif":
+		err = emitLintJSON(cmd, lint.SARIF(reports, buildinfo.Version))
+	default:
+		printLintReports(cmd, reports, errorCount, warningCount)
+	}
+	if err != nil {
+		return err
+	}
+
+	if errorCount > 0 || (strict && warningCount > 0) {
+		return fmt.Errorf("lint failed: %s", pluralCount(errorCount, "error")+", "+pluralCount(warningCount, "warning"))
+	}
+	return nil
+}
+
+// lintTargets returns the clients to check: those named, or every
+// registered client the config doesn't disable. Lint doesn't care what is
+// installed on this machine — a CI runner has none of them.
+func lintTargets(ids []string) ([]clients.Client, error) {
+	if len(ids) > 0 {
+		targets := make([]clients.Client, 0, len(ids))
+		for _, id := range ids {
+			c, err := clients.Global().Get(id)
+			if err != nil {
+				return nil, fmt.Errorf("%w (valid: %s)", err, strings.Join(clients.AllClientIDs(), ", "))
+			}
+			targets = append(targets, c)
+		}
+		return targets, nil
+	}
+	cfg, _ := config.Load()
+	var targets []clients.Client
+	for _, c := range clients.Global().GetAll() {
+		if cfg == nil || cfg.IsClientEnabled(c.ID()) {
+			targets = append(targets, c)
+		}
+	}
+	return targets, nil
+}
+
+// lintArg lints a local asset when arg names a file or directory, and an
+// asset in the vault otherwise.
+func lintArg(ctx context.Context, arg string, targets []clients.Client) (*lint.Report, error) {
+	path, err := utils.NormalizePath(arg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path: %w", err)
+	}
+	if !utils.FileExists(path) {
+		return lintVaultAsset(ctx, arg, targets)
+	}
+
+	// A skill is its directory, as with sx add.
+	if strings.EqualFold(filepath.Base(path), "skill.md") {
+		path = filepath.Dir(path)
+		arg = filepath.Dir(arg)
+	}
+
+	var zipData []byte
+	switch {
+	case utils.IsDirectory(path):
+		if zipData, err = utils.CreateZip(path); err != nil {
+			return nil, fmt.Errorf("failed to read directory: %w", err)
+		}
+	case isSingleFileAsset(path):
+		if zipData, err = createZipFromSingleFile(path); err != nil {
+			return nil, err
+		}
+	default:
+		if zipData, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+		if !utils.IsZipFile(zipData) {
+			return nil, errors.New("not an asset directory, zip, or single-file asset")
+		}
+	}
+
+	rep := lint.Lint(zipData, guessAssetName(path), targets)
+	rep.Source = arg
+	if utils.IsDirectory(path) {
+		rep.Dir = arg
+	} else {
+		rep.Path = arg
+	}
+	return rep, nil
+}
+
+// lintVaultAsset lints a published version: name or name@version.
+func lintVaultAsset(ctx context.Context, arg string, targets []clients.Client) (*lint.Report, error) {
+	name, version, _ := strings.Cut(arg, "@")
+	v, err := createVault()
+	if err != nil {
+		return nil, fmt.Errorf("no such file, and no vault to look it up in: %w", err)
+	}
+	if version, err = resolveStoredVersion(ctx, v, name, version); err != nil {
+		return nil, err
+	}
+	zipData, err := v.GetAssetByVersion(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	rep := lint.Lint(zipData, name, targets)
+	rep.Source = name + "@" + version
+	return rep, nil
+}
+
+func emitLintJSON(cmd *cobra.Command, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	return err
+}
+
+func printLintReports(cmd *cobra.Command, reports []*lint.Report, errorCount, warningCount int) {
+	out := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+	sym := out.Theme().Symbols()
+	warn := func(text string) string {
+		return out.StyledText(func(s theme.Styles) fmt.Stringer { return s.Warning.SetString(text) }, text)
+	}
+
+	for _, rep := range reports {
+		out.Newline()
+		header := out.BoldText(rep.Name)
+		if rep.Type != "" {
+			header += " " + out.MutedText("("+rep.Type+")")
+		}
+		if rep.Source != rep.Name {
+			header += "  " + out.MutedText(rep.Source)
+		}
+		out.Println(header)
+
+		for _, c := range rep.Clients {
+			switch c.Status {
+			case lint.StatusOK:
+				out.Println("  " + out.SuccessText(sym.Success) + " " + c.Name)
+			case lint.StatusWarning:
+				out.Println("  " + warn(sym.Warning) + " " + c.Name)
+			case lint.StatusError:
+				out.Println("  " + out.ErrorText(sym.Error) + " " + c.Name)
+			case lint.StatusSkipped:
+				out.Println(out.MutedText("  - " + c.Name + ": " + c.Reason))
+			}
+		}
+
+		for _, f := range rep.Findings {
+			where := f.File
+			if f.Client != "" {
+				where = strings.TrimSpace(clientName(rep, f.Client) + " " + f.File)
+			}
+			text := f.Message
+			if where != "" {
+				text = where + ": " + text
+			}
+			switch f.Severity {
+			case lint.SeverityError:
+				out.Println("  " + out.ErrorText(sym.Error+" "+text) + " " + out.MutedText("["+f.Rule+"]"))
+			case lint.SeverityWarning:
+				out.Println("  " + warn(sym.Warning+" "+text) + " " + out.MutedText("["+f.Rule+"]"))
+			default:
+				out.Println(out.MutedText("  " + sym.Info + " " + text))
+			}
+		}
+	}
+
+	out.Newline()
+	if errorCount == 0 && warningCount == 0 {
+		out.Success(fmt.Sprintf("%s checked, no problems", pluralCount(len(reports), "asset")))
+		return
+	}
+	out.Println(fmt.Sprintf("%s, %s in %s",
+		pluralCount(errorCount, "error"), pluralCount(warningCount, "warning"), pluralCount(len(reports), "asset")))
+}
+
+// clientName returns the display name of a client in a report.
+func clientName(rep *lint.Report, id string) string {
+	for _, c := range rep.Clients {
+		if c.Client == id {
+			return c.Name
+		}
+	}
+	return id
+}
+
+func pluralCount(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
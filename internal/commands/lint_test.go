@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/lint"
+)
+
+func execLint(args ...string) (string, error) {
+	var stdout bytes.Buffer
+	cmd := NewLintCommand()
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stdout)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestLintCommand_LocalSkillFailsWithoutDescription(t *testing.T) {
+	env := NewTestEnv(t)
+	dir := env.MkdirAll(filepath.Join(env.TempDir, "review"))
+	env.WriteFile(filepath.Join(dir, "SKILL.md"), "Review pull requests.\n")
+
+	out, err := execLint(dir, "--client", "cursor")
+	if err == nil || !strings.Contains(err.Error(), "1 error") {
+		t.Fatalf("lint err = %v, want 1 error\n%s", err, out)
+	}
+	if !strings.Contains(out, "skill-description") {
+		t.Errorf("output doesn't name the failing check:\n%s", out)
+	}
+
+	env.WriteFile(filepath.Join(dir, "SKILL.md"), "---\ndescription: Review pull requests\n---\n\nReview them.\n")
+	if out, err := execLint(dir, "--client", "cursor"); err != nil {
+		t.Fatalf("lint of a described skill failed: %v\n%s", err, out)
+	}
+}
+
+func TestLintCommand_VaultAssetJSONAndStrict(t *testing.T) {
+	env := NewTestEnv(t)
+	env.SetupPathVault()
+	src := env.MkdirAll(filepath.Join(env.TempDir, "tabs"))
+	env.WriteFile(filepath.Join(src, "RULE.md"), "Use tabs.\n")
+	if out, err := execAdd(src, "--yes", "--no-install"); err != nil {
+		t.Fatalf("add: %v\n%s", err, out)
+	}
+
+	out, err := execLint("tabs", "--client", "cursor", "--format", "json")
+	if err != nil {
+		t.Fatalf("warnings alone failed lint: %v\n%s", err, out)
+	}
+	var result struct {
+		Assets   []*lint.Report `json:"assets"`
+		Warnings int            `json:"warnings"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(result.Assets) != 1 || result.Assets[0].Source != "tabs@1" || result.Warnings != 1 {
+		t.Fatalf("result = %+v", result)
+	}
+	if f := result.Assets[0].Findings; len(f) != 1 || f[0].Rule != lint.RuleRuleGlobsMissing {
+		t.Errorf("findings = %+v", f)
+	}
+
+	if _, err := execLint("tabs@1", "--client", "cursor", "--strict"); err == nil {
+		t.Error("--strict passed with a warning")
+	}
+}
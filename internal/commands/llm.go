@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/sx/v2/internal/llm"
+)
+
+// Commands that call a model (sx quality eval, sx bench run) use the
+// provider the user picked in the app's Settings, overridable per run.
+
+// llmFlagsHelp is the Long-help paragraph for commands with addLLMFlags.
+const llmFlagsHelp = `The provider is the one chosen in the sx app's Settings, or --provider
+(claude-cli, codex-cli, gemini-cli, ollama, anthropic, openai, google).
+API keys come from SX_LLM_API_KEY, the vendor's usual variable
+(ANTHROPIC_API_KEY, OPENAI_API_KEY, GEMINI_API_KEY), or the key saved
+in the app.`
+
+// addLLMFlags registers --provider, --model, and --base-url into cfg.
+func addLLMFlags(cmd *cobra.Command, cfg *llm.Config) {
+	cmd.Flags().StringVar(&cfg.Provider, "provider", "", "AI provider (default: the one configured in the sx app)")
+	cmd.Flags().StringVar(&cfg.Model, "model", "", "Model to use (default: the provider's configured model)")
+	cmd.Flags().StringVar(&cfg.BaseURL, "base-url", "", "Provider endpoint override (Ollama server, OpenAI-compatible API, gateway)")
+}
+
+// llmConfigFromFlags is the saved provider selection with the flags
+// applied. Naming a different provider starts from a blank config: the
+// saved model and endpoint belong to the saved provider.
+func llmConfigFromFlags(flags llm.Config) (llm.Config, error) {
+	cfg, err := llm.LoadConfig()
+	if err != nil {
+		return llm.Config{}, err
+	}
+	if flags.Provider != "" && flags.Provider != cfg.Provider {
+		cfg = llm.Config{Provider: flags.Provider}
+	}
+	if flags.Model != "" {
+		cfg.Model = flags.Model
+	}
+	if flags.BaseURL != "" {
+		cfg.BaseURL = flags.BaseURL
+	}
+	return cfg, nil
+}
+
+// newLLMProvider builds the provider for cfg. A var so tests can
+// substitute a fake model.
+var newLLMProvider = defaultLLMProvider
+
+func defaultLLMProvider(cfg llm.Config) (llm.Provider, error) {
+	if cfg.Provider == "" {
+		return nil, errors.New("no AI provider configured: pass --provider, or choose one in the sx app's Settings")
+	}
+	key := llm.LookupAPIKey(cfg.Provider)
+	if llm.NeedsAPIKey(cfg.Provider) && key == "" {
+		return nil, fmt.Errorf("no API key for %s: set %s, or add one in the sx app's Settings",
+			cfg.Provider, strings.Join(llm.KeyEnvNames(cfg.Provider), " or "))
+	}
+	return llm.New(cfg, key)
+}
@@ -0,0 +1,287 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/llm"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/quality"
+	"github.com/sleuth-io/sx/v2/internal/ui"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+	vaultpkg "github.com/sleuth-io/sx/v2/internal/vault"
+)
+
+// NewQualityCommand returns the `sx quality` command group
+// (docs/quality-spec.md).
+func NewQualityCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "quality",
+		Short: "Score skills against the quality rubric",
+		Long: `Quality records rate a skill's structure, actionability, content, and
+completeness. The desktop app shows them on the Quality tab; 'sx quality
+eval' writes them from the command line or CI.
+
+Examples:
+
+  sx quality eval review-pr
+  sx quality eval --all --json
+  sx quality eval --all --provider anthropic --model claude-sonnet-4-6`,
+	}
+	cmd.AddCommand(newQualityEvalCommand())
+	return cmd
+}
+
+// qualityEvalOptions are the flags of `sx quality eval`.
+type qualityEvalOptions struct {
+	all        bool
+	force      bool
+	jsonOutput bool
+	llm        llm.Config
+}
+
+func newQualityEvalCommand() *cobra.Command {
+	var opts qualityEvalOptions
+	cmd := &cobra.Command{
+		Use:   "eval [skill[@version]]",
+		Short: "Evaluate skills and record their quality scores",
+		Long: `Evaluate a skill (its latest version unless one is given), or every skill
+with --all, through an AI provider and add the result to the vault's
+quality records. A skill whose content hasn't changed since its last
+record is skipped unless --force is given, so this is cheap to run on
+every publish.
+
+` + llmFlagsHelp + `
+
+On skills.new the server evaluates skills itself; eval asks it to.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.all == (len(args) == 1) {
+				return errors.New("name one skill, or pass --all")
+			}
+			return runQualityEval(cmd, optionalArg(args, 0), opts)
+		},
+	}
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Evaluate every skill in the vault")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Re-evaluate even when the skill is unchanged")
+	cmd.Flags().BoolVar(&opts.jsonOutput, "json", false, "Output results as JSON")
+	addLLMFlags(cmd, &opts.llm)
+	return cmd
+}
+
+// Per-skill outcomes of `sx quality eval`.
+const (
+	qualityEvaluated = "evaluated"
+	qualityUnchanged = "unchanged"
+	qualityServer    = "server"
+	qualityFailed    = "failed"
+)
+
+// qualityEvalResult is one skill's outcome; --json prints a list of them.
+type qualityEvalResult struct {
+	Asset    string          `json:"asset"`
+	Version  string          `json:"version,omitempty"`
+	Status   string          `json:"status"`
+	Overall  *int            `json:"overall,omitempty"`
+	Previous *int            `json:"previous,omitempty"`
+	Record   *quality.Record `json:"record,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+func runQualityEval(cmd *cobra.Command, arg string, opts qualityEvalOptions) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Minute)
+	defer cancel()
+
+	v, err := createVault()
+	if err != nil {
+		return err
+	}
+	store, ok := v.(vaultpkg.QualityStore)
+	if !ok {
+		return vaultpkg.ErrQualityUnsupported
+	}
+	cfg, err := llmConfigFromFlags(opts.llm)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	if opts.all {
+		list, err := v.ListAssets(ctx, vaultpkg.ListAssetsOptions{Type: asset.TypeSkill.Key})
+		if err != nil {
+			return fmt.Errorf("failed to list skills: %w", err)
+		}
+		for _, a := range list.Assets {
+			names = append(names, a.Name)
+		}
+	} else {
+		names = []string{arg}
+	}
+
+	ev := &qualityEvaluator{vault: v, store: store, cfg: cfg, force: opts.force}
+	var out *ui.Output
+	if !opts.jsonOutput {
+		out = ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+	}
+	results := make([]*qualityEvalResult, 0, len(names))
+	for _, name := range names {
+		res := ev.eval(ctx, name)
+		results = append(results, res)
+		if out != nil {
+			printQualityResult(out, res)
+		}
+	}
+
+	counts := map[string]int{}
+	for _, res := range results {
+		counts[res.Status]++
+	}
+	if opts.jsonOutput {
+		if err := emitLintJSON(cmd, map[string]any{
+			"results":   results,
+			"evaluated": counts[qualityEvaluated],
+			"unchanged": counts[qualityUnchanged],
+			"failed":    counts[qualityFailed],
+		}); err != nil {
+			return err
+		}
+	} else if len(results) == 0 {
+		out.Println("No skills in the vault.")
+	}
+
+	if n := counts[qualityFailed]; n > 0 {
+		return fmt.Errorf("quality eval failed for %s", pluralCount(n, "skill"))
+	}
+	return nil
+}
+
+// qualityEvaluator evaluates skills one at a time, building the provider
+// only once something actually needs a model.
+type qualityEvaluator struct {
+	vault    vaultpkg.Vault
+	store    vaultpkg.QualityStore
+	cfg      llm.Config
+	force    bool
+	provider llm.Provider
+	by       *string
+}
+
+func (e *qualityEvaluator) eval(ctx context.Context, arg string) *qualityEvalResult {
+	name, version, _ := strings.Cut(arg, "@")
+	res := &qualityEvalResult{Asset: name}
+	if err := e.evalInto(ctx, res, version); err != nil {
+		res.Status = qualityFailed
+		res.Error = err.Error()
+	}
+	return res
+}
+
+func (e *qualityEvaluator) evalInto(ctx context.Context, res *qualityEvalResult, version string) error {
+	mode, err := e.store.ReevaluateQuality(ctx, res.Asset)
+	if err != nil {
+		return err
+	}
+	if mode == vaultpkg.QualityEvalServer {
+		res.Status = qualityServer
+		return nil
+	}
+
+	if res.Version, err = resolveStoredVersion(ctx, e.vault, res.Asset, version); err != nil {
+		return err
+	}
+	zipData, err := e.vault.GetAssetByVersion(ctx, res.Asset, res.Version)
+	if err != nil {
+		return err
+	}
+	if data, err := utils.ReadZipFile(zipData, "metadata.toml"); err == nil {
+		if meta, err := metadata.Parse(data); err == nil && meta.Asset.Type.Key != asset.TypeSkill.Key {
+			return fmt.Errorf("%s is a %s; quality eval only rates skills", res.Asset, meta.Asset.Type.Key)
+		}
+	}
+
+	doc, err := e.store.GetQuality(ctx, res.Asset)
+	if err != nil {
+		return err
+	}
+	prev, err := quality.Latest(doc)
+	if err != nil {
+		return err
+	}
+	if prev != nil {
+		res.Previous = &prev.Overall
+	}
+	hash, err := utils.ComputeAssetContentHash(zipData)
+	if err != nil {
+		return err
+	}
+	if !e.force && prev != nil && prev.SkillHash == hash {
+		res.Status = qualityUnchanged
+		res.Overall = &prev.Overall
+		res.Previous = nil
+		return nil
+	}
+
+	if e.provider == nil {
+		if e.provider, err = newLLMProvider(e.cfg); err != nil {
+			return err
+		}
+	}
+	rec, err := quality.Evaluate(ctx, e.provider, res.Asset, zipData)
+	if err != nil {
+		return err
+	}
+	rec.By = e.actor(ctx)
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if err := e.store.AddQuality(ctx, res.Asset, string(data)); err != nil {
+		return err
+	}
+	res.Status = qualityEvaluated
+	res.Overall = &rec.Overall
+	res.Record = rec
+	return nil
+}
+
+// actor is who the records are attributed to; empty when the vault
+// can't tell (records don't require it).
+func (e *qualityEvaluator) actor(ctx context.Context) string {
+	if e.by == nil {
+		var by string
+		if a, err := e.vault.CurrentActor(ctx); err == nil {
+			by = a.Email
+		}
+		e.by = &by
+	}
+	return *e.by
+}
+
+func printQualityResult(out *ui.Output, res *qualityEvalResult) {
+	sym := out.Theme().Symbols()
+	switch res.Status {
+	case qualityEvaluated:
+		line := fmt.Sprintf("%s %s %d", out.SuccessText(sym.Success), out.BoldText(res.Asset), *res.Overall)
+		if res.Previous != nil {
+			line += out.MutedText(fmt.Sprintf(" (was %d)", *res.Previous))
+		}
+		var cats []string
+		for _, cat := range quality.Rubric {
+			cats = append(cats, fmt.Sprintf("%s %d", cat.Name, res.Record.Categories[cat.Name]))
+		}
+		out.Println(line + "  " + out.MutedText(strings.Join(cats, " · ")))
+	case qualityUnchanged:
+		out.Println(out.MutedText(fmt.Sprintf("- %s %d, unchanged since its last evaluation", res.Asset, *res.Overall)))
+	case qualityServer:
+		out.Println(fmt.Sprintf("%s %s evaluating on the server — see the Quality tab", sym.Info, out.BoldText(res.Asset)))
+	case qualityFailed:
+		out.Println(out.ErrorText(sym.Error + " " + res.Asset + ": " + res.Error))
+	}
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/llm"
+	"github.com/sleuth-io/sx/v2/internal/quality"
+)
+
+// fakeQualityModel scores every rubric factor the same and counts calls.
+type fakeQualityModel struct {
+	score int
+	calls int
+}
+
+func (f *fakeQualityModel) ID() string { return "fake" }
+
+func (f *fakeQualityModel) Complete(ctx context.Context, req llm.Request) (llm.Response, error) {
+	f.calls++
+	factors := map[string]any{}
+	for _, cat := range quality.Rubric {
+		for _, factor := range cat.Factors {
+			factors[factor.Name] = map[string]any{"score": f.score, "justification": "ok"}
+		}
+	}
+	text, _ := json.Marshal(map[string]any{
+		"factors": factors, "summary": "Fine.",
+		"strengths": []string{}, "improvements": []string{}, "recommendations": []string{},
+	})
+	return llm.Response{Text: string(text), Provider: "fake", Model: "fake-1"}, nil
+}
+
+func execQualityEval(args ...string) (string, error) {
+	var stdout bytes.Buffer
+	cmd := NewQualityCommand()
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stdout)
+	cmd.SetArgs(append([]string{"eval"}, args...))
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestQualityEval_SkipsUnchangedSkills(t *testing.T) {
+	env := NewTestEnv(t)
+	env.SetupPathVault()
+	src := env.MkdirAll(filepath.Join(env.TempDir, "review"))
+	env.WriteFile(filepath.Join(src, "SKILL.md"), "---\ndescription: Review PRs\n---\n\nRead the diff.\n")
+	if out, err := execAdd(src, "--yes", "--no-install"); err != nil {
+		t.Fatalf("add: %v\n%s", err, out)
+	}
+
+	model := &fakeQualityModel{score: 80}
+	orig := newLLMProvider
+	newLLMProvider = func(cfg llm.Config) (llm.Provider, error) {
+		if cfg.Provider != llm.ProviderOllama || cfg.Model != "m" {
+			t.Errorf("provider config = %+v", cfg)
+		}
+		return model, nil
+	}
+	defer func() { newLLMProvider = orig }()
+
+	var result struct {
+		Results   []qualityEvalResult `json:"results"`
+		Evaluated int                 `json:"evaluated"`
+		Unchanged int                 `json:"unchanged"`
+	}
+	out, err := execQualityEval("--all", "--json", "--provider", "ollama", "--model", "m")
+	if err != nil {
+		t.Fatalf("eval: %v\n%s", err, out)
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result.Evaluated != 1 || len(result.Results) != 1 {
+		t.Fatalf("result = %+v", result)
+	}
+	rec := result.Results[0].Record
+	if rec == nil || rec.Overall != 80 || rec.SkillHash == "" || rec.By != "test@example.com" ||
+		rec.Executor.Provider != "fake" || rec.Source != quality.SourceCLI {
+		t.Fatalf("record = %+v", rec)
+	}
+
+	// Same content: no model call, the stored score is reported.
+	out, err = execQualityEval("review", "--provider", "ollama", "--model", "m")
+	if err != nil || model.calls != 1 || !strings.Contains(out, "unchanged") {
+		t.Fatalf("second eval: calls=%d err=%v\n%s", model.calls, err, out)
+	}
+
+	model.score = 60
+	out, err = execQualityEval("review", "--force", "--provider", "ollama", "--model", "m")
+	if err != nil || model.calls != 2 || !strings.Contains(out, "(was 80)") {
+		t.Fatalf("forced eval: calls=%d err=%v\n%s", model.calls, err, out)
+	}
+}
+
+func TestQualityEval_RejectsNonSkills(t *testing.T) {
+	env := NewTestEnv(t)
+	env.SetupPathVault()
+	src := env.MkdirAll(filepath.Join(env.TempDir, "tabs"))
+	env.WriteFile(filepath.Join(src, "RULE.md"), "Use tabs.\n")
+	if out, err := execAdd(src, "--yes", "--no-install"); err != nil {
+		t.Fatalf("add: %v\n%s", err, out)
+	}
+
+	out, err := execQualityEval("tabs", "--provider", "ollama", "--model", "m")
+	if err == nil || !strings.Contains(out, "only rates skills") {
+		t.Fatalf("eval of a rule: err=%v\n%s", err, out)
+	}
+	if _, err := execQualityEval(); err == nil {
+		t.Error("eval with neither a skill nor --all succeeded")
+	}
+}
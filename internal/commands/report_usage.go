@@ -11,10 +11,14 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/sleuth-io/sx/v2/internal/asset"
 	"github.com/sleuth-io/sx/v2/internal/assets"
 	"github.com/sleuth-io/sx/v2/internal/assets/detectors"
+	"github.com/sleuth-io/sx/v2/internal/clients"
 	"github.com/sleuth-io/sx/v2/internal/config"
+	"github.com/sleuth-io/sx/v2/internal/gitutil"
 	"github.com/sleuth-io/sx/v2/internal/logger"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
 	"github.com/sleuth-io/sx/v2/internal/stats"
 	vaultpkg "github.com/sleuth-io/sx/v2/internal/vault"
 )
@@ -34,7 +38,7 @@ and report it to the vault. Intended to be called from Claude Code hooks.`,
 		},
 	}
 
-	cmd.Flags().StringVar(&clientID, "client", "", "Client ID that triggered the hook (informational only)")
+	cmd.Flags().StringVar(&clientID, "client", "", "Client ID that triggered the hook, recorded on the usage event")
 
 	return cmd
 }
@@ -59,6 +63,22 @@ type CodexNotifyEvent struct {
 	LastAssistantMessage string   `json:"last-assistant-message"`
 }
 
+// hookSession holds the session details clients include in their tool
+// hook payloads, each under its own name
+type hookSession struct {
+	SessionID      string `json:"session_id"`      // Claude Code, Gemini
+	ConversationID string `json:"conversation_id"` // Cursor
+	Cwd            string `json:"cwd"`
+}
+
+// id returns the session ID under whichever name the client used
+func (h hookSession) id() string {
+	if h.SessionID != "" {
+		return h.SessionID
+	}
+	return h.ConversationID
+}
+
 // KiroPostToolUseEvent represents the JSON payload from Kiro postToolUse hook
 type KiroPostToolUseEvent struct {
 	ToolName   string `json:"toolName"`
@@ -235,7 +255,10 @@ func runReportUsage(cmd *cobra.Command, args []string) error {
 		AssetVersion: assetVersion,
 		AssetType:    assetType,
 		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+		Client:       clientID,
+		Trigger:      mgmt.UsageTriggerNative,
 	}
+	addHookUsageContext(&usageEvent, data)
 
 	// Enqueue event
 	if err := stats.EnqueueEvent(usageEvent); err != nil {
@@ -260,6 +283,57 @@ func runReportUsage(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// addHookUsageContext fills in the optional usage event fields from the
+// hook payload and the repository the client is working in. Everything
+// here is best-effort: a field that can't be determined stays empty.
+func addHookUsageContext(ev *stats.UsageEvent, data []byte) {
+	var session hookSession
+	_ = json.Unmarshal(data, &session)
+	ev.SessionID = session.id()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	dir := session.Cwd
+	if dir == "" {
+		var err error
+		if dir, err = os.Getwd(); err != nil {
+			return
+		}
+	}
+	gitCtx, err := gitutil.DetectContextForPath(ctx, dir)
+	if err != nil {
+		return
+	}
+	ev.RepoURL = gitCtx.RepoURL
+
+	if ev.AssetType == asset.TypeSkill.Key {
+		ev.ContextTokens = estimateSkillTokens(ctx, ev.Client, ev.AssetName, gitCtx)
+	}
+}
+
+// estimateSkillTokens estimates the size of a skill's instructions as the
+// client loads them, looking in the current scope and then globally.
+// Returns 0 when the client or skill can't be found.
+func estimateSkillTokens(ctx context.Context, clientID, name string, gitCtx *gitutil.GitContext) int {
+	if clientID == "" {
+		return 0
+	}
+	client, err := clients.Global().Get(clientID)
+	if err != nil {
+		return 0
+	}
+	for _, installScope := range []*clients.InstallScope{
+		buildInstallScope(buildScopeFromGitContext(gitCtx), gitCtx),
+		{Type: clients.ScopeGlobal},
+	} {
+		if content, err := client.ReadSkill(ctx, name, installScope); err == nil {
+			return stats.EstimateTokens(content.Content)
+		}
+	}
+	return 0
+}
+
 // flushUsageQueue loads the config, builds a vault, and flushes the on-disk
 // usage queue to the server. It is a package-level var so tests can replace it
 // with a mock that records when (and whether) the flush happened — the goroutine
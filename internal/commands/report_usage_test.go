@@ -6,6 +6,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/stats"
 )
 
 func TestReportUsageCodexFormat(t *testing.T) {
@@ -454,6 +457,44 @@ func TestReportUsageFlushesQueueSynchronously(t *testing.T) {
 	}
 }
 
+func TestReportUsageRecordsHookContext(t *testing.T) {
+	tempCacheDir := t.TempDir()
+	t.Setenv("SX_CACHE_DIR", tempCacheDir)
+	trackerJSON := `{"version":"3","assets":[{"name":"test-skill","version":"1.0.0","clients":["cursor"]}]}`
+	if err := os.WriteFile(filepath.Join(tempCacheDir, "installed.json"), []byte(trackerJSON), 0644); err != nil {
+		t.Fatalf("failed to write fake tracker: %v", err)
+	}
+	origFlush := flushUsageQueue
+	flushUsageQueue = func(ctx context.Context) error { return nil }
+	defer func() { flushUsageQueue = origFlush }()
+
+	cursorJSON := `{"conversation_id":"conv-42","cwd":"` + filepath.ToSlash(t.TempDir()) + `","tool_name":"Skill","tool_input":{"skill":"test-skill"}}`
+	cmd := NewReportUsageCommand()
+	cmd.SetArgs([]string{cursorJSON})
+	cmd.SetErr(&bytes.Buffer{})
+	if err := cmd.Flags().Set("client", "cursor"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("report-usage execution failed: %v", err)
+	}
+
+	events, _, err := stats.DequeueEvents(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 queued event, got %d", len(events))
+	}
+	ev := events[0]
+	if ev.Client != "cursor" || ev.SessionID != "conv-42" || ev.Trigger != mgmt.UsageTriggerNative {
+		t.Errorf("unexpected event context: %+v", ev)
+	}
+	if ev.RepoURL != "" {
+		t.Errorf("expected no repo URL outside a repository, got %q", ev.RepoURL)
+	}
+}
+
 func TestMain(m *testing.M) {
 	// Run tests
 	os.Exit(m.Run())
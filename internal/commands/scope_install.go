@@ -2,12 +2,14 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	vaultpkg "github.com/sleuth-io/sx/v2/internal/vault"
 )
 
 // runInstallSetTarget points an existing asset at one or more scopes. It runs
@@ -24,6 +26,10 @@ func runInstallSetTarget(cmd *cobra.Command, assetName string, flags scopeFlags,
 		return err
 	}
 
+	if flags.Pin != "" && !vaultpkg.SupportsVersionPins(v) {
+		return errors.New("this vault type does not support version pins (--pin); use a git or path vault")
+	}
+
 	// Verify the asset exists in the chosen vault before mutating. In
 	// multi-active setups the asset the user wants to retarget might be
 	// owned by a non-default profile's vault, and writing to the default
@@ -31,6 +31,7 @@ type scopeFlags struct {
 	Users   []string // --user <email>
 	Bots    []string // --bot <name>
 	Replace bool     // --replace-scope (replace the whole scope set instead of appending)
+	Pin     string   // --pin <version> (pin every named target; "latest" unpins)
 }
 
 // hasTarget reports whether any concrete scope target is named (--replace-scope
@@ -68,6 +69,9 @@ func resolveScopeFlags(f scopeFlags) (scopeChange, error) {
 		if len(f.Repos) > 0 || len(f.Paths) > 0 || len(f.Teams) > 0 || len(f.Users) > 0 || len(f.Bots) > 0 {
 			return scopeChange{}, errors.New("--org is exclusive and cannot be combined with other scope targets")
 		}
+		if f.Pin != "" {
+			return scopeChange{}, errors.New("--pin cannot be combined with --org: an org-wide install has no scope row to pin")
+		}
 		// Org is global: it always replaces the whole set with a single
 		// org-wide target, so append-by-default does not apply here.
 		return scopeChange{
@@ -104,6 +108,10 @@ func resolveScopeFlags(f scopeFlags) (scopeChange, error) {
 		return scopeChange{}, errors.New("no scope specified: name at least one of --org, --repo, --path, --team, --user, --bot")
 	}
 
+	for i := range targets {
+		targets[i].Pin = f.Pin
+	}
+
 	mode := scopeAdd
 	if f.Replace {
 		mode = scopeReplace
@@ -13,6 +13,13 @@ import (
 // formatTarget formats a kind-aware install target for display in the scope
 // editor.
 func formatTarget(t vault.InstallTarget) string {
+	if t.Pin != "" {
+		label := formatTarget(vault.InstallTarget{Kind: t.Kind, Repo: t.Repo, Paths: t.Paths, Team: t.Team, User: t.User, Bot: t.Bot})
+		if t.Pin == vault.LatestChannel {
+			return label
+		}
+		return label + " @ " + t.Pin
+	}
 	switch t.Kind {
 	case vault.InstallKindRepo:
 		return t.Repo + " (entire repository)"
@@ -45,9 +52,19 @@ func targetKey(t vault.InstallTarget) string {
 		t.Team,
 		t.User,
 		t.Bot,
+		pinKey(t.Pin),
 	}, "\x1f")
 }
 
+// pinKey folds the "latest" unpin request into the unpinned key, so
+// unpinning an already-unpinned target previews as no change.
+func pinKey(pin string) string {
+	if pin == vault.LatestChannel {
+		return ""
+	}
+	return pin
+}
+
 // scopesToTargets converts the repo/path scopes the rest of the add flow uses
 // into kind-aware install targets for the editor.
 func scopesToTargets(scopes []lockfile.Scope) []vault.InstallTarget {
@@ -2,38 +2,80 @@ package commands
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 
 	"github.com/spf13/cobra"
 
 	"github.com/sleuth-io/sx/v2/internal/clients"
 	mcpserver "github.com/sleuth-io/sx/v2/internal/mcp"
+	"github.com/sleuth-io/sx/v2/internal/ui"
+	"github.com/sleuth-io/sx/v2/internal/utils"
 )
 
+// serveTokenFile is the default bearer token file for `sx serve --http`,
+// in the sx config directory
+const serveTokenFile = "serve-token"
+
+// serveOptions are the flags of `sx serve`
+type serveOptions struct {
+	httpAddr  string
+	tokenFile string
+	tlsCert   string
+	tlsKey    string
+}
+
 // NewServeCommand creates the serve command
 func NewServeCommand() *cobra.Command {
+	var opts serveOptions
 	cmd := &cobra.Command{
 		Use:   "serve",
-		Short: "Start the MCP server over stdio",
+		Short: "Start the MCP server over stdio or HTTP",
 		Long: `Start an MCP (Model Context Protocol) server that exposes tools for AI clients.
 
-The server runs over stdio and provides tools for querying integrated services.
-This enables AI coding assistants to query GitHub, CircleCI, Linear, and other services.
+By default the server runs over stdio, for a client that launches sx itself.
+With --http it serves the streamable HTTP transport at /mcp instead, so a
+team can share one endpoint on an internal host. Every HTTP request must
+carry the bearer token from --token-file (created with a random token if it
+doesn't exist); --tls-cert and --tls-key turn on HTTPS.
 
 Tools provided:
-  - query: Query integrated services using natural language`,
+  - search_skills, read_skill: find and read installed skills
+  - list_rules_for_path: the installed rules that apply to a file
+  - get_asset_file: read a file bundled with an installed asset
+  - query: query integrated services using natural language (Sleuth vault)
+
+Examples:
+
+  sx serve
+  sx serve --http :8765
+  sx serve --http :8443 --tls-cert server.crt --tls-key server.key`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runServe(cmd, args)
+			return runServe(cmd, opts)
 		},
 	}
+	cmd.Flags().StringVar(&opts.httpAddr, "http", "", "Serve over streamable HTTP on this address (e.g. :8765) instead of stdio")
+	cmd.Flags().StringVar(&opts.tokenFile, "token-file", "", "Bearer token file for --http (default: serve-token in the sx config directory)")
+	cmd.Flags().StringVar(&opts.tlsCert, "tls-cert", "", "TLS certificate file for --http")
+	cmd.Flags().StringVar(&opts.tlsKey, "tls-key", "", "TLS private key file for --http")
 
 	return cmd
 }
 
 // runServe executes the serve command
-func runServe(cmd *cobra.Command, args []string) error {
+func runServe(cmd *cobra.Command, opts serveOptions) error {
+	if opts.httpAddr == "" && (opts.tokenFile != "" || opts.tlsCert != "" || opts.tlsKey != "") {
+		return errors.New("--token-file, --tls-cert, and --tls-key only apply with --http")
+	}
+
 	// Create context that cancels on interrupt
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -41,14 +83,95 @@ func runServe(cmd *cobra.Command, args []string) error {
 	// Handle interrupt signals
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
 	go func() {
-		<-sigChan
-		cancel()
+		select {
+		case <-sigChan:
+			cancel()
+		case <-ctx.Done():
+		}
 	}()
 
 	// Create the MCP server with the global client registry
 	server := mcpserver.NewServer(clients.Global())
 
-	// Run the server (blocks until context is cancelled or error)
-	return server.Run(ctx)
+	if opts.httpAddr == "" {
+		// Run the server (blocks until context is cancelled or error)
+		return server.Run(ctx)
+	}
+	return runServeHTTP(ctx, cmd, server, opts)
+}
+
+// runServeHTTP serves over streamable HTTP until ctx is cancelled
+func runServeHTTP(ctx context.Context, cmd *cobra.Command, server *mcpserver.Server, opts serveOptions) error {
+	out := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+
+	tokenPath := opts.tokenFile
+	if tokenPath == "" {
+		dir, err := utils.GetConfigDir()
+		if err != nil {
+			return err
+		}
+		tokenPath = filepath.Join(dir, serveTokenFile)
+	}
+	token, created, err := loadServeToken(tokenPath)
+	if err != nil {
+		return err
+	}
+	if created {
+		out.Info("Created a bearer token in " + tokenPath)
+	}
+
+	scheme := "http"
+	if opts.tlsCert != "" {
+		scheme = "https"
+	}
+	return server.RunHTTP(ctx, mcpserver.HTTPOptions{
+		Addr:     opts.httpAddr,
+		Token:    token,
+		CertFile: opts.tlsCert,
+		KeyFile:  opts.tlsKey,
+	}, func(addr net.Addr) {
+		out.Info(fmt.Sprintf("Serving MCP on %s://%s%s", scheme, addr, mcpserver.HTTPPath))
+		out.Muted("Clients must send: Authorization: Bearer <token from " + tokenPath + ">")
+		if scheme == "http" && !isLoopback(addr) {
+			out.Warning("Serving without TLS on a non-loopback address; the token travels in clear text.")
+		}
+		out.Printf("%s", "Press Ctrl+C to stop.\n")
+	})
+}
+
+// loadServeToken reads the bearer token from path, creating the file with
+// a random token (readable only by the owner) if it doesn't exist.
+func loadServeToken(path string) (token string, created bool, err error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		token = strings.TrimSpace(string(data))
+		if token == "" {
+			return "", false, fmt.Errorf("token file %s is empty", path)
+		}
+		return token, false, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", false, fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	var b [32]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", false, err
+	}
+	token = hex.EncodeToString(b[:])
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", false, fmt.Errorf("failed to create token directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+		return "", false, fmt.Errorf("failed to write token file: %w", err)
+	}
+	return token, true, nil
+}
+
+// isLoopback reports whether addr only accepts local connections
+func isLoopback(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	return ok && tcp.IP.IsLoopback()
 }
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadServeToken_CreatesThenReuses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sx", "serve-token")
+
+	token, created, err := loadServeToken(path)
+	if err != nil || !created || len(token) != 64 {
+		t.Fatalf("first load: token=%q created=%v err=%v", token, created, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("token file mode = %o, want 600", perm)
+	}
+
+	again, created, err := loadServeToken(path)
+	if err != nil || created || again != token {
+		t.Fatalf("second load: token=%q created=%v err=%v", again, created, err)
+	}
+
+	if err := os.WriteFile(path, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := loadServeToken(path); err == nil {
+		t.Error("empty token file accepted")
+	}
+}
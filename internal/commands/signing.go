@@ -0,0 +1,326 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/signing"
+	"github.com/sleuth-io/sx/v2/internal/ui"
+	vaultpkg "github.com/sleuth-io/sx/v2/internal/vault"
+)
+
+// NewSigningCommand returns the `sx signing` command group: publisher keys,
+// the vault's trusted keys and policy, and manual sign/verify
+// (docs/signing.md).
+func NewSigningCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "signing",
+		Short: "Sign published assets and manage trusted keys",
+		Long: `When you have a signing key, 'sx add' signs every version it publishes.
+'sx install' checks each asset against the keys the vault trusts and, per
+the vault's policy, warns about or refuses anything unsigned or tampered
+with.
+
+Examples:
+
+  sx signing keygen                        # create your key, print its public half
+  sx signing trust alice@acme.com RWQ...   # org-admin: trust a publisher's key
+  sx signing policy enforce                # org-admin: refuse unverified assets
+  sx signing verify my-skill`,
+	}
+	cmd.AddCommand(newSigningKeygenCommand())
+	cmd.AddCommand(newSigningTrustCommand())
+	cmd.AddCommand(newSigningUntrustCommand())
+	cmd.AddCommand(newSigningPolicyCommand())
+	cmd.AddCommand(newSigningSignCommand())
+	cmd.AddCommand(newSigningVerifyCommand())
+	return cmd
+}
+
+// loadSigningStore returns the active vault as a SigningStore.
+func loadSigningStore() (vaultpkg.Vault, vaultpkg.SigningStore, error) {
+	v, err := createVault()
+	if err != nil {
+		return nil, nil, err
+	}
+	store, ok := v.(vaultpkg.SigningStore)
+	if !ok {
+		return nil, nil, errors.New("this vault type does not support asset signing")
+	}
+	return v, store, nil
+}
+
+func newSigningKeygenCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "keygen",
+		Short: "Create your signing key",
+		Long: `Create an ed25519 signing key at $SX_SIGNING_KEY or signing.key in the sx
+config directory, and print its public key for an org-admin to trust.
+The key is stored unencrypted in minisign's format, readable only by you.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := signing.KeyPath()
+			if err != nil {
+				return err
+			}
+			key, err := signing.GenerateKey()
+			if err != nil {
+				return err
+			}
+			if err := signing.SaveKey(path, key); err != nil {
+				return fmt.Errorf("failed to save signing key: %w", err)
+			}
+			out := newOutputHelper(cmd)
+			out.printf("✓ Created signing key %s at %s\n", key.Public().ID(), path)
+			out.println()
+			out.println("Public key:")
+			out.printf("  %s\n", key.Public())
+			out.println()
+			out.println("Ask an org-admin to trust it:")
+			out.printf("  sx signing trust <your email> %s\n", key.Public())
+			return nil
+		},
+	}
+}
+
+func newSigningTrustCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "trust <name> <public-key>",
+		Short: "Trust a publisher's public key",
+		Long: `Add a public key to the vault's trusted keys. <name> identifies who holds
+it, usually an email; trusting a new key under an existing name replaces
+the old one. The key is a minisign public key or ed25519:<base64>.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			_, store, err := loadSigningStore()
+			if err != nil {
+				return err
+			}
+			if err := store.TrustSigningKey(ctx, args[0], args[1]); err != nil {
+				return err
+			}
+			newOutputHelper(cmd).printf("✓ Trusted signing key %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newSigningUntrustCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "untrust <name>",
+		Short: "Stop trusting a publisher's key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			_, store, err := loadSigningStore()
+			if err != nil {
+				return err
+			}
+			if err := store.UntrustSigningKey(ctx, args[0]); err != nil {
+				return err
+			}
+			newOutputHelper(cmd).printf("✓ Removed trusted signing key %s\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newSigningPolicyCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "policy [off|warn|enforce]",
+		Short: "Show or set the signature verification policy",
+		Long: `With no argument, show the policy and trusted keys. Otherwise set it:
+
+  off      installs don't check signatures (default)
+  warn     unsigned or unverifiable assets install with a warning
+  enforce  they are not installed`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			_, store, err := loadSigningStore()
+			if err != nil {
+				return err
+			}
+			if len(args) == 1 {
+				if err := store.SetSigningPolicy(ctx, args[0]); err != nil {
+					return err
+				}
+				newOutputHelper(cmd).printf("✓ Signing policy set to %s\n", args[0])
+				return nil
+			}
+			cfg, err := store.SigningConfig(ctx)
+			if err != nil {
+				return err
+			}
+			out := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+			out.KeyValue("Policy", vaultpkg.SigningPolicyOf(cfg))
+			if cfg == nil || len(cfg.TrustedKeys) == 0 {
+				out.Println("No trusted keys.")
+				return nil
+			}
+			out.Newline()
+			out.SubHeader("Trusted keys")
+			for _, tk := range cfg.TrustedKeys {
+				id := "invalid key"
+				if k, err := signing.ParsePublicKey(tk.PublicKey); err == nil {
+					id = k.ID()
+				}
+				out.Println(fmt.Sprintf("  %s %s", out.BoldText(tk.Name), id))
+			}
+			return nil
+		},
+	}
+}
+
+// resolveStoredVersion returns version, or the asset's latest stored
+// version when it is empty.
+func resolveStoredVersion(ctx context.Context, v vaultpkg.Vault, name, version string) (string, error) {
+	if version != "" {
+		return version, nil
+	}
+	versions, err := v.GetVersionList(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if len(versions) == 0 {
+		return "", fmt.Errorf("asset %q has no stored versions", name)
+	}
+	return versions[len(versions)-1], nil
+}
+
+func newSigningSignCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sign <asset> [version]",
+		Short: "Sign a published version with your key",
+		Long: `Sign a version that is already in the vault — one published before you had
+a key, or through a pull request. Defaults to the latest version.`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			v, store, err := loadSigningStore()
+			if err != nil {
+				return err
+			}
+			key, err := signing.LoadKey()
+			if errors.Is(err, signing.ErrNoKey) {
+				return errors.New("no signing key; run 'sx signing keygen' or set " + signing.KeyEnv)
+			}
+			if err != nil {
+				return err
+			}
+			version, err := resolveStoredVersion(ctx, v, args[0], optionalArg(args, 1))
+			if err != nil {
+				return err
+			}
+			sig, err := store.SignAsset(ctx, args[0], version, key)
+			if err != nil {
+				return err
+			}
+			newOutputHelper(cmd).printf("✓ Signed %s@%s with key %s\n", args[0], version, sig.KeyID)
+			return nil
+		},
+	}
+}
+
+func newSigningVerifyCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify <asset> [version]",
+		Short: "Check a version's signature against the trusted keys",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			v, store, err := loadSigningStore()
+			if err != nil {
+				return err
+			}
+			version, err := resolveStoredVersion(ctx, v, args[0], optionalArg(args, 1))
+			if err != nil {
+				return err
+			}
+			cfg, err := store.SigningConfig(ctx)
+			if err != nil {
+				return err
+			}
+			zipData, err := v.GetAssetByVersion(ctx, args[0], version)
+			if err != nil {
+				return err
+			}
+			sig, err := vaultpkg.VerifyAssetSignature(ctx, store, cfg, args[0], version, zipData)
+			if err != nil {
+				return fmt.Errorf("%s@%s: %w", args[0], version, err)
+			}
+			newOutputHelper(cmd).printf("✓ %s@%s signed by %s (key %s, %s) on %s\n",
+				args[0], version, sig.Signer, sig.KeyID, sig.KeyName, sig.SignedAt.Format(time.RFC3339))
+			return nil
+		},
+	}
+}
+
+func optionalArg(args []string, i int) string {
+	if len(args) > i {
+		return args[i]
+	}
+	return ""
+}
+
+// loadPublishingKey returns the key this command signs what it publishes
+// with: nil when the vault doesn't store signatures or the user has no
+// key. A key that exists but can't be loaded fails the command before
+// anything is published, rather than publishing unsigned.
+func loadPublishingKey(v vaultpkg.Vault) (*signing.PrivateKey, error) {
+	if _, ok := v.(vaultpkg.SigningStore); !ok {
+		return nil, nil
+	}
+	key, err := signing.LoadKey()
+	if errors.Is(err, signing.ErrNoKey) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signing key: %w", err)
+	}
+	return key, nil
+}
+
+// addAssetVersion publishes a version, signed in the same write when key
+// is set (see loadPublishingKey). AddAsset's error is returned as is.
+func addAssetVersion(ctx context.Context, v vaultpkg.Vault, lockAsset *lockfile.Asset, zipData []byte, key *signing.PrivateKey) error {
+	if key == nil {
+		return v.AddAsset(ctx, lockAsset, zipData)
+	}
+	_, err := v.(vaultpkg.SigningStore).AddSignedAsset(ctx, lockAsset, zipData, key)
+	return err
+}
+
+// reportPublishSignature tells the user how a version this command just
+// published was signed, and says loudly when it wasn't but the vault's
+// policy means nobody will be able to install it unsigned.
+func reportPublishSignature(ctx context.Context, out *outputHelper, v vaultpkg.Vault, name, version string, key *signing.PrivateKey) {
+	if key != nil {
+		out.printf("✓ Signed %s@%s with key %s\n", name, version, key.Public().ID())
+		return
+	}
+	store, ok := v.(vaultpkg.SigningStore)
+	if !ok {
+		return
+	}
+	if cfg, err := store.SigningConfig(ctx); err == nil && vaultpkg.SigningPolicyOf(cfg) != signing.PolicyOff {
+		out.printf("⚠ %s@%s is unsigned: this vault checks signatures (policy %s) but you have no signing key. Run 'sx signing keygen', have it trusted, then 'sx signing sign %s %s'.\n",
+			name, version, vaultpkg.SigningPolicyOf(cfg), name, version)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -24,6 +25,13 @@ func NewStatsCommand() *cobra.Command {
 	var sinceStr string
 	var limit int
 	var jsonOutput bool
+	var csvOutput bool
+	var filter mgmt.UsageFilter
+	var groupBy string
+	var intervalStr string
+	var compare bool
+	var cohorts bool
+	var staleDays int
 
 	cmd := &cobra.Command{
 		Use:   "stats",
@@ -34,10 +42,62 @@ Without flags, shows the default summary: top assets by usage and per-team
 adoption percentages. Use --assets to see a detailed breakdown per asset, or
 --teams to see adoption figures for every team.
 
-Time range is controlled with --since (7d, 30d, 90d, or all). Default is 30d.`,
+Time range is controlled with --since (7d, 30d, 90d, or all). Default is 30d.
+
+Use --by client, repo or trigger to break usage down by the client that
+loaded the asset, the repository it was used in, or how it was loaded
+(native for the client's own skill loading, mcp for sx's MCP tools), and
+--client, --repo or --trigger to narrow every view. Events recorded before
+sx captured these fields are counted in the totals but not in breakdowns.
+
+Trend views:
+  --interval day|week|month   uses and users per asset and team, per bucket
+  --compare                   each asset against the previous window of the
+                              same length (needs a --since other than all)
+  --cohorts                   per-asset retention: of the users who first
+                              used an asset in a bucket (--interval, default
+                              week), how many came back 1, 2, ... buckets later
+  --stale N                   published assets nobody has used in N days
+
+Every view except the default summary can be written as --json or --csv.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if assetsOnly && teamsOnly {
-				return errors.New("cannot combine --assets and --teams")
+			// --interval sets the cohort bucket size rather than being a
+			// view of its own when combined with --cohorts
+			seriesView := intervalStr != "" && !cohorts
+			views := 0
+			for _, set := range []bool{assetsOnly, teamsOnly, groupBy != "", seriesView, compare, cohorts, staleDays > 0} {
+				if set {
+					views++
+				}
+			}
+			if views > 1 {
+				return errors.New("--assets, --teams, --by, --interval, --compare, --cohorts and --stale cannot be combined")
+			}
+			if groupBy != "" && !slices.Contains(statsGroupings, groupBy) {
+				return fmt.Errorf("invalid --by value %q (use %s)", groupBy, strings.Join(statsGroupings, ", "))
+			}
+			if staleDays < 0 {
+				return errors.New("--stale must be a positive number of days")
+			}
+			if jsonOutput && csvOutput {
+				return errors.New("--json and --csv cannot be combined")
+			}
+			if csvOutput && views == 0 {
+				return errors.New("--csv needs a table view: --assets, --teams, --by, --interval, --compare, --cohorts or --stale")
+			}
+			format := statsFormatText
+			switch {
+			case jsonOutput:
+				format = statsFormatJSON
+			case csvOutput:
+				format = statsFormatCSV
+			}
+			interval := mgmt.IntervalWeek
+			if intervalStr != "" {
+				var err error
+				if interval, err = mgmt.ParseUsageInterval(intervalStr); err != nil {
+					return err
+				}
 			}
 
 			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
@@ -53,7 +113,19 @@ Time range is controlled with --since (7d, 30d, 90d, or all). Default is 30d.`,
 				return err
 			}
 
-			filter := mgmt.UsageFilter{Since: since}
+			view := statsView{filter: filter, since: since, now: time.Now().UTC(), interval: interval, limit: limit, format: format}
+			switch {
+			case seriesView:
+				return runStatsSeries(ctx, cmd, v, view)
+			case compare:
+				return runStatsCompare(ctx, cmd, v, view)
+			case cohorts:
+				return runStatsCohorts(ctx, cmd, v, view)
+			case staleDays > 0:
+				return runStatsStale(ctx, cmd, v, view, staleDays)
+			}
+
+			filter.Since = since
 			summary, err := v.GetUsageStats(ctx, filter)
 			if err != nil {
 				return err
@@ -69,8 +141,11 @@ Time range is controlled with --since (7d, 30d, 90d, or all). Default is 30d.`,
 
 			report := buildStatsReport(summary, teamResult.Teams, since, limit)
 
-			if jsonOutput {
-				return emitStatsJSON(cmd, report, assetsOnly, teamsOnly)
+			switch format {
+			case statsFormatJSON:
+				return emitStatsJSON(cmd, report, assetsOnly, teamsOnly, groupBy)
+			case statsFormatCSV:
+				return emitStatsCSV(cmd, report, assetsOnly, teamsOnly, groupBy)
 			}
 
 			out := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
@@ -79,6 +154,8 @@ Time range is controlled with --since (7d, 30d, 90d, or all). Default is 30d.`,
 				printStatsAssets(out, report)
 			case teamsOnly:
 				printStatsTeams(out, report)
+			case groupBy != "":
+				printStatsGroups(out, groupBy, report.groups(groupBy))
 			default:
 				printStatsSummary(out, report)
 			}
@@ -91,9 +168,22 @@ Time range is controlled with --since (7d, 30d, 90d, or all). Default is 30d.`,
 	cmd.Flags().StringVar(&sinceStr, "since", "30d", "Time range (7d, 30d, 90d, all)")
 	cmd.Flags().IntVar(&limit, "limit", 10, "Maximum number of rows per section")
 	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
+	cmd.Flags().StringVar(&groupBy, "by", "", "Break usage down by client, repo, or trigger")
+	cmd.Flags().StringVar(&filter.Client, "client", "", "Only count usage from this client")
+	cmd.Flags().StringVar(&filter.RepoURL, "repo", "", "Only count usage in this repository URL")
+	cmd.Flags().StringVar(&filter.Trigger, "trigger", "", "Only count usage with this trigger (native or mcp)")
+	cmd.Flags().StringVar(&filter.AssetName, "asset", "", "Only count usage of this asset")
+	cmd.Flags().BoolVar(&csvOutput, "csv", false, "Output in CSV format")
+	cmd.Flags().StringVar(&intervalStr, "interval", "", "Show usage per day, week, or month")
+	cmd.Flags().BoolVar(&compare, "compare", false, "Compare each asset's usage with the previous window")
+	cmd.Flags().BoolVar(&cohorts, "cohorts", false, "Show per-asset retention cohorts")
+	cmd.Flags().IntVar(&staleDays, "stale", 0, "List published assets unused for this many days")
 	return cmd
 }
 
+// statsGroupings are the values --by accepts
+var statsGroupings = []string{"client", "repo", "trigger"}
+
 // parseSinceFlag converts strings like "7d", "30d", "90d", "all" into an
 // absolute time. Returns a zero time for "all" (meaning no lower bound).
 func parseSinceFlag(s string) (time.Time, error) {
@@ -124,6 +214,22 @@ type statsReport struct {
 	Assets    []mgmt.AssetUsageCount `json:"assets"`
 	TeamStats []teamAdoption         `json:"teams"`
 	TopActors []mgmt.ActorUsageCount `json:"top_actors"`
+	Clients   []mgmt.UsageGroupCount `json:"clients,omitempty"`
+	Repos     []mgmt.UsageGroupCount `json:"repos,omitempty"`
+	Triggers  []mgmt.UsageGroupCount `json:"triggers,omitempty"`
+}
+
+// groups returns the breakdown for a --by value
+func (r *statsReport) groups(by string) []mgmt.UsageGroupCount {
+	switch by {
+	case "client":
+		return r.Clients
+	case "repo":
+		return r.Repos
+	case "trigger":
+		return r.Triggers
+	}
+	return nil
 }
 
 // teamAdoption is the per-team rollup: for each team, how many of its
@@ -142,6 +248,9 @@ func buildStatsReport(summary *mgmt.UsageSummary, teams []mgmt.Team, since time.
 		Total:     summary.TotalEvents,
 		Assets:    summary.PerAsset,
 		TopActors: summary.PerActor,
+		Clients:   summary.PerClient,
+		Repos:     summary.PerRepo,
+		Triggers:  summary.PerTrigger,
 	}
 	if !since.IsZero() {
 		s := since
@@ -153,6 +262,9 @@ func buildStatsReport(summary *mgmt.UsageSummary, teams []mgmt.Team, since time.
 	if limit > 0 && len(report.TopActors) > limit {
 		report.TopActors = report.TopActors[:limit]
 	}
+	if limit > 0 && len(report.Repos) > limit {
+		report.Repos = report.Repos[:limit]
+	}
 
 	activeByActor := make(map[string]struct{}, len(summary.PerActor))
 	for _, a := range summary.PerActor {
@@ -203,6 +315,14 @@ func printStatsSummary(out *ui.Output, report *statsReport) {
 		out.Newline()
 	}
 
+	if len(report.Clients) > 0 {
+		out.Bold("Clients")
+		for _, g := range report.Clients {
+			out.Println(fmt.Sprintf("  %s %s", out.EmphasisText(g.Key), out.MutedText(formatGroupUsage(g))))
+		}
+		out.Newline()
+	}
+
 	if len(report.TeamStats) > 0 {
 		out.Bold("Team adoption")
 		for _, t := range report.TeamStats {
@@ -215,6 +335,29 @@ func printStatsSummary(out *ui.Output, report *statsReport) {
 	}
 }
 
+// printStatsGroups prints the --by breakdown
+func printStatsGroups(out *ui.Output, by string, groups []mgmt.UsageGroupCount) {
+	out.Newline()
+	out.Header("Usage by " + by)
+	out.Newline()
+	if len(groups) == 0 {
+		out.Muted(fmt.Sprintf("No usage events record a %s.", by))
+		return
+	}
+	for _, g := range groups {
+		out.Println(fmt.Sprintf("  %s %s", out.BoldText(g.Key), out.MutedText(formatGroupUsage(g))))
+	}
+	out.Newline()
+}
+
+func formatGroupUsage(g mgmt.UsageGroupCount) string {
+	text := fmt.Sprintf("%d uses · %d users", g.TotalUses, g.UniqueActors)
+	if g.ContextTokens > 0 {
+		text += fmt.Sprintf(" · ~%d tokens", g.ContextTokens)
+	}
+	return text
+}
+
 func printStatsAssets(out *ui.Output, report *statsReport) {
 	out.Newline()
 	out.Header("Asset usage")
@@ -227,8 +370,14 @@ func printStatsAssets(out *ui.Output, report *statsReport) {
 		out.Println(fmt.Sprintf("  %s %s",
 			out.BoldText(a.AssetName),
 			out.MutedText(fmt.Sprintf("[%s]", a.AssetType))))
-		out.Muted(fmt.Sprintf("    %d uses · %d users · last %s",
-			a.TotalUses, a.UniqueActors, relativeTime(a.LastUsed)))
+		detail := fmt.Sprintf("    %d uses · %d users", a.TotalUses, a.UniqueActors)
+		if a.UniqueSessions > 0 {
+			detail += fmt.Sprintf(" · %d sessions", a.UniqueSessions)
+		}
+		if a.ContextTokens > 0 {
+			detail += fmt.Sprintf(" · ~%d tokens", a.ContextTokens)
+		}
+		out.Muted(detail + " · last " + relativeTime(a.LastUsed))
 	}
 	out.Newline()
 }
@@ -250,13 +399,15 @@ func printStatsTeams(out *ui.Output, report *statsReport) {
 	out.Newline()
 }
 
-func emitStatsJSON(cmd *cobra.Command, report *statsReport, assetsOnly, teamsOnly bool) error {
+func emitStatsJSON(cmd *cobra.Command, report *statsReport, assetsOnly, teamsOnly bool, groupBy string) error {
 	var payload any = report
 	switch {
 	case assetsOnly:
 		payload = report.Assets
 	case teamsOnly:
 		payload = report.TeamStats
+	case groupBy != "":
+		payload = report.groups(groupBy)
 	}
 	data, err := json.MarshalIndent(payload, "", "  ")
 	if err != nil {
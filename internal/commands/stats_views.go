@@ -0,0 +1,415 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/ui"
+	"github.com/sleuth-io/sx/v2/internal/vault"
+)
+
+// statsFormat is how `sx stats` writes a view
+type statsFormat int
+
+const (
+	statsFormatText statsFormat = iota
+	statsFormatJSON
+	statsFormatCSV
+)
+
+// csvDate is how CSV output writes bucket and cohort starts
+const csvDate = "2006-01-02"
+
+// statsView carries the flags shared by the trend views
+type statsView struct {
+	filter   mgmt.UsageFilter
+	since    time.Time // zero for all time
+	now      time.Time
+	interval mgmt.UsageInterval
+	limit    int
+	format   statsFormat
+}
+
+// statsSeries is the --interval view
+type statsSeries struct {
+	Interval mgmt.UsageInterval      `json:"interval"`
+	Since    *time.Time              `json:"since,omitempty"`
+	Buckets  []time.Time             `json:"buckets"`
+	Points   []mgmt.UsageSeriesPoint `json:"points"`
+}
+
+// statsComparison is the --compare view
+type statsComparison struct {
+	Since         time.Time          `json:"since"`
+	PreviousSince time.Time          `json:"previous_since"`
+	Total         int                `json:"total_events"`
+	PreviousTotal int                `json:"previous_total_events"`
+	Assets        []mgmt.UsageChange `json:"assets"`
+}
+
+// statsCohorts is the --cohorts view
+type statsCohorts struct {
+	Interval mgmt.UsageInterval `json:"interval"`
+	Since    *time.Time         `json:"since,omitempty"`
+	Cohorts  []mgmt.UsageCohort `json:"cohorts"`
+}
+
+// staleAsset is a published asset nobody has used in the --stale window
+type staleAsset struct {
+	Name          string     `json:"name"`
+	Type          string     `json:"type"`
+	LatestVersion string     `json:"latest_version"`
+	LastUsed      *time.Time `json:"last_used,omitempty"`
+}
+
+// statsStale is the --stale view
+type statsStale struct {
+	Days   int          `json:"days"`
+	Assets []staleAsset `json:"assets"`
+}
+
+func (v statsView) sincePtr() *time.Time {
+	if v.since.IsZero() {
+		return nil
+	}
+	s := v.since
+	return &s
+}
+
+// readEvents reads the raw events for the view's filter from since
+func (v statsView) readEvents(ctx context.Context, repo vault.Vault, since time.Time) ([]mgmt.UsageEvent, error) {
+	filter := v.filter
+	filter.Since = since
+	return repo.ReadUsageEvents(ctx, filter)
+}
+
+func runStatsSeries(ctx context.Context, cmd *cobra.Command, repo vault.Vault, view statsView) error {
+	events, err := view.readEvents(ctx, repo, view.since)
+	if err != nil {
+		return err
+	}
+	teamResult, err := repo.ListTeams(ctx, vault.ListTeamsOptions{Limit: 300})
+	if err != nil {
+		return err
+	}
+
+	from := view.since
+	if from.IsZero() {
+		from = view.now
+		for _, ev := range events {
+			if ev.Timestamp.Before(from) {
+				from = ev.Timestamp
+			}
+		}
+	}
+	series := &statsSeries{
+		Interval: view.interval,
+		Since:    view.sincePtr(),
+		Buckets:  view.interval.Buckets(from, view.now),
+		Points:   mgmt.UsageSeries(events, view.interval, teamResult.Teams),
+	}
+
+	w := cmd.OutOrStdout()
+	switch view.format {
+	case statsFormatJSON:
+		return writeStatsJSON(w, series)
+	case statsFormatCSV:
+		rows := [][]string{{"bucket", "kind", "name", "uses", "users"}}
+		for _, p := range series.Points {
+			rows = append(rows, []string{p.Bucket.Format(csvDate), p.Kind, p.Name, strconv.Itoa(p.Uses), strconv.Itoa(p.Users)})
+		}
+		return writeStatsCSV(w, rows)
+	}
+
+	out := ui.NewOutput(w, cmd.ErrOrStderr())
+	out.Newline()
+	out.Header(fmt.Sprintf("Usage per %s", series.Interval))
+	out.Newline()
+	if len(series.Points) == 0 {
+		out.Muted("No usage events recorded.")
+		return nil
+	}
+	i := 0
+	for _, bucket := range series.Buckets {
+		out.Bold(bucket.Format(csvDate))
+		if i == len(series.Points) || !series.Points[i].Bucket.Equal(bucket) {
+			out.Muted("  no usage")
+		}
+		for ; i < len(series.Points) && series.Points[i].Bucket.Equal(bucket); i++ {
+			p := series.Points[i]
+			name := p.Name
+			if p.Kind == mgmt.SeriesKindTeam {
+				name = "team " + name
+			}
+			out.Println(fmt.Sprintf("  %s %s", out.EmphasisText(name), out.MutedText(fmt.Sprintf("%d uses · %d users", p.Uses, p.Users))))
+		}
+	}
+	out.Newline()
+	return nil
+}
+
+func runStatsCompare(ctx context.Context, cmd *cobra.Command, repo vault.Vault, view statsView) error {
+	if view.since.IsZero() {
+		return fmt.Errorf("--compare needs a bounded --since window, such as 30d")
+	}
+	previousSince := view.since.Add(-view.now.Sub(view.since))
+	events, err := view.readEvents(ctx, repo, previousSince)
+	if err != nil {
+		return err
+	}
+	var current, previous []mgmt.UsageEvent
+	for _, ev := range events {
+		if ev.Timestamp.Before(view.since) {
+			previous = append(previous, ev)
+		} else {
+			current = append(current, ev)
+		}
+	}
+	cmp := &statsComparison{
+		Since:         view.since,
+		PreviousSince: previousSince,
+		Total:         len(current),
+		PreviousTotal: len(previous),
+		Assets:        mgmt.CompareUsage(current, previous),
+	}
+	if view.limit > 0 && len(cmp.Assets) > view.limit {
+		cmp.Assets = cmp.Assets[:view.limit]
+	}
+
+	w := cmd.OutOrStdout()
+	switch view.format {
+	case statsFormatJSON:
+		return writeStatsJSON(w, cmp)
+	case statsFormatCSV:
+		rows := [][]string{{"asset_name", "asset_type", "uses", "previous_uses", "users", "previous_users", "change_pct"}}
+		for _, a := range cmp.Assets {
+			pct := ""
+			if a.ChangePct != nil {
+				pct = strconv.FormatFloat(*a.ChangePct, 'f', 1, 64)
+			}
+			rows = append(rows, []string{a.AssetName, a.AssetType, strconv.Itoa(a.Uses), strconv.Itoa(a.PreviousUses),
+				strconv.Itoa(a.Users), strconv.Itoa(a.PreviousUsers), pct})
+		}
+		return writeStatsCSV(w, rows)
+	}
+
+	out := ui.NewOutput(w, cmd.ErrOrStderr())
+	out.Newline()
+	out.Header("Usage compared with the previous window")
+	out.Muted(fmt.Sprintf("Since %s, against %s to %s (UTC)",
+		cmp.Since.Format(csvDate), cmp.PreviousSince.Format(csvDate), cmp.Since.Format(csvDate)))
+	out.Newline()
+	out.KeyValue("Total events", fmt.Sprintf("%d (was %d)", cmp.Total, cmp.PreviousTotal))
+	out.Newline()
+	for _, a := range cmp.Assets {
+		out.Println(fmt.Sprintf("  %s %s %s",
+			out.BoldText(a.AssetName),
+			out.MutedText(fmt.Sprintf("%d uses (was %d) · %d users (was %d)", a.Uses, a.PreviousUses, a.Users, a.PreviousUsers)),
+			formatUsageChange(a)))
+	}
+	out.Newline()
+	return nil
+}
+
+// formatUsageChange renders an asset's change as +40%, -100% or new
+func formatUsageChange(a mgmt.UsageChange) string {
+	if a.ChangePct == nil {
+		return "new"
+	}
+	return fmt.Sprintf("%+.0f%%", *a.ChangePct)
+}
+
+func runStatsCohorts(ctx context.Context, cmd *cobra.Command, repo vault.Vault, view statsView) error {
+	events, err := view.readEvents(ctx, repo, view.since)
+	if err != nil {
+		return err
+	}
+	result := &statsCohorts{
+		Interval: view.interval,
+		Since:    view.sincePtr(),
+		Cohorts:  mgmt.UsageCohorts(events, view.interval, view.now),
+	}
+
+	w := cmd.OutOrStdout()
+	switch view.format {
+	case statsFormatJSON:
+		return writeStatsJSON(w, result)
+	case statsFormatCSV:
+		rows := [][]string{{"asset_name", "start", "users", "offset", "retained"}}
+		for _, c := range result.Cohorts {
+			for offset, n := range c.Retained {
+				rows = append(rows, []string{c.AssetName, c.Start.Format(csvDate), strconv.Itoa(c.Users), strconv.Itoa(offset), strconv.Itoa(n)})
+			}
+		}
+		return writeStatsCSV(w, rows)
+	}
+
+	out := ui.NewOutput(w, cmd.ErrOrStderr())
+	out.Newline()
+	out.Header(fmt.Sprintf("Retention by %s of first use", result.Interval))
+	out.Muted(fmt.Sprintf("Share of each cohort that used the asset again 1, 2, ... %ss later", result.Interval))
+	out.Newline()
+	if len(result.Cohorts) == 0 {
+		out.Muted("No usage events recorded.")
+		return nil
+	}
+	asset := ""
+	for _, c := range result.Cohorts {
+		if c.AssetName != asset {
+			if asset != "" {
+				out.Newline()
+			}
+			asset = c.AssetName
+			out.Bold(asset)
+		}
+		var cells []string
+		for _, n := range c.Retained[1:] {
+			cells = append(cells, fmt.Sprintf("%3.0f%%", 100*float64(n)/float64(c.Users)))
+		}
+		out.Println(fmt.Sprintf("  %s %s %s",
+			c.Start.Format(csvDate),
+			out.MutedText(fmt.Sprintf("%3d users", c.Users)),
+			strings.Join(cells, " ")))
+	}
+	out.Newline()
+	return nil
+}
+
+func runStatsStale(ctx context.Context, cmd *cobra.Command, repo vault.Vault, view statsView, days int) error {
+	// Read all-time usage so stale assets show when they were last used
+	events, err := view.readEvents(ctx, repo, time.Time{})
+	if err != nil {
+		return err
+	}
+	list, err := repo.ListAssets(ctx, vault.ListAssetsOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list assets: %w", err)
+	}
+	result := &statsStale{Days: days, Assets: findStaleAssets(list.Assets, events, view.now.Add(-time.Duration(days)*24*time.Hour))}
+
+	w := cmd.OutOrStdout()
+	switch view.format {
+	case statsFormatJSON:
+		return writeStatsJSON(w, result)
+	case statsFormatCSV:
+		rows := [][]string{{"name", "type", "latest_version", "last_used"}}
+		for _, a := range result.Assets {
+			lastUsed := ""
+			if a.LastUsed != nil {
+				lastUsed = a.LastUsed.Format(time.RFC3339)
+			}
+			rows = append(rows, []string{a.Name, a.Type, a.LatestVersion, lastUsed})
+		}
+		return writeStatsCSV(w, rows)
+	}
+
+	out := ui.NewOutput(w, cmd.ErrOrStderr())
+	out.Newline()
+	out.Header(fmt.Sprintf("Assets unused for %d days", days))
+	out.Newline()
+	if len(result.Assets) == 0 {
+		out.Muted("Every published asset was used in that time.")
+		return nil
+	}
+	for _, a := range result.Assets {
+		last := "never used"
+		if a.LastUsed != nil {
+			last = "last used " + relativeTime(*a.LastUsed)
+		}
+		out.Println(fmt.Sprintf("  %s %s %s", out.BoldText(a.Name), out.MutedText(fmt.Sprintf("[%s]", a.Type)), out.MutedText(last)))
+	}
+	out.Newline()
+	return nil
+}
+
+// findStaleAssets returns the published assets with no use since cutoff,
+// least recently used first. An asset that was never used only counts once
+// it has been published for the whole window.
+func findStaleAssets(published []vault.AssetSummary, events []mgmt.UsageEvent, cutoff time.Time) []staleAsset {
+	lastUsed := make(map[string]time.Time)
+	for _, ev := range events {
+		if ev.Timestamp.After(lastUsed[ev.AssetName]) {
+			lastUsed[ev.AssetName] = ev.Timestamp
+		}
+	}
+
+	var stale []staleAsset
+	for _, a := range published {
+		last, used := lastUsed[a.Name]
+		if used && !last.Before(cutoff) {
+			continue
+		}
+		if !used && a.CreatedAt.After(cutoff) {
+			continue
+		}
+		sa := staleAsset{Name: a.Name, Type: a.Type.Key, LatestVersion: a.LatestVersion}
+		if used {
+			sa.LastUsed = &last
+		}
+		stale = append(stale, sa)
+	}
+	// Never used first, then oldest last use
+	sort.Slice(stale, func(i, j int) bool {
+		a, b := stale[i], stale[j]
+		switch {
+		case (a.LastUsed == nil) != (b.LastUsed == nil):
+			return a.LastUsed == nil
+		case a.LastUsed != nil && !a.LastUsed.Equal(*b.LastUsed):
+			return a.LastUsed.Before(*b.LastUsed)
+		}
+		return a.Name < b.Name
+	})
+	return stale
+}
+
+// emitStatsCSV writes the --assets, --teams or --by view as CSV
+func emitStatsCSV(cmd *cobra.Command, report *statsReport, assetsOnly, teamsOnly bool, groupBy string) error {
+	var rows [][]string
+	switch {
+	case assetsOnly:
+		rows = [][]string{{"asset_name", "asset_type", "uses", "users", "sessions", "context_tokens", "last_used"}}
+		for _, a := range report.Assets {
+			rows = append(rows, []string{a.AssetName, a.AssetType, strconv.Itoa(a.TotalUses), strconv.Itoa(a.UniqueActors),
+				strconv.Itoa(a.UniqueSessions), strconv.Itoa(a.ContextTokens), a.LastUsed.Format(time.RFC3339)})
+		}
+	case teamsOnly:
+		rows = [][]string{{"name", "member_count", "active_members", "adoption_pct"}}
+		for _, t := range report.TeamStats {
+			rows = append(rows, []string{t.Name, strconv.Itoa(t.MemberCount), strconv.Itoa(t.ActiveMembers),
+				strconv.FormatFloat(t.AdoptionPct, 'f', 1, 64)})
+		}
+	default:
+		rows = [][]string{{groupBy, "uses", "users", "context_tokens"}}
+		for _, g := range report.groups(groupBy) {
+			rows = append(rows, []string{g.Key, strconv.Itoa(g.TotalUses), strconv.Itoa(g.UniqueActors), strconv.Itoa(g.ContextTokens)})
+		}
+	}
+	return writeStatsCSV(cmd.OutOrStdout(), rows)
+}
+
+func writeStatsJSON(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+func writeStatsCSV(w io.Writer, rows [][]string) error {
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/vault"
+)
+
+func runStats(t *testing.T, args ...string) string {
+	t.Helper()
+	var stdout bytes.Buffer
+	cmd := NewStatsCommand()
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("sx stats %v: %v", args, err)
+	}
+	return stdout.String()
+}
+
+func TestStatsTrendViews(t *testing.T) {
+	_, vaultDir := seedScopeVault(t)
+	now := time.Now().UTC()
+	events := []mgmt.UsageEvent{
+		{Timestamp: now.Add(-time.Hour), Actor: "alice@example.com", AssetName: "my-skill", AssetType: "skill"},
+		{Timestamp: now.Add(-2 * time.Hour), Actor: "bob@example.com", AssetName: "my-skill", AssetType: "skill"},
+		{Timestamp: now.AddDate(0, 0, -10), Actor: "alice@example.com", AssetName: "my-skill", AssetType: "skill"},
+	}
+	if err := mgmt.AppendUsageEvents(vaultDir, events); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(runStats(t, "--interval", "day", "--since", "30d", "--csv"))).ReadAll()
+	if err != nil {
+		t.Fatalf("series CSV: %v", err)
+	}
+	if strings.Join(rows[0], ",") != "bucket,kind,name,uses,users" || len(rows) < 3 {
+		t.Errorf("unexpected series CSV: %v", rows)
+	}
+
+	var cmp statsComparison
+	if err := json.Unmarshal([]byte(runStats(t, "--compare", "--since", "7d", "--json")), &cmp); err != nil {
+		t.Fatalf("compare JSON: %v", err)
+	}
+	if cmp.Total != 2 || cmp.PreviousTotal != 1 || len(cmp.Assets) != 1 || *cmp.Assets[0].ChangePct != 100 {
+		t.Errorf("unexpected comparison: %+v", cmp)
+	}
+
+	var cohorts statsCohorts
+	if err := json.Unmarshal([]byte(runStats(t, "--cohorts", "--since", "30d", "--json")), &cohorts); err != nil {
+		t.Fatalf("cohorts JSON: %v", err)
+	}
+	if len(cohorts.Cohorts) == 0 || cohorts.Interval != mgmt.IntervalWeek {
+		t.Errorf("unexpected cohorts: %+v", cohorts)
+	}
+
+	var stale statsStale
+	if err := json.Unmarshal([]byte(runStats(t, "--stale", "5", "--json")), &stale); err != nil {
+		t.Fatalf("stale JSON: %v", err)
+	}
+	if len(stale.Assets) != 0 {
+		t.Errorf("my-skill was used today and shouldn't be stale: %+v", stale.Assets)
+	}
+}
+
+func TestStatsFlagValidation(t *testing.T) {
+	for _, args := range [][]string{
+		{"--interval", "day", "--compare"},
+		{"--interval", "fortnight"},
+		{"--json", "--csv", "--assets"},
+		{"--csv"},
+		{"--stale", "-1"},
+	} {
+		cmd := NewStatsCommand()
+		cmd.SetArgs(args)
+		cmd.SilenceErrors = true
+		cmd.SilenceUsage = true
+		cmd.SetOut(&bytes.Buffer{})
+		if err := cmd.Execute(); err == nil {
+			t.Errorf("expected an error for %v", args)
+		}
+	}
+}
+
+func TestFindStaleAssets(t *testing.T) {
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	cutoff := now.AddDate(0, 0, -30)
+	published := []vault.AssetSummary{
+		{Name: "busy", Type: asset.TypeSkill, CreatedAt: now.AddDate(-1, 0, 0)},
+		{Name: "idle", Type: asset.TypeSkill, CreatedAt: now.AddDate(-1, 0, 0)},
+		{Name: "never", Type: asset.TypeRule, CreatedAt: now.AddDate(-1, 0, 0)},
+		{Name: "fresh", Type: asset.TypeRule, CreatedAt: now.AddDate(0, 0, -2)},
+	}
+	events := []mgmt.UsageEvent{
+		{Timestamp: now.AddDate(0, 0, -1), AssetName: "busy"},
+		{Timestamp: now.AddDate(0, 0, -90), AssetName: "idle"},
+	}
+	stale := findStaleAssets(published, events, cutoff)
+	if len(stale) != 2 || stale[0].Name != "never" || stale[0].LastUsed != nil || stale[1].Name != "idle" || stale[1].LastUsed == nil {
+		t.Errorf("unexpected stale assets: %+v", stale)
+	}
+}
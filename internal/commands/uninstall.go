@@ -18,6 +18,7 @@ import (
 	"github.com/sleuth-io/sx/v2/internal/clients"
 	"github.com/sleuth-io/sx/v2/internal/config"
 	"github.com/sleuth-io/sx/v2/internal/gitutil"
+	"github.com/sleuth-io/sx/v2/internal/handlers/section"
 	"github.com/sleuth-io/sx/v2/internal/lockfile"
 	"github.com/sleuth-io/sx/v2/internal/logger"
 	"github.com/sleuth-io/sx/v2/internal/ui"
@@ -68,6 +69,7 @@ func NewUninstallCommand() *cobra.Command {
 	var yes bool
 	var dryRun bool
 	var verbose bool
+	var force bool
 	var clientsFlag string
 
 	cmd := &cobra.Command{
@@ -80,6 +82,7 @@ func NewUninstallCommand() *cobra.Command {
 				Yes:         yes,
 				DryRun:      dryRun,
 				Verbose:     verbose,
+				Force:       force,
 				ClientsFlag: clientsFlag,
 			}
 			return runUninstall(cmd, args, opts)
@@ -90,6 +93,7 @@ func NewUninstallCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&yes, "yes", false, "Skip confirmation prompt")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be uninstalled without removing")
 	cmd.Flags().BoolVar(&verbose, "verbose", false, "Verbose output")
+	cmd.Flags().BoolVar(&force, "force", false, "Remove sx-managed sections of instruction files even if they were edited by hand")
 	cmd.Flags().StringVar(&clientsFlag, "clients", "", "Comma-separated client IDs to uninstall from (e.g., 'claude-code,cursor')")
 
 	return cmd
@@ -101,6 +105,7 @@ type UninstallOptions struct {
 	Yes         bool
 	DryRun      bool
 	Verbose     bool
+	Force       bool
 	ClientsFlag string
 }
 
@@ -229,13 +234,15 @@ func runUninstall(cmd *cobra.Command, args []string, opts UninstallOptions) erro
 		return nil
 	}
 
-	// Step 7: Execute uninstall
+	// Step 7: Execute uninstall. The tracker holds what sx last wrote to
+	// managed sections of instruction files, so hand-edited ones are kept.
 	styledOut.Newline()
 	styledOut.Header("Uninstalling assets...")
+	ctx = section.WithOptions(ctx, section.Options{Store: tracker, Force: opts.Force})
 	results := executeUninstall(ctx, plan, opts, styledOut)
 
 	// Step 8: Update tracker
-	if err := updateTracker(results, plan, out); err != nil {
+	if err := updateTracker(results, plan, tracker.Sections, out); err != nil {
 		out.printfErr("Warning: failed to update tracker: %v\n", err)
 		logger.Get().Error("failed to update tracker", "error", err)
 	}
@@ -578,7 +585,7 @@ func buildScopeForAsset(assetPlan AssetUninstallPlan, gitContext *gitutil.GitCon
 
 // updateTracker removes successfully uninstalled clients from tracker
 // If an asset has no remaining clients, the asset is fully removed
-func updateTracker(results []UninstallResult, plan UninstallPlan, out *outputHelper) error {
+func updateTracker(results []UninstallResult, plan UninstallPlan, sections []assets.ManagedSection, out *outputHelper) error {
 	status := components.NewStatus(out.cmd.OutOrStdout())
 
 	// Build a map of successful removals: assetKey -> set of removed clients
@@ -636,6 +643,7 @@ func updateTracker(results []UninstallResult, plan UninstallPlan, out *outputHel
 	for _, key := range keysToRemove {
 		tracker.RemoveAsset(key)
 	}
+	tracker.Sections = sections
 
 	if len(tracker.Assets) == 0 {
 		err = assets.DeleteTracker()
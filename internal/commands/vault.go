@@ -36,6 +36,7 @@ func NewVaultCommand() *cobra.Command {
 	cmd.AddCommand(newVaultRenameCommand())
 	cmd.AddCommand(newVaultCopyCommand())
 	cmd.AddCommand(newVaultMigrateCommand())
+	cmd.AddCommand(newVaultExportStaticCommand())
 
 	return cmd
 }
@@ -202,6 +203,12 @@ func describeVaultSource(cfg *config.Config) string {
 		return fmt.Sprintf("Git repository (%s)", cfg.RepositoryURL)
 	case config.RepositoryTypePath:
 		return fmt.Sprintf("Local path (%s)", cfg.RepositoryURL)
+	case config.RepositoryTypeS3:
+		return fmt.Sprintf("S3 bucket (%s)", cfg.RepositoryURL)
+	case config.RepositoryTypeOCI:
+		return fmt.Sprintf("OCI registry (%s)", cfg.RepositoryURL)
+	case config.RepositoryTypeHTTP:
+		return fmt.Sprintf("Static site (%s)", cfg.RepositoryURL)
 	default:
 		return string(cfg.Type)
 	}
@@ -589,6 +596,14 @@ func printVaultShowText(out *outputHelper, details *vaultpkg.AssetDetails, insta
 		ui.KeyValue("Total Versions", strconv.Itoa(len(details.Versions)))
 		ui.Newline()
 
+		if behind := pinnedBehindLatest(currentTargets, latestVersion); len(behind) > 0 {
+			ui.Bold("Pinned behind latest")
+			for _, t := range behind {
+				ui.ListItem("•", fmt.Sprintf("%s → v%s", formatTarget(vaultpkg.InstallTarget{Kind: t.Kind, Repo: t.Repo, Paths: t.Paths, Team: t.Team, User: t.User, Bot: t.Bot}), t.Pin))
+			}
+			ui.Newline()
+		}
+
 		ui.Bold("Versions")
 		// Display in descending order (newest first) for readability
 		for _, v := range slices.Backward(details.Versions) {
@@ -620,6 +635,18 @@ func printVaultShowText(out *outputHelper, details *vaultpkg.AssetDetails, insta
 	return nil
 }
 
+// pinnedBehindLatest returns the targets pinned to a version other than
+// latest, i.e. the scopes that will not pick up the newest publish.
+func pinnedBehindLatest(targets []vaultpkg.InstallTarget, latest string) []vaultpkg.InstallTarget {
+	var behind []vaultpkg.InstallTarget
+	for _, t := range targets {
+		if t.Pin != "" && t.Pin != latest {
+			behind = append(behind, t)
+		}
+	}
+	return behind
+}
+
 func printVaultShowJSON(out *outputHelper, details *vaultpkg.AssetDetails, installed bool, currentTargets []vaultpkg.InstallTarget) error {
 	// Create JSON-friendly output
 	versions := make([]map[string]any, 0, len(details.Versions))
@@ -647,6 +674,14 @@ func printVaultShowJSON(out *outputHelper, details *vaultpkg.AssetDetails, insta
 			scopes = append(scopes, t.AuditData())
 		}
 		output["installationScopes"] = scopes
+		if len(details.Versions) > 0 {
+			latest := details.Versions[len(details.Versions)-1].Version
+			behind := make([]map[string]any, 0)
+			for _, t := range pinnedBehindLatest(currentTargets, latest) {
+				behind = append(behind, t.AuditData())
+			}
+			output["pinnedBehindLatest"] = behind
+		}
 	}
 
 	if details.Metadata != nil {
@@ -791,7 +826,7 @@ func runVaultRemove(cmd *cobra.Command, assetName, versionFlag string, yes, dele
 
 	if shouldInstall {
 		out.println()
-		if err := runInstall(cmd, nil, false, "", false, "", "", false, false); err != nil {
+		if err := runInstall(cmd, nil, false, "", false, "", "", false, false, false, ""); err != nil {
 			out.printfErr("Install failed: %v\n", err)
 		}
 	} else {
@@ -898,7 +933,7 @@ func runVaultRename(cmd *cobra.Command, oldName, newName string, yes bool) error
 
 	if shouldInstall {
 		out.println()
-		if err := runInstall(cmd, nil, false, "", false, "", "", false, false); err != nil {
+		if err := runInstall(cmd, nil, false, "", false, "", "", false, false, false, ""); err != nil {
 			out.printfErr("Install failed: %v\n", err)
 		}
 	} else {
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/sx/v2/internal/config"
+	vaultpkg "github.com/sleuth-io/sx/v2/internal/vault"
+)
+
+func newVaultExportStaticCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-static <dir>",
+		Short: "Export the vault as a static site for any web server",
+		Long: `Render the configured vault into <dir> as a read-only static vault:
+
+  sx.lock                      the vault manifest resolved to a lock file
+  index.json                   every asset and version, for list and show
+  assets/{name}/{version}.zip  one zip per version, sha256-pinned in sx.lock
+
+Upload <dir> to any static host (nginx, S3 website, GitHub Pages) and point
+clients at it with: sx init --type http --repo-url https://host/path
+
+Org-wide, repo, and path installs carry over. Team, user, and bot installs
+need an identity a static host can't check, so they are left out.
+
+Works with path, git, s3, and oci vaults. Re-run after every change to publish it.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+			defer cancel()
+			return runVaultExportStatic(ctx, cmd, args[0])
+		},
+	}
+	return cmd
+}
+
+func runVaultExportStatic(ctx context.Context, cmd *cobra.Command, dir string) error {
+	out := newOutputHelper(cmd)
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w\nRun 'sx init' to configure", err)
+	}
+	vault, err := vaultpkg.NewFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create vault: %w", err)
+	}
+
+	result, err := vaultpkg.ExportStatic(ctx, vault, dir)
+	if err != nil {
+		if errors.Is(err, vaultpkg.ErrLockFileNotFound) {
+			return errors.New("vault has no sx.toml yet; add an asset before exporting")
+		}
+		return fmt.Errorf("export failed: %w", err)
+	}
+
+	out.printf("✓ Exported %d asset(s), %d version(s) to %s\n", result.Assets, result.Versions, dir)
+	if result.Dropped > 0 {
+		out.printf("Note: %d asset(s) are installed only for teams, users, or bots and were left out of sx.lock.\n", result.Dropped)
+	}
+	return nil
+}
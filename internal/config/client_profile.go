@@ -0,0 +1,139 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// MCP config file formats a client profile can declare
+const (
+	MCPFormatJSON = "json"
+	MCPFormatYAML = "yaml"
+	MCPFormatTOML = "toml"
+)
+
+var clientProfileIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// ClientProfile declares a client sx supports through configuration
+// alone: an agent that reads AGENTS.md-style instruction files and an MCP
+// config file (Goose, Amp, Jules, Junie). Profiles are global across
+// vault profiles, like the client enable/disable settings.
+type ClientProfile struct {
+	// ID identifies the client, as in forceEnabledClients and --client
+	ID string `json:"id"`
+
+	// Name is the display name. Empty uses the ID.
+	Name string `json:"name,omitempty"`
+
+	// InstructionFiles are the files the client reads instructions from,
+	// relative to the repository (or path) root. Rules are written as
+	// marked sections of the first one; all of them are importable.
+	InstructionFiles []string `json:"instructionFiles,omitempty"`
+
+	// GlobalInstructionFile is the user-level instruction file (e.g.
+	// ~/.config/goose/.goosehints). Empty skips global rules.
+	GlobalInstructionFile string `json:"globalInstructionFile,omitempty"`
+
+	// MCP declares where the client reads MCP servers from. Nil means
+	// the client gets no MCP servers.
+	MCP *ClientProfileMCP `json:"mcp,omitempty"`
+
+	// DetectCommand is a command whose presence in PATH means the client
+	// is installed
+	DetectCommand string `json:"detectCommand,omitempty"`
+
+	// DetectPaths are files or directories whose existence means the
+	// client is installed. A leading ~/ is the home directory.
+	DetectPaths []string `json:"detectPaths,omitempty"`
+}
+
+// ClientProfileMCP declares a client's MCP config file
+type ClientProfileMCP struct {
+	// File is the config file. A relative path is under the scope root
+	// (the repository, the path, or the home directory for global
+	// installs); an absolute or ~/ path is used for every scope.
+	File string `json:"file"`
+
+	// Format is json, yaml or toml. Empty infers it from File's extension.
+	Format string `json:"format,omitempty"`
+
+	// Pointer is the JSON pointer (RFC 6901) of the object holding server
+	// entries keyed by name, e.g. /mcpServers or /extensions
+	Pointer string `json:"pointer"`
+}
+
+// DisplayName returns the profile's name, defaulting to its ID
+func (p *ClientProfile) DisplayName() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.ID
+}
+
+// Validate checks that the profile declares enough to install anything
+func (p *ClientProfile) Validate() error {
+	if !clientProfileIDPattern.MatchString(p.ID) {
+		return fmt.Errorf("client profile id %q must be lowercase letters, digits and dashes", p.ID)
+	}
+	if len(p.InstructionFiles) == 0 && p.GlobalInstructionFile == "" && p.MCP == nil {
+		return fmt.Errorf("client profile %s declares neither instruction files nor an MCP config", p.ID)
+	}
+	for _, f := range p.InstructionFiles {
+		if f == "" || filepath.IsAbs(f) || strings.HasPrefix(f, "~") {
+			return fmt.Errorf("client profile %s: instruction file %q must be relative to the repository", p.ID, f)
+		}
+	}
+	if p.MCP != nil {
+		if p.MCP.File == "" {
+			return fmt.Errorf("client profile %s: mcp.file is required", p.ID)
+		}
+		if _, err := p.MCP.FileFormat(); err != nil {
+			return fmt.Errorf("client profile %s: %w", p.ID, err)
+		}
+		if _, err := p.MCP.PointerPath(); err != nil {
+			return fmt.Errorf("client profile %s: %w", p.ID, err)
+		}
+	}
+	return nil
+}
+
+// FileFormat returns the config format, inferred from the file extension
+// when Format is empty
+func (m *ClientProfileMCP) FileFormat() (string, error) {
+	format := strings.ToLower(m.Format)
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(m.File)) {
+		case ".json", ".jsonc":
+			format = MCPFormatJSON
+		case ".yaml", ".yml":
+			format = MCPFormatYAML
+		case ".toml":
+			format = MCPFormatTOML
+		default:
+			return "", fmt.Errorf("cannot infer the format of %s; set mcp.format", m.File)
+		}
+	}
+	switch format {
+	case MCPFormatJSON, MCPFormatYAML, MCPFormatTOML:
+		return format, nil
+	}
+	return "", fmt.Errorf("unsupported mcp.format %q (want json, yaml or toml)", m.Format)
+}
+
+// PointerPath splits Pointer into its unescaped reference tokens
+func (m *ClientProfileMCP) PointerPath() ([]string, error) {
+	if !strings.HasPrefix(m.Pointer, "/") || m.Pointer == "/" {
+		return nil, fmt.Errorf("mcp.pointer %q must be a JSON pointer such as /mcpServers", m.Pointer)
+	}
+	tokens := strings.Split(m.Pointer[1:], "/")
+	for i, t := range tokens {
+		if t == "" {
+			return nil, errors.New("mcp.pointer has an empty segment")
+		}
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
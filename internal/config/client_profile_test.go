@@ -0,0 +1,68 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestClientProfileValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile ClientProfile
+		wantErr bool
+	}{
+		{"instruction files only", ClientProfile{ID: "jules", InstructionFiles: []string{"AGENTS.md"}}, false},
+		{"mcp only", ClientProfile{ID: "amp", MCP: &ClientProfileMCP{File: "~/.config/amp/settings.json", Pointer: "/amp.mcpServers"}}, false},
+		{"bad id", ClientProfile{ID: "Goose", InstructionFiles: []string{"AGENTS.md"}}, true},
+		{"nothing to install", ClientProfile{ID: "goose"}, true},
+		{"absolute instruction file", ClientProfile{ID: "goose", InstructionFiles: []string{"/etc/AGENTS.md"}}, true},
+		{"unknown format", ClientProfile{ID: "goose", MCP: &ClientProfileMCP{File: "config.ini", Pointer: "/servers"}}, true},
+		{"explicit format", ClientProfile{ID: "goose", MCP: &ClientProfileMCP{File: "config", Format: "yaml", Pointer: "/extensions"}}, false},
+		{"bad pointer", ClientProfile{ID: "goose", MCP: &ClientProfileMCP{File: "config.yaml", Pointer: "extensions"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.profile.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClientProfileMCPPointerPath(t *testing.T) {
+	m := &ClientProfileMCP{Pointer: "/a~1b/c~0d/mcpServers"}
+	got, err := m.PointerPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a/b", "c~d", "mcpServers"}; !slices.Equal(got, want) {
+		t.Errorf("PointerPath() = %v, want %v", got, want)
+	}
+}
+
+func TestSaveMultiProfileKeepsClientProfiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	mpc := &MultiProfileConfig{
+		DefaultProfile: "default",
+		Profiles: map[string]*Profile{
+			"default": {Type: RepositoryTypeGit, RepositoryURL: "git@github.com:test/repo"},
+		},
+		ClientProfiles: []ClientProfile{{
+			ID:               "goose",
+			InstructionFiles: []string{"AGENTS.md", ".goosehints"},
+			MCP:              &ClientProfileMCP{File: "~/.config/goose/config.yaml", Pointer: "/extensions"},
+		}},
+	}
+	if err := SaveMultiProfile(mpc); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadMultiProfile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded.ClientProfiles) != 1 || loaded.ClientProfiles[0].ID != "goose" || loaded.ClientProfiles[0].MCP.Pointer != "/extensions" {
+		t.Errorf("ClientProfiles = %+v", loaded.ClientProfiles)
+	}
+}
@@ -5,22 +5,26 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strings"
 
 	"github.com/sleuth-io/sx/v2/internal/utils"
 )
 
-// RepositoryType represents the type of repository (sleuth, git, or path)
+// RepositoryType represents the type of repository (sleuth, git, path, s3, oci, or http)
 type RepositoryType string
 
 const (
 	RepositoryTypeSleuth RepositoryType = "sleuth"
 	RepositoryTypeGit    RepositoryType = "git"
 	RepositoryTypePath   RepositoryType = "path"
+	RepositoryTypeS3     RepositoryType = "s3"
+	RepositoryTypeOCI    RepositoryType = "oci"
+	RepositoryTypeHTTP   RepositoryType = "http"
 )
 
 // Config represents the configuration for the skills CLI
 type Config struct {
-	// Type of repository: "sleuth", "git", or "path"
+	// Type of repository: "sleuth", "git", "path", "s3", "oci", or "http"
 	Type RepositoryType `json:"type"`
 
 	// ServerURL is the Sleuth server URL (only for type=sleuth)
@@ -30,12 +34,19 @@ type Config struct {
 	AuthToken string `json:"authToken,omitempty"`
 
 	// AuthUsername is the HTTP(S) basic-auth username used with AuthToken for
-	// git repositories. Empty uses a host-specific default.
+	// git repositories. Empty uses a host-specific default. For s3 vaults,
+	// AuthUsername and AuthToken are the access key ID and secret; empty
+	// uses the AWS_* environment variables. For oci vaults they are the
+	// registry username and password or token. For http vaults, AuthToken
+	// is sent as a bearer token.
 	AuthUsername string `json:"authUsername,omitempty"`
 
 	// RepositoryURL is the repository URL
 	// - For git: git repository URL (https://github.com/org/repo.git)
 	// - For path: file:// URL pointing to local directory (file:///path/to/repo)
+	// - For s3: s3://bucket/prefix, optionally ?endpoint=…&region=…
+	// - For oci: oci://registry/repository, optionally ?insecure=true
+	// - For http: http(s):// URL of a static vault (sx vault export-static)
 	RepositoryURL string `json:"repositoryUrl,omitempty"`
 
 	// Identity is the email used to resolve team and user scopes when
@@ -179,8 +190,8 @@ func Exists() bool {
 
 // Validate validates the configuration
 func (c *Config) Validate() error {
-	if c.Type != RepositoryTypeSleuth && c.Type != RepositoryTypeGit && c.Type != RepositoryTypePath {
-		return fmt.Errorf("invalid repository type: %s (must be 'sleuth', 'git', or 'path')", c.Type)
+	if c.Type != RepositoryTypeSleuth && c.Type != RepositoryTypeGit && c.Type != RepositoryTypePath && c.Type != RepositoryTypeS3 && c.Type != RepositoryTypeOCI && c.Type != RepositoryTypeHTTP {
+		return fmt.Errorf("invalid repository type: %s (must be 'sleuth', 'git', 'path', 's3', 'oci', or 'http')", c.Type)
 	}
 
 	switch c.Type {
@@ -199,6 +210,18 @@ func (c *Config) Validate() error {
 		if c.RepositoryURL == "" {
 			return errors.New("repositoryUrl is required for path repository type")
 		}
+	case RepositoryTypeS3:
+		if !strings.HasPrefix(c.RepositoryURL, "s3://") {
+			return errors.New("repositoryUrl must be an s3:// URL for s3 repository type")
+		}
+	case RepositoryTypeOCI:
+		if !strings.HasPrefix(c.RepositoryURL, "oci://") {
+			return errors.New("repositoryUrl must be an oci:// URL for oci repository type")
+		}
+	case RepositoryTypeHTTP:
+		if !strings.HasPrefix(c.RepositoryURL, "https://") && !strings.HasPrefix(c.RepositoryURL, "http://") {
+			return errors.New("repositoryUrl must be an http:// or https:// URL for http repository type")
+		}
 	}
 
 	return nil
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// OTLPConfig opts into exporting usage and audit events to an
+// OpenTelemetry collector over OTLP/HTTP. It is global across profiles,
+// like the client settings.
+type OTLPConfig struct {
+	// Endpoint is the collector's base URL, e.g. http://localhost:4318.
+	// Logs are posted to <endpoint>/v1/logs and metrics to
+	// <endpoint>/v1/metrics.
+	Endpoint string `json:"endpoint"`
+
+	// Headers are sent with every request, e.g. an API key for a hosted
+	// collector
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// ServiceName is reported as the service.name resource attribute.
+	// Empty uses "sx".
+	ServiceName string `json:"serviceName,omitempty"`
+}
+
+// Validate checks that the endpoint is an http(s) URL
+func (c *OTLPConfig) Validate() error {
+	u, err := url.Parse(strings.TrimSpace(c.Endpoint))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("otlp endpoint %q must be an http(s) URL", c.Endpoint)
+	}
+	return nil
+}
+
+// LoadOTLP returns the OTLP export settings, or nil when there's no
+// config or it doesn't declare an endpoint
+func LoadOTLP() (*OTLPConfig, error) {
+	if !Exists() {
+		return nil, nil
+	}
+	mpc, err := LoadMultiProfile()
+	if err != nil {
+		return nil, err
+	}
+	if mpc.OTLP == nil || strings.TrimSpace(mpc.OTLP.Endpoint) == "" {
+		return nil, nil
+	}
+	if err := mpc.OTLP.Validate(); err != nil {
+		return nil, err
+	}
+	return mpc.OTLP, nil
+}
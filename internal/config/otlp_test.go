@@ -0,0 +1,37 @@
+package config
+
+import "testing"
+
+func TestLoadOTLP(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if cfg, err := LoadOTLP(); err != nil || cfg != nil {
+		t.Fatalf("expected no OTLP config without a config file, got %+v, %v", cfg, err)
+	}
+
+	mpc := &MultiProfileConfig{
+		DefaultProfile: "default",
+		Profiles: map[string]*Profile{
+			"default": {Type: RepositoryTypeGit, RepositoryURL: "git@github.com:test/repo"},
+		},
+		OTLP: &OTLPConfig{Endpoint: "http://localhost:4318", Headers: map[string]string{"X-Api-Key": "secret"}},
+	}
+	if err := SaveMultiProfile(mpc); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadOTLP()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg == nil || cfg.Endpoint != "http://localhost:4318" || cfg.Headers["X-Api-Key"] != "secret" {
+		t.Errorf("LoadOTLP() = %+v", cfg)
+	}
+
+	mpc.OTLP.Endpoint = "localhost:4318"
+	if err := SaveMultiProfile(mpc); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadOTLP(); err == nil {
+		t.Error("expected an error for an endpoint without a scheme")
+	}
+}
@@ -18,7 +18,7 @@ const DefaultProfileName = "default"
 
 // Profile represents a single configuration profile
 type Profile struct {
-	// Type of repository: "sleuth", "git", or "path"
+	// Type of repository: "sleuth", "git", "path", "s3", "oci", or "http"
 	Type RepositoryType `json:"type"`
 
 	// ServerURL is the Sleuth server URL (only for type=sleuth)
@@ -28,12 +28,19 @@ type Profile struct {
 	AuthToken string `json:"authToken,omitempty"`
 
 	// AuthUsername is the HTTP(S) basic-auth username used with AuthToken for
-	// git repositories. Empty uses a host-specific default.
+	// git repositories. Empty uses a host-specific default. For s3 vaults,
+	// AuthUsername and AuthToken are the access key ID and secret; empty
+	// uses the AWS_* environment variables. For oci vaults they are the
+	// registry username and password or token. For http vaults, AuthToken
+	// is sent as a bearer token.
 	AuthUsername string `json:"authUsername,omitempty"`
 
 	// RepositoryURL is the repository URL
 	// - For git: git repository URL (https://github.com/org/repo.git)
 	// - For path: file:// URL pointing to local directory (file:///path/to/repo)
+	// - For s3: s3://bucket/prefix, optionally ?endpoint=…&region=…
+	// - For oci: oci://registry/repository, optionally ?insecure=true
+	// - For http: http(s):// URL of a static vault (sx vault export-static)
 	RepositoryURL string `json:"repositoryUrl,omitempty"`
 
 	// Identity is the email used to resolve team and user scopes for this
@@ -80,6 +87,14 @@ type MultiProfileConfig struct {
 	// BootstrapOptions stores user consent for bootstrap items (hooks, MCP servers).
 	// Keyed by option key, nil/missing = yes (backwards compatible).
 	BootstrapOptions map[string]*bool `json:"bootstrapOptions,omitempty"`
+
+	// ClientProfiles declares clients sx supports through configuration
+	// alone. This is global across all profiles.
+	ClientProfiles []ClientProfile `json:"clientProfiles,omitempty"`
+
+	// OTLP exports usage and audit events to an OpenTelemetry collector
+	// when set. This is global across all profiles.
+	OTLP *OTLPConfig `json:"otlp,omitempty"`
 }
 
 // GetBootstrapOption returns whether a bootstrap option is enabled.
@@ -295,6 +310,12 @@ type backwardsCompatibleConfig struct {
 
 	// Bootstrap options (global across profiles)
 	BootstrapOptions map[string]*bool `json:"bootstrapOptions,omitempty"`
+
+	// Config-declared clients (global across profiles)
+	ClientProfiles []ClientProfile `json:"clientProfiles,omitempty"`
+
+	// OpenTelemetry export (global across profiles)
+	OTLP *OTLPConfig `json:"otlp,omitempty"`
 }
 
 // SaveMultiProfile saves the full multi-profile configuration
@@ -318,6 +339,8 @@ func SaveMultiProfile(mpc *MultiProfileConfig) error {
 		ForceEnabledClients:  mpc.ForceEnabledClients,
 		ForceDisabledClients: mpc.ForceDisabledClients,
 		BootstrapOptions:     mpc.BootstrapOptions,
+		ClientProfiles:       mpc.ClientProfiles,
+		OTLP:                 mpc.OTLP,
 		// Note: EnabledClients intentionally not saved (deprecated)
 	}
 
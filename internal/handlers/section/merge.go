@@ -0,0 +1,234 @@
+package section
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// contextLines is the context a unified diff shows around each change
+const contextLines = 3
+
+// op is one line of a line diff: ' ' kept, '-' removed, '+' added
+type op struct {
+	kind byte
+	line string
+}
+
+// hunk replaces base lines [start, end) with lines
+type hunk struct {
+	start, end int
+	lines      []string
+}
+
+// splitLines splits text into lines without their newlines
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines returns a shortest edit script turning a into b. Sections and
+// instruction files are small, so a longest-common-subsequence table over
+// the lines between the common prefix and suffix is fine.
+func diffLines(a, b []string) []op {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	am, bm := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] is the LCS length of am[i:] and bm[j:]
+	lcs := make([][]int, len(am)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bm)+1)
+	}
+	for i := len(am) - 1; i >= 0; i-- {
+		for j := len(bm) - 1; j >= 0; j-- {
+			if am[i] == bm[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]op, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, op{' ', line})
+	}
+	i, j := 0, 0
+	for i < len(am) && j < len(bm) {
+		switch {
+		case am[i] == bm[j]:
+			ops = append(ops, op{' ', am[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{'-', am[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', bm[j]})
+			j++
+		}
+	}
+	for ; i < len(am); i++ {
+		ops = append(ops, op{'-', am[i]})
+	}
+	for ; j < len(bm); j++ {
+		ops = append(ops, op{'+', bm[j]})
+	}
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, op{' ', line})
+	}
+	return ops
+}
+
+// hunks groups the changes turning base into other by the base lines
+// they replace
+func hunks(base, other []string) []hunk {
+	var result []hunk
+	var cur *hunk
+	pos := 0
+	for _, o := range diffLines(base, other) {
+		if o.kind == ' ' {
+			if cur != nil {
+				result = append(result, *cur)
+				cur = nil
+			}
+			pos++
+			continue
+		}
+		if cur == nil {
+			cur = &hunk{start: pos, end: pos}
+		}
+		if o.kind == '-' {
+			cur.end++
+			pos++
+		} else {
+			cur.lines = append(cur.lines, o.line)
+		}
+	}
+	if cur != nil {
+		result = append(result, *cur)
+	}
+	return result
+}
+
+// merge3 merges two edits of base. It fails when they change the same or
+// adjacent lines differently.
+func merge3(base, ours, theirs string) (string, bool) {
+	b := splitLines(base)
+	a, t := hunks(b, splitLines(ours)), hunks(b, splitLines(theirs))
+
+	var out []string
+	pos := 0
+	apply := func(h hunk) {
+		out = append(out, b[pos:h.start]...)
+		out = append(out, h.lines...)
+		pos = h.end
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(t) {
+		switch {
+		case j == len(t):
+			apply(a[i])
+			i++
+		case i == len(a):
+			apply(t[j])
+			j++
+		case a[i].start <= t[j].end && t[j].start <= a[i].end:
+			// Touching or overlapping: only identical changes merge
+			if a[i].start != t[j].start || a[i].end != t[j].end || !slices.Equal(a[i].lines, t[j].lines) {
+				return "", false
+			}
+			apply(a[i])
+			i++
+			j++
+		case a[i].start < t[j].start:
+			apply(a[i])
+			i++
+		default:
+			apply(t[j])
+			j++
+		}
+	}
+	out = append(out, b[pos:]...)
+
+	if len(out) == 0 {
+		return "", true
+	}
+	return strings.Join(out, "\n") + "\n", true
+}
+
+// unifiedDiff renders the change from before to after as a unified diff
+func unifiedDiff(path, before, after string) string {
+	a, b := splitLines(before), splitLines(after)
+	ops := diffLines(a, b)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", path, path)
+
+	for start := 0; start < len(ops); {
+		// Find the next change
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		// Extend the hunk while changes are within twice the context
+		end := start
+		for k := start; k < len(ops); k++ {
+			if ops[k].kind != ' ' {
+				end = k + 1
+			} else if k-end >= 2*contextLines {
+				break
+			}
+		}
+		from := max(start-contextLines, 0)
+		to := min(end+contextLines, len(ops))
+
+		// Line numbers of the hunk in each file
+		aLine, bLine := 1, 1
+		for _, o := range ops[:from] {
+			if o.kind != '+' {
+				aLine++
+			}
+			if o.kind != '-' {
+				bLine++
+			}
+		}
+		var aCount, bCount int
+		for _, o := range ops[from:to] {
+			if o.kind != '+' {
+				aCount++
+			}
+			if o.kind != '-' {
+				bCount++
+			}
+		}
+		if aCount == 0 {
+			aLine--
+		}
+		if bCount == 0 {
+			bLine--
+		}
+
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", aLine, aCount, bLine, bCount)
+		for _, o := range ops[from:to] {
+			sb.WriteByte(o.kind)
+			sb.WriteString(o.line)
+			sb.WriteByte('\n')
+		}
+		start = to
+	}
+	return sb.String()
+}
@@ -0,0 +1,80 @@
+package section
+
+import "testing"
+
+func TestMerge3(t *testing.T) {
+	base := "a\nb\nc\nd\ne\n"
+	tests := []struct {
+		name   string
+		ours   string
+		theirs string
+		want   string
+		ok     bool
+	}{
+		{
+			name:   "separate edits",
+			ours:   "A\nb\nc\nd\ne\n",
+			theirs: "a\nb\nc\nd\nE\n",
+			want:   "A\nb\nc\nd\nE\n",
+			ok:     true,
+		},
+		{
+			name:   "insert and delete",
+			ours:   "a\nb\nnew\nc\nd\ne\n",
+			theirs: "a\nb\nc\ne\n",
+			want:   "a\nb\nnew\nc\ne\n",
+			ok:     true,
+		},
+		{
+			name:   "identical edits",
+			ours:   "a\nB\nc\nd\ne\n",
+			theirs: "a\nB\nc\nd\ne\n",
+			want:   "a\nB\nc\nd\ne\n",
+			ok:     true,
+		},
+		{
+			name:   "same line",
+			ours:   "a\nB1\nc\nd\ne\n",
+			theirs: "a\nB2\nc\nd\ne\n",
+			ok:     false,
+		},
+		{
+			name:   "adjacent lines",
+			ours:   "a\nB\nc\nd\ne\n",
+			theirs: "a\nb\nC\nd\ne\n",
+			ok:     false,
+		},
+		{
+			name:   "everything deleted",
+			ours:   "",
+			theirs: base,
+			want:   "",
+			ok:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := merge3(base, tt.ours, tt.theirs)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && got != tt.want {
+				t.Errorf("merge3 = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	before := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	after := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n"
+	want := "--- f\n+++ f\n" +
+		"@@ -1,6 +1,6 @@\n 1\n 2\n-3\n+three\n 4\n 5\n 6\n" +
+		"@@ -10,3 +10,4 @@\n 10\n 11\n 12\n+13\n"
+	if got := unifiedDiff("f", before, after); got != want {
+		t.Errorf("unifiedDiff:\n%s\nwant:\n%s", got, want)
+	}
+	if got := unifiedDiff("f", "", "x\n"); got != "--- f\n+++ f\n@@ -0,0 +1,1 @@\n+x\n" {
+		t.Errorf("diff from empty = %q", got)
+	}
+}
@@ -0,0 +1,266 @@
+// Package section owns the sx-managed sections of instruction files
+// (GEMINI.md, AGENTS.md, .rules and the like): marker-delimited blocks
+// that share a file with hand-written content.
+//
+// What sx last wrote to each section is kept in a Store (the install
+// tracker) and used as the base of a three-way merge, so hand edits
+// inside a section survive an update that doesn't touch the same lines.
+// When sx and the user changed the same lines, the write is refused with
+// a ConflictError unless forced.
+package section
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// ErrConflict is matched by a ConflictError
+var ErrConflict = errors.New("managed section edited by hand")
+
+// ConflictError is returned when a managed section was edited by hand in
+// a way sx can't merge with its own change
+type ConflictError struct {
+	Path string
+	Name string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("the sx:%s section of %s was edited by hand since sx wrote it; rerun with --force to discard the edits", e.Name, e.Path)
+}
+
+// Is makes errors.Is(err, ErrConflict) match
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// Store remembers what sx last wrote to each managed section: the base
+// of the three-way merge. Implementations must be safe for concurrent use,
+// since clients install in parallel.
+type Store interface {
+	SectionBase(path, name string) (string, bool)
+	SetSectionBase(path, name, content string)
+	DeleteSectionBase(path, name string)
+}
+
+// Options control how sections are written. They travel in the context
+// so rule handlers don't need them threaded through every client.
+type Options struct {
+	// Store holds the merge bases. Without one, hand edits can't be told
+	// apart from older sx content and are overwritten.
+	Store Store
+
+	// Force overwrites hand edits that conflict with sx's change
+	Force bool
+
+	// DryRun writes a unified diff of each change to Diff instead of
+	// changing the file
+	DryRun bool
+	Diff   io.Writer
+}
+
+type optionsKey struct{}
+
+// WithOptions returns a context carrying section options
+func WithOptions(ctx context.Context, opts Options) context.Context {
+	return context.WithValue(ctx, optionsKey{}, opts)
+}
+
+func optionsFrom(ctx context.Context) Options {
+	opts, _ := ctx.Value(optionsKey{}).(Options)
+	return opts
+}
+
+// diffMu keeps dry-run diffs from parallel installs from interleaving
+var diffMu sync.Mutex
+
+// StartMarker returns the line that opens a managed section
+func StartMarker(name string) string {
+	return fmt.Sprintf("<!-- sx:%s -->", name)
+}
+
+// EndMarker returns the line that closes a managed section
+func EndMarker(name string) string {
+	return fmt.Sprintf("<!-- /sx:%s -->", name)
+}
+
+// Write adds or updates the named section of the file at path. Hand edits
+// made since sx last wrote the section are merged with the new body.
+func Write(ctx context.Context, path, name, body string) error {
+	opts := optionsFrom(ctx)
+	ours := normalize(body)
+
+	existing, err := readFile(path)
+	if err != nil {
+		return err
+	}
+
+	merged := ours
+	if theirs, found := extract(existing, name); found && opts.Store != nil {
+		if base, ok := opts.Store.SectionBase(path, name); ok && theirs != base && theirs != ours {
+			if ours == base {
+				// Only the user changed it: keep their edit
+				merged = theirs
+			} else if m, ok := merge3(base, ours, theirs); ok {
+				merged = m
+			} else if !opts.Force {
+				return &ConflictError{Path: path, Name: name}
+			}
+		}
+	}
+
+	section := StartMarker(name) + "\n" + merged + EndMarker(name) + "\n"
+	var updated string
+	if before, after, ok := cut(existing, name); ok {
+		updated = join(before, section, after)
+	} else {
+		updated = join(existing, section, "")
+	}
+
+	if opts.DryRun {
+		writeDiff(opts.Diff, path, existing, updated)
+		return nil
+	}
+	if updated != existing {
+		if err := utils.EnsureDir(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+		}
+	}
+	if opts.Store != nil {
+		opts.Store.SetSectionBase(path, name, ours)
+	}
+	return nil
+}
+
+// Remove removes the named section of the file at path, deleting the file
+// once nothing else is left. A section edited by hand since sx wrote it is
+// only removed when forced.
+func Remove(ctx context.Context, path, name string) error {
+	opts := optionsFrom(ctx)
+
+	existing, err := readFile(path)
+	if err != nil {
+		return err
+	}
+	theirs, found := extract(existing, name)
+	if !found {
+		if opts.Store != nil && !opts.DryRun {
+			opts.Store.DeleteSectionBase(path, name)
+		}
+		return nil
+	}
+	if opts.Store != nil && !opts.Force {
+		if base, ok := opts.Store.SectionBase(path, name); ok && theirs != base {
+			return &ConflictError{Path: path, Name: name}
+		}
+	}
+
+	before, after, _ := cut(existing, name)
+	updated := join(before, "", after)
+
+	if opts.DryRun {
+		writeDiff(opts.Diff, path, existing, updated)
+		return nil
+	}
+	if strings.TrimSpace(updated) == "" {
+		err = os.Remove(path)
+	} else {
+		err = os.WriteFile(path, []byte(updated), 0644)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", filepath.Base(path), err)
+	}
+	if opts.Store != nil {
+		opts.Store.DeleteSectionBase(path, name)
+	}
+	return nil
+}
+
+// Contains reports whether the file at path has the named section
+func Contains(path, name string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(data), StartMarker(name))
+}
+
+func readFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	return string(data), nil
+}
+
+// extract returns the body of the named section
+func extract(content, name string) (string, bool) {
+	start := strings.Index(content, StartMarker(name))
+	if start < 0 {
+		return "", false
+	}
+	rest := content[start+len(StartMarker(name)):]
+	end := strings.Index(rest, EndMarker(name))
+	if end < 0 {
+		return "", false
+	}
+	return normalize(rest[:end]), true
+}
+
+// cut splits content around the named section, markers included
+func cut(content, name string) (before, after string, ok bool) {
+	start := strings.Index(content, StartMarker(name))
+	if start < 0 {
+		return content, "", false
+	}
+	end := strings.Index(content[start:], EndMarker(name))
+	if end < 0 {
+		return content, "", false
+	}
+	return content[:start], content[start+end+len(EndMarker(name)):], true
+}
+
+// join joins the parts with a blank line between the non-empty ones
+func join(parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == 0 {
+		return ""
+	}
+	return strings.Join(kept, "\n\n") + "\n"
+}
+
+// normalize trims surrounding blank lines and ends the body with a newline
+func normalize(body string) string {
+	body = strings.Trim(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	if body == "" {
+		return ""
+	}
+	return body + "\n"
+}
+
+func writeDiff(w io.Writer, path, before, after string) {
+	if w == nil || before == after {
+		return
+	}
+	diffMu.Lock()
+	defer diffMu.Unlock()
+	fmt.Fprint(w, unifiedDiff(path, before, after))
+}
@@ -0,0 +1,203 @@
+package section
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mapStore is an in-memory Store
+type mapStore map[string]string
+
+func (s mapStore) SectionBase(path, name string) (string, bool) {
+	v, ok := s[path+"#"+name]
+	return v, ok
+}
+
+func (s mapStore) SetSectionBase(path, name, content string) { s[path+"#"+name] = content }
+
+func (s mapStore) DeleteSectionBase(path, name string) { delete(s, path+"#"+name) }
+
+func readString(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestWriteAppendsAndReplaces(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "AGENTS.md")
+	if err := os.WriteFile(path, []byte("# My notes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if err := Write(ctx, path, "style", "## Style\n\nUse tabs.\n"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	want := "# My notes\n\n<!-- sx:style -->\n## Style\n\nUse tabs.\n<!-- /sx:style -->\n"
+	if got := readString(t, path); got != want {
+		t.Errorf("after append:\n%s\nwant:\n%s", got, want)
+	}
+
+	if err := Write(ctx, path, "style", "## Style\n\nUse spaces.\n"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := readString(t, path); !strings.Contains(got, "Use spaces.") || strings.Contains(got, "Use tabs.") {
+		t.Errorf("section not replaced:\n%s", got)
+	}
+	if !Contains(path, "style") || Contains(path, "other") {
+		t.Error("Contains reports the wrong sections")
+	}
+}
+
+func TestWriteMergesHandEdits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "GEMINI.md")
+	store := mapStore{}
+	ctx := WithOptions(context.Background(), Options{Store: store})
+
+	v1 := "## Style\n\nUse tabs.\nWrap at 100.\n\nPrefer early returns.\n"
+	if err := Write(ctx, path, "style", v1); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	// The user edits the last line; sx then changes the first
+	edited := strings.Replace(readString(t, path), "Prefer early returns.", "Prefer early returns, always.", 1)
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	v2 := strings.Replace(v1, "Use tabs.", "Use spaces.", 1)
+	if err := Write(ctx, path, "style", v2); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	got := readString(t, path)
+	if !strings.Contains(got, "Use spaces.") || !strings.Contains(got, "Prefer early returns, always.") {
+		t.Errorf("edits not merged:\n%s", got)
+	}
+
+	// The base is sx's content, not the merged result
+	if base, _ := store.SectionBase(path, "style"); base != v2 {
+		t.Errorf("base = %q, want %q", base, v2)
+	}
+
+	// Reinstalling the same content keeps the user's edit
+	if err := Write(ctx, path, "style", v2); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if !strings.Contains(readString(t, path), "Prefer early returns, always.") {
+		t.Error("reinstall clobbered the user's edit")
+	}
+}
+
+func TestWriteConflict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "GEMINI.md")
+	store := mapStore{}
+	ctx := WithOptions(context.Background(), Options{Store: store})
+
+	if err := Write(ctx, path, "style", "Use tabs.\n"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	edited := strings.Replace(readString(t, path), "Use tabs.", "Use tabs, width 8.", 1)
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Write(ctx, path, "style", "Use spaces.\n")
+	var conflict *ConflictError
+	if !errors.As(err, &conflict) || !errors.Is(err, ErrConflict) {
+		t.Fatalf("Write error = %v, want a ConflictError", err)
+	}
+	if got := readString(t, path); got != edited {
+		t.Errorf("conflicting write changed the file:\n%s", got)
+	}
+
+	force := WithOptions(context.Background(), Options{Store: store, Force: true})
+	if err := Write(force, path, "style", "Use spaces.\n"); err != nil {
+		t.Fatalf("forced Write: %v", err)
+	}
+	if got := readString(t, path); !strings.Contains(got, "Use spaces.") || strings.Contains(got, "width 8") {
+		t.Errorf("forced write didn't overwrite:\n%s", got)
+	}
+}
+
+func TestWriteDryRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "AGENTS.md")
+	original := "# Notes\n\n<!-- sx:style -->\nUse tabs.\n<!-- /sx:style -->\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := mapStore{}
+	var diff bytes.Buffer
+	ctx := WithOptions(context.Background(), Options{Store: store, DryRun: true, Diff: &diff})
+
+	if err := Write(ctx, path, "style", "Use spaces.\n"); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := readString(t, path); got != original {
+		t.Errorf("dry run changed the file:\n%s", got)
+	}
+	if len(store) != 0 {
+		t.Error("dry run recorded a base")
+	}
+	want := "--- " + path + "\n+++ " + path + "\n@@ -1,5 +1,5 @@\n # Notes\n \n <!-- sx:style -->\n-Use tabs.\n+Use spaces.\n <!-- /sx:style -->\n"
+	if diff.String() != want {
+		t.Errorf("diff:\n%s\nwant:\n%s", diff.String(), want)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "AGENTS.md")
+	if err := os.WriteFile(path, []byte("# Notes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store := mapStore{}
+	ctx := WithOptions(context.Background(), Options{Store: store})
+
+	if err := Write(ctx, path, "a", "A\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Write(ctx, path, "b", "B\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A hand-edited section is only removed when forced
+	edited := strings.Replace(readString(t, path), "B\n", "B, edited\n", 1)
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Remove(ctx, path, "b"); !errors.Is(err, ErrConflict) {
+		t.Fatalf("Remove error = %v, want a conflict", err)
+	}
+	force := WithOptions(context.Background(), Options{Store: store, Force: true})
+	if err := Remove(force, path, "b"); err != nil {
+		t.Fatalf("forced Remove: %v", err)
+	}
+	if err := Remove(ctx, path, "a"); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if got := readString(t, path); got != "# Notes\n" {
+		t.Errorf("after removal = %q", got)
+	}
+	if len(store) != 0 {
+		t.Errorf("bases left behind: %v", store)
+	}
+
+	// A file holding only sx content is deleted with its last section
+	other := filepath.Join(dir, ".rules")
+	if err := Write(ctx, other, "a", "A\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Remove(ctx, other, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(other); !os.IsNotExist(err) {
+		t.Error("empty file not deleted")
+	}
+}
@@ -0,0 +1,358 @@
+// Package lint checks an asset against every client's constraints before it
+// is published or installed (docs/lint.md). It runs the same pieces install
+// does — the type detectors, metadata validation, each client's rule
+// capabilities and hook event mapping — and reports what each client would
+// do with the asset, so problems that install only reports per client (or
+// silently skips) surface once, up front.
+package lint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/assets/detectors"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/handlers/rule"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// Severity is how serious a finding is. The values match SARIF levels.
+type Severity string
+
+const (
+	// SeverityError means the asset is broken, or won't install anywhere.
+	SeverityError Severity = "error"
+	// SeverityWarning means it installs, but not everywhere or not as the
+	// author probably intended.
+	SeverityWarning Severity = "warning"
+	// SeverityNote is informational.
+	SeverityNote Severity = "note"
+)
+
+// Client statuses in a Report.
+const (
+	StatusOK      = "ok"
+	StatusWarning = "warning"
+	StatusError   = "error"
+	// StatusSkipped means install would not put the asset on this client.
+	StatusSkipped = "skipped"
+)
+
+// Rule describes one check.
+type Rule struct {
+	ID          string
+	Description string
+}
+
+// Check IDs, reported as Finding.Rule and SARIF ruleId.
+const (
+	RuleArchiveInvalid       = "archive-invalid"
+	RuleMetadataParse        = "metadata-parse"
+	RuleMetadataMissing      = "metadata-missing"
+	RuleMetadataInvalid      = "metadata-invalid"
+	RuleSkillDescription     = "skill-description"
+	RuleHookEventUnsupported = "hook-event-unsupported"
+	RuleRuleGlobsMissing     = "rule-globs-missing"
+	RuleRuleGlobsIgnored     = "rule-globs-ignored"
+	RuleRuleFormat           = "rule-format"
+	RuleNoCompatibleClient   = "no-compatible-client"
+)
+
+// Rules lists every check, in the order docs/lint.md describes them.
+var Rules = []Rule{
+	{RuleArchiveInvalid, "The asset is not a readable zip archive."},
+	{RuleMetadataParse, "metadata.toml does not parse."},
+	{RuleMetadataMissing, "There is no metadata.toml; the asset type is detected from its files, as sx add would."},
+	{RuleMetadataInvalid, "metadata.toml fails validation or references files the asset doesn't contain."},
+	{RuleSkillDescription, "The skill's prompt file has no frontmatter description, which clients use to decide when to load it."},
+	{RuleHookEventUnsupported, "The client has no lifecycle event for the hook; install skips the hook there."},
+	{RuleRuleGlobsMissing, "The rule has no globs; Cursor attaches it to every request."},
+	{RuleRuleGlobsIgnored, "The client does not scope rules by path; the rule's globs are dropped and it applies everywhere."},
+	{RuleRuleFormat, "The client's rule file generated from this asset does not parse back."},
+	{RuleNoCompatibleClient, "None of the checked clients would install the asset."},
+}
+
+// Finding is one problem with an asset.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	// Client is the client ID the finding applies to; empty when it
+	// applies everywhere.
+	Client string `json:"client,omitempty"`
+	// File is the file inside the asset the finding is about, if any.
+	File    string `json:"file,omitempty"`
+	Message string `json:"message"`
+}
+
+// ClientResult is what one client would do with the asset.
+type ClientResult struct {
+	Client string `json:"client"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Reason says why the client is skipped.
+	Reason string `json:"reason,omitempty"`
+}
+
+// Report is the result of linting one asset.
+type Report struct {
+	// Source is what was linted: a path or name@version. Set by the caller.
+	Source string `json:"source"`
+	// Dir is the directory the asset's files live in, when Source is one,
+	// and Path the file it was read from otherwise. Set by the caller for
+	// SARIF locations; both are empty for assets read from the vault.
+	Dir      string         `json:"-"`
+	Path     string         `json:"-"`
+	Name     string         `json:"name,omitempty"`
+	Type     string         `json:"type,omitempty"`
+	Clients  []ClientResult `json:"clients"`
+	Findings []Finding      `json:"findings"`
+}
+
+// Count returns how many findings have severity s.
+func (r *Report) Count(s Severity) int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Severity == s {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *Report) add(f Finding) {
+	r.Findings = append(r.Findings, f)
+}
+
+// Lint checks a zipped asset (as sx add would build it from a directory or
+// file) against targets. fallbackName names the asset when it has no
+// metadata.toml.
+func Lint(zipData []byte, fallbackName string, targets []clients.Client) *Report {
+	r := &Report{Name: fallbackName, Clients: []ClientResult{}, Findings: []Finding{}}
+
+	files, err := utils.ListZipFiles(zipData)
+	if err != nil {
+		r.add(Finding{Rule: RuleArchiveInvalid, Severity: SeverityError, Message: err.Error()})
+		return r
+	}
+
+	var meta *metadata.Metadata
+	if data, err := utils.ReadZipFile(zipData, "metadata.toml"); err == nil {
+		if meta, err = metadata.Parse(data); err != nil {
+			r.add(Finding{Rule: RuleMetadataParse, Severity: SeverityError, File: "metadata.toml", Message: err.Error()})
+			return r
+		}
+	} else {
+		meta = detectors.DetectAssetType(files, fallbackName, "")
+		r.add(Finding{
+			Rule:     RuleMetadataMissing,
+			Severity: SeverityNote,
+			Message:  fmt.Sprintf("no metadata.toml; detected a %s from its files", meta.Asset.Type.Key),
+		})
+	}
+	if meta.Asset.Name == "" {
+		meta.Asset.Name = fallbackName
+	}
+	r.Name = meta.Asset.Name
+	r.Type = meta.Asset.Type.Key
+	if meta.Asset.Version == "" {
+		// sx add assigns the version; a source tree doesn't need one.
+		meta.Asset.Version = "1"
+	}
+	if err := meta.ValidateWithFiles(files); err != nil {
+		r.add(Finding{Rule: RuleMetadataInvalid, Severity: SeverityError, File: "metadata.toml", Message: err.Error()})
+		return r
+	}
+
+	if meta.Asset.Type == asset.TypeSkill {
+		checkSkillDescription(r, meta, zipData)
+	}
+
+	installable := 0
+	for _, c := range targets {
+		res := lintClient(r, c, meta, zipData)
+		if res.Status != StatusSkipped {
+			installable++
+		}
+		r.Clients = append(r.Clients, res)
+	}
+	if installable == 0 && len(targets) > 0 {
+		r.add(Finding{
+			Rule:     RuleNoCompatibleClient,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("none of the %d checked clients would install this %s", len(targets), meta.Asset.Type.Key),
+		})
+	}
+	return r
+}
+
+// lintClient runs the per-client checks and returns the client's status.
+func lintClient(r *Report, c clients.Client, meta *metadata.Metadata, zipData []byte) ClientResult {
+	res := ClientResult{Client: c.ID(), Name: c.DisplayName()}
+	if len(meta.Asset.Clients) > 0 && !slices.Contains(meta.Asset.Clients, c.ID()) {
+		res.Status, res.Reason = StatusSkipped, "not listed in [asset].clients"
+		return res
+	}
+	if !c.SupportsAssetType(meta.Asset.Type) {
+		res.Status, res.Reason = StatusSkipped, fmt.Sprintf("doesn't install %s assets", meta.Asset.Type.Key)
+		return res
+	}
+
+	before := len(r.Findings)
+	switch meta.Asset.Type {
+	case asset.TypeHook:
+		if mapper, ok := c.(clients.HookEventMapper); ok {
+			if _, supported := mapper.MapHookEvent(meta.Hook); !supported {
+				r.add(Finding{
+					Rule:     RuleHookEventUnsupported,
+					Severity: SeverityWarning,
+					Client:   c.ID(),
+					File:     "metadata.toml",
+					Message:  fmt.Sprintf("%s has no %q event; install skips this hook there", c.DisplayName(), meta.Hook.Event),
+				})
+				res.Status, res.Reason = StatusSkipped, fmt.Sprintf("no %q event", meta.Hook.Event)
+				return res
+			}
+		}
+	case asset.TypeRule:
+		checkRule(r, c, meta, zipData)
+	}
+
+	res.Status = StatusOK
+	for _, f := range r.Findings[before:] {
+		switch f.Severity {
+		case SeverityError:
+			res.Status = StatusError
+		case SeverityWarning:
+			if res.Status == StatusOK {
+				res.Status = StatusWarning
+			}
+		}
+	}
+	return res
+}
+
+// checkRule round-trips the rule through the client's rule format: what
+// GenerateRuleFile writes and ParseRuleFile reads back is what the client
+// will see.
+func checkRule(r *Report, c clients.Client, meta *metadata.Metadata, zipData []byte) {
+	caps := c.RuleCapabilities()
+	if caps == nil || caps.GenerateRuleFile == nil || caps.ParseRuleFile == nil {
+		return
+	}
+	cfg := meta.Rule
+	if cfg == nil {
+		cfg = &metadata.RuleConfig{}
+	}
+	promptFile := cfg.PromptFile
+	if promptFile == "" {
+		promptFile = rule.DefaultPromptFile
+	}
+	body, _ := utils.ReadZipFile(zipData, promptFile)
+
+	generated := caps.GenerateRuleFile(cfg, string(body))
+	// Client parsers fall back to "no frontmatter" on bad YAML, which would
+	// read as dropped globs; say what actually went wrong. Cursor reads
+	// .mdc globs as plain text, so unquoted globs like **/*.go are fine there.
+	if _, err := frontmatter(generated); err != nil {
+		if c.ID() == clients.ClientIDCursor {
+			return
+		}
+		r.add(Finding{
+			Rule:     RuleRuleFormat,
+			Severity: SeverityWarning,
+			Client:   c.ID(),
+			File:     "metadata.toml",
+			Message:  fmt.Sprintf("%s's rule frontmatter isn't valid YAML with these globs (%v); globs starting with '*' may be ignored — anchor them to a directory, e.g. src/**/*.go", c.DisplayName(), err),
+		})
+		return
+	}
+	parsed, err := caps.ParseRuleFile(generated)
+	if err != nil {
+		r.add(Finding{
+			Rule:     RuleRuleFormat,
+			Severity: SeverityError,
+			Client:   c.ID(),
+			File:     promptFile,
+			Message:  fmt.Sprintf("%s can't read the rule file generated from this asset: %v", c.DisplayName(), err),
+		})
+		return
+	}
+
+	switch {
+	case len(cfg.Globs) > 0 && len(parsed.Globs) == 0:
+		r.add(Finding{
+			Rule:     RuleRuleGlobsIgnored,
+			Severity: SeverityWarning,
+			Client:   c.ID(),
+			File:     "metadata.toml",
+			Message:  fmt.Sprintf("%s doesn't scope rules by path; globs %s are dropped and the rule applies everywhere", c.DisplayName(), strings.Join(cfg.Globs, ", ")),
+		})
+	case len(cfg.Globs) == 0 && c.ID() == clients.ClientIDCursor && !explicitAlwaysApply(cfg):
+		r.add(Finding{
+			Rule:     RuleRuleGlobsMissing,
+			Severity: SeverityWarning,
+			Client:   c.ID(),
+			File:     "metadata.toml",
+			Message:  "no globs: Cursor attaches the rule to every request unless it's installed to a path; set [rule].globs, or [rule.cursor] always-apply = true if that's intended",
+		})
+	}
+}
+
+func explicitAlwaysApply(cfg *metadata.RuleConfig) bool {
+	_, ok := cfg.Cursor["always-apply"].(bool)
+	return ok
+}
+
+// checkSkillDescription requires a description in the skill's frontmatter.
+// Clients list skills by it and load one only when it matches the task;
+// metadata.toml's description is sx's own and no client reads it.
+func checkSkillDescription(r *Report, meta *metadata.Metadata, zipData []byte) {
+	promptFile := meta.Skill.PromptFile
+	content, err := utils.ReadZipFile(zipData, promptFile)
+	if err != nil {
+		return // ValidateWithFiles already reported it
+	}
+	fm, err := frontmatter(content)
+	var msg string
+	switch {
+	case err != nil:
+		msg = fmt.Sprintf("invalid frontmatter: %v", err)
+	case fm == nil:
+		msg = "no frontmatter; add one with a description so clients know when to use the skill"
+	default:
+		if desc, _ := fm["description"].(string); strings.TrimSpace(desc) != "" {
+			return
+		}
+		msg = "frontmatter has no description; clients use it to decide when to load the skill"
+	}
+	r.add(Finding{Rule: RuleSkillDescription, Severity: SeverityError, File: promptFile, Message: msg})
+}
+
+// frontmatter returns the YAML frontmatter of a markdown file, or nil when
+// it has none.
+func frontmatter(content []byte) (map[string]any, error) {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	rest, ok := bytes.CutPrefix(content, []byte("---\n"))
+	if !ok {
+		return nil, nil
+	}
+	end := bytes.Index(rest, []byte("\n---"))
+	if end == -1 {
+		if bytes.HasPrefix(rest, []byte("---")) {
+			return map[string]any{}, nil
+		}
+		return nil, errors.New("unclosed frontmatter")
+	}
+	fm := map[string]any{}
+	if err := yaml.Unmarshal(rest[:end], &fm); err != nil {
+		return nil, err
+	}
+	return fm, nil
+}
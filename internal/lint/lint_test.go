@@ -0,0 +1,218 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/clients/claude_code"
+	"github.com/sleuth-io/sx/v2/internal/clients/codex"
+	"github.com/sleuth-io/sx/v2/internal/clients/cursor"
+	"github.com/sleuth-io/sx/v2/internal/clients/gemini"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+func testClients() []clients.Client {
+	return []clients.Client{claude_code.NewClient(), codex.NewClient(), cursor.NewClient(), gemini.NewClient()}
+}
+
+// zipOf zips files (name → content) the way sx add zips a directory.
+func zipOf(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := utils.CreateZip(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func findingsFor(r *Report, rule string) []Finding {
+	var out []Finding
+	for _, f := range r.Findings {
+		if f.Rule == rule {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func clientStatus(t *testing.T, r *Report, id string) ClientResult {
+	t.Helper()
+	for _, c := range r.Clients {
+		if c.Client == id {
+			return c
+		}
+	}
+	t.Fatalf("no result for client %s", id)
+	return ClientResult{}
+}
+
+func TestLint_SkillDescription(t *testing.T) {
+	r := Lint(zipOf(t, map[string]string{"SKILL.md": "# Review\n\nReview PRs.\n"}), "review", testClients())
+	if r.Type != "skill" || r.Name != "review" {
+		t.Fatalf("detected %s %q, want skill review", r.Type, r.Name)
+	}
+	if len(findingsFor(r, RuleMetadataMissing)) != 1 {
+		t.Errorf("expected a metadata-missing note: %+v", r.Findings)
+	}
+	got := findingsFor(r, RuleSkillDescription)
+	if len(got) != 1 || got[0].Severity != SeverityError || got[0].File != "SKILL.md" {
+		t.Fatalf("skill-description findings = %+v", got)
+	}
+
+	r = Lint(zipOf(t, map[string]string{
+		"SKILL.md": "---\nname: review\ndescription: Review pull requests\n---\n\nReview PRs.\n",
+	}), "review", testClients())
+	if r.Count(SeverityError) != 0 || r.Count(SeverityWarning) != 0 {
+		t.Errorf("described skill has problems: %+v", r.Findings)
+	}
+	if s := clientStatus(t, r, "codex").Status; s != StatusOK {
+		t.Errorf("codex status = %s, want ok", s)
+	}
+}
+
+func TestLint_HookEventUnsupported(t *testing.T) {
+	hook := map[string]string{
+		"hook.sh":       "echo compacting\n",
+		"metadata.toml": "[asset]\nname = \"compact-log\"\ntype = \"hook\"\n\n[hook]\nevent = \"pre-compact\"\nscript-file = \"hook.sh\"\n",
+	}
+	r := Lint(zipOf(t, hook), "x", testClients())
+	if r.Count(SeverityError) != 0 {
+		t.Fatalf("unexpected errors: %+v", r.Findings)
+	}
+	got := findingsFor(r, RuleHookEventUnsupported)
+	if len(got) != 1 || got[0].Client != "gemini" || got[0].Severity != SeverityWarning {
+		t.Fatalf("hook-event-unsupported findings = %+v", got)
+	}
+	if s := clientStatus(t, r, "gemini").Status; s != StatusSkipped {
+		t.Errorf("gemini status = %s, want skipped", s)
+	}
+	if c := clientStatus(t, r, "codex"); c.Status != StatusSkipped || c.Reason != "doesn't install hook assets" {
+		t.Errorf("codex = %+v, want skipped for asset type", c)
+	}
+	if s := clientStatus(t, r, "claude-code").Status; s != StatusOK {
+		t.Errorf("claude-code status = %s, want ok", s)
+	}
+
+	// A per-client override makes the event available.
+	hook["metadata.toml"] += "\n[hook.gemini]\nevent = \"PreCompress\"\n"
+	r = Lint(zipOf(t, hook), "x", testClients())
+	if len(findingsFor(r, RuleHookEventUnsupported)) != 0 {
+		t.Errorf("override not honored: %+v", r.Findings)
+	}
+
+	// Restricted to a client that can't fire it, nothing installs it.
+	hook["metadata.toml"] = "[asset]\nname = \"compact-log\"\ntype = \"hook\"\nclients = [\"gemini\"]\n\n[hook]\nevent = \"pre-compact\"\nscript-file = \"hook.sh\"\n"
+	r = Lint(zipOf(t, hook), "x", testClients())
+	if len(findingsFor(r, RuleNoCompatibleClient)) != 1 || r.Count(SeverityError) != 1 {
+		t.Errorf("expected no-compatible-client error: %+v", r.Findings)
+	}
+}
+
+func TestLint_RuleGlobs(t *testing.T) {
+	r := Lint(zipOf(t, map[string]string{"RULE.md": "Use tabs.\n"}), "tabs", testClients())
+	got := findingsFor(r, RuleRuleGlobsMissing)
+	if len(got) != 1 || got[0].Client != "cursor" {
+		t.Fatalf("rule-globs-missing findings = %+v", got)
+	}
+
+	r = Lint(zipOf(t, map[string]string{
+		"RULE.md":       "Use tabs.\n",
+		"metadata.toml": "[asset]\nname = \"tabs\"\ntype = \"rule\"\n\n[rule.cursor]\nalways-apply = true\n",
+	}), "tabs", testClients())
+	if len(findingsFor(r, RuleRuleGlobsMissing)) != 0 {
+		t.Errorf("explicit always-apply still flagged: %+v", r.Findings)
+	}
+
+	r = Lint(zipOf(t, map[string]string{
+		"RULE.md":       "Use tabs.\n",
+		"metadata.toml": "[asset]\nname = \"tabs\"\ntype = \"rule\"\n\n[rule]\nglobs = [\"src/**/*.go\"]\n",
+	}), "tabs", testClients())
+	got = findingsFor(r, RuleRuleGlobsIgnored)
+	if len(got) != 1 || got[0].Client != "gemini" {
+		t.Fatalf("rule-globs-ignored findings = %+v", got)
+	}
+	for _, id := range []string{"claude-code", "cursor"} {
+		if s := clientStatus(t, r, id).Status; s != StatusOK {
+			t.Errorf("%s status = %s, want ok", id, s)
+		}
+	}
+
+	r = Lint(zipOf(t, map[string]string{
+		"RULE.md":       "Use tabs.\n",
+		"metadata.toml": "[asset]\nname = \"tabs\"\ntype = \"rule\"\n\n[rule]\nglobs = [\"**/*.go\"]\n",
+	}), "tabs", testClients())
+	got = findingsFor(r, RuleRuleFormat)
+	if len(got) != 1 || got[0].Client != "claude-code" {
+		t.Fatalf("rule-format findings = %+v", got)
+	}
+}
+
+func TestLint_MetadataErrors(t *testing.T) {
+	r := Lint(zipOf(t, map[string]string{
+		"hook.sh":       "echo\n",
+		"metadata.toml": "[asset]\nname = \"h\"\ntype = \"hook\"\n\n[hook]\nevent = \"on-save\"\nscript-file = \"hook.sh\"\n",
+	}), "h", testClients())
+	if got := findingsFor(r, RuleMetadataInvalid); len(got) != 1 || got[0].Severity != SeverityError {
+		t.Fatalf("metadata-invalid findings = %+v", r.Findings)
+	}
+	if len(r.Clients) != 0 {
+		t.Errorf("clients checked despite invalid metadata: %+v", r.Clients)
+	}
+
+	r = Lint(zipOf(t, map[string]string{"metadata.toml": "[asset\n"}), "h", testClients())
+	if len(findingsFor(r, RuleMetadataParse)) != 1 {
+		t.Errorf("metadata-parse findings = %+v", r.Findings)
+	}
+
+	r = Lint([]byte("not a zip"), "h", testClients())
+	if len(findingsFor(r, RuleArchiveInvalid)) != 1 {
+		t.Errorf("archive-invalid findings = %+v", r.Findings)
+	}
+}
+
+func TestSARIF(t *testing.T) {
+	dirRep := Lint(zipOf(t, map[string]string{"SKILL.md": "no frontmatter\n"}), "review", testClients())
+	dirRep.Source, dirRep.Dir = "skills/review", "skills/review"
+	vaultRep := Lint(zipOf(t, map[string]string{"RULE.md": "Use tabs.\n"}), "tabs", testClients())
+	vaultRep.Source = "tabs@2"
+
+	log := SARIF([]*Report{dirRep, vaultRep}, "1.2.3")
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("log = %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != len(Rules) || run.Tool.Driver.Version != "1.2.3" {
+		t.Errorf("driver = %+v", run.Tool.Driver)
+	}
+	var sawSkill, sawRule bool
+	for _, res := range run.Results {
+		switch res.RuleID {
+		case RuleSkillDescription:
+			sawSkill = true
+			if res.Level != "error" || len(res.Locations) != 1 ||
+				res.Locations[0].PhysicalLocation.ArtifactLocation.URI != "skills/review/SKILL.md" {
+				t.Errorf("skill result = %+v", res)
+			}
+		case RuleRuleGlobsMissing:
+			sawRule = true
+			if res.Level != "warning" || len(res.Locations) != 0 || res.Properties["client"] != "cursor" {
+				t.Errorf("rule result = %+v", res)
+			}
+		}
+	}
+	if !sawSkill || !sawRule {
+		t.Errorf("missing results: %+v", run.Results)
+	}
+}
@@ -0,0 +1,113 @@
+package lint
+
+import (
+	"path"
+	"path/filepath"
+)
+
+// SARIF 2.1.0, the subset code-scanning tools (GitHub, GitLab, Azure
+// DevOps) read: one run, the rule catalogue, and one result per finding.
+
+// SARIFLog is a SARIF log file.
+type SARIFLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIF converts reports into one SARIF log. toolVersion is sx's version.
+func SARIF(reports []*Report, toolVersion string) *SARIFLog {
+	rules := make([]sarifRule, 0, len(Rules))
+	for _, r := range Rules {
+		rules = append(rules, sarifRule{ID: r.ID, ShortDescription: sarifMessage{Text: r.Description}})
+	}
+	results := []sarifResult{}
+	for _, rep := range reports {
+		for _, f := range rep.Findings {
+			res := sarifResult{
+				RuleID:  f.Rule,
+				Level:   string(f.Severity),
+				Message: sarifMessage{Text: rep.Name + ": " + f.Message},
+			}
+			if uri := findingURI(rep, f); uri != "" {
+				res.Locations = []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: uri}}}}
+			}
+			if f.Client != "" {
+				res.Properties = map[string]string{"client": f.Client}
+			}
+			results = append(results, res)
+		}
+	}
+	return &SARIFLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "sx",
+				Version:        toolVersion,
+				InformationURI: "https://github.com/sleuth-io/sx",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}
+}
+
+// findingURI locates a finding: the file inside the asset's directory when
+// the asset came from one, else the local file it came from. Assets read
+// from the vault have no location.
+func findingURI(rep *Report, f Finding) string {
+	switch {
+	case rep.Dir != "" && f.File != "":
+		return path.Join(filepath.ToSlash(rep.Dir), f.File)
+	case rep.Dir != "":
+		return filepath.ToSlash(rep.Dir)
+	default:
+		return filepath.ToSlash(rep.Path)
+	}
+}
@@ -0,0 +1,103 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/zalando/go-keyring"
+
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// The provider selection is machine-level: one llm.json under the sx
+// config dir, written by the app's settings panel and read by anything
+// else on the machine that needs a model (sx quality eval). API keys
+// live in the OS keyring under the app's extension-secrets service, or
+// — for CI, which has no keyring — in the environment.
+
+// ConfigFile holds the provider selection under the sx config dir.
+const ConfigFile = "llm.json"
+
+// KeyringService is the OS keyring service API keys are stored under,
+// shared with extension secrets so they're revocable together.
+const KeyringService = "sx-app-plugins"
+
+// KeyringAccount namespaces a provider's API key within KeyringService.
+func KeyringAccount(provider string) string {
+	return "llm-provider/" + provider
+}
+
+// ConfigPath returns the path of llm.json.
+func ConfigPath() (string, error) {
+	dir, err := utils.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ConfigFile), nil
+}
+
+// LoadConfig reads the saved provider selection; a missing file is an
+// empty Config, not an error.
+func LoadConfig() (Config, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return Config{}, err
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- fixed name under the sx config dir
+	if os.IsNotExist(err) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, err
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("corrupt %s: %w", ConfigFile, err)
+	}
+	return cfg, nil
+}
+
+// NeedsAPIKey reports whether a provider is a hosted API reached with
+// the user's own key.
+func NeedsAPIKey(provider string) bool {
+	return provider == ProviderAnthropic || provider == ProviderOpenAI || provider == ProviderGoogle
+}
+
+// APIKeyEnv is the generic environment variable for a provider API key;
+// it wins over the vendor's own variable.
+const APIKeyEnv = "SX_LLM_API_KEY"
+
+// vendorKeyEnvs lists each API provider's conventional key variables.
+var vendorKeyEnvs = map[string][]string{
+	ProviderAnthropic: {"ANTHROPIC_API_KEY"},
+	ProviderOpenAI:    {"OPENAI_API_KEY"},
+	ProviderGoogle:    {"GEMINI_API_KEY", "GOOGLE_API_KEY"},
+}
+
+// KeyEnvNames returns the environment variables LookupAPIKey checks for
+// a provider, in order.
+func KeyEnvNames(provider string) []string {
+	return append([]string{APIKeyEnv}, vendorKeyEnvs[provider]...)
+}
+
+// LookupAPIKey resolves a provider's API key for headless callers: the
+// environment first (KeyEnvNames), then the key the app stored in the
+// OS keyring. An unavailable keyring is treated as no key — the caller
+// reports which variables to set.
+func LookupAPIKey(provider string) string {
+	if !NeedsAPIKey(provider) {
+		return ""
+	}
+	for _, name := range KeyEnvNames(provider) {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	v, err := keyring.Get(KeyringService, KeyringAccount(provider))
+	if err != nil {
+		return ""
+	}
+	return v
+}
@@ -97,6 +97,12 @@ type SourceHTTP struct {
 // SourcePath represents a local path source for an asset
 type SourcePath struct {
 	Path string `toml:"path"`
+
+	// Hashes optionally pins the content at Path. The only algorithm is
+	// "sha256", computed over file names and contents rather than bytes
+	// on disk (see utils.ComputeZipContentSHA256), so it holds for both a
+	// zip and an exploded directory.
+	Hashes map[string]string `toml:"hashes,omitempty"`
 }
 
 // SourceGit represents a Git repository source for an asset
@@ -106,7 +112,11 @@ type SourceGit struct {
 	Subdirectory string `toml:"subdirectory,omitempty"`
 }
 
-// Dependency represents a dependency reference
+// Dependency represents a dependency reference. Version is either empty
+// (any version), an exact version ("1.5.0", "3"), or a semver range
+// ("^1.2", ">=2.0 <3"), matched with the version package. A range stays
+// as declared; install-time resolution moves the dependency's own row to
+// the version it picked.
 type Dependency struct {
 	Name    string `toml:"name"`
 	Version string `toml:"version,omitempty"`
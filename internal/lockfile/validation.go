@@ -6,6 +6,8 @@ import (
 	"regexp"
 
 	"github.com/Masterminds/semver/v3"
+
+	"github.com/sleuth-io/sx/v2/internal/version"
 )
 
 var (
@@ -95,7 +97,7 @@ func dependencyBrokenByExtraction(a Asset, surviving, extracted []Asset) bool {
 
 func depSatisfiedBy(d Dependency, rows []Asset) bool {
 	for i := range rows {
-		if rows[i].Name == d.Name && (d.Version == "" || d.Version == rows[i].Version) {
+		if rows[i].Name == d.Name && version.Satisfies(d.Version, rows[i].Version) {
 			return true
 		}
 	}
@@ -245,6 +247,11 @@ func (s *SourcePath) Validate() error {
 	if s.Path == "" {
 		return errors.New("path is required")
 	}
+	for algo := range s.Hashes {
+		if algo != "sha256" {
+			return fmt.Errorf("unsupported hash algorithm: %s (path sources use sha256)", algo)
+		}
+	}
 	return nil
 }
 
@@ -278,8 +285,14 @@ func validateDependency(dep *Dependency, assetMap map[string]*Asset, parent *Ass
 		return errors.New("dependency not found in lock file")
 	}
 
-	// If version is specified, it must match
-	if dep.Version != "" && dep.Version != ast.Version {
+	// An exact version must match the row in the lock file. A range is
+	// only checked for syntax here: install-time resolution picks the
+	// highest published version that satisfies every dependent's range.
+	if version.IsRange(dep.Version) {
+		if _, err := version.ParseRange(dep.Version); err != nil {
+			return fmt.Errorf("dependency %s: %w", dep.Name, err)
+		}
+	} else if dep.Version != "" && dep.Version != ast.Version {
 		return fmt.Errorf("dependency version %q does not match asset version %q", dep.Version, ast.Version)
 	}
 
@@ -107,6 +107,26 @@ type Manifest struct {
 	// AppPlugins is the org's desktop-app extension policy
 	// (docs/app-plugins-spec.md). Nil means open.
 	AppPlugins *AppPluginPolicy `toml:"app-plugins,omitempty"`
+
+	// Signing configures asset signature verification: the keys whose
+	// signatures installs trust and what happens to assets they didn't
+	// sign (docs/signing.md). Nil means verification is off.
+	Signing *Signing `toml:"signing,omitempty"`
+}
+
+// Signing is the vault's [signing] table. Policy is "off" (the default
+// when empty), "warn", or "enforce".
+type Signing struct {
+	Policy      string       `toml:"policy,omitempty"`
+	TrustedKeys []TrustedKey `toml:"trusted_keys,omitempty"`
+}
+
+// TrustedKey is one public key installs accept signatures from. Name is
+// who holds it — usually an email — and PublicKey is a minisign public
+// key or "ed25519:<base64>".
+type TrustedKey struct {
+	Name      string `toml:"name"`
+	PublicKey string `toml:"public_key"`
 }
 
 // AppPluginPolicy gates which extensions the desktop app may enable.
@@ -161,6 +181,22 @@ type Asset struct {
 	// caller regardless of identity or repo. See ScopeKind for the set of
 	// permitted kinds.
 	Scopes []Scope `toml:"scopes,omitempty"`
+
+	// Channels names published versions other callers can follow, e.g.
+	// stable = "3", beta = "4". A scope row opts into a channel through
+	// Scope.Channel; rows without one get Version. See docs/channels.md.
+	Channels map[string]string `toml:"channels,omitempty"`
+}
+
+// ChannelVersion returns the version a channel currently points at, or
+// Version when channel is empty or not defined on this asset.
+func (a *Asset) ChannelVersion(channel string) string {
+	if channel != "" {
+		if v, ok := a.Channels[channel]; ok && v != "" {
+			return v
+		}
+	}
+	return a.Version
 }
 
 // Dependency is a reference to another asset.
@@ -232,6 +268,25 @@ type Scope struct {
 	Team  string    `toml:"team,omitempty"`
 	User  string    `toml:"user,omitempty"`
 	Bot   string    `toml:"bot,omitempty"`
+
+	// Channel selects which of the asset's Channels this target follows.
+	// Empty means the asset's latest Version. Not part of the row's
+	// identity: re-installing to the same target replaces the selector.
+	Channel string `toml:"channel,omitempty"`
+
+	// Version pins this target to one stored version of the asset. It
+	// overrides Channel and the asset's latest Version. Like Channel, it
+	// is not part of the row's identity.
+	Version string `toml:"version,omitempty"`
+}
+
+// RowVersion returns the version a scope row of a resolves to: its pin,
+// else the channel it follows, else a's latest Version.
+func (s *Scope) RowVersion(a *Asset) string {
+	if s.Version != "" {
+		return s.Version
+	}
+	return a.ChannelVersion(s.Channel)
 }
 
 // Validate returns nil if this scope row has the fields required by its Kind.
@@ -491,7 +546,9 @@ func (m *Manifest) FindAsset(name string) *Asset {
 // NAME alone (it was name+version before schema v2 hardening): no caller
 // keeps multiple versions of one name as separate rows — callers that need
 // per-version granularity (RemoveAsset, findAssetVersionInManifest) take an
-// explicit version instead. Returns the pointer into the manifest's slice.
+// explicit version instead. Channels belong to the asset rather than to a
+// version, so a row written without any keeps the existing row's channels
+// through the republish. Returns the pointer into the manifest's slice.
 func (m *Manifest) UpsertAsset(a Asset) *Asset {
 	idx := -1
 	for i := range m.Assets {
@@ -504,6 +561,9 @@ func (m *Manifest) UpsertAsset(a Asset) *Asset {
 		m.Assets = append(m.Assets, a)
 		return &m.Assets[len(m.Assets)-1]
 	}
+	if a.Channels == nil {
+		a.Channels = m.Assets[idx].Channels
+	}
 	m.Assets[idx] = a
 	kept := m.Assets[:idx+1]
 	for _, other := range m.Assets[idx+1:] {
@@ -627,6 +687,8 @@ func normalizeScopeInPlace(s *Scope) {
 	s.Team = strings.TrimSpace(s.Team)
 	s.User = NormalizeEmail(s.User)
 	s.Bot = strings.TrimSpace(s.Bot)
+	s.Channel = strings.ToLower(strings.TrimSpace(s.Channel))
+	s.Version = strings.TrimSpace(s.Version)
 
 	if len(s.Paths) > 0 {
 		cleaned := make([]string, 0, len(s.Paths))
@@ -852,6 +914,21 @@ func normalized(m *Manifest) *Manifest {
 func normalizeAssetInPlace(a *Asset) {
 	a.Name = strings.TrimSpace(a.Name)
 	a.Version = strings.TrimSpace(a.Version)
+	if len(a.Channels) > 0 {
+		channels := make(map[string]string, len(a.Channels))
+		for name, v := range a.Channels {
+			name = strings.ToLower(strings.TrimSpace(name))
+			v = strings.TrimSpace(v)
+			if name == "" || v == "" {
+				continue
+			}
+			channels[name] = v
+		}
+		a.Channels = channels
+		if len(channels) == 0 {
+			a.Channels = nil
+		}
+	}
 	if len(a.Scopes) > 0 {
 		scopes := make([]Scope, 0, len(a.Scopes))
 		type scopeKey struct {
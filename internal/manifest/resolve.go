@@ -1,7 +1,11 @@
 package manifest
 
 import (
+	"errors"
+	"fmt"
+	"slices"
 	"sort"
+	"strings"
 
 	"github.com/sleuth-io/sx/v2/internal/asset"
 	"github.com/sleuth-io/sx/v2/internal/lockfile"
@@ -9,6 +13,13 @@ import (
 	"github.com/sleuth-io/sx/v2/internal/scope"
 )
 
+// VersionSource locates an archived version of an asset, for callers that
+// resolve to a version other than the manifest row's (a channel
+// subscription or a pinned scope). It returns a lock row carrying that version's sources,
+// clients and dependencies, or ok=false when the version is not available —
+// the caller then gets the row's own version.
+type VersionSource func(name, version string) (_ *lockfile.Asset, ok bool)
+
 // Resolve produces a lockfile.LockFile from the manifest, flattening
 // team/user/bot scopes into repo scopes based on the caller's identity.
 // The resulting lock file is the per-user, machine-generated artifact
@@ -49,9 +60,24 @@ import (
 // verdict, the asset's Scopes is nil. Otherwise repo-wide and
 // path-restricted entries are deduped per normalized repo URL — a
 // repo-wide entry wins over path-restricted entries for the same repo.
+//
+// Resolve always emits each asset at its manifest row's Version. Use
+// ResolveWithVersions to honor release channels.
 func Resolve(m *Manifest, actor mgmt.Actor) *lockfile.LockFile {
+	lf, _ := ResolveWithVersions(m, actor, nil)
+	return lf
+}
+
+// ResolveWithVersions is Resolve with release channels and scope pins
+// applied: each asset is emitted at the version the caller's most specific
+// scope rows pin or follow in each place it installs (see callerVersions),
+// with that version's sources looked up through versions — one lock row
+// per version. A version it can't find leaves those places at the row's
+// Version. Assets whose rows conflict are left out of the lock and
+// reported as VersionConflictErrors; a nil versions skips all of this.
+func ResolveWithVersions(m *Manifest, actor mgmt.Actor, versions VersionSource) (*lockfile.LockFile, error) {
 	if m == nil {
-		return nil
+		return nil, nil
 	}
 
 	actorEmail := mgmt.NormalizeEmail(actor.Email)
@@ -63,7 +89,7 @@ func Resolve(m *Manifest, actor mgmt.Actor) *lockfile.LockFile {
 	}
 
 	if len(m.Assets) == 0 {
-		return out
+		return out, nil
 	}
 
 	// For bot callers, look up the bot's team list once (constant across
@@ -82,6 +108,7 @@ func Resolve(m *Manifest, actor mgmt.Actor) *lockfile.LockFile {
 	}
 
 	collectionScopes := collectionScopesByAsset(m)
+	var conflicts []error
 
 	out.Assets = make([]lockfile.Asset, 0, len(m.Assets))
 	for i := range m.Assets {
@@ -128,9 +155,268 @@ func Resolve(m *Manifest, actor mgmt.Actor) *lockfile.LockFile {
 			continue
 		}
 		dst.Scopes = resolved
-		out.Assets = append(out.Assets, dst)
+		if versions == nil {
+			out.Assets = append(out.Assets, dst)
+			continue
+		}
+		picks, err := callerVersions(&src, effective, resolved, m, actor, actorEmail, botTeams)
+		if err != nil {
+			conflicts = append(conflicts, err)
+			continue
+		}
+		out.Assets = append(out.Assets, versionedRows(dst, picks, versions)...)
 	}
-	return out
+	return out, errors.Join(conflicts...)
+}
+
+// versionedRows emits dst once per version the caller resolved to, each
+// with that version's sources from the archive. A version the archive
+// can't find falls back to the row's own, sharing its lock row.
+func versionedRows(dst lockfile.Asset, picks []versionedScopes, versions VersionSource) []lockfile.Asset {
+	var rows []lockfile.Asset
+	for _, p := range picks {
+		row := dst
+		if p.version != dst.Version {
+			if alt, ok := versions(dst.Name, p.version); ok {
+				row.Version = p.version
+				row.Clients = append([]string(nil), alt.Clients...)
+				row.Dependencies = alt.Dependencies
+				row.SourceHTTP = alt.SourceHTTP
+				row.SourcePath = alt.SourcePath
+				row.SourceGit = alt.SourceGit
+			}
+		}
+		row.Scopes = p.scopes
+		if i := slices.IndexFunc(rows, func(r lockfile.Asset) bool { return r.Version == row.Version }); i >= 0 {
+			rows[i].Scopes = mergeScopes(append(rows[i].Scopes, row.Scopes...))
+			continue
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// Scope rows that choose a caller's version, from least to most specific.
+// Rows that reach the caller everywhere (org, team, user, bot) are ranked
+// against each other, and rows that reach one repository (team
+// repositories, repo, path) against each other.
+const (
+	scopeRankNone = iota
+	scopeRankOrg
+	scopeRankTeam
+	scopeRankIdentity
+	scopeRankRepo
+)
+
+// VersionConflictError reports scope rows that reach the same caller in
+// the same place but pin or follow different versions of an asset. The
+// lock can install only one version there, and picking either would
+// silently override the other's pin.
+type VersionConflictError struct {
+	Asset string
+	Where string // a repository, or "everywhere"
+	Rows  []string
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("version conflict for %s %s: %s", e.Asset, e.Where, strings.Join(e.Rows, "; "))
+}
+
+// versionRow is one scope row's say in which version a caller gets
+type versionRow struct {
+	rank     int
+	repos    []string // nil when the row reaches the caller everywhere
+	version  string
+	explicit bool // pinned, or follows a channel the asset defines
+	label    string
+}
+
+// versionedScopes is one lock row's worth of a caller's resolution: a
+// version and the scopes it installs to (nil for everywhere)
+type versionedScopes struct {
+	version string
+	scopes  []lockfile.Scope
+}
+
+// callerVersions picks which version of a the caller gets where. The lock
+// carries one row per version, so resolution runs per place: where the
+// asset is global for the caller, the most specific row reaching them
+// everywhere decides (their own user or bot row, then a team, then org);
+// in each scoped repository, the most specific row naming it decides
+// (repo and path rows, then team repositories). A row that is pinned or
+// follows a defined channel is explicit; unpinned rows count as latest.
+//
+// Two explicit rows at the same level that disagree, or an explicit repo
+// row that disagrees with the version a global install brings into that
+// repo, are a VersionConflictError rather than a silent pick.
+func callerVersions(a *Asset, in []Scope, resolved []lockfile.Scope, m *Manifest, actor mgmt.Actor, actorEmail string, botTeams []string) ([]versionedScopes, error) {
+	var everywhere []versionRow
+	byRepo := make(map[string][]versionRow)
+	var repoOrder []string
+	for _, s := range in {
+		row, ok := versionRowFor(a, s, m, actor, actorEmail, botTeams)
+		if !ok {
+			continue
+		}
+		if row.repos == nil {
+			everywhere = append(everywhere, row)
+			continue
+		}
+		for _, repo := range row.repos {
+			k := scope.NormalizeRepoURL(repo)
+			if _, seen := byRepo[k]; !seen {
+				repoOrder = append(repoOrder, k)
+			}
+			byRepo[k] = append(byRepo[k], row)
+		}
+	}
+
+	if resolved == nil {
+		global, err := pickVersion(a, everywhere, "everywhere")
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range repoOrder {
+			local, err := pickVersion(a, byRepo[k], "in "+k)
+			if err != nil {
+				return nil, err
+			}
+			if local.explicit && local.version != global.version {
+				return nil, &VersionConflictError{
+					Asset: a.Name,
+					Where: "in " + k,
+					Rows:  append(explicitLabels(byRepo[k], local.version), global.describe("everywhere")),
+				}
+			}
+		}
+		return []versionedScopes{{version: global.version}}, nil
+	}
+
+	var out []versionedScopes
+	for _, sc := range resolved {
+		k := scope.NormalizeRepoURL(sc.Repo)
+		local, err := pickVersion(a, byRepo[k], "in "+k)
+		if err != nil {
+			return nil, err
+		}
+		i := slices.IndexFunc(out, func(v versionedScopes) bool { return v.version == local.version })
+		if i < 0 {
+			out = append(out, versionedScopes{version: local.version})
+			i = len(out) - 1
+		}
+		out[i].scopes = append(out[i].scopes, sc)
+	}
+	return out, nil
+}
+
+// versionPick is the outcome of pickVersion, with the rows that decided it
+type versionPick struct {
+	version  string
+	explicit bool
+	rows     []versionRow
+}
+
+func (p versionPick) describe(where string) string {
+	if len(p.rows) == 0 {
+		return "latest " + p.version + " " + where
+	}
+	return strings.Join(explicitLabels(p.rows, p.version), "; ")
+}
+
+// pickVersion applies the most specific of rows: its explicit version, or
+// a's latest Version when none of those rows is explicit
+func pickVersion(a *Asset, rows []versionRow, where string) (versionPick, error) {
+	best := scopeRankNone
+	for _, r := range rows {
+		best = max(best, r.rank)
+	}
+	var decisive []versionRow
+	var versions []string
+	for _, r := range rows {
+		if r.rank != best || !r.explicit {
+			continue
+		}
+		decisive = append(decisive, r)
+		if !slices.Contains(versions, r.version) {
+			versions = append(versions, r.version)
+		}
+	}
+	switch len(versions) {
+	case 0:
+		return versionPick{version: a.Version}, nil
+	case 1:
+		return versionPick{version: versions[0], explicit: true, rows: decisive}, nil
+	}
+	labels := make([]string, len(decisive))
+	for i, r := range decisive {
+		labels[i] = r.label
+	}
+	return versionPick{}, &VersionConflictError{Asset: a.Name, Where: where, Rows: labels}
+}
+
+func explicitLabels(rows []versionRow, version string) []string {
+	var labels []string
+	for _, r := range rows {
+		if r.explicit && r.version == version {
+			labels = append(labels, r.label)
+		}
+	}
+	return labels
+}
+
+// versionRowFor reports whether a scope row reaches the caller and, if so,
+// where and how specifically, using the same membership rules as
+// resolveScopes and resolveScopesForBot.
+func versionRowFor(a *Asset, s Scope, m *Manifest, actor mgmt.Actor, actorEmail string, botTeams []string) (versionRow, bool) {
+	row := versionRow{version: s.RowVersion(a)}
+	var target string
+	switch s.Kind {
+	case ScopeKindRepo:
+		row.rank, row.repos, target = scopeRankRepo, []string{s.Repo}, "repo "+s.Repo
+	case ScopeKindPath:
+		row.rank, row.repos, target = scopeRankRepo, []string{s.Repo}, "path "+s.Repo+":"+strings.Join(s.Paths, ",")
+	case ScopeKindOrg:
+		row.rank, target = scopeRankOrg, "org"
+	case ScopeKindTeam:
+		team, err := m.FindTeam(s.Team)
+		if err != nil || team == nil {
+			return row, false
+		}
+		if actor.IsBot() {
+			if !slices.Contains(botTeams, s.Team) {
+				return row, false
+			}
+		} else if actorEmail == "" || !team.IsMember(actorEmail) {
+			return row, false
+		}
+		row.rank, target = scopeRankTeam, "team "+s.Team
+		if len(team.Repositories) > 0 {
+			row.repos = team.Repositories
+		}
+	case ScopeKindUser:
+		if actor.IsBot() || actorEmail == "" || mgmt.NormalizeEmail(s.User) != actorEmail {
+			return row, false
+		}
+		row.rank, target = scopeRankIdentity, "user "+s.User
+	case ScopeKindBot:
+		if !actor.IsBot() || s.Bot != actor.Bot {
+			return row, false
+		}
+		row.rank, target = scopeRankIdentity, "bot "+s.Bot
+	default:
+		return row, false
+	}
+	switch {
+	case s.Version != "":
+		row.explicit = true
+		row.label = fmt.Sprintf("%s pins %s", target, s.Version)
+	case s.Channel != "" && a.Channels[s.Channel] != "":
+		row.explicit = true
+		row.label = fmt.Sprintf("%s follows %s (%s)", target, s.Channel, row.version)
+	default:
+		row.label = fmt.Sprintf("%s installs latest (%s)", target, row.version)
+	}
+	return row, true
 }
 
 // collectionScopesByAsset flattens every collection's own scope rows onto
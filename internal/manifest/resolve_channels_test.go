@@ -0,0 +1,237 @@
+package manifest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+)
+
+func channelManifest() *Manifest {
+	return &Manifest{
+		SchemaVersion: CurrentSchemaVersion,
+		Teams: []Team{{
+			Name:    "platform",
+			Members: []string{"alice@acme.com"},
+			Admins:  []string{"alice@acme.com"},
+		}},
+		Bots: []Bot{{Name: "ci", Teams: []string{"platform"}}},
+		Assets: []Asset{{
+			Name: "reviewer", Version: "5", Type: asset.TypeSkill,
+			SourcePath: &SourcePath{Path: "assets/reviewer"},
+			Channels:   map[string]string{"stable": "3", "beta": "4"},
+			Scopes: []Scope{
+				{Kind: ScopeKindOrg, Channel: "stable"},
+				{Kind: ScopeKindTeam, Team: "platform", Channel: "beta"},
+				{Kind: ScopeKindBot, Bot: "ci"},
+			},
+		}},
+	}
+}
+
+func archive(name, version string) (*lockfile.Asset, bool) {
+	return &lockfile.Asset{
+		Name:       name,
+		Version:    version,
+		SourcePath: &lockfile.SourcePath{Path: ".sx/versions/" + name + "/" + version},
+	}, true
+}
+
+func mustResolveWithVersions(t *testing.T, m *Manifest, actor mgmt.Actor, versions VersionSource) *lockfile.LockFile {
+	t.Helper()
+	lf, err := ResolveWithVersions(m, actor, versions)
+	if err != nil {
+		t.Fatalf("ResolveWithVersions: %v", err)
+	}
+	return lf
+}
+
+// repoScopedManifest installs reviewer to two repositories with the given
+// selectors on each row.
+func repoScopedManifest(a, b Scope) *Manifest {
+	m := channelManifest()
+	a.Kind, a.Repo = ScopeKindRepo, "github.com/acme/a"
+	b.Kind, b.Repo = ScopeKindRepo, "github.com/acme/b"
+	m.Assets[0].Scopes = []Scope{a, b}
+	return m
+}
+
+// versionsByRepo maps each lock row's repositories to its version.
+func versionsByRepo(t *testing.T, lf *lockfile.LockFile) map[string]string {
+	t.Helper()
+	got := map[string]string{}
+	for _, a := range lf.Assets {
+		if len(a.Scopes) == 0 {
+			t.Fatalf("%s@%s resolved global, want repo-scoped rows", a.Name, a.Version)
+		}
+		for _, s := range a.Scopes {
+			got[s.Repo] = a.Version
+		}
+	}
+	return got
+}
+
+// TestResolveWithVersions_Channels: each caller gets the version of the
+// channel their most specific scope row follows, with that version's
+// source from the archive.
+func TestResolveWithVersions_Channels(t *testing.T) {
+	cases := []struct {
+		name  string
+		actor mgmt.Actor
+		want  string
+	}{
+		{"org row follows stable", mgmt.Actor{Email: "bob@acme.com"}, "3"},
+		{"team member follows beta", mgmt.Actor{Email: "alice@acme.com"}, "4"},
+		{"bot row beats its team", mgmt.Actor{Email: "bot:ci", Bot: "ci"}, "5"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			lf := mustResolveWithVersions(t, channelManifest(), c.actor, archive)
+			if len(lf.Assets) != 1 {
+				t.Fatalf("assets = %+v", lf.Assets)
+			}
+			got := lf.Assets[0]
+			if got.Version != c.want {
+				t.Fatalf("version = %s, want %s", got.Version, c.want)
+			}
+			if c.want != "5" && got.SourcePath.Path != ".sx/versions/reviewer/"+c.want {
+				t.Fatalf("source = %s, want the archived %s", got.SourcePath.Path, c.want)
+			}
+		})
+	}
+}
+
+// TestResolveWithVersions_MissingArchiveFallsBack: a channel pointing at a
+// version the archive doesn't have leaves the caller on the latest row
+// rather than emitting a row with no source.
+func TestResolveWithVersions_MissingArchiveFallsBack(t *testing.T) {
+	none := func(string, string) (*lockfile.Asset, bool) { return nil, false }
+	lf := mustResolveWithVersions(t, channelManifest(), mgmt.Actor{Email: "bob@acme.com"}, none)
+	if got := lf.Assets[0]; got.Version != "5" || got.SourcePath.Path != "assets/reviewer" {
+		t.Fatalf("got %s from %s, want the latest row", got.Version, got.SourcePath.Path)
+	}
+	if lf := Resolve(channelManifest(), mgmt.Actor{Email: "bob@acme.com"}); lf.Assets[0].Version != "5" {
+		t.Fatalf("plain Resolve ignores channels, got %s", lf.Assets[0].Version)
+	}
+}
+
+// TestUpsertAsset_KeepsChannels: publishing a new version must not drop
+// the asset's channel table.
+func TestUpsertAsset_KeepsChannels(t *testing.T) {
+	m := channelManifest()
+	m.UpsertAsset(Asset{Name: "reviewer", Version: "6", Type: asset.TypeSkill})
+	if got := m.FindAsset("reviewer").Channels["stable"]; got != "3" {
+		t.Fatalf("stable = %q after republish, want 3", got)
+	}
+}
+
+// TestResolveWithVersions_PinBeatsChannel: a pinned row installs its pin
+// even when it also follows a channel.
+func TestResolveWithVersions_PinBeatsChannel(t *testing.T) {
+	m := channelManifest()
+	m.Assets[0].Scopes[1].Version = "3"
+	lf := mustResolveWithVersions(t, m, mgmt.Actor{Email: "alice@acme.com"}, archive)
+	if got := lf.Assets[0].Version; got != "3" {
+		t.Fatalf("version = %s, want the pinned 3", got)
+	}
+}
+
+// TestResolveWithVersions_PinnedRepoNextToUnpinned: a repo pin holds in
+// its own repository and doesn't drag an unpinned repository along.
+func TestResolveWithVersions_PinnedRepoNextToUnpinned(t *testing.T) {
+	lf := mustResolveWithVersions(t, repoScopedManifest(Scope{Version: "3"}, Scope{}), mgmt.Actor{Email: "bob@acme.com"}, archive)
+	got := versionsByRepo(t, lf)
+	if got["github.com/acme/a"] != "3" || got["github.com/acme/b"] != "5" {
+		t.Fatalf("versions by repo = %v, want a pinned to 3 and b on latest 5", got)
+	}
+	lf.CreatedBy = "test"
+	if err := lf.Validate(); err != nil {
+		t.Fatalf("two versions of one asset must make a valid lock: %v", err)
+	}
+}
+
+// TestResolveWithVersions_ConflictingPins: pins that reach the same caller
+// in the same place are reported rather than settled by taking the highest.
+func TestResolveWithVersions_ConflictingPins(t *testing.T) {
+	cases := []struct {
+		name   string
+		scopes []Scope
+	}{
+		{"two rows on one repo", []Scope{
+			{Kind: ScopeKindRepo, Repo: "github.com/acme/a", Version: "3"},
+			{Kind: ScopeKindPath, Repo: "github.com/acme/a", Paths: []string{"svc"}, Version: "4"},
+		}},
+		{"a team row against a repo pin", []Scope{
+			{Kind: ScopeKindTeam, Team: "platform", Channel: "beta"},
+			{Kind: ScopeKindRepo, Repo: "github.com/acme/a", Version: "3"},
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := channelManifest()
+			m.Assets[0].Scopes = c.scopes
+			lf, err := ResolveWithVersions(m, mgmt.Actor{Email: "alice@acme.com"}, archive)
+			var conflict *VersionConflictError
+			if !errors.As(err, &conflict) {
+				t.Fatalf("err = %v, want a VersionConflictError", err)
+			}
+			if conflict.Asset != "reviewer" || len(conflict.Rows) != 2 {
+				t.Errorf("conflict = %+v, want both rows named", conflict)
+			}
+			if len(lf.Assets) != 0 {
+				t.Errorf("a conflicting asset must not be resolved, got %+v", lf.Assets)
+			}
+		})
+	}
+}
+
+// TestResolveWithVersions_RepoPinMatchingGlobalIsNoConflict: a repo pin
+// that agrees with what the caller gets everywhere else is not a conflict,
+// and an unpinned repo row never is.
+func TestResolveWithVersions_RepoPinMatchingGlobalIsNoConflict(t *testing.T) {
+	m := channelManifest()
+	m.Assets[0].Scopes = []Scope{
+		{Kind: ScopeKindTeam, Team: "platform", Channel: "beta"},
+		{Kind: ScopeKindRepo, Repo: "github.com/acme/a", Version: "4"},
+		{Kind: ScopeKindRepo, Repo: "github.com/acme/b"},
+	}
+	lf := mustResolveWithVersions(t, m, mgmt.Actor{Email: "alice@acme.com"}, archive)
+	if len(lf.Assets) != 1 || lf.Assets[0].Version != "4" || lf.Assets[0].Scopes != nil {
+		t.Fatalf("got %+v, want one global row at 4", lf.Assets)
+	}
+}
+
+// TestResolveWithVersions_ChannelPerRepo: a repo following stable next to
+// a repo following beta installs each channel's version in its own
+// repository, not the higher of the two in both.
+func TestResolveWithVersions_ChannelPerRepo(t *testing.T) {
+	m := repoScopedManifest(Scope{Channel: "stable"}, Scope{Channel: "beta"})
+	lf := mustResolveWithVersions(t, m, mgmt.Actor{Email: "bob@acme.com"}, archive)
+	got := versionsByRepo(t, lf)
+	if got["github.com/acme/a"] != "3" || got["github.com/acme/b"] != "4" {
+		t.Fatalf("versions by repo = %v, want a on stable 3 and b on beta 4", got)
+	}
+	for _, a := range lf.Assets {
+		if a.SourcePath.Path != ".sx/versions/reviewer/"+a.Version {
+			t.Errorf("%s@%s source = %s, want its archived version", a.Name, a.Version, a.SourcePath.Path)
+		}
+	}
+}
+
+// TestResolveWithVersions_ConflictingChannels: two teams the caller is on
+// following different channels are a conflict, not the higher channel.
+func TestResolveWithVersions_ConflictingChannels(t *testing.T) {
+	m := channelManifest()
+	m.Teams = append(m.Teams, Team{Name: "web", Members: []string{"alice@acme.com"}, Admins: []string{"alice@acme.com"}})
+	m.Assets[0].Scopes = []Scope{
+		{Kind: ScopeKindTeam, Team: "platform", Channel: "beta"},
+		{Kind: ScopeKindTeam, Team: "web", Channel: "stable"},
+	}
+	_, err := ResolveWithVersions(m, mgmt.Actor{Email: "alice@acme.com"}, archive)
+	var conflict *VersionConflictError
+	if !errors.As(err, &conflict) || conflict.Where != "everywhere" {
+		t.Fatalf("err = %v, want a VersionConflictError everywhere", err)
+	}
+}
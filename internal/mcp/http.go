@@ -0,0 +1,100 @@
+package mcpserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/sleuth-io/sx/v2/internal/logger"
+)
+
+// HTTPPath is where RunHTTP serves the MCP endpoint
+const HTTPPath = "/mcp"
+
+// HTTPOptions configures RunHTTP
+type HTTPOptions struct {
+	// Addr is the address to listen on, e.g. ":8765"
+	Addr string
+	// Token is the bearer token every request must carry
+	Token string
+	// CertFile and KeyFile enable TLS when both are set
+	CertFile string
+	KeyFile  string
+}
+
+// RunHTTP serves the MCP server over the streamable HTTP transport until
+// ctx is cancelled. ready, if non-nil, is called with the bound address
+// once the listener is open.
+func (s *Server) RunHTTP(ctx context.Context, opts HTTPOptions, ready func(addr net.Addr)) error {
+	if opts.Token == "" {
+		return errors.New("a bearer token is required to serve over HTTP")
+	}
+	if (opts.CertFile == "") != (opts.KeyFile == "") {
+		return errors.New("TLS needs both a certificate and a key")
+	}
+
+	listener, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", opts.Addr, err)
+	}
+	httpServer := &http.Server{
+		Handler:           s.HTTPHandler(ctx, opts.Token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if ready != nil {
+		ready(listener.Addr())
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if opts.CertFile != "" {
+			errCh <- httpServer.ServeTLS(listener, opts.CertFile, opts.KeyFile)
+		} else {
+			errCh <- httpServer.Serve(listener)
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			logger.Get().Warn("MCP HTTP server did not shut down cleanly", "error", err)
+		}
+		return nil
+	}
+}
+
+// HTTPHandler returns the MCP endpoint behind bearer-token auth, mounted
+// at HTTPPath. Every session shares one MCP server, so vault tools are
+// registered once rather than per connection.
+func (s *Server) HTTPHandler(ctx context.Context, token string) http.Handler {
+	mcpServer := s.newMCPServer(ctx)
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+		return mcpServer
+	}, nil)
+
+	verify := func(_ context.Context, got string, _ *http.Request) (*auth.TokenInfo, error) {
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			return nil, auth.ErrInvalidToken
+		}
+		// The token is a static shared secret: it has no expiry, and one
+		// user ID keeps sessions bound to token holders.
+		return &auth.TokenInfo{UserID: "sx-serve"}, nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(HTTPPath, auth.RequireBearerToken(verify, &auth.RequireBearerTokenOptions{
+		AllowMissingExpiration: true,
+	})(handler))
+	return mux
+}
@@ -0,0 +1,86 @@
+package mcpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/sleuth-io/sx/v2/internal/clients"
+)
+
+// bearerTransport adds an Authorization header to every request
+type bearerTransport struct {
+	token string
+}
+
+func (b bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestServer_HTTPHandler(t *testing.T) {
+	t.Setenv("SX_CONFIG_DIR", t.TempDir())
+
+	mock := newMockClient()
+	mock.addSkill("http-skill", "Served over HTTP", "1.0.0", "# HTTP\n\nHello.", "/tmp/skills/http-skill")
+	registry := clients.NewRegistry()
+	registry.Register(mock)
+	server := NewServer(registry)
+	server.SetUsageReporter(newMockUsageReporter())
+
+	ctx := context.Background()
+	ts := httptest.NewServer(server.HTTPHandler(ctx, "s3cret"))
+	defer ts.Close()
+
+	t.Run("rejects missing and wrong tokens", func(t *testing.T) {
+		for _, header := range []string{"", "Bearer nope"} {
+			req, _ := http.NewRequest(http.MethodPost, ts.URL+HTTPPath, nil)
+			if header != "" {
+				req.Header.Set("Authorization", header)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("Authorization %q: status %d, want 401", header, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("serves tools with the token", func(t *testing.T) {
+		client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v1.0.0"}, nil)
+		session, err := client.Connect(ctx, &mcp.StreamableClientTransport{
+			Endpoint:   ts.URL + HTTPPath,
+			HTTPClient: &http.Client{Transport: bearerTransport{"s3cret"}},
+		}, nil)
+		if err != nil {
+			t.Fatalf("Failed to connect client: %v", err)
+		}
+		defer session.Close()
+
+		tools, err := session.ListTools(ctx, nil)
+		if err != nil {
+			t.Fatalf("ListTools failed: %v", err)
+		}
+		var names []string
+		for _, tool := range tools.Tools {
+			names = append(names, tool.Name)
+		}
+		for _, want := range []string{"search_skills", "read_skill", "list_rules_for_path", "get_asset_file"} {
+			if !slices.Contains(names, want) {
+				t.Errorf("Tool %s not served; got %v", want, names)
+			}
+		}
+
+		if text := callText(t, session, "read_skill", map[string]any{"name": "http-skill"}); text != "# HTTP\n\nHello." {
+			t.Errorf("Unexpected skill content: %q", text)
+		}
+	})
+}
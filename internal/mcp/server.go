@@ -9,8 +9,10 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"github.com/sleuth-io/sx/v2/internal/asset"
 	"github.com/sleuth-io/sx/v2/internal/clients"
 	"github.com/sleuth-io/sx/v2/internal/config"
 	"github.com/sleuth-io/sx/v2/internal/gitutil"
@@ -19,21 +21,25 @@ import (
 	vaultpkg "github.com/sleuth-io/sx/v2/internal/vault"
 )
 
-// UsageReporter handles reporting skill usage
+// UsageReporter handles reporting asset usage
 type UsageReporter interface {
-	ReportSkillUsage(skillName, skillVersion string)
+	ReportAssetUsage(event stats.UsageEvent)
 }
 
 // Server provides an MCP server that exposes skill operations
 type Server struct {
 	registry      *clients.Registry
 	usageReporter UsageReporter
+	// sessionID identifies this server process in usage events when the
+	// transport has no session ID of its own (stdio)
+	sessionID string
 }
 
 // NewServer creates a new MCP server
 func NewServer(registry *clients.Registry) *Server {
 	s := &Server{
-		registry: registry,
+		registry:  registry,
+		sessionID: uuid.New().String(),
 	}
 	s.usageReporter = s // Server implements UsageReporter by default
 	return s
@@ -54,6 +60,11 @@ var fileRefPattern = regexp.MustCompile(`@([a-zA-Z0-9_\-./]+\.[a-zA-Z0-9]+)`)
 
 // Run starts the MCP server over stdio
 func (s *Server) Run(ctx context.Context) error {
+	return s.newMCPServer(ctx).Run(ctx, &mcp.StdioTransport{})
+}
+
+// newMCPServer builds the MCP server with every tool registered
+func (s *Server) newMCPServer(ctx context.Context) *mcp.Server {
 	impl := &mcp.Implementation{
 		Name:    "skills",
 		Version: "1.0.0",
@@ -61,19 +72,13 @@ func (s *Server) Run(ctx context.Context) error {
 
 	mcpServer := NewMCPServer(impl)
 
-	// NOTE: read_skill tool is available but not currently registered.
-	// It can be re-enabled for clients that don't support native skill discovery.
-	// To re-enable, uncomment the following:
-	// mcp.AddTool(mcpServer, &mcp.Tool{
-	// 	Name:        "read_skill",
-	// 	Description: "Read a skill's full instructions and content. Returns the skill content as markdown with @file references resolved to absolute paths.",
-	// }, s.handleReadSkill)
+	// Built-in tools for clients without native skill or rule discovery
+	s.registerBuiltinTools(mcpServer)
 
 	// Register additional tools from vault (e.g., query tool for Sleuth vault)
 	s.registerVaultTools(ctx, mcpServer)
 
-	// Run over stdio
-	return mcpServer.Run(ctx, &mcp.StdioTransport{})
+	return mcpServer
 }
 
 // registerVaultTools registers additional MCP tools provided by the configured vault
@@ -139,12 +144,11 @@ func (s *Server) handleReadSkill(ctx context.Context, req *mcp.CallToolRequest,
 	for _, client := range installedClients {
 		content, err := client.ReadSkill(ctx, input.Name, scope)
 		if err == nil {
-			// Report usage (best-effort, won't fail the MCP call)
-			go s.usageReporter.ReportSkillUsage(content.Name, content.Version)
-
 			// Resolve @file references to absolute paths
 			resolvedContent := resolveFileReferences(content.Content, content.BaseDir)
 
+			s.reportUsage(req, scope, assetUse{content.Name, content.Version, asset.TypeSkill.Key, stats.EstimateTokens(resolvedContent)})
+
 			// Return plain markdown text
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{
@@ -204,26 +208,22 @@ func (s *Server) detectScope(ctx context.Context) (*clients.InstallScope, error)
 	}, nil
 }
 
-// ReportSkillUsage reports usage of a skill to the vault.
+// ReportAssetUsage reports usage of an asset to the vault.
 // This function runs in a goroutine and is best-effort - it will not block the MCP call.
-func (s *Server) ReportSkillUsage(skillName, skillVersion string) {
+func (s *Server) ReportAssetUsage(usageEvent stats.UsageEvent) {
 	log := logger.Get()
 
-	// Create usage event
-	usageEvent := stats.UsageEvent{
-		AssetName:    skillName,
-		AssetVersion: skillVersion,
-		AssetType:    "skill",
-		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+	if usageEvent.Timestamp == "" {
+		usageEvent.Timestamp = time.Now().UTC().Format(time.RFC3339)
 	}
 
 	// Enqueue event (fast, local file write)
 	if err := stats.EnqueueEvent(usageEvent); err != nil {
-		log.Warn("failed to enqueue usage event", "skill", skillName, "error", err)
+		log.Warn("failed to enqueue usage event", "asset", usageEvent.AssetName, "error", err)
 		return
 	}
 
-	log.Debug("skill usage enqueued", "name", skillName, "version", skillVersion)
+	log.Debug("asset usage enqueued", "name", usageEvent.AssetName, "version", usageEvent.AssetVersion, "type", usageEvent.AssetType)
 
 	// Try to flush queue with timeout (network call, but we're already in a goroutine)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
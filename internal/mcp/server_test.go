@@ -15,6 +15,8 @@ import (
 	"github.com/sleuth-io/sx/v2/internal/bootstrap"
 	"github.com/sleuth-io/sx/v2/internal/clients"
 	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/stats"
 )
 
 // mockClient implements clients.Client for testing
@@ -106,8 +108,6 @@ func (m *mockClient) addSkill(name, description, version, content, baseDir strin
 }
 
 // TestServer_ReadSkill tests the read_skill handler functionality.
-// Note: read_skill is not currently exposed via MCP (clients use native skill discovery)
-// but the code is kept for potential future use with clients that don't support it.
 func TestServer_ReadSkill(t *testing.T) {
 	// Create a mock client with test skills
 	mock := newMockClient()
@@ -212,7 +212,6 @@ func TestServer_ReadSkill(t *testing.T) {
 }
 
 // TestServer_Integration tests read_skill with actual file system.
-// Note: read_skill is not currently exposed via MCP but code is kept for future use.
 func TestServer_Integration(t *testing.T) {
 	// Create a temp directory with an actual skill
 	tempDir := t.TempDir()
@@ -375,12 +374,14 @@ func TestResolveFileReferences(t *testing.T) {
 type mockUsageReporter struct {
 	mu      sync.Mutex
 	reports []usageReport
+	events  []stats.UsageEvent
 	called  chan struct{}
 }
 
 type usageReport struct {
 	skillName    string
 	skillVersion string
+	assetType    string
 }
 
 func newMockUsageReporter() *mockUsageReporter {
@@ -389,9 +390,10 @@ func newMockUsageReporter() *mockUsageReporter {
 	}
 }
 
-func (m *mockUsageReporter) ReportSkillUsage(skillName, skillVersion string) {
+func (m *mockUsageReporter) ReportAssetUsage(event stats.UsageEvent) {
 	m.mu.Lock()
-	m.reports = append(m.reports, usageReport{skillName, skillVersion})
+	m.reports = append(m.reports, usageReport{event.AssetName, event.AssetVersion, event.AssetType})
+	m.events = append(m.events, event)
 	m.mu.Unlock()
 	select {
 	case m.called <- struct{}{}:
@@ -405,8 +407,13 @@ func (m *mockUsageReporter) getReports() []usageReport {
 	return append([]usageReport{}, m.reports...)
 }
 
+func (m *mockUsageReporter) getEvents() []stats.UsageEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]stats.UsageEvent{}, m.events...)
+}
+
 // TestServer_ReadSkill_ReportsUsage tests that read_skill reports usage statistics.
-// Note: read_skill is not currently exposed via MCP but code is kept for future use.
 func TestServer_ReadSkill_ReportsUsage(t *testing.T) {
 	// Create a mock client with a test skill
 	mock := newMockClient()
@@ -471,4 +478,18 @@ func TestServer_ReadSkill_ReportsUsage(t *testing.T) {
 	if reports[0].skillVersion != "2.0.0" {
 		t.Errorf("Expected skill version '2.0.0', got %q", reports[0].skillVersion)
 	}
+
+	ev := mockReporter.getEvents()[0]
+	if ev.Trigger != mgmt.UsageTriggerMCP {
+		t.Errorf("Expected trigger %q, got %q", mgmt.UsageTriggerMCP, ev.Trigger)
+	}
+	if ev.Client != "test-client" {
+		t.Errorf("Expected client 'test-client', got %q", ev.Client)
+	}
+	if ev.SessionID == "" {
+		t.Error("Expected a session ID")
+	}
+	if ev.ContextTokens == 0 {
+		t.Error("Expected a context token estimate")
+	}
 }
@@ -0,0 +1,419 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/assets"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/scope"
+	"github.com/sleuth-io/sx/v2/internal/stats"
+)
+
+// SearchSkillsInput is the input type for the search_skills tool
+type SearchSkillsInput struct {
+	Query string `json:"query,omitempty" jsonschema:"words to look for in skill names, descriptions, and instructions; empty lists every installed skill"`
+	Limit int    `json:"limit,omitempty" jsonschema:"maximum number of results (default 10)"`
+}
+
+// ListRulesForPathInput is the input type for the list_rules_for_path tool
+type ListRulesForPathInput struct {
+	Path string `json:"path" jsonschema:"file path, absolute or relative to the repository root"`
+}
+
+// GetAssetFileInput is the input type for the get_asset_file tool
+type GetAssetFileInput struct {
+	Name string `json:"name" jsonschema:"name of the installed asset"`
+	Path string `json:"path" jsonschema:"path of the file within the asset, e.g. scripts/check.sh"`
+}
+
+// defaultSearchLimit caps search_skills results when the caller gives no limit
+const defaultSearchLimit = 10
+
+// maxAssetFileBytes caps what get_asset_file returns in one response
+const maxAssetFileBytes = 256 * 1024
+
+// assetUse is one asset a tool call put in front of the model, with an
+// estimate of the tokens it added to the response
+type assetUse struct {
+	name, version, assetType string
+	tokens                   int
+}
+
+// registerBuiltinTools registers the tools sx serves regardless of vault,
+// so clients without native skill or rule discovery still get sx assets.
+func (s *Server) registerBuiltinTools(mcpServer *mcp.Server) {
+	mcp.AddTool(mcpServer, &mcp.Tool{
+		Name:        "search_skills",
+		Description: "Search the installed skills by name, description, and instructions. Returns the best matches with their descriptions; read one with read_skill before following it.",
+	}, s.handleSearchSkills)
+	mcp.AddTool(mcpServer, &mcp.Tool{
+		Name:        "read_skill",
+		Description: "Read a skill's full instructions and content. Returns the skill content as markdown with @file references resolved to absolute paths.",
+	}, s.handleReadSkill)
+	mcp.AddTool(mcpServer, &mcp.Tool{
+		Name:        "list_rules_for_path",
+		Description: "List the installed rules that apply to a file, with their instructions. Call this before editing a file and follow the rules it returns.",
+	}, s.handleListRulesForPath)
+	mcp.AddTool(mcpServer, &mcp.Tool{
+		Name:        "get_asset_file",
+		Description: "Read a file bundled with an installed skill or other directory asset, such as a script or reference document its instructions mention.",
+	}, s.handleGetAssetFile)
+}
+
+// reportUsage reports uses in the background (best-effort, won't fail the MCP call)
+func (s *Server) reportUsage(req *mcp.CallToolRequest, installScope *clients.InstallScope, uses ...assetUse) {
+	if len(uses) == 0 {
+		return
+	}
+	client, sessionID := s.sessionInfo(req)
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	go func() {
+		for _, u := range uses {
+			s.usageReporter.ReportAssetUsage(stats.UsageEvent{
+				AssetName:     u.name,
+				AssetVersion:  u.version,
+				AssetType:     u.assetType,
+				Timestamp:     timestamp,
+				Client:        client,
+				RepoURL:       installScope.RepoURL,
+				SessionID:     sessionID,
+				Trigger:       mgmt.UsageTriggerMCP,
+				ContextTokens: u.tokens,
+			})
+		}
+	}()
+}
+
+// sessionInfo returns the name the MCP client gave when it connected and
+// the session ID, falling back to this server's own for transports
+// without one
+func (s *Server) sessionInfo(req *mcp.CallToolRequest) (client, sessionID string) {
+	sessionID = s.sessionID
+	if req == nil || req.Session == nil {
+		return "", sessionID
+	}
+	if id := req.Session.ID(); id != "" {
+		sessionID = id
+	}
+	if params := req.Session.InitializeParams(); params != nil && params.ClientInfo != nil {
+		client = params.ClientInfo.Name
+	}
+	return client, sessionID
+}
+
+// skillMatch is one search_skills result
+type skillMatch struct {
+	content *clients.SkillContent
+	score   int
+	snippet string
+}
+
+// handleSearchSkills handles the search_skills tool invocation. Every query
+// word must appear in the skill's name, description, or instructions; name
+// hits rank above description hits, which rank above instruction hits.
+func (s *Server) handleSearchSkills(ctx context.Context, req *mcp.CallToolRequest, input SearchSkillsInput) (*mcp.CallToolResult, any, error) {
+	installScope, err := s.detectScope(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to detect scope: %w", err)
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	terms := strings.Fields(strings.ToLower(input.Query))
+
+	seen := map[string]bool{}
+	var matches []skillMatch
+	for _, client := range s.registry.DetectInstalled() {
+		skills, err := client.ListAssets(ctx, installScope)
+		if err != nil {
+			continue
+		}
+		for _, skill := range skills {
+			if seen[skill.Name] {
+				continue
+			}
+			content, err := client.ReadSkill(ctx, skill.Name, installScope)
+			if err != nil {
+				continue
+			}
+			seen[skill.Name] = true
+			if content.Description == "" {
+				content.Description = skill.Description
+			}
+			if m, ok := matchSkill(content, terms); ok {
+				matches = append(matches, m)
+			}
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].content.Name < matches[j].content.Name
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	if len(matches) == 0 {
+		return textResult(fmt.Sprintf("No installed skills match %q.", input.Query)), nil, nil
+	}
+	var b strings.Builder
+	uses := make([]assetUse, 0, len(matches))
+	for _, m := range matches {
+		start := b.Len()
+		fmt.Fprintf(&b, "- %s", m.content.Name)
+		if m.content.Description != "" {
+			fmt.Fprintf(&b, ": %s", m.content.Description)
+		}
+		b.WriteString("\n")
+		if m.snippet != "" {
+			fmt.Fprintf(&b, "  > %s\n", m.snippet)
+		}
+		uses = append(uses, assetUse{m.content.Name, m.content.Version, asset.TypeSkill.Key, stats.EstimateTokens(b.String()[start:])})
+	}
+	s.reportUsage(req, installScope, uses...)
+	return textResult(strings.TrimSuffix(b.String(), "\n")), nil, nil
+}
+
+// matchSkill scores skill against the lowercased query terms; ok is false
+// when a term appears nowhere. With no terms every skill matches.
+func matchSkill(skill *clients.SkillContent, terms []string) (skillMatch, bool) {
+	m := skillMatch{content: skill}
+	name := strings.ToLower(skill.Name)
+	desc := strings.ToLower(skill.Description)
+	body := strings.ToLower(skill.Content)
+	for _, term := range terms {
+		hits := strings.Count(body, term)
+		switch {
+		case strings.Contains(name, term):
+			m.score += 10 + hits
+		case strings.Contains(desc, term):
+			m.score += 5 + hits
+		case hits > 0:
+			m.score += hits
+			if m.snippet == "" {
+				m.snippet = snippetFor(skill.Content, term)
+			}
+		default:
+			return skillMatch{}, false
+		}
+	}
+	return m, true
+}
+
+// snippetFor returns the first line of content that mentions term
+func snippetFor(content, term string) string {
+	for line := range strings.SplitSeq(content, "\n") {
+		if strings.Contains(strings.ToLower(line), term) {
+			line = strings.TrimSpace(line)
+			if len(line) > 160 {
+				line = line[:157] + "..."
+			}
+			return line
+		}
+	}
+	return ""
+}
+
+// handleListRulesForPath handles the list_rules_for_path tool invocation.
+// It evaluates the globs of every rule installed for the current scope
+// against the path; rules without globs apply to every file in their scope.
+func (s *Server) handleListRulesForPath(ctx context.Context, req *mcp.CallToolRequest, input ListRulesForPathInput) (*mcp.CallToolResult, any, error) {
+	if input.Path == "" {
+		return nil, nil, errors.New("path is required")
+	}
+	installScope, err := s.detectScope(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to detect scope: %w", err)
+	}
+	root := installScope.RepoRoot
+	if root == "" {
+		if root, err = os.Getwd(); err != nil {
+			return nil, nil, err
+		}
+	}
+	target := input.Path
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(root, target)
+	}
+
+	tracker, err := assets.LoadTracker()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load installed assets: %w", err)
+	}
+	installed := append([]assets.InstalledAsset(nil), tracker.Assets...)
+	sort.SliceStable(installed, func(i, j int) bool { return installed[i].Name < installed[j].Name })
+
+	var b strings.Builder
+	var uses []assetUse
+	for _, a := range installed {
+		if a.Type != asset.TypeRule.Key {
+			continue
+		}
+		// Path-scoped rules elsewhere in the repo can still cover the
+		// target, so match the repository here and the path below.
+		if !a.IsGlobal() && (installScope.RepoURL == "" || !scope.MatchRepoURLs(a.Repository, installScope.RepoURL)) {
+			continue
+		}
+		// Globs are relative to the directory the rule is installed for.
+		anchor := root
+		if a.Path != "" {
+			anchor = filepath.Join(root, a.Path)
+		}
+		rel, err := filepath.Rel(anchor, target)
+		if err != nil || !filepath.IsLocal(rel) {
+			continue
+		}
+		rule := s.readInstalledRule(a, installScope)
+		if rule == nil || !ruleApplies(rule.Globs, filepath.ToSlash(rel)) {
+			continue
+		}
+
+		start := b.Len()
+		fmt.Fprintf(&b, "## %s\n\n", a.Name)
+		if rule.Description != "" {
+			fmt.Fprintf(&b, "%s\n\n", rule.Description)
+		}
+		fmt.Fprintf(&b, "%s\n\n", strings.TrimSpace(rule.Content))
+		uses = append(uses, assetUse{a.Name, a.Version, asset.TypeRule.Key, stats.EstimateTokens(b.String()[start:])})
+	}
+
+	if len(uses) == 0 {
+		return textResult("No installed rules apply to " + input.Path + "."), nil, nil
+	}
+	s.reportUsage(req, installScope, uses...)
+	return textResult(strings.TrimSpace(b.String())), nil, nil
+}
+
+// readInstalledRule reads and parses a tracked rule from the first of its
+// clients that has it on disk. Returns nil if none does.
+func (s *Server) readInstalledRule(a assets.InstalledAsset, installScope *clients.InstallScope) *clients.ParsedRule {
+	home, _ := os.UserHomeDir()
+	base := home
+	if !a.IsGlobal() {
+		base = filepath.Join(installScope.RepoRoot, a.Path)
+	}
+	for _, id := range a.Clients {
+		client, err := s.registry.Get(id)
+		if err != nil {
+			continue
+		}
+		caps := client.RuleCapabilities()
+		if caps == nil || caps.RulesDirectory == "" || caps.ParseRuleFile == nil {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(base, caps.RulesDirectory, a.Name+caps.FileExtension))
+		if err != nil {
+			continue
+		}
+		rule, err := caps.ParseRuleFile(content)
+		if err != nil {
+			continue
+		}
+		return rule
+	}
+	return nil
+}
+
+// ruleApplies reports whether a rule with globs applies to rel, a
+// slash-separated path relative to the rule's scope.
+func ruleApplies(globs []string, rel string) bool {
+	if len(globs) == 0 {
+		return true
+	}
+	for _, glob := range globs {
+		if ok, _ := doublestar.Match(strings.TrimPrefix(glob, "./"), rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// handleGetAssetFile handles the get_asset_file tool invocation
+func (s *Server) handleGetAssetFile(ctx context.Context, req *mcp.CallToolRequest, input GetAssetFileInput) (*mcp.CallToolResult, any, error) {
+	if input.Name == "" || input.Path == "" {
+		return nil, nil, errors.New("name and path are required")
+	}
+	rel := filepath.Clean(filepath.FromSlash(input.Path))
+	if !filepath.IsLocal(rel) {
+		return nil, nil, fmt.Errorf("path must stay within the asset: %s", input.Path)
+	}
+	installScope, err := s.detectScope(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to detect scope: %w", err)
+	}
+
+	dir, use, err := s.findAssetDir(ctx, input.Name, installScope)
+	if err != nil {
+		return nil, nil, err
+	}
+	path := filepath.Join(dir, rel)
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return nil, nil, fmt.Errorf("file not found in %s: %s", input.Name, input.Path)
+	}
+	if info.Size() > maxAssetFileBytes {
+		return nil, nil, fmt.Errorf("%s is too large to return (%d bytes, limit %d)", input.Path, info.Size(), maxAssetFileBytes)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	use.tokens = stats.EstimateTokens(string(data))
+	s.reportUsage(req, installScope, use)
+	return textResult(string(data)), nil, nil
+}
+
+// findAssetDir returns the directory an installed asset lives in: a
+// skill's base directory, or the client path of another tracked asset.
+func (s *Server) findAssetDir(ctx context.Context, name string, installScope *clients.InstallScope) (string, assetUse, error) {
+	installedClients := s.registry.DetectInstalled()
+	for _, client := range installedClients {
+		if content, err := client.ReadSkill(ctx, name, installScope); err == nil && content.BaseDir != "" {
+			return content.BaseDir, assetUse{name: content.Name, version: content.Version, assetType: asset.TypeSkill.Key}, nil
+		}
+	}
+
+	tracker, err := assets.LoadTracker()
+	if err != nil {
+		return "", assetUse{}, fmt.Errorf("failed to load installed assets: %w", err)
+	}
+	for _, a := range tracker.FindForScope(installScope.RepoURL, installScope.Path, scope.MatchRepoURLs) {
+		if a.Name != name {
+			continue
+		}
+		assetType := asset.FromString(a.Type)
+		for _, client := range installedClients {
+			path, err := client.GetAssetPath(ctx, name, assetType, installScope)
+			if err != nil {
+				continue
+			}
+			if info, err := os.Stat(path); err == nil && info.IsDir() {
+				return path, assetUse{name: a.Name, version: a.Version, assetType: a.Type}, nil
+			}
+		}
+	}
+	return "", assetUse{}, fmt.Errorf("asset not found or has no files: %s", name)
+}
+
+// textResult wraps text as a tool result
+func textResult(text string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: text}},
+	}
+}
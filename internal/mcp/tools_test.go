@@ -0,0 +1,212 @@
+package mcpserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/sleuth-io/sx/v2/internal/assets"
+	"github.com/sleuth-io/sx/v2/internal/clients"
+)
+
+// rulesMockClient is a mockClient that also installs rules, in
+// .mock/rules/<name>.md files whose first line lists the globs
+type rulesMockClient struct {
+	*mockClient
+}
+
+func (m *rulesMockClient) RuleCapabilities() *clients.RuleCapabilities {
+	return &clients.RuleCapabilities{
+		ClientName:     "mock",
+		RulesDirectory: ".mock/rules",
+		FileExtension:  ".md",
+		ParseRuleFile: func(content []byte) (*clients.ParsedRule, error) {
+			head, body, _ := strings.Cut(string(content), "\n")
+			rule := &clients.ParsedRule{ClientName: "mock", Content: body}
+			if globs := strings.TrimPrefix(head, "globs:"); strings.TrimSpace(globs) != "" {
+				rule.Globs = strings.Split(strings.TrimSpace(globs), ",")
+			}
+			return rule, nil
+		},
+	}
+}
+
+// connectBuiltinTools serves the built-in tools over an in-memory transport
+func connectBuiltinTools(t *testing.T, server *Server) *mcp.ClientSession {
+	t.Helper()
+	mcpServer := mcp.NewServer(&mcp.Implementation{Name: "skills", Version: "1.0.0"}, nil)
+	server.registerBuiltinTools(mcpServer)
+
+	ctx := context.Background()
+	t1, t2 := mcp.NewInMemoryTransports()
+	if _, err := mcpServer.Connect(ctx, t1, nil); err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v1.0.0"}, nil)
+	session, err := client.Connect(ctx, t2, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+// callText calls a tool and returns its text, failing on tool errors
+func callText(t *testing.T, session *mcp.ClientSession, name string, args map[string]any) string {
+	t.Helper()
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("CallTool %s failed: %v", name, err)
+	}
+	if result.IsError {
+		t.Fatalf("%s returned error: %v", name, result.Content)
+	}
+	return result.Content[0].(*mcp.TextContent).Text
+}
+
+// waitForReports waits until the reporter has n reports
+func waitForReports(t *testing.T, reporter *mockUsageReporter, n int) []usageReport {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for {
+		if reports := reporter.getReports(); len(reports) >= n {
+			return reports
+		}
+		select {
+		case <-reporter.called:
+		case <-deadline:
+			t.Fatalf("Expected %d usage reports, got %d", n, len(reporter.getReports()))
+		}
+	}
+}
+
+func TestServer_SearchSkills(t *testing.T) {
+	mock := newMockClient()
+	mock.addSkill("commit-messages", "Write conventional commits", "1.0.0", "# Commits\n\nPrefix with feat: or fix:.", "/tmp/skills/commit-messages")
+	mock.addSkill("pr-review", "Review pull requests", "2.0.0", "# Review\n\nCheck each commit is focused.", "/tmp/skills/pr-review")
+	mock.addSkill("release", "Cut a release", "1.0.0", "# Release\n\nTag the build.", "/tmp/skills/release")
+
+	registry := clients.NewRegistry()
+	registry.Register(mock)
+	server := NewServer(registry)
+	reporter := newMockUsageReporter()
+	server.SetUsageReporter(reporter)
+	session := connectBuiltinTools(t, server)
+
+	text := callText(t, session, "search_skills", map[string]any{"query": "commit"})
+	lines := strings.Split(text, "\n")
+	if len(lines) != 3 ||
+		lines[0] != "- commit-messages: Write conventional commits" ||
+		lines[1] != "- pr-review: Review pull requests" ||
+		lines[2] != "  > Check each commit is focused." {
+		t.Fatalf("Unexpected results:\n%s", text)
+	}
+
+	reports := waitForReports(t, reporter, 2)
+	if reports[0] != (usageReport{"commit-messages", "1.0.0", "skill"}) || reports[1] != (usageReport{"pr-review", "2.0.0", "skill"}) {
+		t.Errorf("Unexpected usage reports: %+v", reports)
+	}
+
+	if text := callText(t, session, "search_skills", map[string]any{"query": "commit deploy"}); !strings.HasPrefix(text, "No installed skills match") {
+		t.Errorf("Every term should have to match, got:\n%s", text)
+	}
+	if text := callText(t, session, "search_skills", map[string]any{"limit": 1}); text != "- commit-messages: Write conventional commits" {
+		t.Errorf("Empty query should list skills up to the limit, got:\n%s", text)
+	}
+}
+
+func TestServer_ListRulesForPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("SX_CACHE_DIR", t.TempDir())
+
+	rulesDir := filepath.Join(home, ".mock", "rules")
+	if err := os.MkdirAll(rulesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"go-style":   "globs: **/*.go\nUse gofmt.",
+		"docs-style": "globs: docs/**\nWrite short sentences.",
+		"always":     "\nBe kind.",
+	} {
+		if err := os.WriteFile(filepath.Join(rulesDir, name+".md"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tracker := &assets.Tracker{Version: assets.TrackerFormatVersion}
+	for _, name := range []string{"go-style", "docs-style", "always"} {
+		tracker.UpsertAsset(assets.InstalledAsset{Name: name, Version: "3", Type: "rule", Clients: []string{"mock"}})
+	}
+	if err := assets.SaveTracker(tracker); err != nil {
+		t.Fatal(err)
+	}
+
+	registry := clients.NewRegistry()
+	registry.Register(&rulesMockClient{newMockClient()})
+	server := NewServer(registry)
+	reporter := newMockUsageReporter()
+	server.SetUsageReporter(reporter)
+	session := connectBuiltinTools(t, server)
+
+	text := callText(t, session, "list_rules_for_path", map[string]any{"path": "internal/app/main.go"})
+	if text != "## always\n\nBe kind.\n\n## go-style\n\nUse gofmt." {
+		t.Fatalf("Unexpected rules:\n%s", text)
+	}
+	reports := waitForReports(t, reporter, 2)
+	if reports[0] != (usageReport{"always", "3", "rule"}) || reports[1] != (usageReport{"go-style", "3", "rule"}) {
+		t.Errorf("Unexpected usage reports: %+v", reports)
+	}
+
+	text = callText(t, session, "list_rules_for_path", map[string]any{"path": "docs/guide.md"})
+	if !strings.Contains(text, "## docs-style") || strings.Contains(text, "go-style") {
+		t.Errorf("Unexpected rules for docs:\n%s", text)
+	}
+}
+
+func TestServer_GetAssetFile(t *testing.T) {
+	t.Setenv("SX_CACHE_DIR", t.TempDir())
+	skillDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(skillDir, "scripts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "scripts", "check.sh"), []byte("#!/bin/sh\nexit 0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mock := newMockClient()
+	mock.addSkill("checker", "Runs checks", "1.2.0", "Run @scripts/check.sh", skillDir)
+	registry := clients.NewRegistry()
+	registry.Register(mock)
+	server := NewServer(registry)
+	reporter := newMockUsageReporter()
+	server.SetUsageReporter(reporter)
+	session := connectBuiltinTools(t, server)
+
+	text := callText(t, session, "get_asset_file", map[string]any{"name": "checker", "path": "scripts/check.sh"})
+	if text != "#!/bin/sh\nexit 0\n" {
+		t.Errorf("Unexpected file content: %q", text)
+	}
+	if reports := waitForReports(t, reporter, 1); reports[0] != (usageReport{"checker", "1.2.0", "skill"}) {
+		t.Errorf("Unexpected usage reports: %+v", reports)
+	}
+
+	for _, args := range []map[string]any{
+		{"name": "checker", "path": "../escape.txt"},
+		{"name": "checker", "path": "missing.txt"},
+		{"name": "checker", "path": "scripts"},
+		{"name": "unknown", "path": "scripts/check.sh"},
+	} {
+		result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "get_asset_file", Arguments: args})
+		if err != nil {
+			t.Fatalf("CallTool failed: %v", err)
+		}
+		if !result.IsError {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
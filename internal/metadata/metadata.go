@@ -133,6 +133,8 @@ type RuleConfig struct {
 	ClaudeCode  map[string]any `toml:"claude-code,omitempty"` // Claude Code-specific settings
 	Copilot     map[string]any `toml:"copilot,omitempty"`     // GitHub Copilot-specific settings
 	Kiro        map[string]any `toml:"kiro,omitempty"`        // Kiro-specific settings
+	Windsurf    map[string]any `toml:"windsurf,omitempty"`    // Windsurf-specific settings
+	Continue    map[string]any `toml:"continue,omitempty"`    // Continue-specific settings
 }
 
 // metadataCompat is used for parsing old-style metadata with [artifact] section
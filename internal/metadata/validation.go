@@ -62,6 +62,10 @@ var (
 		"openclaw":       true,
 		"opencode":       true,
 		"kiro":           true,
+		"windsurf":       true,
+		"zed":            true,
+		"continue":       true,
+		"aider":          true,
 	}
 )
 
@@ -162,6 +166,16 @@ func (a *Asset) Validate() error {
 		}
 	}
 
+	for _, dep := range a.Dependencies {
+		_, constraint, err := ParseDependency(dep)
+		if err != nil {
+			return err
+		}
+		if err := ValidateDependencyConstraint(constraint); err != nil {
+			return fmt.Errorf("dependency %q: %w", dep, err)
+		}
+	}
+
 	return nil
 }
 
@@ -355,26 +369,31 @@ func ValidateZip(zipData []byte, expectedType *asset.Type) error {
 	return nil
 }
 
-// ParseDependency parses a dependency string (e.g., "package>=1.0.0,<2.0.0")
-// Returns the package name and version constraint
-func ParseDependency(dep string) (name string, constraint string, err error) {
-	// Simple regex to split name and version constraint
-	// Format: name[>=version][,<version]...
-	re := regexp.MustCompile(`^([a-zA-Z0-9_-]+)([><=~!,.\d\s]*)$`)
-	matches := re.FindStringSubmatch(dep)
+// dependencyPattern splits a dependency string into the asset name and an
+// optional version range. The range must start with an operator so a
+// trailing version can't be mistaken for part of a hyphenated name.
+var dependencyPattern = regexp.MustCompile(`^([a-zA-Z0-9_.-]+?)\s*([<>=~!^].*)?$`)
 
+// ParseDependency parses a dependency string (e.g., "package>=1.0.0,<2.0.0"
+// or "package ^1.2") and returns the package name and version constraint.
+// The PEP 508 compatible-release operator "~=" is rewritten to the semver
+// tilde form so the constraint can be handed straight to the resolver.
+func ParseDependency(dep string) (name string, constraint string, err error) {
+	matches := dependencyPattern.FindStringSubmatch(strings.TrimSpace(dep))
 	if matches == nil {
 		return "", "", fmt.Errorf("invalid dependency format: %s", dep)
 	}
 
 	name = matches[1]
-	constraint = matches[2]
+	constraint = strings.TrimSpace(matches[2])
+	constraint = strings.ReplaceAll(constraint, "~=", "~")
 
 	return name, constraint, nil
 }
 
 // ValidateDependencyConstraint validates a version constraint string
-// Supports: >=X.Y.Z, ~=X.Y.Z, ~X.Y.Z, and comma-separated constraints
+// Supports semver ranges: >=X.Y.Z, ^X.Y, ~X.Y.Z, space- or comma-separated
+// AND, and || for alternatives
 func ValidateDependencyConstraint(constraint string) error {
 	if constraint == "" {
 		return nil // No constraint is valid
@@ -319,7 +319,7 @@ func TestAsset_Validate_Clients(t *testing.T) {
 			Name:    "x",
 			Version: "1.0.0",
 			Type:    asset.TypeSkill,
-			Clients: []string{"claude-code", "cursor", "gemini", "cline", "github-copilot", "codex", "openclaw", "opencode", "kiro"},
+			Clients: []string{"claude-code", "cursor", "gemini", "cline", "github-copilot", "codex", "openclaw", "opencode", "kiro", "windsurf", "zed", "continue", "aider"},
 		}
 		if err := a.Validate(); err != nil {
 			t.Errorf("known client IDs should not error: %v", err)
@@ -342,3 +342,50 @@ func TestAsset_Validate_Clients(t *testing.T) {
 		}
 	})
 }
+
+func TestParseDependency(t *testing.T) {
+	tests := []struct {
+		in             string
+		wantName       string
+		wantConstraint string
+	}{
+		{"helper", "helper", ""},
+		{"sql-formatter~=1.5.0", "sql-formatter", "~1.5.0"},
+		{"helper-agent>=1.0.0", "helper-agent", ">=1.0.0"},
+		{"git-helper>=2.0.0,<3.0.0", "git-helper", ">=2.0.0,<3.0.0"},
+		{"package >= 2.0.0, < 3.0.0", "package", ">= 2.0.0, < 3.0.0"},
+		{"linter^1.2", "linter", "^1.2"},
+		{"linter ^1.2", "linter", "^1.2"},
+		{"linter>=2.0 <3", "linter", ">=2.0 <3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			name, constraint, err := ParseDependency(tt.in)
+			if err != nil {
+				t.Fatalf("ParseDependency(%q): %v", tt.in, err)
+			}
+			if name != tt.wantName || constraint != tt.wantConstraint {
+				t.Errorf("ParseDependency(%q) = (%q, %q), want (%q, %q)", tt.in, name, constraint, tt.wantName, tt.wantConstraint)
+			}
+			if err := ValidateDependencyConstraint(constraint); err != nil {
+				t.Errorf("constraint %q should validate: %v", constraint, err)
+			}
+		})
+	}
+}
+
+func TestAsset_Validate_Dependencies(t *testing.T) {
+	a := Asset{Name: "x", Version: "1.0.0", Type: asset.TypeSkill, Dependencies: []string{"helper^1.2"}}
+	if err := a.Validate(); err != nil {
+		t.Errorf("valid range should not error: %v", err)
+	}
+
+	a.Dependencies = []string{"helper>=banana"}
+	err := a.Validate()
+	if err == nil {
+		t.Fatal("unparsable range should error")
+	}
+	if !strings.Contains(err.Error(), "helper>=banana") {
+		t.Errorf("error should mention the bad dependency: %v", err)
+	}
+}
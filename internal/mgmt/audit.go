@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -79,6 +80,29 @@ const (
 	EventPluginUpdated     = "plugin.updated"
 	EventPluginUninstalled = "plugin.uninstalled"
 	EventPluginShared      = "plugin.shared"
+
+	// Change-request events (docs/change-requests.md). The target is the
+	// change ID; Data carries the asset name and proposed version, plus the
+	// reviewer's comment on approve/reject.
+	EventChangeProposed = "change.proposed"
+	EventChangeApproved = "change.approved"
+	EventChangeRejected = "change.rejected"
+	EventChangeMerged   = "change.merged"
+
+	// Release-channel events (docs/channels.md). The target is the asset
+	// name; Data carries the channel and the version it now points at, or
+	// the scope that started or stopped following it.
+	EventChannelSet      = "channel.set"
+	EventChannelPromoted = "channel.promoted"
+	EventChannelFollowed = "channel.followed"
+
+	// Signing events (docs/signing.md). asset.signed targets the asset and
+	// records the version, signer, key ID, and content hash; the rest
+	// target the signing policy or the trusted key's name.
+	EventAssetSigned         = "asset.signed"
+	EventSigningPolicySet    = "signing.policy_set"
+	EventSigningKeyTrusted   = "signing.key_trusted"
+	EventSigningKeyUntrusted = "signing.key_untrusted"
 )
 
 // Audit target type constants.
@@ -91,6 +115,8 @@ const (
 	TargetTypeVault        = "vault"
 	TargetTypeCollection   = "collection"
 	TargetTypePlugin       = "plugin"
+	TargetTypeChange       = "change"
+	TargetTypeSigning      = "signing"
 )
 
 // AuditEvent is a single row in .sx/audit/YYYY-MM.jsonl.
@@ -106,6 +132,15 @@ type AuditEvent struct {
 	// audit stream.
 	Profile string         `json:"profile,omitempty"`
 	Data    map[string]any `json:"data,omitempty"`
+	// PrevHash and Hash chain the event to the one written before it (see
+	// audit_chain.go). Set by AppendAuditEvents; empty on events written
+	// by sx versions that predate the chain.
+	PrevHash string `json:"prev_hash,omitempty"`
+	Hash     string `json:"hash,omitempty"`
+	// KeyID and Signature sign Hash with the writer's signing key, when
+	// they have one.
+	KeyID     string `json:"key_id,omitempty"`
+	Signature string `json:"sig,omitempty"`
 }
 
 // AuditFilter narrows an audit query. Zero values mean "don't filter on
@@ -145,6 +180,128 @@ func getAuditProfileTag() string {
 	return auditProfileTag
 }
 
+// auditExporter receives the audit events that have landed in a vault.
+// Set by SetAuditExporter at command boot when an OTLP endpoint is
+// configured.
+//
+// A vault write holds its root's exports (HoldAuditExports) while it runs:
+// events appended meanwhile wait on the innermost hold until the write
+// commits and pushes, and are dropped if it fails or is replayed. Events appended
+// outside a write are exported as soon as they're on disk. The last
+// auditExportWindow hashes handed to the exporter are remembered, so an
+// event queued twice is exported once; a repeat always follows its first
+// export closely, so older hashes are forgotten.
+var (
+	auditExporterMu     sync.Mutex
+	auditExporter       func([]AuditEvent)
+	auditHolds          = map[string][]*auditHold{}
+	auditExportedSet    = map[string]bool{}
+	auditExportedHashes []string
+)
+
+// auditExportWindow bounds how many exported hashes a long-running
+// process remembers
+const auditExportWindow = 4096
+
+// SetAuditExporter registers fn to receive each batch of audit events
+// once it has landed, stamped as written. fn runs while vault writes wait
+// on it, so it must not block on the network. Nil disables exporting.
+func SetAuditExporter(fn func([]AuditEvent)) {
+	auditExporterMu.Lock()
+	auditExporter = fn
+	auditExporterMu.Unlock()
+}
+
+// auditHold collects the events appended while it is the innermost hold
+// on its root
+type auditHold struct {
+	pending []AuditEvent
+}
+
+// HoldAuditExports defers exporting the audit events appended under
+// vaultRoot until the returned release is called: with landed true once
+// the write has committed, or false to drop them. Holds nest: a hold owns
+// only the events appended while it was innermost, so a failed inner
+// write drops its own events and nothing the outer write queued. A landed
+// inner hold passes its events to the enclosing one; only the outermost
+// exports.
+func HoldAuditExports(vaultRoot string) (release func(landed bool)) {
+	root := filepath.Clean(vaultRoot)
+	hold := &auditHold{}
+	auditExporterMu.Lock()
+	auditHolds[root] = append(auditHolds[root], hold)
+	auditExporterMu.Unlock()
+
+	var once sync.Once
+	return func(landed bool) {
+		once.Do(func() {
+			auditExporterMu.Lock()
+			defer auditExporterMu.Unlock()
+			holds := auditHolds[root]
+			i := slices.Index(holds, hold)
+			holds = slices.Delete(holds, i, i+1)
+			if len(holds) == 0 {
+				delete(auditHolds, root)
+			} else {
+				auditHolds[root] = holds
+			}
+			switch {
+			case !landed:
+			case i > 0:
+				holds[i-1].pending = append(holds[i-1].pending, hold.pending...)
+			default:
+				exportAuditEventsLocked(hold.pending)
+			}
+		})
+	}
+}
+
+// queueAuditExport exports written events, or keeps them on the innermost
+// hold while a write holds vaultRoot's exports
+func queueAuditExport(vaultRoot string, written []AuditEvent) {
+	root := filepath.Clean(vaultRoot)
+	auditExporterMu.Lock()
+	defer auditExporterMu.Unlock()
+	if holds := auditHolds[root]; len(holds) > 0 {
+		inner := holds[len(holds)-1]
+		inner.pending = append(inner.pending, written...)
+		return
+	}
+	exportAuditEventsLocked(written)
+}
+
+// exportAuditEventsLocked hands events not exported before to the
+// exporter. Callers hold auditExporterMu.
+func exportAuditEventsLocked(events []AuditEvent) {
+	if auditExporter == nil || len(events) == 0 {
+		return
+	}
+	fresh := make([]AuditEvent, 0, len(events))
+	for _, ev := range events {
+		if ev.Hash != "" && auditExportedSet[ev.Hash] {
+			continue
+		}
+		if ev.Hash != "" {
+			rememberAuditExportLocked(ev.Hash)
+		}
+		fresh = append(fresh, ev)
+	}
+	if len(fresh) > 0 {
+		auditExporter(fresh)
+	}
+}
+
+// rememberAuditExportLocked records an exported hash, forgetting the
+// oldest once auditExportWindow are held. Callers hold auditExporterMu.
+func rememberAuditExportLocked(hash string) {
+	auditExportedSet[hash] = true
+	auditExportedHashes = append(auditExportedHashes, hash)
+	if len(auditExportedHashes) > auditExportWindow {
+		delete(auditExportedSet, auditExportedHashes[0])
+		auditExportedHashes = auditExportedHashes[1:]
+	}
+}
+
 // AppendAuditEvent appends an event to the monthly audit file for the
 // event's timestamp. The parent directory is created if needed. Stamps
 // the active profile (when set via SetAuditProfileTag) so consumers can
@@ -153,16 +310,28 @@ func AppendAuditEvent(vaultRoot string, event AuditEvent) error {
 	return AppendAuditEvents(vaultRoot, []AuditEvent{event})
 }
 
-// AppendAuditEvents appends a batch of events to the monthly audit files.
-// Events without timestamps share a single timestamp so related audit rows
-// stay together in the log.
+// AppendAuditEvents appends a batch of events to the monthly audit files,
+// extending the hash chain. Events without timestamps share a single
+// timestamp so related audit rows stay together in the log.
 func AppendAuditEvents(vaultRoot string, events []AuditEvent) error {
 	if len(events) == 0 {
 		return nil
 	}
+	dir := filepath.Join(vaultRoot, AuditDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create audit directory: %w", err)
+	}
+	tipFile, prev, err := auditChainTip(dir)
+	if err != nil {
+		return err
+	}
+
 	now := time.Now().UTC()
 	profile := getAuditProfileTag()
-	byPath := make(map[string][]AuditEvent)
+	signer := getAuditSigner()
+	var files []string
+	byFile := make(map[string][]AuditEvent)
+	written := make([]AuditEvent, 0, len(events))
 	for _, event := range events {
 		if event.Timestamp.IsZero() {
 			event.Timestamp = now
@@ -172,24 +341,32 @@ func AppendAuditEvents(vaultRoot string, events []AuditEvent) error {
 		if event.Profile == "" {
 			event.Profile = profile
 		}
-		path := filepath.Join(vaultRoot, AuditDirName, monthFile(event.Timestamp))
-		byPath[path] = append(byPath[path], event)
-	}
+		event, err = chainAuditEvent(event, prev, signer)
+		if err != nil {
+			return err
+		}
+		prev = event.Hash
 
-	dir := filepath.Join(vaultRoot, AuditDirName)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create audit directory: %w", err)
-	}
-	paths := make([]string, 0, len(byPath))
-	for path := range byPath {
-		paths = append(paths, path)
+		// The chain runs through the files in name order, so an event
+		// dated before the newest file's month is appended to that file
+		file := monthFile(event.Timestamp)
+		if file < tipFile {
+			file = tipFile
+		}
+		tipFile = file
+		if _, ok := byFile[file]; !ok {
+			files = append(files, file)
+		}
+		byFile[file] = append(byFile[file], event)
+		written = append(written, event)
 	}
-	sort.Strings(paths)
-	for _, path := range paths {
-		if err := appendJSONL(path, byPath[path]); err != nil {
+
+	for _, file := range files {
+		if err := appendJSONL(filepath.Join(dir, file), byFile[file]); err != nil {
 			return err
 		}
 	}
+	queueAuditExport(vaultRoot, written)
 	return nil
 }
 
@@ -0,0 +1,280 @@
+package mgmt
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sleuth-io/sx/v2/internal/signing"
+)
+
+// ---- Hash chain (docs/audit.md) ----
+// Every event written to .sx/audit records the hash of the event before it
+// (prev_hash) and its own hash, which covers its contents and prev_hash.
+// The chain runs through the monthly files in name order, so editing,
+// deleting, inserting or reordering a line breaks it at that point.
+// Events are optionally signed over their hash with the writer's signing
+// key.
+
+// auditSigner is the key that signs appended audit events. Set by
+// SetAuditSigner at command boot when the user has a signing key.
+var (
+	auditSignerMu sync.RWMutex
+	auditSigner   *signing.PrivateKey
+)
+
+// SetAuditSigner sets the key that signs subsequent audit events. Nil
+// leaves them unsigned.
+func SetAuditSigner(key *signing.PrivateKey) {
+	auditSignerMu.Lock()
+	auditSigner = key
+	auditSignerMu.Unlock()
+}
+
+func getAuditSigner() *signing.PrivateKey {
+	auditSignerMu.RLock()
+	defer auditSignerMu.RUnlock()
+	return auditSigner
+}
+
+// decodeAuditEvent parses one audit line. Numbers in Data are kept as
+// json.Number so that re-encoding reproduces them exactly.
+func decodeAuditEvent(data []byte) (AuditEvent, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var ev AuditEvent
+	err := dec.Decode(&ev)
+	return ev, err
+}
+
+// normalizeAuditEvent round-trips ev through JSON so it encodes the same
+// way when written as when it's read back and verified: Data holding
+// structs or typed slices becomes plain maps and slices.
+func normalizeAuditEvent(ev AuditEvent) (AuditEvent, error) {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return ev, fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+	return decodeAuditEvent(data)
+}
+
+// auditEventHash is the hex SHA-256 of ev's JSON without its hash and
+// signature, so it covers PrevHash and with it the whole chain before ev
+func auditEventHash(ev AuditEvent) (string, error) {
+	ev.Hash, ev.KeyID, ev.Signature = "", "", ""
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal audit event: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// chainAuditEvent fills in ev's chain fields after prev, signing it when
+// a signer is set. Chain fields already on ev (e.g. on events imported
+// from another vault) are replaced.
+func chainAuditEvent(ev AuditEvent, prev string, signer *signing.PrivateKey) (AuditEvent, error) {
+	ev.PrevHash, ev.Hash, ev.KeyID, ev.Signature = prev, "", "", ""
+	ev, err := normalizeAuditEvent(ev)
+	if err != nil {
+		return ev, err
+	}
+	if ev.Hash, err = auditEventHash(ev); err != nil {
+		return ev, err
+	}
+	if signer != nil {
+		ev.KeyID, ev.Signature = signing.SignAuditHash(signer, ev.Hash)
+	}
+	return ev, nil
+}
+
+// auditFiles lists the monthly audit files in dir, oldest first
+func auditFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".jsonl") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// scanLines calls fn with each non-empty line of path and its 1-based
+// line number, stopping early when fn returns false
+func scanLines(path string, fn func(line int, data []byte) bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if !fn(n, scanner.Bytes()) {
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return nil
+}
+
+// auditChainTip returns the newest audit file's name and the hash of its
+// last event. The hash is empty for an empty log, or one whose last line
+// predates the chain or is malformed; verify reports the latter.
+func auditChainTip(dir string) (file, hash string, err error) {
+	names, err := auditFiles(dir)
+	if err != nil || len(names) == 0 {
+		return "", "", err
+	}
+	file = names[len(names)-1]
+	var last []byte
+	err = scanLines(filepath.Join(dir, file), func(_ int, data []byte) bool {
+		last = append(last[:0], data...)
+		return true
+	})
+	if err != nil || last == nil {
+		return file, "", err
+	}
+	ev, err := decodeAuditEvent(last)
+	if err != nil {
+		return file, "", nil
+	}
+	return file, ev.Hash, nil
+}
+
+// AuditBreak is the first point where an audit log fails verification
+type AuditBreak struct {
+	// File is relative to the vault root, e.g. .sx/audit/2026-05.jsonl
+	File string `json:"file"`
+	Line int    `json:"line"`
+	// Event is nil when the line isn't valid JSON
+	Event  *AuditEvent `json:"event,omitempty"`
+	Reason string      `json:"reason"`
+}
+
+// AuditVerification is the result of verifying an audit log's hash chain
+// and signatures
+type AuditVerification struct {
+	Files int `json:"files"`
+	// Events counts the events verified, up to the break if there is one
+	Events int `json:"events"`
+	// Unchained counts events written before the log was hash-chained.
+	// Their contents aren't protected.
+	Unchained int `json:"unchained"`
+	// Signed counts events signed by a trusted key
+	Signed int `json:"signed"`
+	// UntrustedSigned counts events signed by a key the vault doesn't
+	// trust, so their signatures couldn't be checked
+	UntrustedSigned int `json:"untrusted_signed"`
+	// Head is the hash of the last verified event. Recording it elsewhere
+	// lets a later verify detect events truncated from the end of the log.
+	Head   string      `json:"head,omitempty"`
+	Broken *AuditBreak `json:"broken,omitempty"`
+}
+
+// VerifyAuditLog walks the hash chain through every monthly audit file
+// under vaultRoot and checks signatures against trusted. It stops at the
+// first broken link, reported in the result's Broken field; an error
+// means the log couldn't be read at all.
+func VerifyAuditLog(vaultRoot string, trusted []*signing.PublicKey) (*AuditVerification, error) {
+	dir := filepath.Join(vaultRoot, AuditDirName)
+	names, err := auditFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &AuditVerification{Files: len(names)}
+	for _, name := range names {
+		rel := filepath.ToSlash(filepath.Join(AuditDirName, name))
+		var lineErr error
+		err := scanLines(filepath.Join(dir, name), func(line int, data []byte) bool {
+			reason, ev, err := v.check(data, trusted)
+			if err != nil {
+				lineErr = err
+				return false
+			}
+			if reason != "" {
+				v.Broken = &AuditBreak{File: rel, Line: line, Event: ev, Reason: reason}
+				return false
+			}
+			return true
+		})
+		if err == nil {
+			err = lineErr
+		}
+		if err != nil {
+			return nil, err
+		}
+		if v.Broken != nil {
+			break
+		}
+	}
+	return v, nil
+}
+
+// check verifies one line against the chain so far, returning why it
+// breaks the chain, or "" when it doesn't
+func (v *AuditVerification) check(data []byte, trusted []*signing.PublicKey) (string, *AuditEvent, error) {
+	ev, err := decodeAuditEvent(data)
+	if err != nil {
+		return "line is not a valid audit event", nil, nil
+	}
+	if ev.Hash == "" {
+		if v.Head != "" {
+			return "event has no hash but follows hash-chained events: it was inserted, or its hash removed", &ev, nil
+		}
+		v.Unchained++
+		v.Events++
+		return "", nil, nil
+	}
+	if ev.PrevHash != v.Head {
+		if v.Head == "" {
+			return "prev_hash points at an event that is missing: earlier events were deleted", &ev, nil
+		}
+		return "prev_hash doesn't match the previous event's hash: events were deleted, inserted or reordered", &ev, nil
+	}
+	hash, err := auditEventHash(ev)
+	if err != nil {
+		return "", nil, err
+	}
+	if hash != ev.Hash {
+		return "hash doesn't match the event's contents: it was modified after it was written", &ev, nil
+	}
+	if ev.KeyID != "" || ev.Signature != "" {
+		_, err := signing.VerifyAuditHash(trusted, ev.KeyID, ev.Hash, ev.Signature)
+		switch {
+		case err == nil:
+			v.Signed++
+		case errors.Is(err, signing.ErrUntrustedKey):
+			v.UntrustedSigned++
+		default:
+			return "signature doesn't match the event: " + err.Error(), &ev, nil
+		}
+	}
+	v.Head = ev.Hash
+	v.Events++
+	return "", nil, nil
+}
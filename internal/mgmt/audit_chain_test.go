@@ -0,0 +1,224 @@
+package mgmt
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sleuth-io/sx/v2/internal/signing"
+)
+
+// writeChainedLog appends one event per month from March to May 2026 and
+// returns the vault root
+func writeChainedLog(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for i, month := range []time.Month{3, 4, 4, 5} {
+		ev := AuditEvent{
+			Timestamp:  time.Date(2026, month, 1+i, 10, 0, 0, 0, time.UTC),
+			Actor:      "alice@example.com",
+			Event:      EventTeamMemberAdded,
+			TargetType: TargetTypeTeam,
+			Target:     "platform",
+			// Typed values must hash the same once read back
+			Data: map[string]any{"member": "bob@example.com", "count": i, "repos": []string{"a", "b"}},
+		}
+		if err := AppendAuditEvent(dir, ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func auditFile(root, month string) string {
+	return filepath.Join(root, AuditDirName, month+".jsonl")
+}
+
+func readAuditLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+}
+
+func writeAuditLines(t *testing.T, path string, lines []string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuditChainVerifies(t *testing.T) {
+	root := writeChainedLog(t)
+	v, err := VerifyAuditLog(root, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Broken != nil || v.Events != 4 || v.Files != 3 || v.Head == "" {
+		t.Fatalf("expected an intact chain of 4 events in 3 files, got %+v (broken: %+v)", v, v.Broken)
+	}
+
+	// A backdated event still extends the chain at the end of the log
+	if err := AppendAuditEvent(root, AuditEvent{Timestamp: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Event: EventTeamCreated}); err != nil {
+		t.Fatal(err)
+	}
+	if lines := readAuditLines(t, auditFile(root, "2026-05")); len(lines) != 2 {
+		t.Errorf("expected the backdated event in the newest file, got %d lines", len(lines))
+	}
+	if v, _ := VerifyAuditLog(root, nil); v.Broken != nil || v.Events != 5 {
+		t.Errorf("expected an intact chain of 5 events, got %+v", v)
+	}
+}
+
+func TestAuditChainReportsFirstBrokenLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(t *testing.T, root string)
+		file   string
+		line   int
+		reason string
+	}{
+		{
+			name: "edited",
+			tamper: func(t *testing.T, root string) {
+				path := auditFile(root, "2026-04")
+				lines := readAuditLines(t, path)
+				lines[1] = strings.Replace(lines[1], "bob@example.com", "mallory@example.com", 1)
+				writeAuditLines(t, path, lines)
+			},
+			file: "2026-04", line: 2, reason: "modified",
+		},
+		{
+			name: "deleted",
+			tamper: func(t *testing.T, root string) {
+				path := auditFile(root, "2026-04")
+				writeAuditLines(t, path, readAuditLines(t, path)[1:])
+			},
+			file: "2026-04", line: 1, reason: "deleted",
+		},
+		{
+			name: "reordered",
+			tamper: func(t *testing.T, root string) {
+				path := auditFile(root, "2026-04")
+				lines := readAuditLines(t, path)
+				writeAuditLines(t, path, []string{lines[1], lines[0]})
+			},
+			file: "2026-04", line: 1, reason: "reordered",
+		},
+		{
+			name: "month removed",
+			tamper: func(t *testing.T, root string) {
+				if err := os.Remove(auditFile(root, "2026-03")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			file: "2026-04", line: 1, reason: "missing",
+		},
+		{
+			name: "unchained line inserted",
+			tamper: func(t *testing.T, root string) {
+				path := auditFile(root, "2026-05")
+				lines := readAuditLines(t, path)
+				writeAuditLines(t, path, append([]string{`{"ts":"2026-05-01T00:00:00Z","actor":"mallory@example.com","event":"team.deleted","target_type":"team","target":"platform"}`}, lines...))
+			},
+			file: "2026-05", line: 1, reason: "inserted",
+		},
+		{
+			name: "garbage",
+			tamper: func(t *testing.T, root string) {
+				path := auditFile(root, "2026-05")
+				writeAuditLines(t, path, append(readAuditLines(t, path), "not json"))
+			},
+			file: "2026-05", line: 2, reason: "not a valid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeChainedLog(t)
+			tt.tamper(t, root)
+			v, err := VerifyAuditLog(root, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b := v.Broken
+			if b == nil {
+				t.Fatalf("expected a broken chain, got %+v", v)
+			}
+			if b.File != AuditDirName+"/"+tt.file+".jsonl" || b.Line != tt.line || !strings.Contains(b.Reason, tt.reason) {
+				t.Errorf("broken at %s:%d (%s), want %s.jsonl:%d (%s)", b.File, b.Line, b.Reason, tt.file, tt.line, tt.reason)
+			}
+		})
+	}
+}
+
+func TestAuditChainContinuesLegacyLog(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, AuditDirName), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeAuditLines(t, auditFile(root, "2026-04"), []string{
+		`{"ts":"2026-04-01T00:00:00Z","actor":"alice@example.com","event":"team.created","target_type":"team","target":"platform"}`,
+	})
+	if err := AppendAuditEvent(root, AuditEvent{Timestamp: time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC), Event: EventTeamDeleted}); err != nil {
+		t.Fatal(err)
+	}
+	v, err := VerifyAuditLog(root, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Broken != nil || v.Events != 2 || v.Unchained != 1 {
+		t.Errorf("expected one legacy and one chained event, got %+v", v)
+	}
+}
+
+func TestAuditChainSignatures(t *testing.T) {
+	alice, err := signing.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mallory, err := signing.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+	SetAuditSigner(alice)
+	t.Cleanup(func() { SetAuditSigner(nil) })
+	if err := AppendAuditEvent(root, AuditEvent{Actor: "alice@example.com", Event: EventTeamCreated}); err != nil {
+		t.Fatal(err)
+	}
+	SetAuditSigner(mallory)
+	if err := AppendAuditEvent(root, AuditEvent{Actor: "mallory@example.com", Event: EventTeamDeleted}); err != nil {
+		t.Fatal(err)
+	}
+	SetAuditSigner(nil)
+	if err := AppendAuditEvent(root, AuditEvent{Actor: "bob@example.com", Event: EventTeamUpdated}); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := VerifyAuditLog(root, []*signing.PublicKey{alice.Public()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Broken != nil || v.Events != 3 || v.Signed != 1 || v.UntrustedSigned != 1 {
+		t.Fatalf("unexpected verification: %+v", v)
+	}
+
+	// Swapping in another event's signature breaks the chain at that event
+	path := auditFile(root, time.Now().UTC().Format("2006-01"))
+	lines := readAuditLines(t, path)
+	first, _ := decodeAuditEvent([]byte(lines[0]))
+	second, _ := decodeAuditEvent([]byte(lines[1]))
+	lines[0] = strings.Replace(lines[0], first.Signature, second.Signature, 1)
+	writeAuditLines(t, path, lines)
+	v, err = VerifyAuditLog(root, []*signing.PublicKey{alice.Public()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Broken == nil || v.Broken.Line != 1 || !strings.Contains(v.Broken.Reason, "signature") {
+		t.Errorf("expected a bad signature on line 1, got %+v", v.Broken)
+	}
+}
@@ -0,0 +1,110 @@
+package mgmt
+
+import (
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// auditCursorVersion prefixes encoded cursors so the format can change
+const auditCursorVersion = "v1"
+
+// AuditEventID identifies an event for pagination: its chain hash, or for
+// events without one (logs older than the chain, the Sleuth backend) the
+// hash of its contents.
+func AuditEventID(ev AuditEvent) string {
+	if ev.Hash != "" {
+		return ev.Hash
+	}
+	id, err := auditEventHash(ev)
+	if err != nil {
+		return ""
+	}
+	return id
+}
+
+// AuditCursor is a position in the oldest-first stream of audit events:
+// the last event a page returned
+type AuditCursor struct {
+	Timestamp time.Time
+	ID        string
+}
+
+// CursorFor returns the cursor positioned at ev
+func CursorFor(ev AuditEvent) AuditCursor {
+	return AuditCursor{Timestamp: ev.Timestamp.UTC(), ID: AuditEventID(ev)}
+}
+
+// String encodes the cursor as an opaque token
+func (c AuditCursor) String() string {
+	raw := auditCursorVersion + "|" + c.Timestamp.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseAuditCursor decodes a token from AuditCursor.String
+func ParseAuditCursor(s string) (AuditCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return AuditCursor{}, errors.New("invalid cursor")
+	}
+	parts := strings.SplitN(string(raw), "|", 3)
+	if len(parts) != 3 || parts[0] != auditCursorVersion {
+		return AuditCursor{}, errors.New("invalid cursor")
+	}
+	ts, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return AuditCursor{}, errors.New("invalid cursor")
+	}
+	return AuditCursor{Timestamp: ts, ID: parts[2]}, nil
+}
+
+// Precedes reports whether the cursor's position comes before ev, i.e.
+// whether ev belongs on a later page
+func (c AuditCursor) Precedes(ev AuditEvent) bool {
+	if !ev.Timestamp.Equal(c.Timestamp) {
+		return ev.Timestamp.After(c.Timestamp)
+	}
+	return AuditEventID(ev) > c.ID
+}
+
+// SortAuditEventsAscending sorts events oldest first, breaking timestamp
+// ties by ID so that every query sees the same order
+func SortAuditEventsAscending(events []AuditEvent) {
+	type keyed struct {
+		ev AuditEvent
+		id string
+	}
+	ks := make([]keyed, len(events))
+	for i, ev := range events {
+		ks[i] = keyed{ev, AuditEventID(ev)}
+	}
+	sort.Slice(ks, func(i, j int) bool {
+		if !ks[i].ev.Timestamp.Equal(ks[j].ev.Timestamp) {
+			return ks[i].ev.Timestamp.Before(ks[j].ev.Timestamp)
+		}
+		return ks[i].id < ks[j].id
+	})
+	for i := range ks {
+		events[i] = ks[i].ev
+	}
+}
+
+// PageAuditEvents returns up to limit events that come after the cursor
+// (all of them when after is nil), from events sorted oldest first. next
+// is the cursor for the following page, or nil when this page is the
+// last. A limit of zero returns everything.
+func PageAuditEvents(sorted []AuditEvent, after *AuditCursor, limit int) (page []AuditEvent, next *AuditCursor) {
+	start := 0
+	if after != nil {
+		start = sort.Search(len(sorted), func(i int) bool { return after.Precedes(sorted[i]) })
+	}
+	page = sorted[start:]
+	if limit > 0 && len(page) > limit {
+		page = page[:limit]
+		c := CursorFor(page[len(page)-1])
+		next = &c
+	}
+	return page, next
+}
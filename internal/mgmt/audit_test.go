@@ -1,6 +1,7 @@
 package mgmt
 
 import (
+	"fmt"
 	"path/filepath"
 	"testing"
 	"time"
@@ -121,3 +122,98 @@ func TestAppendAuditEventDefaultsTimestamp(t *testing.T) {
 		t.Errorf("default timestamp outside expected window: %v", got[0].Timestamp)
 	}
 }
+
+func TestAuditExporterReceivesWrittenEvents(t *testing.T) {
+	dir := t.TempDir()
+	var exported []AuditEvent
+	SetAuditExporter(func(events []AuditEvent) { exported = append(exported, events...) })
+	t.Cleanup(func() { SetAuditExporter(nil) })
+	SetAuditProfileTag("work")
+	t.Cleanup(func() { SetAuditProfileTag("") })
+
+	if err := AppendAuditEvent(dir, AuditEvent{Actor: "alice@example.com", Event: EventTeamCreated, Target: "platform"}); err != nil {
+		t.Fatalf("AppendAuditEvent failed: %v", err)
+	}
+	if len(exported) != 1 || exported[0].Profile != "work" || exported[0].Timestamp.IsZero() {
+		t.Errorf("expected the stamped event to be exported, got %+v", exported)
+	}
+}
+
+// TestAuditExportHeldUntilLanded: events appended during a vault write
+// are exported once the write lands, dropped when it fails, and never
+// exported twice
+func TestAuditExportHeldUntilLanded(t *testing.T) {
+	dir := t.TempDir()
+	var exported []AuditEvent
+	SetAuditExporter(func(events []AuditEvent) { exported = append(exported, events...) })
+	t.Cleanup(func() { SetAuditExporter(nil) })
+	appendEvent := func(target string) {
+		t.Helper()
+		if err := AppendAuditEvent(dir, AuditEvent{Actor: "alice@example.com", Event: EventTeamCreated, Target: target}); err != nil {
+			t.Fatalf("AppendAuditEvent failed: %v", err)
+		}
+	}
+
+	// A failed write, or a replayed attempt, exports nothing
+	release := HoldAuditExports(dir)
+	appendEvent("discarded")
+	release(false)
+	if len(exported) != 0 {
+		t.Fatalf("events from a failed write were exported: %+v", exported)
+	}
+
+	// Nested holds export once the outermost write lands
+	outer := HoldAuditExports(dir)
+	inner := HoldAuditExports(dir)
+	appendEvent("platform")
+	inner(true)
+	if len(exported) != 0 {
+		t.Fatalf("events exported before the outer write landed: %+v", exported)
+	}
+	outer(true)
+	outer(true)
+	if len(exported) != 1 || exported[0].Target != "platform" {
+		t.Fatalf("exported = %+v, want the landed event once", exported)
+	}
+
+	// A failed inner write drops only its own events, not the outer's
+	exported = nil
+	outer = HoldAuditExports(dir)
+	appendEvent("outer")
+	inner = HoldAuditExports(dir)
+	appendEvent("inner")
+	inner(false)
+	outer(true)
+	if len(exported) != 1 || exported[0].Target != "outer" {
+		t.Fatalf("exported = %+v, want only the outer write's event", exported)
+	}
+
+	// The same event handed over again is skipped by hash
+	auditExporterMu.Lock()
+	exportAuditEventsLocked(exported)
+	auditExporterMu.Unlock()
+	if len(exported) != 1 {
+		t.Errorf("an exported event was exported again: %+v", exported)
+	}
+}
+
+// TestAuditExportWindowIsBounded: the exported-hash set forgets the
+// oldest hashes instead of growing for the life of the process
+func TestAuditExportWindowIsBounded(t *testing.T) {
+	SetAuditExporter(func([]AuditEvent) {})
+	t.Cleanup(func() { SetAuditExporter(nil) })
+
+	auditExporterMu.Lock()
+	defer auditExporterMu.Unlock()
+	events := make([]AuditEvent, auditExportWindow+10)
+	for i := range events {
+		events[i].Hash = fmt.Sprintf("hash-%d", i)
+	}
+	exportAuditEventsLocked(events)
+	if len(auditExportedSet) != auditExportWindow || len(auditExportedHashes) != auditExportWindow {
+		t.Fatalf("remembered %d hashes (%d in order), want %d", len(auditExportedSet), len(auditExportedHashes), auditExportWindow)
+	}
+	if auditExportedSet["hash-0"] || !auditExportedSet[fmt.Sprintf("hash-%d", len(events)-1)] {
+		t.Error("the window should keep the newest hashes and forget the oldest")
+	}
+}
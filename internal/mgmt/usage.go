@@ -15,37 +15,79 @@ const UsageDirName = ".sx/usage"
 
 // UsageEvent is a single row in .sx/usage/YYYY-MM.jsonl. Timestamp and
 // actor are normalized to UTC and lowercase respectively at append time.
+//
+// The fields after AssetType are optional: events recorded before they
+// existed, or by clients whose hooks don't report them, leave them empty.
 type UsageEvent struct {
 	Timestamp    time.Time `json:"ts"`
 	Actor        string    `json:"actor"`
 	AssetName    string    `json:"asset_name"`
 	AssetVersion string    `json:"asset_version"`
 	AssetType    string    `json:"asset_type"`
+	// Client is the sx client ID whose hook reported the use, or for MCP
+	// the name the MCP client gave when it connected.
+	Client    string `json:"client,omitempty"`
+	RepoURL   string `json:"repo_url,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+	// Trigger is how the asset reached the model: UsageTriggerNative or
+	// UsageTriggerMCP.
+	Trigger string `json:"trigger,omitempty"`
+	// ContextTokens estimates how many tokens the use added to the
+	// model's context. Zero when unknown.
+	ContextTokens int `json:"context_tokens,omitempty"`
 }
 
+// Usage triggers
+const (
+	// UsageTriggerNative is a use the client loaded itself, reported by
+	// its hooks (e.g. Claude Code's Skill tool)
+	UsageTriggerNative = "native"
+	// UsageTriggerMCP is a use served by sx's own MCP tools (read_skill,
+	// list_rules_for_path and friends)
+	UsageTriggerMCP = "mcp"
+)
+
 // UsageFilter narrows a usage query.
 type UsageFilter struct {
 	AssetName string
 	AssetType string
 	Actor     string
+	Client    string
+	RepoURL   string
+	Trigger   string
 	Since     time.Time
 	Until     time.Time
 }
 
-// UsageSummary is the aggregated result of a usage query.
+// UsageSummary is the aggregated result of a usage query. PerClient,
+// PerRepo and PerTrigger only count events that carry the field.
 type UsageSummary struct {
 	TotalEvents int
 	PerAsset    []AssetUsageCount
 	PerActor    []ActorUsageCount
+	PerClient   []UsageGroupCount
+	PerRepo     []UsageGroupCount
+	PerTrigger  []UsageGroupCount
 }
 
 // AssetUsageCount is the per-asset rollup in a UsageSummary.
 type AssetUsageCount struct {
-	AssetName    string
-	AssetType    string
-	TotalUses    int
-	UniqueActors int
-	LastUsed     time.Time
+	AssetName      string
+	AssetType      string
+	TotalUses      int
+	UniqueActors   int
+	UniqueSessions int
+	ContextTokens  int
+	LastUsed       time.Time
+}
+
+// UsageGroupCount is the rollup for one client, repository or trigger in
+// a UsageSummary.
+type UsageGroupCount struct {
+	Key           string
+	TotalUses     int
+	UniqueActors  int
+	ContextTokens int
 }
 
 // ActorUsageCount is the per-actor rollup in a UsageSummary.
@@ -94,13 +136,7 @@ func ReadUsageEvents(vaultRoot string, filter UsageFilter) ([]UsageEvent, error)
 	if err != nil {
 		return nil, err
 	}
-	var out []UsageEvent
-	for _, ev := range events {
-		if matchesUsageFilter(ev, filter) {
-			out = append(out, ev)
-		}
-	}
-	return out, nil
+	return FilterUsageEvents(events, filter), nil
 }
 
 // SummarizeUsage computes per-asset and per-actor rollups over the matching
@@ -121,16 +157,22 @@ func SummarizeUsageEvents(events []UsageEvent) *UsageSummary {
 	type assetKey struct{ name, typ string }
 	assetAgg := make(map[assetKey]*AssetUsageCount)
 	assetActors := make(map[assetKey]map[string]struct{})
+	assetSessions := make(map[assetKey]map[string]struct{})
 	actorAgg := make(map[string]int)
+	clients := newUsageGrouper()
+	repos := newUsageGrouper()
+	triggers := newUsageGrouper()
 
 	for _, ev := range events {
 		k := assetKey{ev.AssetName, ev.AssetType}
 		if assetAgg[k] == nil {
 			assetAgg[k] = &AssetUsageCount{AssetName: ev.AssetName, AssetType: ev.AssetType}
 			assetActors[k] = make(map[string]struct{})
+			assetSessions[k] = make(map[string]struct{})
 		}
 		agg := assetAgg[k]
 		agg.TotalUses++
+		agg.ContextTokens += ev.ContextTokens
 		if ev.Timestamp.After(agg.LastUsed) {
 			agg.LastUsed = ev.Timestamp
 		}
@@ -138,11 +180,23 @@ func SummarizeUsageEvents(events []UsageEvent) *UsageSummary {
 			assetActors[k][ev.Actor] = struct{}{}
 			actorAgg[ev.Actor]++
 		}
+		if ev.SessionID != "" {
+			assetSessions[k][ev.SessionID] = struct{}{}
+		}
+		clients.add(ev.Client, ev)
+		repos.add(ev.RepoURL, ev)
+		triggers.add(ev.Trigger, ev)
 	}
 
-	summary := &UsageSummary{TotalEvents: len(events)}
+	summary := &UsageSummary{
+		TotalEvents: len(events),
+		PerClient:   clients.counts(),
+		PerRepo:     repos.counts(),
+		PerTrigger:  triggers.counts(),
+	}
 	for k, agg := range assetAgg {
 		agg.UniqueActors = len(assetActors[k])
+		agg.UniqueSessions = len(assetSessions[k])
 		summary.PerAsset = append(summary.PerAsset, *agg)
 	}
 	sort.Slice(summary.PerAsset, func(i, j int) bool {
@@ -165,6 +219,63 @@ func SummarizeUsageEvents(events []UsageEvent) *UsageSummary {
 	return summary
 }
 
+// usageGrouper rolls events up by one optional field. Events with the
+// field empty are left out.
+type usageGrouper struct {
+	agg    map[string]*UsageGroupCount
+	actors map[string]map[string]struct{}
+}
+
+func newUsageGrouper() *usageGrouper {
+	return &usageGrouper{
+		agg:    make(map[string]*UsageGroupCount),
+		actors: make(map[string]map[string]struct{}),
+	}
+}
+
+func (g *usageGrouper) add(key string, ev UsageEvent) {
+	if key == "" {
+		return
+	}
+	if g.agg[key] == nil {
+		g.agg[key] = &UsageGroupCount{Key: key}
+		g.actors[key] = make(map[string]struct{})
+	}
+	g.agg[key].TotalUses++
+	g.agg[key].ContextTokens += ev.ContextTokens
+	if ev.Actor != "" {
+		g.actors[key][ev.Actor] = struct{}{}
+	}
+}
+
+// counts returns the groups sorted by TotalUses descending
+func (g *usageGrouper) counts() []UsageGroupCount {
+	var out []UsageGroupCount
+	for key, agg := range g.agg {
+		agg.UniqueActors = len(g.actors[key])
+		out = append(out, *agg)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].TotalUses != out[j].TotalUses {
+			return out[i].TotalUses > out[j].TotalUses
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+// FilterUsageEvents returns the events matching filter. Server-backed
+// vaults use it for the filter fields their query API doesn't take.
+func FilterUsageEvents(events []UsageEvent, filter UsageFilter) []UsageEvent {
+	var out []UsageEvent
+	for _, ev := range events {
+		if matchesUsageFilter(ev, filter) {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
 func matchesUsageFilter(ev UsageEvent, f UsageFilter) bool {
 	if f.AssetName != "" && ev.AssetName != f.AssetName {
 		return false
@@ -175,6 +286,15 @@ func matchesUsageFilter(ev UsageEvent, f UsageFilter) bool {
 	if f.Actor != "" && !strings.EqualFold(ev.Actor, f.Actor) {
 		return false
 	}
+	if f.Client != "" && ev.Client != f.Client {
+		return false
+	}
+	if f.RepoURL != "" && !strings.EqualFold(ev.RepoURL, f.RepoURL) {
+		return false
+	}
+	if f.Trigger != "" && ev.Trigger != f.Trigger {
+		return false
+	}
 	if !f.Since.IsZero() && ev.Timestamp.Before(f.Since) {
 		return false
 	}
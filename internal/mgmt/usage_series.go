@@ -0,0 +1,255 @@
+package mgmt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// UsageInterval is the bucket size of a usage time series
+type UsageInterval string
+
+const (
+	IntervalDay   UsageInterval = "day"
+	IntervalWeek  UsageInterval = "week"
+	IntervalMonth UsageInterval = "month"
+)
+
+// ParseUsageInterval validates an interval name
+func ParseUsageInterval(s string) (UsageInterval, error) {
+	switch i := UsageInterval(strings.ToLower(strings.TrimSpace(s))); i {
+	case IntervalDay, IntervalWeek, IntervalMonth:
+		return i, nil
+	}
+	return "", fmt.Errorf("invalid interval %q (use day, week, or month)", s)
+}
+
+// Start returns the start of the bucket containing t, in UTC. Weeks start
+// on Monday.
+func (i UsageInterval) Start(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch i {
+	case IntervalWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case IntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+// Next returns the start of the bucket after the one starting at start
+func (i UsageInterval) Next(start time.Time) time.Time {
+	switch i {
+	case IntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case IntervalMonth:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// Buckets returns the start of every bucket from the one containing from
+// through the one containing to
+func (i UsageInterval) Buckets(from, to time.Time) []time.Time {
+	var out []time.Time
+	for b := i.Start(from); !b.After(to); b = i.Next(b) {
+		out = append(out, b)
+	}
+	return out
+}
+
+// Series point kinds
+const (
+	SeriesKindAsset = "asset"
+	SeriesKindTeam  = "team"
+)
+
+// UsageSeriesPoint is the usage of one asset, or by one team's members,
+// within one bucket of a time series
+type UsageSeriesPoint struct {
+	Bucket time.Time `json:"bucket"`
+	Kind   string    `json:"kind"`
+	Name   string    `json:"name"`
+	Uses   int       `json:"uses"`
+	Users  int       `json:"users"`
+}
+
+// UsageSeries buckets events by interval, per asset and per team (counting
+// the uses of the team's members). Only buckets with usage get a point;
+// the points are sorted by bucket, kind and name.
+func UsageSeries(events []UsageEvent, interval UsageInterval, teams []Team) []UsageSeriesPoint {
+	type key struct {
+		bucket     time.Time
+		kind, name string
+	}
+	uses := make(map[key]int)
+	users := make(map[key]map[string]struct{})
+	count := func(k key, actor string) {
+		uses[k]++
+		if users[k] == nil {
+			users[k] = make(map[string]struct{})
+		}
+		if actor != "" {
+			users[k][actor] = struct{}{}
+		}
+	}
+
+	for _, ev := range events {
+		bucket := interval.Start(ev.Timestamp)
+		count(key{bucket, SeriesKindAsset, ev.AssetName}, ev.Actor)
+		for _, t := range teams {
+			if ev.Actor != "" && t.IsMember(ev.Actor) {
+				count(key{bucket, SeriesKindTeam, t.Name}, ev.Actor)
+			}
+		}
+	}
+
+	points := make([]UsageSeriesPoint, 0, len(uses))
+	for k, n := range uses {
+		points = append(points, UsageSeriesPoint{Bucket: k.bucket, Kind: k.kind, Name: k.name, Uses: n, Users: len(users[k])})
+	}
+	sort.Slice(points, func(i, j int) bool {
+		a, b := points[i], points[j]
+		if !a.Bucket.Equal(b.Bucket) {
+			return a.Bucket.Before(b.Bucket)
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+	return points
+}
+
+// UsageChange compares an asset's usage in a window with the window of
+// the same length before it. ChangePct is nil when the asset wasn't used
+// in the previous window.
+type UsageChange struct {
+	AssetName     string   `json:"asset_name"`
+	AssetType     string   `json:"asset_type"`
+	Uses          int      `json:"uses"`
+	PreviousUses  int      `json:"previous_uses"`
+	Users         int      `json:"users"`
+	PreviousUsers int      `json:"previous_users"`
+	ChangePct     *float64 `json:"change_pct,omitempty"`
+}
+
+// CompareUsage rolls up current and previous per asset, sorted by current
+// uses descending
+func CompareUsage(current, previous []UsageEvent) []UsageChange {
+	cur := SummarizeUsageEvents(current)
+	prev := SummarizeUsageEvents(previous)
+
+	type assetKey struct{ name, typ string }
+	byAsset := make(map[assetKey]*UsageChange)
+	get := func(name, typ string) *UsageChange {
+		k := assetKey{name, typ}
+		if byAsset[k] == nil {
+			byAsset[k] = &UsageChange{AssetName: name, AssetType: typ}
+		}
+		return byAsset[k]
+	}
+	for _, a := range cur.PerAsset {
+		c := get(a.AssetName, a.AssetType)
+		c.Uses, c.Users = a.TotalUses, a.UniqueActors
+	}
+	for _, a := range prev.PerAsset {
+		c := get(a.AssetName, a.AssetType)
+		c.PreviousUses, c.PreviousUsers = a.TotalUses, a.UniqueActors
+	}
+
+	changes := make([]UsageChange, 0, len(byAsset))
+	for _, c := range byAsset {
+		if c.PreviousUses > 0 {
+			pct := 100.0 * float64(c.Uses-c.PreviousUses) / float64(c.PreviousUses)
+			c.ChangePct = &pct
+		}
+		changes = append(changes, *c)
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Uses != changes[j].Uses {
+			return changes[i].Uses > changes[j].Uses
+		}
+		if changes[i].PreviousUses != changes[j].PreviousUses {
+			return changes[i].PreviousUses > changes[j].PreviousUses
+		}
+		return changes[i].AssetName < changes[j].AssetName
+	})
+	return changes
+}
+
+// UsageCohort groups the users whose first use of an asset fell in the
+// same bucket. Retained[k] is how many of them used the asset again k
+// buckets later, so Retained[0] is the cohort size.
+type UsageCohort struct {
+	AssetName string    `json:"asset_name"`
+	Start     time.Time `json:"start"`
+	Users     int       `json:"users"`
+	Retained  []int     `json:"retained"`
+}
+
+// UsageCohorts computes per-asset retention cohorts over events, with
+// bucket offsets running up to the bucket containing until. Events
+// without an actor are ignored. Cohorts are sorted by asset and start.
+func UsageCohorts(events []UsageEvent, interval UsageInterval, until time.Time) []UsageCohort {
+	type actorKey struct{ asset, actor string }
+	first := make(map[actorKey]time.Time)
+	active := make(map[actorKey]map[time.Time]struct{})
+	for _, ev := range events {
+		if ev.Actor == "" {
+			continue
+		}
+		k := actorKey{ev.AssetName, ev.Actor}
+		bucket := interval.Start(ev.Timestamp)
+		if f, ok := first[k]; !ok || bucket.Before(f) {
+			first[k] = bucket
+		}
+		if active[k] == nil {
+			active[k] = make(map[time.Time]struct{})
+		}
+		active[k][bucket] = struct{}{}
+	}
+
+	type cohortKey struct {
+		asset string
+		start time.Time
+	}
+	cohorts := make(map[cohortKey]*UsageCohort)
+	last := interval.Start(until)
+	for k, start := range first {
+		ck := cohortKey{k.asset, start}
+		c := cohorts[ck]
+		if c == nil {
+			c = &UsageCohort{AssetName: k.asset, Start: start}
+			for b := start; !b.After(last); b = interval.Next(b) {
+				c.Retained = append(c.Retained, 0)
+			}
+			if len(c.Retained) == 0 {
+				// Events after until still form a cohort of their own
+				c.Retained = []int{0}
+			}
+			cohorts[ck] = c
+		}
+		c.Users++
+		offset := 0
+		for b := start; offset < len(c.Retained); b, offset = interval.Next(b), offset+1 {
+			if _, ok := active[k][b]; ok {
+				c.Retained[offset]++
+			}
+		}
+	}
+
+	out := make([]UsageCohort, 0, len(cohorts))
+	for _, c := range cohorts {
+		out = append(out, *c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].AssetName != out[j].AssetName {
+			return out[i].AssetName < out[j].AssetName
+		}
+		return out[i].Start.Before(out[j].Start)
+	})
+	return out
+}
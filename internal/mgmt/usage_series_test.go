@@ -0,0 +1,106 @@
+package mgmt
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUsageIntervalStart(t *testing.T) {
+	// Wednesday afternoon
+	ts := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC)
+	tests := map[UsageInterval]time.Time{
+		IntervalDay:   time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC),
+		IntervalWeek:  time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+		IntervalMonth: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for interval, want := range tests {
+		if got := interval.Start(ts); !got.Equal(want) {
+			t.Errorf("%s start = %s, want %s", interval, got, want)
+		}
+	}
+	// Sunday belongs to the week that started the Monday before
+	sunday := time.Date(2026, 10, 18, 23, 0, 0, 0, time.UTC)
+	if got := IntervalWeek.Start(sunday); !got.Equal(tests[IntervalWeek]) {
+		t.Errorf("week start for Sunday = %s", got)
+	}
+	if _, err := ParseUsageInterval("fortnight"); err == nil {
+		t.Error("expected an error for an unknown interval")
+	}
+}
+
+func TestUsageSeries(t *testing.T) {
+	w1 := time.Date(2026, 10, 5, 10, 0, 0, 0, time.UTC)
+	w2 := w1.AddDate(0, 0, 7)
+	events := []UsageEvent{
+		{Timestamp: w1, Actor: "alice@example.com", AssetName: "a"},
+		{Timestamp: w1.Add(time.Hour), Actor: "alice@example.com", AssetName: "a"},
+		{Timestamp: w1, Actor: "carol@example.com", AssetName: "b"},
+		{Timestamp: w2, Actor: "bob@example.com", AssetName: "a"},
+	}
+	teams := []Team{{Name: "platform", Members: []string{"alice@example.com", "bob@example.com"}}}
+
+	want := []UsageSeriesPoint{
+		{Bucket: IntervalWeek.Start(w1), Kind: SeriesKindAsset, Name: "a", Uses: 2, Users: 1},
+		{Bucket: IntervalWeek.Start(w1), Kind: SeriesKindAsset, Name: "b", Uses: 1, Users: 1},
+		{Bucket: IntervalWeek.Start(w1), Kind: SeriesKindTeam, Name: "platform", Uses: 2, Users: 1},
+		{Bucket: IntervalWeek.Start(w2), Kind: SeriesKindAsset, Name: "a", Uses: 1, Users: 1},
+		{Bucket: IntervalWeek.Start(w2), Kind: SeriesKindTeam, Name: "platform", Uses: 1, Users: 1},
+	}
+	if got := UsageSeries(events, IntervalWeek, teams); !reflect.DeepEqual(got, want) {
+		t.Errorf("UsageSeries:\n%+v\nwant:\n%+v", got, want)
+	}
+}
+
+func TestCompareUsage(t *testing.T) {
+	ts := time.Date(2026, 10, 5, 10, 0, 0, 0, time.UTC)
+	current := []UsageEvent{
+		{Timestamp: ts, Actor: "alice@example.com", AssetName: "a", AssetType: "skill"},
+		{Timestamp: ts, Actor: "bob@example.com", AssetName: "a", AssetType: "skill"},
+		{Timestamp: ts, Actor: "bob@example.com", AssetName: "a", AssetType: "skill"},
+		{Timestamp: ts, Actor: "bob@example.com", AssetName: "new", AssetType: "rule"},
+	}
+	previous := []UsageEvent{
+		{Timestamp: ts, Actor: "alice@example.com", AssetName: "a", AssetType: "skill"},
+		{Timestamp: ts, Actor: "alice@example.com", AssetName: "a", AssetType: "skill"},
+		{Timestamp: ts, Actor: "alice@example.com", AssetName: "old", AssetType: "skill"},
+	}
+	changes := CompareUsage(current, previous)
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 assets, got %+v", changes)
+	}
+	a := changes[0]
+	if a.AssetName != "a" || a.Uses != 3 || a.PreviousUses != 2 || a.Users != 2 || a.PreviousUsers != 1 || a.ChangePct == nil || *a.ChangePct != 50 {
+		t.Errorf("unexpected change for a: %+v", a)
+	}
+	if changes[1].AssetName != "new" || changes[1].ChangePct != nil {
+		t.Errorf("expected a new asset without a change percentage, got %+v", changes[1])
+	}
+	if changes[2].AssetName != "old" || changes[2].ChangePct == nil || *changes[2].ChangePct != -100 {
+		t.Errorf("expected old to drop 100%%, got %+v", changes[2])
+	}
+}
+
+func TestUsageCohorts(t *testing.T) {
+	w0 := time.Date(2026, 9, 7, 10, 0, 0, 0, time.UTC)
+	week := func(n int) time.Time { return w0.AddDate(0, 0, 7*n) }
+	events := []UsageEvent{
+		// alice starts in week 0 and comes back in week 2
+		{Timestamp: week(0), Actor: "alice@example.com", AssetName: "a"},
+		{Timestamp: week(2), Actor: "alice@example.com", AssetName: "a"},
+		// bob starts in week 0 and never returns
+		{Timestamp: week(0), Actor: "bob@example.com", AssetName: "a"},
+		// carol starts in week 1 and returns in week 2
+		{Timestamp: week(1), Actor: "carol@example.com", AssetName: "a"},
+		{Timestamp: week(2), Actor: "carol@example.com", AssetName: "a"},
+		// Events without an actor don't form cohorts
+		{Timestamp: week(0), AssetName: "a"},
+	}
+	want := []UsageCohort{
+		{AssetName: "a", Start: IntervalWeek.Start(week(0)), Users: 2, Retained: []int{2, 0, 1}},
+		{AssetName: "a", Start: IntervalWeek.Start(week(1)), Users: 1, Retained: []int{1, 1}},
+	}
+	if got := UsageCohorts(events, IntervalWeek, week(2)); !reflect.DeepEqual(got, want) {
+		t.Errorf("UsageCohorts:\n%+v\nwant:\n%+v", got, want)
+	}
+}
@@ -1,6 +1,9 @@
 package mgmt
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -117,3 +120,59 @@ func TestReadUsageEventsMissingDir(t *testing.T) {
 		t.Errorf("expected empty events, got %d", len(got))
 	}
 }
+
+func TestUsageEventsContextFields(t *testing.T) {
+	dir := t.TempDir()
+	usageDir := filepath.Join(dir, UsageDirName)
+	if err := os.MkdirAll(usageDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// A line written before the optional fields existed still parses
+	legacy := `{"ts":"2026-04-15T10:00:00Z","actor":"alice@example.com","asset_name":"my-skill","asset_version":"1.0.0","asset_type":"skill"}` + "\n"
+	if err := os.WriteFile(filepath.Join(usageDir, "2026-04.jsonl"), []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t0 := time.Date(2026, 4, 16, 10, 0, 0, 0, time.UTC)
+	events := []UsageEvent{
+		{Timestamp: t0, Actor: "alice@example.com", AssetName: "my-skill", AssetType: "skill", Client: "claude-code", RepoURL: "https://github.com/acme/app", SessionID: "s1", Trigger: UsageTriggerNative, ContextTokens: 100},
+		{Timestamp: t0, Actor: "bob@example.com", AssetName: "my-skill", AssetType: "skill", Client: "cursor", SessionID: "s2", Trigger: UsageTriggerMCP, ContextTokens: 50},
+		{Timestamp: t0, Actor: "bob@example.com", AssetName: "my-skill", AssetType: "skill", Client: "cursor", SessionID: "s2", Trigger: UsageTriggerMCP, ContextTokens: 50},
+	}
+	if err := AppendUsageEvents(dir, events); err != nil {
+		t.Fatalf("AppendUsageEvents failed: %v", err)
+	}
+
+	summary, err := SummarizeUsage(dir, UsageFilter{})
+	if err != nil {
+		t.Fatalf("SummarizeUsage failed: %v", err)
+	}
+	if summary.TotalEvents != 4 {
+		t.Errorf("expected 4 events, got %d", summary.TotalEvents)
+	}
+	a := summary.PerAsset[0]
+	if a.TotalUses != 4 || a.UniqueSessions != 2 || a.ContextTokens != 200 {
+		t.Errorf("unexpected asset rollup: %+v", a)
+	}
+	wantClients := []UsageGroupCount{
+		{Key: "cursor", TotalUses: 2, UniqueActors: 1, ContextTokens: 100},
+		{Key: "claude-code", TotalUses: 1, UniqueActors: 1, ContextTokens: 100},
+	}
+	if !reflect.DeepEqual(summary.PerClient, wantClients) {
+		t.Errorf("PerClient = %+v, want %+v", summary.PerClient, wantClients)
+	}
+	if len(summary.PerRepo) != 1 || summary.PerRepo[0].Key != "https://github.com/acme/app" {
+		t.Errorf("PerRepo = %+v", summary.PerRepo)
+	}
+	if len(summary.PerTrigger) != 2 || summary.PerTrigger[0].Key != UsageTriggerMCP {
+		t.Errorf("PerTrigger = %+v", summary.PerTrigger)
+	}
+
+	got, err := ReadUsageEvents(dir, UsageFilter{Trigger: UsageTriggerNative})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Client != "claude-code" {
+		t.Errorf("trigger filter = %+v", got)
+	}
+}
@@ -0,0 +1,229 @@
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/sleuth-io/sx/v2/internal/buildinfo"
+	"github.com/sleuth-io/sx/v2/internal/config"
+)
+
+// maxSpoolFiles caps how many undelivered payloads are buffered. When a
+// collector stays unreachable, the oldest are dropped first.
+const maxSpoolFiles = 500
+
+// spool file suffixes, which record the signal path to replay them to
+const (
+	logsSuffix    = ".logs.json"
+	metricsSuffix = ".metrics.json"
+)
+
+// Exporter sends OTLP/HTTP JSON requests to a collector. Payloads that
+// can't be delivered are written to a spool directory and replayed by
+// Flush.
+type Exporter struct {
+	endpoint string
+	headers  map[string]string
+	resource []Attribute
+	spoolDir string
+	client   *http.Client
+}
+
+// NewExporter returns an exporter for cfg that buffers undelivered
+// payloads in spoolDir
+func NewExporter(cfg config.OTLPConfig, spoolDir string) *Exporter {
+	service := cfg.ServiceName
+	if service == "" {
+		service = "sx"
+	}
+	return &Exporter{
+		endpoint: strings.TrimRight(strings.TrimSpace(cfg.Endpoint), "/"),
+		headers:  cfg.Headers,
+		resource: []Attribute{
+			String("service.name", service),
+			String("service.version", buildinfo.Version),
+		},
+		spoolDir: spoolDir,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// ExportLogs sends records to the collector, buffering them when the
+// collector can't be reached
+func (e *Exporter) ExportLogs(ctx context.Context, records []LogRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	body, err := EncodeLogs(e.resource, records, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to encode logs: %w", err)
+	}
+	return e.export(ctx, LogsPath, body)
+}
+
+// SpoolLogs buffers records for the next Flush without contacting the
+// collector, for callers that can't wait on the network
+func (e *Exporter) SpoolLogs(records []LogRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	body, err := EncodeLogs(e.resource, records, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to encode logs: %w", err)
+	}
+	return e.spool(LogsPath, body)
+}
+
+// ExportSums sends counter data points to the collector, buffering them
+// when the collector can't be reached
+func (e *Exporter) ExportSums(ctx context.Context, sums []Sum) error {
+	if len(sums) == 0 {
+		return nil
+	}
+	body, err := EncodeSums(e.resource, sums)
+	if err != nil {
+		return fmt.Errorf("failed to encode metrics: %w", err)
+	}
+	return e.export(ctx, MetricsPath, body)
+}
+
+// Flush replays buffered payloads oldest first, stopping at the first one
+// the collector still can't take
+func (e *Exporter) Flush(ctx context.Context) error {
+	names, err := e.spooled()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		path := filepath.Join(e.spoolDir, name)
+		body, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read buffered payload: %w", err)
+		}
+		signal := LogsPath
+		if strings.HasSuffix(name, metricsSuffix) {
+			signal = MetricsPath
+		}
+		if err := e.send(ctx, signal, body); err != nil && isRetryable(err) {
+			return err
+		}
+		// Delivered, or rejected for good: either way it's done
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove buffered payload: %w", err)
+		}
+	}
+	return nil
+}
+
+func (e *Exporter) export(ctx context.Context, signal string, body []byte) error {
+	err := e.send(ctx, signal, body)
+	if err == nil || !isRetryable(err) {
+		return err
+	}
+	if spoolErr := e.spool(signal, body); spoolErr != nil {
+		return errors.Join(err, spoolErr)
+	}
+	return fmt.Errorf("%w (buffered for retry)", err)
+}
+
+func (e *Exporter) send(ctx context.Context, signal string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint+signal, bytes.NewReader(body))
+	if err != nil {
+		return &exportError{err: fmt.Errorf("failed to create OTLP request: %w", err)}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "sx/"+buildinfo.Version)
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return &exportError{err: fmt.Errorf("failed to reach OTLP endpoint: %w", err), retryable: true}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return &exportError{
+		err: fmt.Errorf("OTLP endpoint returned %s for %s: %s", resp.Status, signal, strings.TrimSpace(string(msg))),
+		// The OTLP spec's retryable statuses; anything else won't
+		// succeed on a replay either
+		retryable: resp.StatusCode == http.StatusTooManyRequests ||
+			resp.StatusCode == http.StatusBadGateway ||
+			resp.StatusCode == http.StatusServiceUnavailable ||
+			resp.StatusCode == http.StatusGatewayTimeout,
+	}
+}
+
+func (e *Exporter) spool(signal string, body []byte) error {
+	if err := os.MkdirAll(e.spoolDir, 0755); err != nil {
+		return fmt.Errorf("failed to create OTLP buffer directory: %w", err)
+	}
+	suffix := logsSuffix
+	if signal == MetricsPath {
+		suffix = metricsSuffix
+	}
+	// Same naming as the usage queue, so files sort oldest first
+	name := fmt.Sprintf("%s-%s%s", time.Now().Format("20060102-150405"), uuid.New().String(), suffix)
+	if err := os.WriteFile(filepath.Join(e.spoolDir, name), body, 0644); err != nil {
+		return fmt.Errorf("failed to buffer OTLP payload: %w", err)
+	}
+
+	names, err := e.spooled()
+	if err != nil {
+		return err
+	}
+	for len(names) > maxSpoolFiles {
+		_ = os.Remove(filepath.Join(e.spoolDir, names[0]))
+		names = names[1:]
+	}
+	return nil
+}
+
+// spooled lists buffered payloads, oldest first
+func (e *Exporter) spooled() ([]string, error) {
+	entries, err := os.ReadDir(e.spoolDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OTLP buffer directory: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && (strings.HasSuffix(name, logsSuffix) || strings.HasSuffix(name, metricsSuffix)) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// exportError records whether a failed request is worth retrying
+type exportError struct {
+	err       error
+	retryable bool
+}
+
+func (e *exportError) Error() string { return e.err.Error() }
+
+func (e *exportError) Unwrap() error { return e.err }
+
+func isRetryable(err error) bool {
+	var ee *exportError
+	return errors.As(err, &ee) && ee.retryable
+}
@@ -0,0 +1,157 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sleuth-io/sx/v2/internal/config"
+)
+
+// collector records the requests an OTLP endpoint receives
+type collector struct {
+	mu       sync.Mutex
+	down     bool
+	requests map[string][]map[string]any
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.down {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if r.Header.Get("Content-Type") != "application/json" || r.Header.Get("X-Api-Key") != "secret" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if c.requests == nil {
+		c.requests = make(map[string][]map[string]any)
+	}
+	c.requests[r.URL.Path] = append(c.requests[r.URL.Path], payload)
+}
+
+func (c *collector) count(path string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.requests[path])
+}
+
+func TestExporterSendsAndBuffers(t *testing.T) {
+	col := &collector{}
+	srv := httptest.NewServer(col)
+	defer srv.Close()
+	spool := t.TempDir()
+	exp := NewExporter(config.OTLPConfig{Endpoint: srv.URL + "/", Headers: map[string]string{"X-Api-Key": "secret"}}, spool)
+	ctx := context.Background()
+	now := time.Now()
+
+	records := []LogRecord{{Time: now, Body: "skill my-skill used", Attributes: []Attribute{String("sx.asset.name", "my-skill"), Int("sx.context_tokens", 42)}}}
+	sums := []Sum{{Name: "sx.asset.uses", Unit: "{use}", Start: now, Time: now, Value: 3}}
+	if err := exp.ExportLogs(ctx, records); err != nil {
+		t.Fatalf("ExportLogs: %v", err)
+	}
+	if err := exp.ExportSums(ctx, sums); err != nil {
+		t.Fatalf("ExportSums: %v", err)
+	}
+	if col.count(LogsPath) != 1 || col.count(MetricsPath) != 1 {
+		t.Fatalf("expected one logs and one metrics request, got %+v", col.requests)
+	}
+
+	// Check the encoding a collector relies on
+	rl := col.requests[LogsPath][0]["resourceLogs"].([]any)[0].(map[string]any)
+	rec := rl["scopeLogs"].([]any)[0].(map[string]any)["logRecords"].([]any)[0].(map[string]any)
+	attrs := rec["attributes"].([]any)
+	if tokens := attrs[1].(map[string]any)["value"].(map[string]any)["intValue"]; tokens != "42" {
+		t.Errorf("int attributes must be encoded as strings, got %v", tokens)
+	}
+	metric := col.requests[MetricsPath][0]["resourceMetrics"].([]any)[0].(map[string]any)["scopeMetrics"].([]any)[0].(map[string]any)["metrics"].([]any)[0].(map[string]any)
+	sum := metric["sum"].(map[string]any)
+	if sum["isMonotonic"] != true || sum["aggregationTemporality"] != float64(aggregationDelta) {
+		t.Errorf("expected a monotonic delta sum, got %v", sum)
+	}
+
+	// While the collector is down, payloads are buffered
+	col.mu.Lock()
+	col.down = true
+	col.mu.Unlock()
+	if err := exp.ExportLogs(ctx, records); err == nil {
+		t.Fatal("expected an error while the collector is down")
+	}
+	if err := exp.ExportSums(ctx, sums); err == nil {
+		t.Fatal("expected an error while the collector is down")
+	}
+	if entries, _ := os.ReadDir(spool); len(entries) != 2 {
+		t.Fatalf("expected 2 buffered payloads, got %d", len(entries))
+	}
+	if err := exp.Flush(ctx); err == nil {
+		t.Fatal("expected Flush to fail while the collector is down")
+	}
+
+	// and replayed once it's back
+	col.mu.Lock()
+	col.down = false
+	col.mu.Unlock()
+	if err := exp.Flush(ctx); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if col.count(LogsPath) != 2 || col.count(MetricsPath) != 2 {
+		t.Errorf("expected buffered payloads to be replayed, got %+v", col.requests)
+	}
+	if entries, _ := os.ReadDir(spool); len(entries) != 0 {
+		t.Errorf("expected the buffer to be empty, got %d files", len(entries))
+	}
+}
+
+func TestExporterDropsRejectedPayloads(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+	spool := t.TempDir()
+	exp := NewExporter(config.OTLPConfig{Endpoint: srv.URL}, spool)
+
+	if err := exp.ExportLogs(context.Background(), []LogRecord{{Body: "x"}}); err == nil {
+		t.Fatal("expected an error for a rejected payload")
+	}
+	if entries, _ := os.ReadDir(spool); len(entries) != 0 {
+		t.Errorf("a payload the collector rejects shouldn't be buffered, got %d files", len(entries))
+	}
+}
+
+func TestExporterSpoolLogs(t *testing.T) {
+	col := &collector{}
+	srv := httptest.NewServer(col)
+	defer srv.Close()
+	spool := t.TempDir()
+	exp := NewExporter(config.OTLPConfig{Endpoint: srv.URL, Headers: map[string]string{"X-Api-Key": "secret"}}, spool)
+
+	if err := exp.SpoolLogs([]LogRecord{{Time: time.Now(), Body: "team.created"}}); err != nil {
+		t.Fatalf("SpoolLogs: %v", err)
+	}
+	if col.count(LogsPath) != 0 {
+		t.Fatal("SpoolLogs must not contact the collector")
+	}
+	if err := exp.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if col.count(LogsPath) != 1 {
+		t.Errorf("expected the spooled records to be sent by Flush, got %+v", col.requests)
+	}
+	if entries, _ := os.ReadDir(spool); len(entries) != 0 {
+		t.Errorf("expected the buffer to be empty, got %d files", len(entries))
+	}
+}
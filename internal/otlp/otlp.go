@@ -0,0 +1,232 @@
+// Package otlp exports log records and counters to an OpenTelemetry
+// collector using the OTLP/HTTP JSON encoding. sx only emits a handful of
+// signals, so it speaks the wire format directly rather than pulling in
+// the OpenTelemetry SDK.
+package otlp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sleuth-io/sx/v2/internal/buildinfo"
+)
+
+// Signal paths under the collector's base URL
+const (
+	LogsPath    = "/v1/logs"
+	MetricsPath = "/v1/metrics"
+)
+
+// scopeName is the instrumentation scope reported with every signal
+const scopeName = "github.com/sleuth-io/sx"
+
+// severityInfo is the OTLP severity number for INFO
+const severityInfo = 9
+
+// aggregationDelta is the OTLP delta aggregation temporality. Each export
+// counts only the events it carries; the backend sums them.
+const aggregationDelta = 1
+
+// Attribute is a key/value pair on a record, data point or resource.
+// Values may be strings, bools, ints, int64s or float64s; anything else
+// is exported as its fmt representation.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String returns a string attribute
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an integer attribute
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// LogRecord is one event exported as an OTLP log record
+type LogRecord struct {
+	Time       time.Time
+	Body       string
+	Attributes []Attribute
+}
+
+// Sum is one data point of a monotonic counter. Points sharing a Name are
+// exported as a single metric.
+type Sum struct {
+	Name        string
+	Description string
+	Unit        string
+	Start       time.Time
+	Time        time.Time
+	Value       int64
+	Attributes  []Attribute
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type logRecord struct {
+	TimeUnixNano         string     `json:"timeUnixNano"`
+	ObservedTimeUnixNano string     `json:"observedTimeUnixNano"`
+	SeverityNumber       int        `json:"severityNumber"`
+	SeverityText         string     `json:"severityText"`
+	Body                 anyValue   `json:"body"`
+	Attributes           []keyValue `json:"attributes,omitempty"`
+}
+
+type scopeLogs struct {
+	Scope      scope       `json:"scope"`
+	LogRecords []logRecord `json:"logRecords"`
+}
+
+type resourceLogs struct {
+	Resource  resource    `json:"resource"`
+	ScopeLogs []scopeLogs `json:"scopeLogs"`
+}
+
+type logsRequest struct {
+	ResourceLogs []resourceLogs `json:"resourceLogs"`
+}
+
+type dataPoint struct {
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	TimeUnixNano      string     `json:"timeUnixNano"`
+	AsInt             string     `json:"asInt"`
+}
+
+type metric struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Unit        string `json:"unit,omitempty"`
+	Sum         struct {
+		DataPoints             []dataPoint `json:"dataPoints"`
+		AggregationTemporality int         `json:"aggregationTemporality"`
+		IsMonotonic            bool        `json:"isMonotonic"`
+	} `json:"sum"`
+}
+
+type scopeMetrics struct {
+	Scope   scope    `json:"scope"`
+	Metrics []metric `json:"metrics"`
+}
+
+type resourceMetrics struct {
+	Resource     resource       `json:"resource"`
+	ScopeMetrics []scopeMetrics `json:"scopeMetrics"`
+}
+
+type metricsRequest struct {
+	ResourceMetrics []resourceMetrics `json:"resourceMetrics"`
+}
+
+// EncodeLogs encodes records as an OTLP/HTTP JSON logs request
+func EncodeLogs(res []Attribute, records []LogRecord, now time.Time) ([]byte, error) {
+	sl := scopeLogs{
+		Scope:      scope{Name: scopeName, Version: buildinfo.Version},
+		LogRecords: make([]logRecord, 0, len(records)),
+	}
+	for _, r := range records {
+		ts := r.Time
+		if ts.IsZero() {
+			ts = now
+		}
+		body := r.Body
+		sl.LogRecords = append(sl.LogRecords, logRecord{
+			TimeUnixNano:         unixNano(ts),
+			ObservedTimeUnixNano: unixNano(now),
+			SeverityNumber:       severityInfo,
+			SeverityText:         "INFO",
+			Body:                 anyValue{StringValue: &body},
+			Attributes:           encodeAttributes(r.Attributes),
+		})
+	}
+	return json.Marshal(logsRequest{ResourceLogs: []resourceLogs{{
+		Resource:  resource{Attributes: encodeAttributes(res)},
+		ScopeLogs: []scopeLogs{sl},
+	}}})
+}
+
+// EncodeSums encodes counter data points as an OTLP/HTTP JSON metrics
+// request, one delta sum metric per name in first-seen order
+func EncodeSums(res []Attribute, sums []Sum) ([]byte, error) {
+	sm := scopeMetrics{Scope: scope{Name: scopeName, Version: buildinfo.Version}}
+	byName := make(map[string]int)
+	for _, s := range sums {
+		i, ok := byName[s.Name]
+		if !ok {
+			m := metric{Name: s.Name, Description: s.Description, Unit: s.Unit}
+			m.Sum.AggregationTemporality = aggregationDelta
+			m.Sum.IsMonotonic = true
+			sm.Metrics = append(sm.Metrics, m)
+			i = len(sm.Metrics) - 1
+			byName[s.Name] = i
+		}
+		sm.Metrics[i].Sum.DataPoints = append(sm.Metrics[i].Sum.DataPoints, dataPoint{
+			Attributes:        encodeAttributes(s.Attributes),
+			StartTimeUnixNano: unixNano(s.Start),
+			TimeUnixNano:      unixNano(s.Time),
+			AsInt:             strconv.FormatInt(s.Value, 10),
+		})
+	}
+	return json.Marshal(metricsRequest{ResourceMetrics: []resourceMetrics{{
+		Resource:     resource{Attributes: encodeAttributes(res)},
+		ScopeMetrics: []scopeMetrics{sm},
+	}}})
+}
+
+func encodeAttributes(attrs []Attribute) []keyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	out := make([]keyValue, 0, len(attrs))
+	for _, a := range attrs {
+		var v anyValue
+		switch val := a.Value.(type) {
+		case string:
+			v.StringValue = &val
+		case bool:
+			v.BoolValue = &val
+		case int:
+			s := strconv.Itoa(val)
+			v.IntValue = &s
+		case int64:
+			s := strconv.FormatInt(val, 10)
+			v.IntValue = &s
+		case float64:
+			v.DoubleValue = &val
+		default:
+			s := fmt.Sprint(val)
+			v.StringValue = &s
+		}
+		out = append(out, keyValue{Key: a.Key, Value: v})
+	}
+	return out
+}
+
+// unixNano formats t the way OTLP JSON encodes fixed64 timestamps
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/sleuth-io/sx/v2/internal/asset"
 	"github.com/sleuth-io/sx/v2/internal/assets/detectors"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
 	"github.com/sleuth-io/sx/v2/internal/metadata"
 	"github.com/sleuth-io/sx/v2/internal/utils"
 	"github.com/sleuth-io/sx/v2/internal/version"
@@ -164,3 +165,19 @@ func ApplyMetadata(meta *metadata.Metadata, zipData []byte, hasMetadata bool) ([
 	}
 	return out, nil
 }
+
+// LockDependencies converts metadata.toml dependency strings ("name",
+// "name^1.2", "name>=2.0 <3") into lock-file dependency rows, keeping the
+// version range for install-time resolution. Entries that don't parse are
+// skipped; metadata validation has already rejected them at publish time.
+func LockDependencies(meta *metadata.Metadata) []lockfile.Dependency {
+	var deps []lockfile.Dependency
+	for _, dep := range meta.Asset.Dependencies {
+		name, constraint, err := metadata.ParseDependency(dep)
+		if err != nil {
+			continue
+		}
+		deps = append(deps, lockfile.Dependency{Name: name, Version: constraint})
+	}
+	return deps
+}
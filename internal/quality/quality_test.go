@@ -0,0 +1,179 @@
+package quality
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/llm"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// fakeProvider answers every completion with reply and keeps the last
+// request.
+type fakeProvider struct {
+	reply string
+	last  llm.Request
+}
+
+func (f *fakeProvider) ID() string { return "fake" }
+
+func (f *fakeProvider) Complete(ctx context.Context, req llm.Request) (llm.Response, error) {
+	f.last = req
+	return llm.Response{Text: f.reply, Provider: "fake", Model: "fake-1"}, nil
+}
+
+// scoredReply is a model reply giving every factor score, except those
+// in overrides.
+func scoredReply(t *testing.T, score int, overrides map[string]int) string {
+	t.Helper()
+	factors := map[string]any{}
+	for _, cat := range Rubric {
+		for _, f := range cat.Factors {
+			s := score
+			if o, ok := overrides[f.Name]; ok {
+				s = o
+			}
+			factors[f.Name] = map[string]any{"score": s, "justification": "because"}
+		}
+	}
+	data, err := json.Marshal(map[string]any{
+		"factors":         factors,
+		"summary":         "Solid.",
+		"strengths":       []string{"examples"},
+		"improvements":    nil,
+		"recommendations": []string{"add troubleshooting"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func zipOf(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := utils.CreateZip(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// TestRubric_Weights pins the rubric as docs/quality-spec.md documents
+// it: changing a weight or factor changes every new overall score.
+func TestRubric_Weights(t *testing.T) {
+	want := []struct {
+		name    string
+		weight  float64
+		factors string
+	}{
+		{"structure", 0.24, "organization formatting frontmatter"},
+		{"actionability", 0.36, "specificity examples workflow"},
+		{"content", 0.27, "accuracy clarity conciseness"},
+		{"completeness", 0.13, "scope edge_cases"},
+	}
+	if len(Rubric) != len(want) {
+		t.Fatalf("rubric has %d categories, want %d", len(Rubric), len(want))
+	}
+	total := 0.0
+	for i, cat := range Rubric {
+		var factors []string
+		for _, f := range cat.Factors {
+			factors = append(factors, f.Name)
+		}
+		if cat.Name != want[i].name || cat.Weight != want[i].weight || strings.Join(factors, " ") != want[i].factors {
+			t.Errorf("category %d = %s %v %v, want %s %v [%s]", i, cat.Name, cat.Weight, factors, want[i].name, want[i].weight, want[i].factors)
+		}
+		total += cat.Weight
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("weights sum to %v, want 1", total)
+	}
+}
+
+func TestEvaluate_ScoresFromFactors(t *testing.T) {
+	skill := zipOf(t, map[string]string{
+		"SKILL.md":         "---\ndescription: Review PRs\n---\n\nRead the diff first.\n",
+		"docs/guide.md":    "one two three",
+		"metadata.toml":    "[asset]\nname = \"review\"\nversion = \"1\"\ntype = \"skill\"\n",
+		"assets/logo.png":  "\x89PNG\x00\x00",
+		"scripts/check.sh": "echo ok",
+	})
+	p := &fakeProvider{reply: scoredReply(t, 80, map[string]int{"specificity": 50, "examples": 110})}
+
+	rec, err := Evaluate(context.Background(), p, "review", skill)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Source != SourceCLI || rec.Executor.Provider != "fake" || rec.Executor.Model != "fake-1" {
+		t.Errorf("record attribution = %s %+v", rec.Source, rec.Executor)
+	}
+	// actionability = (50 + 100 + 80) / 3; overall weights it at 0.36.
+	if got := rec.Categories["actionability"]; got != 77 {
+		t.Errorf("actionability = %d, want 77", got)
+	}
+	if rec.Categories["structure"] != 80 || rec.Overall != 79 {
+		t.Errorf("structure %d, overall %d; want 80, 79", rec.Categories["structure"], rec.Overall)
+	}
+	if f := rec.Factors["specificity"]; f.Score != 50 || f.Tier != "Needs Work" || f.Justification != "because" {
+		t.Errorf("specificity = %+v", f)
+	}
+	if f := rec.Factors["examples"]; f.Score != 100 || f.Tier != "Excellent" {
+		t.Errorf("examples = %+v, want clamped to 100", f)
+	}
+	if rec.Stats.FileCount != 3 || rec.Stats.WordCount != 14 {
+		t.Errorf("stats = %+v, want 3 files, 14 words", rec.Stats)
+	}
+	if rec.Insights.Improvements == nil {
+		t.Error("null improvements should become an empty list")
+	}
+
+	prompt := p.last.Messages[1].Content
+	if !strings.HasPrefix(prompt, "Evaluate the skill \"review\".\n\n=== SKILL.md ===") {
+		t.Errorf("SKILL.md should lead the prompt:\n%s", prompt)
+	}
+	if strings.Contains(prompt, "metadata.toml") || strings.Contains(prompt, "logo.png") {
+		t.Errorf("prompt includes packaging or binary files:\n%s", prompt)
+	}
+	if len(p.last.Schema) == 0 {
+		t.Error("request carries no response schema")
+	}
+}
+
+func TestEvaluate_MissingFactor(t *testing.T) {
+	reply := map[string]any{}
+	if err := json.Unmarshal([]byte(scoredReply(t, 70, nil)), &reply); err != nil {
+		t.Fatal(err)
+	}
+	delete(reply["factors"].(map[string]any), "edge_cases")
+	data, _ := json.Marshal(reply)
+
+	_, err := Evaluate(context.Background(), &fakeProvider{reply: string(data)}, "x", zipOf(t, map[string]string{"SKILL.md": "hi"}))
+	if err == nil || !strings.Contains(err.Error(), "edge_cases") {
+		t.Fatalf("err = %v, want missing edge_cases", err)
+	}
+}
+
+func TestLatest(t *testing.T) {
+	rec, err := Latest(`{"evaluating":false,"records":[{"overall":84,"categories":{},"skill_hash":"b"},{"overall":70,"categories":{}}]}`)
+	if err != nil || rec == nil || rec.Overall != 84 || rec.SkillHash != "b" {
+		t.Fatalf("Latest = %+v, %v", rec, err)
+	}
+	if rec, err := Latest(`{"evaluating":false,"records":[]}`); err != nil || rec != nil {
+		t.Fatalf("empty Latest = %+v, %v", rec, err)
+	}
+}
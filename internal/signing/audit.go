@@ -0,0 +1,45 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// AuditStatement is the message an audit event signature covers: the
+// event's chain hash, which commits to the event and every event before it
+// in the log (docs/audit.md).
+func AuditStatement(hash string) []byte {
+	return fmt.Appendf(nil, "sx-audit-event-v1\nhash=%s\n", hash)
+}
+
+// SignAuditHash signs an audit event's chain hash. It returns the key ID
+// and the base64 ed25519 signature to store on the event.
+func SignAuditHash(k *PrivateKey, hash string) (keyID, sig string) {
+	return formatKeyID(k.id), base64.StdEncoding.EncodeToString(ed25519.Sign(k.key, AuditStatement(hash)))
+}
+
+// VerifyAuditHash checks an audit event signature made by the key with
+// keyID. It returns ErrUntrustedKey when that key isn't trusted and
+// ErrInvalidSignature when the signature doesn't cover hash.
+func VerifyAuditHash(trusted []*PublicKey, keyID, hash, sig string) (*PublicKey, error) {
+	var key *PublicKey
+	for _, k := range trusted {
+		if k.ID() == keyID {
+			key = k
+			break
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%w (key %s)", ErrUntrustedKey, keyID)
+	}
+	raw, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil {
+		return nil, errors.New("malformed audit signature")
+	}
+	if !ed25519.Verify(key.key, AuditStatement(hash), raw) {
+		return nil, ErrInvalidSignature
+	}
+	return key, nil
+}
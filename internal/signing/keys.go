@@ -0,0 +1,258 @@
+// Package signing signs and verifies published assets (docs/signing.md).
+//
+// Keys are ed25519. They are read and written in minisign's formats so a
+// team can use minisign's own tooling to inspect them, and a bare
+// "ed25519:<base64>" public key or a PKCS#8 PEM private key (e.g. from
+// `openssl genpkey -algorithm ed25519`) works too. Signatures are minisign
+// signature files over a short statement naming the asset, its version,
+// and its content hash; verification needs nothing but the trusted keys.
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// KeyEnv names the environment variable that overrides the signing key
+// path.
+const KeyEnv = "SX_SIGNING_KEY"
+
+// rawKeyPrefix marks a bare ed25519 public key in a trusted-keys list.
+const rawKeyPrefix = "ed25519:"
+
+var (
+	sigAlgEd       = [2]byte{'E', 'd'}
+	kdfNone        = [2]byte{0, 0}
+	kdfScrypt      = [2]byte{'S', 'c'}
+	chkBlake2b     = [2]byte{'B', '2'}
+	errNotMinisign = errors.New("not a minisign key")
+)
+
+// ErrNoKey is returned by LoadKey when no signing key is configured.
+var ErrNoKey = errors.New("no signing key configured")
+
+// PublicKey is an ed25519 public key with its 8-byte key ID. Minisign keys
+// carry their ID; for bare ed25519 keys it is derived from the key.
+type PublicKey struct {
+	id  [8]byte
+	key ed25519.PublicKey
+}
+
+// PrivateKey is an ed25519 private key with its key ID.
+type PrivateKey struct {
+	id  [8]byte
+	key ed25519.PrivateKey
+}
+
+// ID returns the key ID in minisign's display form (16 hex digits).
+func (k *PublicKey) ID() string {
+	return formatKeyID(k.id)
+}
+
+// String returns the key in minisign's public key encoding, the form
+// trusted-keys lists store.
+func (k *PublicKey) String() string {
+	buf := make([]byte, 0, 42)
+	buf = append(buf, sigAlgEd[:]...)
+	buf = append(buf, k.id[:]...)
+	buf = append(buf, k.key...)
+	return base64.StdEncoding.EncodeToString(buf)
+}
+
+// Public returns the key's public half.
+func (k *PrivateKey) Public() *PublicKey {
+	pub, _ := k.key.Public().(ed25519.PublicKey)
+	return &PublicKey{id: k.id, key: pub}
+}
+
+// GenerateKey returns a new random key.
+func GenerateKey() (*PrivateKey, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	k := &PrivateKey{key: priv}
+	if _, err := rand.Read(k.id[:]); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// ParsePublicKey parses a minisign public key (the base64 line, or a whole
+// .pub file) or "ed25519:<base64 of the 32 key bytes>".
+func ParsePublicKey(s string) (*PublicKey, error) {
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(s, rawKeyPrefix); ok {
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(rest))
+		if err != nil || len(raw) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid ed25519 public key: want ed25519:<base64 of 32 bytes>")
+		}
+		return &PublicKey{id: derivedKeyID(raw), key: ed25519.PublicKey(raw)}, nil
+	}
+	raw, err := base64.StdEncoding.DecodeString(lastLine(s))
+	if err != nil || len(raw) != 42 || !bytes.Equal(raw[:2], sigAlgEd[:]) {
+		return nil, errors.New("invalid public key: want a minisign public key or ed25519:<base64>")
+	}
+	k := &PublicKey{key: ed25519.PublicKey(bytes.Clone(raw[10:]))}
+	copy(k.id[:], raw[2:10])
+	return k, nil
+}
+
+// ParsePrivateKey parses an unencrypted minisign secret key file or a
+// PKCS#8 PEM ed25519 key.
+func ParsePrivateKey(data []byte) (*PrivateKey, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PEM private key: %w", err)
+		}
+		priv, ok := parsed.(ed25519.PrivateKey)
+		if !ok {
+			return nil, errors.New("PEM private key is not ed25519")
+		}
+		pub, _ := priv.Public().(ed25519.PublicKey)
+		return &PrivateKey{id: derivedKeyID(pub), key: priv}, nil
+	}
+	k, err := parseMinisignSecretKey(string(data))
+	if errors.Is(err, errNotMinisign) {
+		return nil, errors.New("unrecognized private key: want a minisign secret key or a PKCS#8 PEM ed25519 key")
+	}
+	return k, err
+}
+
+// Minisign secret key layout: sig_alg(2) kdf_alg(2) chk_alg(2) salt(32)
+// opslimit(8) memlimit(8) key_id(8) secret_key(64) checksum(32). The last
+// three are scrypt-encrypted unless kdf_alg is zero.
+func parseMinisignSecretKey(s string) (*PrivateKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(lastLine(strings.TrimSpace(s)))
+	if err != nil || len(raw) != 158 || !bytes.Equal(raw[:2], sigAlgEd[:]) {
+		return nil, errNotMinisign
+	}
+	if bytes.Equal(raw[2:4], kdfScrypt[:]) {
+		return nil, errors.New("password-protected minisign keys are not supported; create one without a password (minisign -G -W) or use `sx signing keygen`")
+	}
+	if !bytes.Equal(raw[2:4], kdfNone[:]) || !bytes.Equal(raw[4:6], chkBlake2b[:]) {
+		return nil, errors.New("unsupported minisign secret key algorithms")
+	}
+	k := &PrivateKey{key: ed25519.PrivateKey(bytes.Clone(raw[62:126]))}
+	copy(k.id[:], raw[54:62])
+	if !bytes.Equal(secretKeyChecksum(k), raw[126:158]) {
+		return nil, errors.New("minisign secret key checksum mismatch")
+	}
+	return k, nil
+}
+
+// MarshalText encodes the key as an unencrypted minisign secret key file.
+// Keep it private: anyone holding it can sign as you.
+func (k *PrivateKey) MarshalText() ([]byte, error) {
+	buf := make([]byte, 0, 158)
+	buf = append(buf, sigAlgEd[:]...)
+	buf = append(buf, kdfNone[:]...)
+	buf = append(buf, chkBlake2b[:]...)
+	buf = append(buf, make([]byte, 32+8+8)...) // salt, opslimit, memlimit: unused without a KDF
+	buf = append(buf, k.id[:]...)
+	buf = append(buf, k.key...)
+	buf = append(buf, secretKeyChecksum(k)...)
+	text := "untrusted comment: sx secret key " + formatKeyID(k.id) + "\n" +
+		base64.StdEncoding.EncodeToString(buf) + "\n"
+	return []byte(text), nil
+}
+
+func secretKeyChecksum(k *PrivateKey) []byte {
+	h, _ := blake2b.New256(nil)
+	h.Write(sigAlgEd[:])
+	h.Write(k.id[:])
+	h.Write(k.key)
+	return h.Sum(nil)
+}
+
+// KeyPath returns where the signing key is read from: $SX_SIGNING_KEY, or
+// signing.key in the sx config directory.
+func KeyPath() (string, error) {
+	if p := strings.TrimSpace(os.Getenv(KeyEnv)); p != "" {
+		return p, nil
+	}
+	dir, err := utils.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "signing.key"), nil
+}
+
+// LoadKey reads the signing key from KeyPath. It returns ErrNoKey when the
+// file doesn't exist.
+func LoadKey() (*PrivateKey, error) {
+	path, err := KeyPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- the user's own configured key path
+	if os.IsNotExist(err) {
+		return nil, ErrNoKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	k, err := ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return k, nil
+}
+
+// SaveKey writes k to path, readable only by the current user. It refuses
+// to overwrite an existing key.
+func SaveKey(path string, k *PrivateKey) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	text, err := k.MarshalText()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(path, text, 0o600)
+}
+
+// derivedKeyID gives bare ed25519 keys a stable ID: the first 8 bytes of
+// the SHA-256 of the key.
+func derivedKeyID(pub []byte) [8]byte {
+	sum := sha256.Sum256(pub)
+	var id [8]byte
+	copy(id[:], sum[:8])
+	return id
+}
+
+// formatKeyID renders an ID as minisign does: the bytes as a little-endian
+// 64-bit number in upper-case hex.
+func formatKeyID(id [8]byte) string {
+	rev := make([]byte, 8)
+	for i := range id {
+		rev[7-i] = id[i]
+	}
+	return strings.ToUpper(hex.EncodeToString(rev))
+}
+
+// lastLine returns the last non-empty line of s, skipping minisign's
+// "untrusted comment:" header.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,156 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Verification policies, stored in the vault's [signing] table.
+const (
+	// PolicyOff skips verification. It is the default.
+	PolicyOff = "off"
+	// PolicyWarn installs unsigned or untrusted assets with a warning.
+	PolicyWarn = "warn"
+	// PolicyEnforce refuses to install them.
+	PolicyEnforce = "enforce"
+)
+
+// ValidatePolicy checks a policy name.
+func ValidatePolicy(p string) error {
+	switch p {
+	case PolicyOff, PolicyWarn, PolicyEnforce:
+		return nil
+	}
+	return fmt.Errorf("invalid signing policy %q (off|warn|enforce)", p)
+}
+
+// Verification errors.
+var (
+	// ErrUntrustedKey means the signature was made by a key that is not in
+	// the trusted list.
+	ErrUntrustedKey = errors.New("signed by an untrusted key")
+	// ErrInvalidSignature means the signature doesn't match the asset: its
+	// content, name, or version changed after signing.
+	ErrInvalidSignature = errors.New("signature does not match the asset")
+)
+
+// Minisign signature algorithms: "ED" signs the BLAKE2b-512 hash of the
+// message, legacy "Ed" signs the message itself. sx writes "ED" and reads
+// both.
+var (
+	sigAlgPrehashed = [2]byte{'E', 'D'}
+	sigAlgLegacy    = sigAlgEd
+)
+
+// Signature is a parsed and verified asset signature.
+type Signature struct {
+	// KeyID identifies the key that made it.
+	KeyID string
+	// Signer is the identity (usually an email) recorded when signing.
+	Signer string
+	// SignedAt is when it was made, per the signer's clock.
+	SignedAt time.Time
+}
+
+// Statement is the message an asset signature covers. It binds the asset's
+// name and version to its content hash (utils.ComputeZipContentSHA256), so
+// a signature can be neither moved to other content nor replayed for
+// another version.
+func Statement(name, version, digest string) []byte {
+	return fmt.Appendf(nil, "sx-asset-signature-v1\nname=%s\nversion=%s\nsha256=%s\n", name, version, digest)
+}
+
+// Sign returns a minisign signature file for name@version with content
+// hash digest. signer is recorded in the signed trusted comment.
+func Sign(k *PrivateKey, name, version, digest, signer string, now time.Time) []byte {
+	hashed := blake2b.Sum512(Statement(name, version, digest))
+	sig := ed25519.Sign(k.key, hashed[:])
+
+	trusted := fmt.Sprintf("timestamp:%d\tasset:%s@%s\tsigner:%s", now.Unix(), name, version, signer)
+	global := ed25519.Sign(k.key, append(bytes.Clone(sig), trusted...))
+
+	blob := make([]byte, 0, 74)
+	blob = append(blob, sigAlgPrehashed[:]...)
+	blob = append(blob, k.id[:]...)
+	blob = append(blob, sig...)
+
+	var b strings.Builder
+	b.WriteString("untrusted comment: signature from sx secret key " + formatKeyID(k.id) + "\n")
+	b.WriteString(base64.StdEncoding.EncodeToString(blob) + "\n")
+	b.WriteString("trusted comment: " + trusted + "\n")
+	b.WriteString(base64.StdEncoding.EncodeToString(global) + "\n")
+	return []byte(b.String())
+}
+
+// Verify checks a signature file against the trusted keys for name@version
+// with content hash digest.
+func Verify(data []byte, trusted []*PublicKey, name, version, digest string) (*Signature, error) {
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 4 {
+		return nil, errors.New("malformed signature file")
+	}
+	blob, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(blob) != 74 {
+		return nil, errors.New("malformed signature")
+	}
+	trustedComment, ok := strings.CutPrefix(strings.TrimRight(lines[2], "\r"), "trusted comment: ")
+	if !ok {
+		return nil, errors.New("malformed signature: missing trusted comment")
+	}
+	global, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil {
+		return nil, errors.New("malformed signature: bad global signature")
+	}
+
+	var id [8]byte
+	copy(id[:], blob[2:10])
+	var key *PublicKey
+	for _, k := range trusted {
+		if k.id == id {
+			key = k
+			break
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("%w (key %s)", ErrUntrustedKey, formatKeyID(id))
+	}
+
+	message := Statement(name, version, digest)
+	sig := blob[10:]
+	switch {
+	case bytes.Equal(blob[:2], sigAlgPrehashed[:]):
+		hashed := blake2b.Sum512(message)
+		message = hashed[:]
+	case bytes.Equal(blob[:2], sigAlgLegacy[:]):
+	default:
+		return nil, errors.New("unsupported signature algorithm")
+	}
+	if !ed25519.Verify(key.key, message, sig) {
+		return nil, ErrInvalidSignature
+	}
+	if !ed25519.Verify(key.key, append(bytes.Clone(sig), trustedComment...), global) {
+		return nil, fmt.Errorf("%w: trusted comment was altered", ErrInvalidSignature)
+	}
+
+	out := &Signature{KeyID: key.ID()}
+	for field := range strings.SplitSeq(trustedComment, "\t") {
+		k, v, _ := strings.Cut(field, ":")
+		switch k {
+		case "signer":
+			out.Signer = v
+		case "timestamp":
+			if ts, err := strconv.ParseInt(v, 10, 64); err == nil {
+				out.SignedAt = time.Unix(ts, 0).UTC()
+			}
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,102 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const digest = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+func newKey(t *testing.T) *PrivateKey {
+	t.Helper()
+	k, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	return k
+}
+
+// TestSignVerify_RoundTrip: a signature verifies against the trusted public
+// key, survives a text round trip of both keys, and carries the signer.
+func TestSignVerify_RoundTrip(t *testing.T) {
+	k := newKey(t)
+	text, err := k.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText: %v", err)
+	}
+	loaded, err := ParsePrivateKey(text)
+	if err != nil {
+		t.Fatalf("ParsePrivateKey: %v", err)
+	}
+	pub, err := ParsePublicKey(k.Public().String())
+	if err != nil {
+		t.Fatalf("ParsePublicKey: %v", err)
+	}
+
+	now := time.Unix(1_700_000_000, 0)
+	sig := Sign(loaded, "reviewer", "3", digest, "alice@acme.com", now)
+	got, err := Verify(sig, []*PublicKey{pub}, "reviewer", "3", digest)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if got.Signer != "alice@acme.com" || got.KeyID != pub.ID() || !got.SignedAt.Equal(now) {
+		t.Fatalf("signature = %+v", got)
+	}
+}
+
+// TestVerify_Rejects: changed content, a moved version, an untrusted key,
+// and an edited trusted comment all fail.
+func TestVerify_Rejects(t *testing.T) {
+	k := newKey(t)
+	trusted := []*PublicKey{k.Public()}
+	sig := Sign(k, "reviewer", "3", digest, "alice@acme.com", time.Now())
+
+	if _, err := Verify(sig, trusted, "reviewer", "3", "00"+digest[2:]); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("changed content: err = %v", err)
+	}
+	if _, err := Verify(sig, trusted, "reviewer", "4", digest); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("replayed for another version: err = %v", err)
+	}
+	if _, err := Verify(sig, []*PublicKey{newKey(t).Public()}, "reviewer", "3", digest); !errors.Is(err, ErrUntrustedKey) {
+		t.Errorf("untrusted key: err = %v", err)
+	}
+	forged := []byte(strings.Replace(string(sig), "signer:alice@acme.com", "signer:mallory@acme.com", 1))
+	if _, err := Verify(forged, trusted, "reviewer", "3", digest); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("edited trusted comment: err = %v", err)
+	}
+}
+
+// TestKeys_RawAndPEM: a PKCS#8 PEM key signs, and the bare ed25519 form of
+// its public key verifies — the two derive the same key ID.
+func TestKeys_RawAndPEM(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("ParsePrivateKey(PEM): %v", err)
+	}
+	raw, err := ParsePublicKey("ed25519:" + base64.StdEncoding.EncodeToString(pub))
+	if err != nil {
+		t.Fatalf("ParsePublicKey(raw): %v", err)
+	}
+	sig := Sign(k, "reviewer", "3", digest, "ci", time.Now())
+	if _, err := Verify(sig, []*PublicKey{raw}, "reviewer", "3", digest); err != nil {
+		t.Fatalf("Verify with raw key: %v", err)
+	}
+	if _, err := ParsePublicKey("ed25519:AAAA"); err == nil {
+		t.Error("short raw key should be rejected")
+	}
+}
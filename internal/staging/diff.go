@@ -0,0 +1,175 @@
+package staging
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// Change describes what happened to a file or config entry
+type Change string
+
+const (
+	Created  Change = "created"
+	Modified Change = "modified"
+	Deleted  Change = "deleted"
+	Added    Change = "added"
+	Removed  Change = "removed"
+	Changed  Change = "changed"
+)
+
+// Snapshot is the content of every file in a sandbox, by staged path
+type Snapshot map[string][]byte
+
+// FileChange is one file an install created, modified or deleted. For
+// config files staged by name in JSON, YAML or TOML, Entries lists the
+// keys that changed (MCP servers, hook registrations, settings).
+type FileChange struct {
+	Path    string        `json:"path"`
+	Change  Change        `json:"change"`
+	Entries []EntryChange `json:"entries,omitempty"`
+}
+
+// EntryChange is one config key, dotted from the document root
+type EntryChange struct {
+	Key    string `json:"key"`
+	Change Change `json:"change"`
+}
+
+// Snapshot reads every file currently in the sandbox
+func (s *Sandbox) Snapshot() (Snapshot, error) {
+	snap := Snapshot{}
+	err := filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		snap[path] = data
+		return nil
+	})
+	return snap, err
+}
+
+// Diff compares two snapshots and returns the changed files with their
+// real paths, sorted by path
+func (s *Sandbox) Diff(before, after Snapshot) []FileChange {
+	var changes []FileChange
+	add := func(path string, change Change, old, cur []byte) {
+		fc := FileChange{Path: s.Real(path), Change: change}
+		if s.files[path] {
+			fc.Entries = diffEntries(path, old, cur)
+		}
+		changes = append(changes, fc)
+	}
+	for path, data := range after {
+		old, existed := before[path]
+		switch {
+		case !existed:
+			add(path, Created, nil, data)
+		case !bytes.Equal(old, data):
+			add(path, Modified, old, data)
+		}
+	}
+	for path, old := range before {
+		if _, ok := after[path]; !ok {
+			add(path, Deleted, old, nil)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// diffEntries compares two versions of a config file key by key. Files
+// that aren't structured config, or don't parse, yield no entries.
+func diffEntries(path string, before, after []byte) []EntryChange {
+	old, ok := parseConfig(path, before)
+	if !ok {
+		return nil
+	}
+	cur, ok := parseConfig(path, after)
+	if !ok {
+		return nil
+	}
+	var entries []EntryChange
+	diffMaps("", old, cur, &entries)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
+func parseConfig(path string, data []byte) (map[string]any, bool) {
+	doc := map[string]any{}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return doc, isConfig(path)
+	}
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".jsonc":
+		err = utils.UnmarshalJSONC(data, &doc)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	case ".toml":
+		err = toml.Unmarshal(data, &doc)
+	default:
+		return nil, false
+	}
+	return doc, err == nil
+}
+
+func isConfig(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".jsonc", ".yaml", ".yml", ".toml":
+		return true
+	}
+	return false
+}
+
+// diffMaps records keys added to or removed from a document, recursing
+// into objects present on both sides. A key whose whole subtree is new or
+// gone is reported once rather than leaf by leaf; other values, including
+// lists, are reported as changed.
+func diffMaps(prefix string, before, after map[string]any, entries *[]EntryChange) {
+	for key, cur := range after {
+		name := joinKey(prefix, key)
+		old, ok := before[key]
+		if !ok {
+			*entries = append(*entries, EntryChange{Key: name, Change: Added})
+			continue
+		}
+		oldMap, oldIsMap := old.(map[string]any)
+		curMap, curIsMap := cur.(map[string]any)
+		if oldIsMap && curIsMap {
+			diffMaps(name, oldMap, curMap, entries)
+			continue
+		}
+		if !reflect.DeepEqual(old, cur) {
+			*entries = append(*entries, EntryChange{Key: name, Change: Changed})
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			*entries = append(*entries, EntryChange{Key: joinKey(prefix, key), Change: Removed})
+		}
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
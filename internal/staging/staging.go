@@ -0,0 +1,260 @@
+// Package staging runs client installs against a throwaway copy of the
+// files they touch, so `sx install --plan` can report what an install
+// would change without changing anything.
+//
+// A Sandbox mirrors the home directory and the repository root under a
+// temporary directory. Callers stage the real paths an install may read
+// or write, enter the sandbox (which points HOME at the mirror), run the
+// install with the repository root swapped for its mirror, and diff
+// snapshots taken before and after.
+package staging
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrOutside is returned for a path that is under neither the home
+// directory nor the repository root, so installs would write it for real
+var ErrOutside = errors.New("outside the home directory and repository")
+
+// Sandbox is a temporary mirror of the home directory and repository root
+type Sandbox struct {
+	dir        string
+	home       string
+	repoRoot   string
+	stagedHome string
+	stagedRepo string
+	files      map[string]bool // staged paths that aren't directories
+}
+
+// New creates a sandbox for home and repoRoot. repoRoot may be empty
+// outside a repository.
+func New(home, repoRoot string) (*Sandbox, error) {
+	dir, err := os.MkdirTemp("", "sx-plan-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create plan directory: %w", err)
+	}
+	s := &Sandbox{
+		dir:        dir,
+		home:       filepath.Clean(home),
+		stagedHome: filepath.Join(dir, "home"),
+		stagedRepo: filepath.Join(dir, "repo"),
+		files:      map[string]bool{},
+	}
+	if repoRoot != "" {
+		s.repoRoot = filepath.Clean(repoRoot)
+	}
+	for _, d := range []string{s.stagedHome, s.stagedRepo} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			s.Close()
+			return nil, fmt.Errorf("failed to create plan directory: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// Close deletes the sandbox
+func (s *Sandbox) Close() error {
+	return os.RemoveAll(s.dir)
+}
+
+// RepoRoot returns the mirror of the repository root
+func (s *Sandbox) RepoRoot() string {
+	if s.repoRoot == "" {
+		return ""
+	}
+	return s.stagedRepo
+}
+
+// Path maps a real path to its mirror. The repository root is matched
+// first, since it usually lives under the home directory.
+func (s *Sandbox) Path(real string) (string, error) {
+	real = filepath.Clean(real)
+	if s.repoRoot != "" {
+		if rel, ok := under(s.repoRoot, real); ok {
+			return filepath.Join(s.stagedRepo, rel), nil
+		}
+	}
+	if rel, ok := under(s.home, real); ok {
+		return filepath.Join(s.stagedHome, rel), nil
+	}
+	return "", fmt.Errorf("%s is %w", real, ErrOutside)
+}
+
+// Real maps a mirrored path back to the real one
+func (s *Sandbox) Real(staged string) string {
+	if rel, ok := under(s.stagedRepo, staged); ok && s.repoRoot != "" {
+		return filepath.Join(s.repoRoot, rel)
+	}
+	if rel, ok := under(s.stagedHome, staged); ok {
+		return filepath.Join(s.home, rel)
+	}
+	return staged
+}
+
+// RealText rewrites mirrored paths in text, such as a client's result
+// message, to the real ones
+func (s *Sandbox) RealText(text string) string {
+	if s.repoRoot != "" {
+		text = strings.ReplaceAll(text, s.stagedRepo, s.repoRoot)
+	}
+	return strings.ReplaceAll(text, s.stagedHome, s.home)
+}
+
+// Stage copies a real file or directory into the sandbox. Symlinks are
+// copied as what they point at, so writes through them stay in the
+// sandbox. For a path that doesn't exist, its nearest existing parent
+// directory is mirrored instead, so installs that check whether a config
+// directory exists make the same choice they would for real. Files staged
+// by name, rather than inside a directory, are treated as config files
+// and diffed key by key.
+func (s *Sandbox) Stage(real string) error {
+	dst, err := s.Path(real)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(real)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.files[dst] = true
+		return s.stageParent(filepath.Dir(filepath.Clean(real)))
+	}
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		s.files[dst] = true
+	}
+	return copyPath(real, dst, info, map[string]bool{})
+}
+
+// stageParent creates the mirror of dir, or of its nearest existing
+// ancestor, as an empty directory
+func (s *Sandbox) stageParent(dir string) error {
+	for {
+		dst, err := s.Path(dir)
+		if err != nil {
+			return nil
+		}
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return os.MkdirAll(dst, 0755)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// Enter points HOME (and USERPROFILE and APPDATA where they are under the
+// home directory) at the sandbox and returns a function restoring them.
+// Installs resolve the home directory per call, so they land in the
+// mirror while entered.
+func (s *Sandbox) Enter() (restore func()) {
+	type saved struct {
+		key   string
+		value string
+		set   bool
+	}
+	var prev []saved
+	swap := func(key, value string) {
+		old, set := os.LookupEnv(key)
+		prev = append(prev, saved{key, old, set})
+		_ = os.Setenv(key, value)
+	}
+
+	swap("HOME", s.stagedHome)
+	if _, ok := os.LookupEnv("USERPROFILE"); ok {
+		swap("USERPROFILE", s.stagedHome)
+	}
+	if appData := os.Getenv("APPDATA"); appData != "" {
+		if mirrored, err := s.Path(appData); err == nil {
+			swap("APPDATA", mirrored)
+		}
+	}
+
+	return func() {
+		for i := len(prev) - 1; i >= 0; i-- {
+			if prev[i].set {
+				_ = os.Setenv(prev[i].key, prev[i].value)
+			} else {
+				_ = os.Unsetenv(prev[i].key)
+			}
+		}
+	}
+}
+
+// under returns path relative to root when it is root or inside it
+func under(root, path string) (string, bool) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// copyPath copies src to dst, following symlinks. seen guards against
+// symlinked directory loops.
+func copyPath(src, dst string, info fs.FileInfo, seen map[string]bool) error {
+	if !info.IsDir() {
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return copyFile(src, dst, info.Mode().Perm())
+	}
+
+	resolved, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return err
+	}
+	if seen[resolved] {
+		return nil
+	}
+	seen[resolved] = true
+
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		child := filepath.Join(src, e.Name())
+		childInfo, err := os.Stat(child)
+		if err != nil {
+			// Dangling symlink
+			continue
+		}
+		if err := copyPath(child, filepath.Join(dst, e.Name()), childInfo, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string, perm fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
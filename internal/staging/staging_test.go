@@ -0,0 +1,128 @@
+package staging
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSandboxStageAndDiff(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	repo := filepath.Join(home, "src", "repo")
+	writeFile(t, filepath.Join(home, ".tool", "config.json"), `{"mcpServers": {"old": {"command": "a"}, "keep": {"command": "b"}}}`)
+	writeFile(t, filepath.Join(home, ".tool", "skills", "gone", "SKILL.md"), "gone")
+	writeFile(t, filepath.Join(repo, ".tool", "rules.md"), "rules")
+
+	s, err := New(home, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	for _, p := range []string{
+		filepath.Join(home, ".tool"),
+		filepath.Join(home, ".tool", "config.json"),
+		filepath.Join(repo, ".tool"),
+		filepath.Join(home, "missing"),
+	} {
+		if err := s.Stage(p); err != nil {
+			t.Fatalf("Stage(%s): %v", p, err)
+		}
+	}
+	if _, err := s.Path("/elsewhere/file"); err == nil {
+		t.Error("expected a path outside home and repo to be rejected")
+	}
+
+	before, err := s.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restore := s.Enter()
+	stagedHome, _ := os.UserHomeDir()
+	writeFile(t, filepath.Join(stagedHome, ".tool", "config.json"), `{"mcpServers": {"keep": {"command": "c"}, "new": {"command": "d"}}}`)
+	if err := os.RemoveAll(filepath.Join(stagedHome, ".tool", "skills", "gone")); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(s.RepoRoot(), ".tool", "added.md"), "added")
+	restore()
+
+	if got := os.Getenv("HOME"); got != home {
+		t.Errorf("HOME not restored: %s", got)
+	}
+
+	after, err := s.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []FileChange{
+		{Path: filepath.Join(home, ".tool", "config.json"), Change: Modified, Entries: []EntryChange{
+			{Key: "mcpServers.keep.command", Change: Changed},
+			{Key: "mcpServers.new", Change: Added},
+			{Key: "mcpServers.old", Change: Removed},
+		}},
+		{Path: filepath.Join(home, ".tool", "skills", "gone", "SKILL.md"), Change: Deleted},
+		{Path: filepath.Join(repo, ".tool", "added.md"), Change: Created},
+	}
+	if got := s.Diff(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff:\n%+v\nwant:\n%+v", got, want)
+	}
+
+	// The real tree is untouched
+	data, err := os.ReadFile(filepath.Join(home, ".tool", "config.json"))
+	if err != nil || !strings.Contains(string(data), `"old"`) {
+		t.Errorf("real config changed: %s", data)
+	}
+}
+
+func TestDiffEntriesFormats(t *testing.T) {
+	tests := []struct {
+		path   string
+		before string
+		after  string
+		want   []EntryChange
+	}{
+		{
+			path:  "config.toml",
+			after: "[mcp_servers.a]\ncommand = \"x\"\n",
+			want:  []EntryChange{{Key: "mcp_servers", Change: Added}},
+		},
+		{
+			path:   "config.yaml",
+			before: "mcpServers:\n  a:\n    command: x\n",
+			after:  "mcpServers:\n  a:\n    command: x\n  b:\n    command: y\n",
+			want:   []EntryChange{{Key: "mcpServers.b", Change: Added}},
+		},
+		{
+			path:   "settings.jsonc",
+			before: "{\n  // comment\n  \"hooks\": {\"Stop\": [1]},\n}\n",
+			after:  "{\"hooks\": {\"Stop\": [1, 2]}}",
+			want:   []EntryChange{{Key: "hooks.Stop", Change: Changed}},
+		},
+		{
+			path:  "SKILL.md",
+			after: "x",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := diffEntries(tt.path, []byte(tt.before), []byte(tt.after))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffEntries = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,267 @@
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sleuth-io/sx/v2/internal/config"
+	"github.com/sleuth-io/sx/v2/internal/logger"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/otlp"
+)
+
+// Event names on exported log records
+const (
+	otlpUsageEventName = "sx.asset.used"
+	otlpAuditEventName = "sx.audit"
+)
+
+// otlpSpoolDir buffers OTLP payloads the collector couldn't take. It sits
+// inside the usage queue so both kinds of offline data live together.
+func otlpSpoolDir() string {
+	return filepath.Join(GetQueuePath(), "otlp")
+}
+
+// newOTLPExporter returns nil when no OTLP endpoint is configured
+func newOTLPExporter() (*otlp.Exporter, error) {
+	cfg, err := config.LoadOTLP()
+	if err != nil || cfg == nil {
+		return nil, err
+	}
+	return otlp.NewExporter(*cfg, otlpSpoolDir()), nil
+}
+
+// exportUsageOTLP sends flushed usage events as log records plus counters.
+// Failures are logged, never returned: the events are already recorded in
+// the vault, and undelivered payloads are buffered for the next flush.
+func exportUsageOTLP(ctx context.Context, exp *otlp.Exporter, events []UsageEvent) {
+	log := logger.Get()
+	now := time.Now()
+	if err := exp.ExportLogs(ctx, usageLogRecords(events)); err != nil {
+		log.Warn("failed to export usage logs", "error", err)
+	}
+	if err := exp.ExportSums(ctx, usageSums(events, now)); err != nil {
+		log.Warn("failed to export usage metrics", "error", err)
+	}
+}
+
+func usageLogRecords(events []UsageEvent) []otlp.LogRecord {
+	records := make([]otlp.LogRecord, 0, len(events))
+	for _, ev := range events {
+		attrs := []otlp.Attribute{
+			otlp.String("event.name", otlpUsageEventName),
+			otlp.String("sx.asset.name", ev.AssetName),
+			otlp.String("sx.asset.version", ev.AssetVersion),
+			otlp.String("sx.asset.type", ev.AssetType),
+		}
+		attrs = appendNonEmpty(attrs, "sx.client", ev.Client)
+		attrs = appendNonEmpty(attrs, "sx.trigger", ev.Trigger)
+		attrs = appendNonEmpty(attrs, "sx.repo_url", ev.RepoURL)
+		attrs = appendNonEmpty(attrs, "session.id", ev.SessionID)
+		if ev.ContextTokens > 0 {
+			attrs = append(attrs, otlp.Int("sx.context_tokens", ev.ContextTokens))
+		}
+		records = append(records, otlp.LogRecord{
+			Time:       parseUsageTimestamp(ev.Timestamp),
+			Body:       ev.AssetType + " " + ev.AssetName + " used",
+			Attributes: attrs,
+		})
+	}
+	return records
+}
+
+// usageSums counts uses and context tokens per asset, client and trigger.
+// Each point covers the span from its earliest event to now.
+func usageSums(events []UsageEvent, now time.Time) []otlp.Sum {
+	type key struct{ name, typ, client, trigger string }
+	type group struct {
+		start        time.Time
+		uses, tokens int64
+	}
+	groups := make(map[key]*group)
+	var keys []key
+	for _, ev := range events {
+		k := key{ev.AssetName, ev.AssetType, ev.Client, ev.Trigger}
+		g := groups[k]
+		if g == nil {
+			g = &group{start: now}
+			groups[k] = g
+			keys = append(keys, k)
+		}
+		if ts := parseUsageTimestamp(ev.Timestamp); !ts.IsZero() && ts.Before(g.start) {
+			g.start = ts
+		}
+		g.uses++
+		g.tokens += int64(ev.ContextTokens)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		if a.typ != b.typ {
+			return a.typ < b.typ
+		}
+		if a.client != b.client {
+			return a.client < b.client
+		}
+		return a.trigger < b.trigger
+	})
+
+	var sums []otlp.Sum
+	for _, k := range keys {
+		g := groups[k]
+		attrs := []otlp.Attribute{otlp.String("sx.asset.name", k.name), otlp.String("sx.asset.type", k.typ)}
+		attrs = appendNonEmpty(attrs, "sx.client", k.client)
+		attrs = appendNonEmpty(attrs, "sx.trigger", k.trigger)
+		sums = append(sums, otlp.Sum{
+			Name:        "sx.asset.uses",
+			Description: "Asset uses reported by AI clients",
+			Unit:        "{use}",
+			Start:       g.start,
+			Time:        now,
+			Value:       g.uses,
+			Attributes:  attrs,
+		})
+		if g.tokens > 0 {
+			sums = append(sums, otlp.Sum{
+				Name:        "sx.asset.context_tokens",
+				Description: "Estimated tokens assets added to model context",
+				Unit:        "{token}",
+				Start:       g.start,
+				Time:        now,
+				Value:       g.tokens,
+				Attributes:  attrs,
+			})
+		}
+	}
+	return sums
+}
+
+// auditDeliveries tracks the background sends RegisterAuditExporter
+// starts, so the process can give them a moment before it exits.
+// auditFlushMu keeps two sends from replaying the same spooled payload.
+var (
+	auditDeliveries sync.WaitGroup
+	auditFlushMu    sync.Mutex
+)
+
+// auditDeliveryTimeout bounds one background send of buffered payloads
+const auditDeliveryTimeout = 10 * time.Second
+
+// RegisterAuditExporter mirrors every audit event that lands in a vault
+// to the configured OTLP endpoint as a log record. Events are buffered to
+// disk and sent in the background, so a slow collector never holds up a
+// vault write; whatever hasn't been delivered when the process exits is
+// replayed by the next flush. It does nothing when no endpoint is
+// configured.
+func RegisterAuditExporter() error {
+	exp, err := newOTLPExporter()
+	if err != nil || exp == nil {
+		return err
+	}
+	mgmt.SetAuditExporter(func(events []mgmt.AuditEvent) {
+		if err := exp.SpoolLogs(auditLogRecords(events)); err != nil {
+			logger.Get().Warn("failed to buffer audit events for export", "error", err)
+			return
+		}
+		auditDeliveries.Add(1)
+		go func() {
+			defer auditDeliveries.Done()
+			auditFlushMu.Lock()
+			defer auditFlushMu.Unlock()
+			ctx, cancel := context.WithTimeout(context.Background(), auditDeliveryTimeout)
+			defer cancel()
+			if err := exp.Flush(ctx); err != nil {
+				logger.Get().Warn("failed to export audit events", "error", err)
+			}
+		}()
+	})
+	return nil
+}
+
+// WaitAuditExports waits up to timeout for background audit exports to
+// finish. Undelivered events stay buffered for the next flush.
+func WaitAuditExports(timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		auditDeliveries.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+	}
+}
+
+func auditLogRecords(events []mgmt.AuditEvent) []otlp.LogRecord {
+	records := make([]otlp.LogRecord, 0, len(events))
+	for _, ev := range events {
+		attrs := []otlp.Attribute{
+			otlp.String("event.name", otlpAuditEventName),
+			otlp.String("sx.audit.event", ev.Event),
+			otlp.String("sx.audit.target_type", ev.TargetType),
+			otlp.String("sx.audit.target", ev.Target),
+		}
+		attrs = appendNonEmpty(attrs, "enduser.id", ev.Actor)
+		attrs = appendNonEmpty(attrs, "sx.profile", ev.Profile)
+		attrs = appendNonEmpty(attrs, "sx.audit.hash", ev.Hash)
+		keys := make([]string, 0, len(ev.Data))
+		for k := range ev.Data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			attrs = append(attrs, auditDataAttribute("sx.audit.data."+k, ev.Data[k]))
+		}
+		records = append(records, otlp.LogRecord{
+			Time:       ev.Timestamp,
+			Body:       ev.Event,
+			Attributes: attrs,
+		})
+	}
+	return records
+}
+
+// auditDataAttribute keeps scalars as they are and JSON-encodes the rest,
+// since attribute values are flat
+func auditDataAttribute(key string, v any) otlp.Attribute {
+	switch val := v.(type) {
+	case string, bool, int, int64, float64:
+		return otlp.Attribute{Key: key, Value: v}
+	case json.Number:
+		// Written audit events keep their numbers as json.Number
+		if n, err := val.Int64(); err == nil {
+			return otlp.Attribute{Key: key, Value: n}
+		}
+		if f, err := val.Float64(); err == nil {
+			return otlp.Attribute{Key: key, Value: f}
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return otlp.Attribute{Key: key, Value: v}
+	}
+	return otlp.String(key, string(data))
+}
+
+func appendNonEmpty(attrs []otlp.Attribute, key, value string) []otlp.Attribute {
+	if value == "" {
+		return attrs
+	}
+	return append(attrs, otlp.String(key, value))
+}
+
+// parseUsageTimestamp parses an event's RFC 3339 timestamp, returning the
+// zero time when it's missing or malformed
+func parseUsageTimestamp(s string) time.Time {
+	ts, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return ts
+}
@@ -0,0 +1,189 @@
+package stats
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sleuth-io/sx/v2/internal/config"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/otlp"
+)
+
+// auditCollector records the audit hashes an OTLP endpoint receives. While
+// down it refuses every request; while gate is set, requests wait on it.
+type auditCollector struct {
+	mu     sync.Mutex
+	down   bool
+	gate   chan struct{}
+	hashes []string
+}
+
+func (c *auditCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	gate := c.gate
+	c.mu.Unlock()
+	if gate != nil {
+		<-gate
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.down || r.URL.Path != otlp.LogsPath {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	var payload struct {
+		ResourceLogs []struct {
+			ScopeLogs []struct {
+				LogRecords []struct {
+					Attributes []struct {
+						Key   string `json:"key"`
+						Value struct {
+							StringValue string `json:"stringValue"`
+						} `json:"value"`
+					} `json:"attributes"`
+				} `json:"logRecords"`
+			} `json:"scopeLogs"`
+		} `json:"resourceLogs"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	for _, rl := range payload.ResourceLogs {
+		for _, sl := range rl.ScopeLogs {
+			for _, rec := range sl.LogRecords {
+				for _, attr := range rec.Attributes {
+					if attr.Key == "sx.audit.hash" {
+						c.hashes = append(c.hashes, attr.Value.StringValue)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (c *auditCollector) received() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.hashes...)
+}
+
+// registerTestAuditExporter points the OTLP config at col and registers
+// the audit exporter, returning a vault root to append events under.
+func registerTestAuditExporter(t *testing.T, col *auditCollector) string {
+	t.Helper()
+	t.Setenv("SX_CONFIG_DIR", t.TempDir())
+	t.Setenv("SX_CACHE_DIR", t.TempDir())
+	srv := httptest.NewServer(col)
+	t.Cleanup(srv.Close)
+
+	mpc := &config.MultiProfileConfig{
+		DefaultProfile: "default",
+		Profiles: map[string]*config.Profile{
+			"default": {Type: config.RepositoryTypePath, RepositoryURL: "file://" + t.TempDir()},
+		},
+		OTLP: &config.OTLPConfig{Endpoint: srv.URL},
+	}
+	if err := config.SaveMultiProfile(mpc); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterAuditExporter(); err != nil {
+		t.Fatalf("RegisterAuditExporter: %v", err)
+	}
+	t.Cleanup(func() {
+		WaitAuditExports(5 * time.Second)
+		mgmt.SetAuditExporter(nil)
+	})
+	return t.TempDir()
+}
+
+func appendTestAuditEvent(t *testing.T, vaultRoot, target string) {
+	t.Helper()
+	if err := mgmt.AppendAuditEvent(vaultRoot, mgmt.AuditEvent{Actor: "alice@example.com", Event: mgmt.EventTeamCreated, Target: target}); err != nil {
+		t.Fatalf("AppendAuditEvent: %v", err)
+	}
+}
+
+func TestAuditExport_FailedVaultWriteExportsNothing(t *testing.T) {
+	col := &auditCollector{}
+	root := registerTestAuditExporter(t, col)
+
+	release := mgmt.HoldAuditExports(root)
+	appendTestAuditEvent(t, root, "discarded")
+	release(false)
+	WaitAuditExports(5 * time.Second)
+
+	if got := col.received(); len(got) != 0 {
+		t.Errorf("exported %v from a write that never landed", got)
+	}
+}
+
+func TestAuditExport_EachHashExportedOnce(t *testing.T) {
+	col := &auditCollector{down: true}
+	root := registerTestAuditExporter(t, col)
+
+	// The first flush fails and buffers; later flushes replay it alongside
+	// their own events.
+	appendTestAuditEvent(t, root, "platform")
+	WaitAuditExports(5 * time.Second)
+	col.mu.Lock()
+	col.down = false
+	col.mu.Unlock()
+	appendTestAuditEvent(t, root, "mobile")
+	WaitAuditExports(5 * time.Second)
+	appendTestAuditEvent(t, root, "web")
+	WaitAuditExports(5 * time.Second)
+
+	events, err := mgmt.QueryAuditEvents(root, mgmt.AuditFilter{})
+	if err != nil || len(events) != 3 {
+		t.Fatalf("vault events = %+v, %v; want 3", events, err)
+	}
+	got := col.received()
+	seen := map[string]int{}
+	for _, h := range got {
+		seen[h]++
+	}
+	for _, ev := range events {
+		if seen[ev.Hash] != 1 {
+			t.Errorf("event %s (%s) exported %d times, want once; received %v", ev.Target, ev.Hash, seen[ev.Hash], got)
+		}
+	}
+	if len(got) != len(events) {
+		t.Errorf("received %d records, want %d", len(got), len(events))
+	}
+}
+
+func TestWaitAuditExports_WaitsForInFlightFlush(t *testing.T) {
+	gate := make(chan struct{})
+	col := &auditCollector{gate: gate}
+	root := registerTestAuditExporter(t, col)
+
+	appendTestAuditEvent(t, root, "platform")
+	waited := make(chan struct{})
+	go func() {
+		WaitAuditExports(5 * time.Second)
+		close(waited)
+	}()
+	select {
+	case <-waited:
+		t.Fatal("WaitAuditExports returned while the flush was still in flight")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(gate)
+	select {
+	case <-waited:
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitAuditExports didn't return once the flush finished")
+	}
+	if got := col.received(); len(got) != 1 {
+		t.Errorf("received %v, want the one event delivered before WaitAuditExports returned", got)
+	}
+}
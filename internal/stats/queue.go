@@ -13,15 +13,22 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/sleuth-io/sx/v2/internal/cache"
+	"github.com/sleuth-io/sx/v2/internal/logger"
 	"github.com/sleuth-io/sx/v2/internal/vault"
 )
 
-// UsageEvent represents a single asset usage event
+// UsageEvent represents a single asset usage event. The fields after
+// Timestamp are optional and mirror mgmt.UsageEvent.
 type UsageEvent struct {
-	AssetName    string `json:"asset_name"`
-	AssetVersion string `json:"asset_version"`
-	AssetType    string `json:"asset_type"`
-	Timestamp    string `json:"timestamp"`
+	AssetName     string `json:"asset_name"`
+	AssetVersion  string `json:"asset_version"`
+	AssetType     string `json:"asset_type"`
+	Timestamp     string `json:"timestamp"`
+	Client        string `json:"client,omitempty"`
+	RepoURL       string `json:"repo_url,omitempty"`
+	SessionID     string `json:"session_id,omitempty"`
+	Trigger       string `json:"trigger,omitempty"`
+	ContextTokens int    `json:"context_tokens,omitempty"`
 }
 
 // GetQueuePath returns the path to the usage queue directory
@@ -125,8 +132,20 @@ func DeleteEventFiles(filePaths []string) error {
 	return nil
 }
 
-// FlushQueue loads pending events from queue and sends them to the repository
+// FlushQueue loads pending events from queue and sends them to the repository.
+// When an OTLP endpoint is configured, the flushed events are exported to it
+// too, after buffered OTLP payloads from earlier flushes are replayed.
 func FlushQueue(ctx context.Context, repo vault.Vault) error {
+	exp, err := newOTLPExporter()
+	if err != nil {
+		logger.Get().Warn("OTLP export disabled", "error", err)
+	}
+	if exp != nil {
+		if err := exp.Flush(ctx); err != nil {
+			logger.Get().Warn("failed to replay buffered OTLP payloads", "error", err)
+		}
+	}
+
 	// Load pending events
 	events, filePaths, err := DequeueEvents(100)
 	if err != nil {
@@ -150,6 +169,12 @@ func FlushQueue(ctx context.Context, repo vault.Vault) error {
 		return fmt.Errorf("failed to delete processed queue files: %w", err)
 	}
 
+	// Export only once the vault has the events, so a failed post that
+	// leaves them queued doesn't export them twice
+	if exp != nil {
+		exportUsageOTLP(ctx, exp, events)
+	}
+
 	return nil
 }
 
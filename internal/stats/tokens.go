@@ -0,0 +1,15 @@
+package stats
+
+import "unicode/utf8"
+
+// charsPerToken is the usual rule of thumb for English prose and code
+// across current tokenizers. Usage events only need the order of
+// magnitude, so no model-specific tokenizer is pulled in.
+const charsPerToken = 4
+
+// EstimateTokens estimates how many tokens text takes up in a model's
+// context
+func EstimateTokens(text string) int {
+	n := utf8.RuneCountInString(text)
+	return (n + charsPerToken - 1) / charsPerToken
+}
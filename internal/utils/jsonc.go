@@ -1,7 +1,11 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/tailscale/hujson"
 )
@@ -20,3 +24,157 @@ func UnmarshalJSONC(data []byte, v any) error {
 	}
 	return json.Unmarshal(standardized, v)
 }
+
+// SetJSONCValue sets the member at path (object keys from the root) to
+// value, creating missing parent objects, and returns the updated
+// document. Unlike a decode/encode round trip, comments, trailing commas
+// and the formatting of everything outside the replaced value are kept,
+// so it is safe for settings files users edit by hand. Empty data is
+// treated as an empty object.
+func SetJSONCValue(data []byte, path []string, value any) ([]byte, error) {
+	if len(path) == 0 {
+		return nil, errors.New("empty JSONC path")
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		data = []byte("{}\n")
+	}
+	root, err := hujson.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	indent := detectJSONIndent(data)
+
+	obj, ok := root.Value.(*hujson.Object)
+	if !ok {
+		return nil, errors.New("JSONC document is not an object")
+	}
+	for depth, key := range path {
+		i := jsoncMemberIndex(obj, key)
+		if i < 0 {
+			// Build the rest of the path as nested objects in one go
+			nested := value
+			for j := len(path) - 1; j > depth; j-- {
+				nested = map[string]any{path[j]: nested}
+			}
+			if err := appendJSONCMember(obj, key, nested, depth, indent); err != nil {
+				return nil, err
+			}
+			return root.Pack(), nil
+		}
+
+		if depth == len(path)-1 {
+			v, err := marshalJSONCValue(value, depth, indent)
+			if err != nil {
+				return nil, err
+			}
+			obj.Members[i].Value.Value = v
+			return root.Pack(), nil
+		}
+
+		next, ok := obj.Members[i].Value.Value.(*hujson.Object)
+		if !ok {
+			return nil, fmt.Errorf("JSONC member %q is not an object", strings.Join(path[:depth+1], "."))
+		}
+		obj = next
+	}
+	return root.Pack(), nil
+}
+
+// DeleteJSONCValue removes the member at path, keeping comments and
+// formatting elsewhere. A missing member (or parent) is not an error;
+// removed reports whether anything changed.
+func DeleteJSONCValue(data []byte, path []string) (updated []byte, removed bool, err error) {
+	if len(path) == 0 {
+		return nil, false, errors.New("empty JSONC path")
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return data, false, nil
+	}
+	root, err := hujson.Parse(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var pointer strings.Builder
+	for _, key := range path {
+		pointer.WriteByte('/')
+		pointer.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(key))
+	}
+	if root.Find(pointer.String()) == nil {
+		return data, false, nil
+	}
+
+	patch, err := json.Marshal([]map[string]string{{"op": "remove", "path": pointer.String()}})
+	if err != nil {
+		return nil, false, err
+	}
+	if err := root.Patch(patch); err != nil {
+		return nil, false, err
+	}
+	return root.Pack(), true, nil
+}
+
+// jsoncMemberIndex returns the index of the member named key, or -1
+func jsoncMemberIndex(obj *hujson.Object, key string) int {
+	for i, m := range obj.Members {
+		if lit, ok := m.Name.Value.(hujson.Literal); ok && lit.String() == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// appendJSONCMember adds key: value as the last member of obj, an object
+// nested depth levels below the root, on its own line. A trailing comma
+// after the previous last member is carried over to the new one.
+func appendJSONCMember(obj *hujson.Object, key string, value any, depth int, indent string) error {
+	v, err := marshalJSONCValue(value, depth, indent)
+	if err != nil {
+		return err
+	}
+
+	member := hujson.ObjectMember{
+		Name: hujson.Value{
+			BeforeExtra: hujson.Extra("\n" + strings.Repeat(indent, depth+1)),
+			Value:       hujson.String(key),
+		},
+		Value: hujson.Value{BeforeExtra: hujson.Extra(" "), Value: v},
+	}
+	if n := len(obj.Members); n > 0 && obj.Members[n-1].Value.AfterExtra != nil {
+		member.Value.AfterExtra = hujson.Extra{}
+	}
+	obj.Members = append(obj.Members, member)
+
+	// Put the closing brace back on its own line
+	if !bytes.Contains(obj.AfterExtra, []byte("\n")) {
+		obj.AfterExtra = hujson.Extra("\n" + strings.Repeat(indent, depth))
+	}
+	return nil
+}
+
+// marshalJSONCValue encodes value indented for a member of an object
+// nested depth levels below the root
+func marshalJSONCValue(value any, depth int, indent string) (hujson.ValueTrimmed, error) {
+	data, err := json.MarshalIndent(value, strings.Repeat(indent, depth+1), indent)
+	if err != nil {
+		return nil, err
+	}
+	v, err := hujson.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return v.Value, nil
+}
+
+// detectJSONIndent returns the indentation unit of the first indented
+// line, defaulting to two spaces
+func detectJSONIndent(data []byte) string {
+	for line := range bytes.SplitSeq(data, []byte("\n")) {
+		trimmed := bytes.TrimLeft(line, " \t")
+		if len(trimmed) == len(line) || len(trimmed) == 0 {
+			continue
+		}
+		return string(line[:len(line)-len(trimmed)])
+	}
+	return "  "
+}
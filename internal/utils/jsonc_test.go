@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+const zedSettings = `// Zed settings
+{
+  // Keep the theme
+  "theme": "One Dark",
+  "context_servers": {
+    // hand-written server
+    "other": {"command": "other-mcp"},
+  },
+}
+`
+
+func TestSetJSONCValue_AddsMemberKeepingComments(t *testing.T) {
+	got, err := SetJSONCValue([]byte(zedSettings), []string{"context_servers", "docs"}, map[string]any{"url": "https://docs.example.com/mcp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `// Zed settings
+{
+  // Keep the theme
+  "theme": "One Dark",
+  "context_servers": {
+    // hand-written server
+    "other": {"command": "other-mcp"},
+    "docs": {
+      "url": "https://docs.example.com/mcp"
+    },
+  },
+}
+`
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	var parsed map[string]any
+	if err := UnmarshalJSONC(got, &parsed); err != nil {
+		t.Fatalf("result is not valid JSONC: %v", err)
+	}
+}
+
+func TestSetJSONCValue_ReplacesExisting(t *testing.T) {
+	got, err := SetJSONCValue([]byte(zedSettings), []string{"context_servers", "other"}, map[string]any{"command": "new-mcp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(got), "// hand-written server\n    \"other\": {\n      \"command\": \"new-mcp\"\n    },") {
+		t.Errorf("got:\n%s", got)
+	}
+	if strings.Count(string(got), `"other"`) != 1 {
+		t.Errorf("member duplicated:\n%s", got)
+	}
+}
+
+func TestSetJSONCValue_CreatesParents(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"empty file", "", "{\n  \"context_servers\": {\n    \"docs\": true\n  }\n}\n"},
+		{"empty object", "{}", "{\n  \"context_servers\": {\n    \"docs\": true\n  }\n}"},
+		{"tab indented", "{\n\t\"theme\": \"x\"\n}", "{\n\t\"theme\": \"x\",\n\t\"context_servers\": {\n\t\t\"docs\": true\n\t}\n}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetJSONCValue([]byte(tt.in), []string{"context_servers", "docs"}, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got:\n%q\nwant:\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetJSONCValue_RejectsNonObjectParent(t *testing.T) {
+	if _, err := SetJSONCValue([]byte(`{"context_servers": []}`), []string{"context_servers", "docs"}, true); err == nil {
+		t.Error("expected an error when the parent is not an object")
+	}
+}
+
+func TestDeleteJSONCValue(t *testing.T) {
+	added, err := SetJSONCValue([]byte(zedSettings), []string{"context_servers", "docs"}, map[string]any{"url": "https://docs.example.com/mcp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, removed, err := DeleteJSONCValue(added, []string{"context_servers", "docs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !removed {
+		t.Fatal("expected the member to be removed")
+	}
+	if strings.Contains(string(got), "docs") || !strings.Contains(string(got), "// hand-written server") || !strings.Contains(string(got), "// Keep the theme") {
+		t.Errorf("got:\n%s", got)
+	}
+
+	again, removed, err := DeleteJSONCValue(got, []string{"context_servers", "docs"})
+	if err != nil || removed || string(again) != string(got) {
+		t.Errorf("deleting a missing member should be a no-op, got removed=%v err=%v", removed, err)
+	}
+}
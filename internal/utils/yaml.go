@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ParseYAMLDocument parses a YAML file for in-place editing. Editing the
+// node tree rather than decoding into Go values keeps the user's
+// comments, key order and quoting when the file is written back. The
+// document's content is always a mapping; empty data yields an empty one.
+func ParseYAMLDocument(data []byte) (*yaml.Node, error) {
+	doc := &yaml.Node{}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, doc); err != nil {
+			return nil, err
+		}
+	}
+	if doc.Kind == 0 {
+		doc.Kind = yaml.DocumentNode
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("YAML document is not a mapping")
+	}
+	return doc, nil
+}
+
+// EncodeYAMLDocument encodes a document from ParseYAMLDocument with
+// two-space indentation
+func EncodeYAMLDocument(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// YAMLMapValue returns the value of key in mapping m, or nil
+func YAMLMapValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// SetYAMLMapValue sets key in mapping m to value, replacing the existing
+// value in place or appending the key
+func SetYAMLMapValue(m *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content[i+1] = value
+			return
+		}
+	}
+	m.Content = append(m.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		value,
+	)
+}
+
+// DeleteYAMLMapKey removes key from mapping m, reporting whether it was there
+func DeleteYAMLMapKey(m *yaml.Node, key string) bool {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// YAMLNode encodes v as a node, for inserting into a parsed document
+func YAMLNode(v any) (*yaml.Node, error) {
+	n := &yaml.Node{}
+	if err := n.Encode(v); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func yamlString(v string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+}
+
+func TestYAMLDocument_EditKeepsComments(t *testing.T) {
+	src := `# Continue config
+name: Local Assistant # shown in the picker
+version: 1.0.0
+schema: v1
+models:
+  - name: gpt
+    provider: openai
+`
+	doc, err := ParseYAMLDocument([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := doc.Content[0]
+
+	servers, err := YAMLNode([]map[string]string{{"name": "docs", "url": "https://docs.example.com/mcp"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetYAMLMapValue(root, "mcpServers", servers)
+	SetYAMLMapValue(root, "version", yamlString("2.0.0"))
+	if !DeleteYAMLMapKey(root, "models") || DeleteYAMLMapKey(root, "models") {
+		t.Error("DeleteYAMLMapKey should remove models exactly once")
+	}
+
+	out, err := EncodeYAMLDocument(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# Continue config
+name: Local Assistant # shown in the picker
+version: 2.0.0
+schema: v1
+mcpServers:
+  - name: docs
+    url: https://docs.example.com/mcp
+`
+	if string(out) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+	if YAMLMapValue(root, "schema").Value != "v1" || YAMLMapValue(root, "missing") != nil {
+		t.Error("YAMLMapValue lookup mismatch")
+	}
+}
+
+func TestParseYAMLDocument_Empty(t *testing.T) {
+	doc, err := ParseYAMLDocument(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetYAMLMapValue(doc.Content[0], "read", yamlString("2.0.0"))
+	out, err := EncodeYAMLDocument(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(string(out)) != "read: 2.0.0" {
+		t.Errorf("got %q", out)
+	}
+}
+
+func TestParseYAMLDocument_RejectsNonMapping(t *testing.T) {
+	if _, err := ParseYAMLDocument([]byte("- a\n- b\n")); err == nil {
+		t.Error("expected an error for a top-level list")
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"archive/zip"
 	"bytes"
 	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"path/filepath"
@@ -342,6 +345,33 @@ func copyZipFile(writer *zip.Writer, file *zip.File) error {
 // ComputeZipHash computes an MD5 hash of all files in a zip archive
 // Files are hashed individually, then combined in alphabetical order by filename
 func ComputeZipHash(zipData []byte) ([]byte, error) {
+	return zipContentHash(zipData, md5.New)
+}
+
+// ComputeZipContentSHA256 is ComputeZipHash with SHA-256, hex-encoded. It
+// depends only on file names and contents — not on timestamps or
+// compression — so a directory zipped twice hashes the same.
+func ComputeZipContentSHA256(zipData []byte) (string, error) {
+	sum, err := zipContentHash(zipData, sha256.New)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sum), nil
+}
+
+// ComputeAssetContentHash is ComputeZipContentSHA256 without
+// metadata.toml: it changes when an asset's files do, not when the same
+// files are republished under a new version. Quality and benchmark
+// records carry it as skill_hash.
+func ComputeAssetContentHash(zipData []byte) (string, error) {
+	stripped, err := RemoveFileFromZip(zipData, "metadata.toml")
+	if err != nil {
+		return "", err
+	}
+	return ComputeZipContentSHA256(stripped)
+}
+
+func zipContentHash(zipData []byte, newHash func() hash.Hash) ([]byte, error) {
 	if !IsZipFile(zipData) {
 		return nil, errors.New("invalid zip file: missing magic bytes")
 	}
@@ -365,7 +395,7 @@ func ComputeZipHash(zipData []byte) ([]byte, error) {
 			return nil, fmt.Errorf("failed to open file %s in zip: %w", file.Name, err)
 		}
 
-		h := md5.New()
+		h := newHash()
 		if _, err := io.Copy(h, rc); err != nil {
 			rc.Close()
 			return nil, fmt.Errorf("failed to hash file %s: %w", file.Name, err)
@@ -383,7 +413,7 @@ func ComputeZipHash(zipData []byte) ([]byte, error) {
 	sort.Strings(filenames)
 
 	// Combine all hashes in sorted order
-	combined := md5.New()
+	combined := newHash()
 	for _, name := range filenames {
 		// Include filename in hash to detect renames
 		combined.Write([]byte(name))
@@ -276,3 +276,19 @@ func TestRenameFileInZip(t *testing.T) {
 		}
 	})
 }
+
+func TestComputeAssetContentHash_IgnoresMetadata(t *testing.T) {
+	v1 := createTestZip(t, map[string]string{"SKILL.md": "hi", "metadata.toml": "[asset]\nversion = \"1\"\n"})
+	v2 := createTestZip(t, map[string]string{"SKILL.md": "hi", "metadata.toml": "[asset]\nversion = \"2\"\n"})
+	edited := createTestZip(t, map[string]string{"SKILL.md": "hello", "metadata.toml": "[asset]\nversion = \"2\"\n"})
+
+	h1, _ := ComputeAssetContentHash(v1)
+	h2, _ := ComputeAssetContentHash(v2)
+	h3, _ := ComputeAssetContentHash(edited)
+	if h1 == "" || h1 != h2 {
+		t.Errorf("republished content hashes differ: %q vs %q", h1, h2)
+	}
+	if h2 == h3 {
+		t.Error("edited content hashes the same")
+	}
+}
@@ -0,0 +1,119 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// VersionLocker is implemented by vaults that can describe any published
+// version of an asset as a lock file entry: its source with content hashes,
+// type, clients and dependencies. Dependency range resolution uses it to
+// move a lock row to another version without losing integrity checks.
+type VersionLocker interface {
+	LockAssetVersion(ctx context.Context, name, version string) (*lockfile.Asset, error)
+}
+
+// lockArchivedVersion describes a version stored in a file-backed vault's
+// archive, pinned to the content hash of its files
+func lockArchivedVersion(vaultRoot, name, ver string) (*lockfile.Asset, error) {
+	l, err := detectLayout(vaultRoot)
+	if err != nil {
+		return nil, err
+	}
+	a, ok := archivedVersionSource(vaultRoot, l)(name, ver)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s@%s", ErrAssetNotFound, name, ver)
+	}
+	return a, nil
+}
+
+// lockAssetFromMetadata fills a lock entry's type, clients and
+// dependencies from a version's metadata
+func lockAssetFromMetadata(meta *metadata.Metadata, name, ver string) (*lockfile.Asset, error) {
+	if meta.Asset.Type.Key == "" {
+		return nil, fmt.Errorf("metadata for %q@%s has no asset type", name, ver)
+	}
+	deps := make([]lockfile.Dependency, 0, len(meta.Asset.Dependencies))
+	for _, dep := range meta.Asset.Dependencies {
+		if depName, constraint, err := metadata.ParseDependency(dep); err == nil {
+			deps = append(deps, lockfile.Dependency{Name: depName, Version: constraint})
+		}
+	}
+	return &lockfile.Asset{
+		Name:         name,
+		Version:      ver,
+		Type:         meta.Asset.Type,
+		Clients:      append([]string(nil), meta.Asset.Clients...),
+		Dependencies: deps,
+	}, nil
+}
+
+// LockAssetVersion describes a stored version, pinned to its content hash
+func (p *PathVault) LockAssetVersion(ctx context.Context, name, version string) (*lockfile.Asset, error) {
+	return lockArchivedVersion(p.repoPath, name, version)
+}
+
+// LockAssetVersion describes a stored version, pinned to its content hash
+func (g *GitVault) LockAssetVersion(ctx context.Context, name, version string) (*lockfile.Asset, error) {
+	if err := g.cloneOrUpdate(ctx); err != nil {
+		return nil, err
+	}
+	return lockArchivedVersion(g.repoPath, name, version)
+}
+
+// LockAssetVersion describes a version stored in the mirror
+func (m *mirrorVault) LockAssetVersion(ctx context.Context, name, version string) (*lockfile.Asset, error) {
+	return mirrorRead(ctx, m, func() (*lockfile.Asset, error) { return m.local.LockAssetVersion(ctx, name, version) })
+}
+
+// LockAssetVersion describes a version from the site's index, pinned to
+// the index hash
+func (h *HTTPVault) LockAssetVersion(ctx context.Context, name, version string) (*lockfile.Asset, error) {
+	ver, err := h.indexVersion(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := h.GetMetadata(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	a, err := lockAssetFromMetadata(meta, name, version)
+	if err != nil {
+		return nil, err
+	}
+	a.SourceHTTP = &lockfile.SourceHTTP{URL: ver.URL, Hashes: map[string]string{"sha256": ver.SHA256}, Size: ver.Size}
+	return a, nil
+}
+
+// LockAssetVersion downloads a version and pins the lock entry to the
+// bytes it got. The server doesn't publish per-version hashes, so this
+// guards the install against the download changing after resolution
+// rather than against the server itself.
+func (s *SleuthVault) LockAssetVersion(ctx context.Context, name, version string) (*lockfile.Asset, error) {
+	data, err := s.GetAssetByVersion(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := utils.ReadZipFile(data, "metadata.toml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata.toml from %s@%s: %w", name, version, err)
+	}
+	meta, err := metadata.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	a, err := lockAssetFromMetadata(meta, name, version)
+	if err != nil {
+		return nil, err
+	}
+	a.SourceHTTP = &lockfile.SourceHTTP{
+		URL:    s.assetVersionURL(name, version),
+		Hashes: map[string]string{"sha256": utils.ComputeSHA256(data)},
+		Size:   int64(len(data)),
+	}
+	return a, nil
+}
@@ -0,0 +1,36 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/manifest"
+)
+
+// TestLockAssetVersion_PinsArchivedVersion: an older stored version is
+// described with its own source path and content hash.
+func TestLockAssetVersion_PinsArchivedVersion(t *testing.T) {
+	v := seedRBACVault(t, "alice@example.com", []manifest.Team{platformTeam()}, nil)
+	publishSkillVersion(t, v, "2.0.0")
+	publishSkillVersion(t, v, "3.0.0")
+
+	a, err := v.LockAssetVersion(context.Background(), "my-skill", "2.0.0")
+	if err != nil {
+		t.Fatalf("LockAssetVersion: %v", err)
+	}
+	want, err := v.StorageSourcePath("my-skill", "2.0.0")
+	if err != nil {
+		t.Fatalf("StorageSourcePath: %v", err)
+	}
+	if a.Version != "2.0.0" || a.SourcePath == nil || a.SourcePath.Path != want {
+		t.Fatalf("got %s from %+v, want 2.0.0 from %s", a.Version, a.SourcePath, want)
+	}
+	if a.SourcePath.Hashes["sha256"] == "" {
+		t.Error("archived version must be pinned to a content hash")
+	}
+
+	if _, err := v.LockAssetVersion(context.Background(), "my-skill", "9.9.9"); !errors.Is(err, ErrAssetNotFound) {
+		t.Errorf("unknown version: err = %v, want ErrAssetNotFound", err)
+	}
+}
@@ -0,0 +1,57 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/signing"
+)
+
+// AuditVerifier is implemented by vaults that keep the hash-chained
+// .sx/audit log (docs/audit.md). skills.new keeps its audit log
+// server-side.
+type AuditVerifier interface {
+	// VerifyAuditLog checks the audit log's hash chain, and its event
+	// signatures against the manifest's [signing] trusted keys.
+	VerifyAuditLog(ctx context.Context) (*mgmt.AuditVerification, error)
+}
+
+func commonVerifyAuditLog(vaultRoot string) (*mgmt.AuditVerification, error) {
+	cfg, err := commonSigningConfig(vaultRoot)
+	if err != nil {
+		return nil, err
+	}
+	var trusted []*signing.PublicKey
+	if cfg != nil {
+		for _, tk := range cfg.TrustedKeys {
+			k, err := signing.ParsePublicKey(tk.PublicKey)
+			if err != nil {
+				return nil, fmt.Errorf("trusted key %q: %w", tk.Name, err)
+			}
+			trusted = append(trusted, k)
+		}
+	}
+	return mgmt.VerifyAuditLog(vaultRoot, trusted)
+}
+
+func (p *PathVault) VerifyAuditLog(ctx context.Context) (*mgmt.AuditVerification, error) {
+	var out *mgmt.AuditVerification
+	err := p.withReadLock(ctx, func() error {
+		v, err := commonVerifyAuditLog(p.repoPath)
+		out = v
+		return err
+	})
+	return out, err
+}
+
+func (g *GitVault) VerifyAuditLog(ctx context.Context) (*mgmt.AuditVerification, error) {
+	if err := g.cloneOrUpdate(ctx); err != nil {
+		return nil, err
+	}
+	return commonVerifyAuditLog(g.repoPath)
+}
+
+func (m *mirrorVault) VerifyAuditLog(ctx context.Context) (*mgmt.AuditVerification, error) {
+	return mirrorRead(ctx, m, func() (*mgmt.AuditVerification, error) { return m.local.VerifyAuditLog(ctx) })
+}
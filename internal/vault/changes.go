@@ -0,0 +1,645 @@
+package vault
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/manifest"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/signing"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// ---- Change requests (docs/change-requests.md) ----
+// A change request is a proposed asset version that does not publish until
+// someone with review rights approves it and it is merged. File-backed
+// vaults keep each pending change as a pair of files under .sx/changes/:
+// <id>.json (the request and its reviews) and <id>.zip (the proposed
+// asset). Merging applies the zip through the same AddAsset +
+// InheritInstallations path `sx add` uses, so a merged change is
+// indistinguishable from a direct publish.
+
+// ChangesDirName is the directory under the vault root that holds pending
+// and closed change requests.
+const ChangesDirName = ".sx/changes"
+
+// ChangeStatus is the lifecycle state of a change request.
+type ChangeStatus string
+
+const (
+	ChangeStatusPending  ChangeStatus = "pending"
+	ChangeStatusApproved ChangeStatus = "approved"
+	ChangeStatusRejected ChangeStatus = "rejected"
+	ChangeStatusMerged   ChangeStatus = "merged"
+)
+
+// Review decisions recorded on a change request.
+const (
+	ChangeDecisionApprove = "approve"
+	ChangeDecisionReject  = "reject"
+)
+
+// ErrChangeNotFound is returned when a change request ID does not exist.
+var ErrChangeNotFound = errors.New("change request not found")
+
+// ChangeReview is one approve/reject decision on a change request.
+type ChangeReview struct {
+	Timestamp time.Time `json:"ts"`
+	Actor     string    `json:"actor"`
+	Decision  string    `json:"decision"`
+	Comment   string    `json:"comment,omitempty"`
+}
+
+// ChangeRequest is the on-disk record in .sx/changes/<id>.json.
+type ChangeRequest struct {
+	ID           string                `json:"id"`
+	AssetName    string                `json:"asset_name"`
+	Version      string                `json:"version"`
+	Type         asset.Type            `json:"type"`
+	Clients      []string              `json:"clients,omitempty"`
+	Dependencies []lockfile.Dependency `json:"dependencies,omitempty"`
+	Message      string                `json:"message,omitempty"`
+	Proposer     string                `json:"proposer"`
+	Status       ChangeStatus          `json:"status"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+	Reviews      []ChangeReview        `json:"reviews,omitempty"`
+	MergedBy     string                `json:"merged_by,omitempty"`
+}
+
+// lockAsset returns the lock entry a merge publishes. The caller fills in
+// the source path from the vault's storage layout once the version is
+// stored.
+func (c *ChangeRequest) lockAsset() *lockfile.Asset {
+	return &lockfile.Asset{
+		Name:         c.AssetName,
+		Version:      c.Version,
+		Type:         c.Type,
+		Clients:      append([]string(nil), c.Clients...),
+		Dependencies: append([]lockfile.Dependency(nil), c.Dependencies...),
+	}
+}
+
+// ChangeRequestStore is implemented by vaults that support the gated
+// propose → review → merge flow. Only the file-backed vaults do; skills.new
+// has its own server-side pull requests (see assetPRProposer in commands).
+type ChangeRequestStore interface {
+	// ProposeChange stores a proposed asset version without publishing it.
+	// The zip must already carry its final metadata.toml.
+	ProposeChange(ctx context.Context, asset *lockfile.Asset, zipData []byte, message string) (*ChangeRequest, error)
+
+	// ListChanges returns change requests, newest first. An empty status
+	// returns every request.
+	ListChanges(ctx context.Context, status ChangeStatus) ([]ChangeRequest, error)
+
+	// GetChange returns one change request and its proposed zip.
+	GetChange(ctx context.Context, id string) (*ChangeRequest, []byte, error)
+
+	// ApproveChange records an approval. Only an eligible reviewer (see
+	// ChangeReviewers) other than the proposer may approve.
+	ApproveChange(ctx context.Context, id, comment string) (*ChangeRequest, error)
+
+	// RejectChange closes a pending or approved change without merging.
+	RejectChange(ctx context.Context, id, comment string) (*ChangeRequest, error)
+
+	// MergeChange publishes an approved change through the vault's normal
+	// write path and marks it merged. With a key, the version is signed by
+	// the merger in the same write (see SigningStore.AddSignedAsset).
+	MergeChange(ctx context.Context, id string, key *signing.PrivateKey) (*ChangeRequest, error)
+}
+
+// changeIDPattern keeps change IDs safe to use as file names.
+var changeIDPattern = regexp.MustCompile(`^[a-f0-9]{8}$`)
+
+func newChangeID() (string, error) {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate change id: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+func changePaths(vaultRoot, id string) (recordPath, zipPath string, err error) {
+	if !changeIDPattern.MatchString(id) {
+		return "", "", fmt.Errorf("invalid change id %q", id)
+	}
+	dir := filepath.Join(vaultRoot, ChangesDirName)
+	return filepath.Join(dir, id+".json"), filepath.Join(dir, id+".zip"), nil
+}
+
+func readChange(vaultRoot, id string) (*ChangeRequest, error) {
+	recordPath, _, err := changePaths(vaultRoot, id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(recordPath) // #nosec G304 -- path is under the vault root with a validated id
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrChangeNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cr ChangeRequest
+	if err := json.Unmarshal(data, &cr); err != nil {
+		return nil, fmt.Errorf("malformed change request %s: %w", id, err)
+	}
+	return &cr, nil
+}
+
+func writeChange(vaultRoot string, cr *ChangeRequest) error {
+	recordPath, _, err := changePaths(vaultRoot, cr.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cr, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(recordPath), 0o755); err != nil {
+		return err
+	}
+	// Atomic so a synced folder never replicates a half-written record.
+	return utils.WriteFileAtomic(recordPath, append(data, '\n'), 0o644)
+}
+
+// ChangeReviewers returns who may approve a change to the named asset:
+// every org-admin plus the admins of each team the asset is scoped to.
+// An empty result means the vault has no one with review rights over the
+// asset (ungoverned and not team-scoped), in which case any actor other
+// than the proposer may review. Mirrors the edit gate in docs/rbac.md.
+func ChangeReviewers(m *manifest.Manifest, assetName string) []string {
+	reviewers := append([]string(nil), m.OrgAdmins()...)
+	if a := m.FindAsset(assetName); a != nil {
+		for _, s := range a.Scopes {
+			if s.Kind != manifest.ScopeKindTeam {
+				continue
+			}
+			if team, err := m.FindTeam(s.Team); err == nil {
+				reviewers = append(reviewers, team.Admins...)
+			}
+		}
+	}
+	slices.Sort(reviewers)
+	return slices.Compact(reviewers)
+}
+
+// changeReviewDenial returns a non-nil error when actor may not review cr.
+// Proposers never review their own change, even as org-admins — the point
+// of the flow is a second pair of eyes.
+func changeReviewDenial(m *manifest.Manifest, cr *ChangeRequest, actor mgmt.Actor) error {
+	email := mgmt.NormalizeEmail(actor.Email)
+	if email == mgmt.NormalizeEmail(cr.Proposer) {
+		return fmt.Errorf("permission denied: you (%s) proposed change %s and cannot review it", actor.Email, cr.ID)
+	}
+	reviewers := ChangeReviewers(m, cr.AssetName)
+	if len(reviewers) == 0 || slices.Contains(reviewers, email) {
+		return nil
+	}
+	return fmt.Errorf("permission denied: only an org-admin or an admin of a team %q is scoped to may review change %s", cr.AssetName, cr.ID)
+}
+
+// changeMergeDenial returns a non-nil error when actor may not merge cr.
+// Once approved, the proposer may land their own change; anyone with
+// review rights may land it for them.
+func changeMergeDenial(m *manifest.Manifest, cr *ChangeRequest, actor mgmt.Actor) error {
+	if cr.Status != ChangeStatusApproved {
+		return fmt.Errorf("change %s is %s — only approved changes can be merged", cr.ID, cr.Status)
+	}
+	email := mgmt.NormalizeEmail(actor.Email)
+	if email == mgmt.NormalizeEmail(cr.Proposer) {
+		return nil
+	}
+	reviewers := ChangeReviewers(m, cr.AssetName)
+	if len(reviewers) == 0 || slices.Contains(reviewers, email) {
+		return nil
+	}
+	return fmt.Errorf("permission denied: only the proposer or a reviewer of %q may merge change %s", cr.AssetName, cr.ID)
+}
+
+// changeAuditEvent builds the audit row for a change-request transition.
+func changeAuditEvent(event string, cr *ChangeRequest, data map[string]any) mgmt.AuditEvent {
+	payload := map[string]any{"asset": cr.AssetName, "version": cr.Version}
+	for k, v := range data {
+		payload[k] = v
+	}
+	return mgmt.AuditEvent{
+		Event:      event,
+		TargetType: mgmt.TargetTypeChange,
+		Target:     cr.ID,
+		Data:       payload,
+	}
+}
+
+// commonProposeChange stores a new pending change. It refuses a version
+// that is already published so a merge can never overwrite history.
+func commonProposeChange(vaultRoot string, actor mgmt.Actor, a *lockfile.Asset, zipData []byte, message string) (*ChangeRequest, error) {
+	if err := actor.RequireRealIdentity(); err != nil {
+		return nil, fmt.Errorf("proposing a change requires a real git identity (set git config user.email): %w", err)
+	}
+	l, err := detectLayout(vaultRoot)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := versionListForAsset(vaultRoot, l, a.Name)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(versions, a.Version) {
+		return nil, &ErrVersionExists{Name: a.Name, Version: a.Version}
+	}
+
+	id, err := newChangeID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	cr := &ChangeRequest{
+		ID:           id,
+		AssetName:    a.Name,
+		Version:      a.Version,
+		Type:         a.Type,
+		Clients:      append([]string(nil), a.Clients...),
+		Dependencies: append([]lockfile.Dependency(nil), a.Dependencies...),
+		Message:      strings.TrimSpace(message),
+		Proposer:     mgmt.NormalizeEmail(actor.Email),
+		Status:       ChangeStatusPending,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	_, zipPath, err := changePaths(vaultRoot, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(zipPath), 0o755); err != nil {
+		return nil, err
+	}
+	if err := utils.WriteFileAtomic(zipPath, zipData, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to store proposed asset: %w", err)
+	}
+	if err := writeChange(vaultRoot, cr); err != nil {
+		return nil, err
+	}
+	event := changeAuditEvent(mgmt.EventChangeProposed, cr, nil)
+	event.Actor = actor.Email
+	if err := mgmt.AppendAuditEvent(vaultRoot, event); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+// commonListChanges reads every change record, newest first.
+func commonListChanges(vaultRoot string, status ChangeStatus) ([]ChangeRequest, error) {
+	entries, err := os.ReadDir(filepath.Join(vaultRoot, ChangesDirName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var out []ChangeRequest
+	for _, e := range entries {
+		id, ok := strings.CutSuffix(e.Name(), ".json")
+		if e.IsDir() || !ok || !changeIDPattern.MatchString(id) {
+			continue
+		}
+		cr, err := readChange(vaultRoot, id)
+		if err != nil {
+			return nil, err
+		}
+		if status != "" && cr.Status != status {
+			continue
+		}
+		out = append(out, *cr)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].CreatedAt.After(out[j].CreatedAt)
+	})
+	return out, nil
+}
+
+func commonGetChange(vaultRoot, id string) (*ChangeRequest, []byte, error) {
+	cr, err := readChange(vaultRoot, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, zipPath, err := changePaths(vaultRoot, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	zipData, err := os.ReadFile(zipPath) // #nosec G304 -- path is under the vault root with a validated id
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read proposed asset for change %s: %w", id, err)
+	}
+	return cr, zipData, nil
+}
+
+// commonReviewChange records an approve or reject decision. Permission is
+// checked against the manifest as it stands now, not at proposal time, so
+// a reviewer removed from a team in the meantime loses the right.
+func commonReviewChange(vaultRoot string, actor mgmt.Actor, id, decision, comment string) (*ChangeRequest, error) {
+	if err := actor.RequireRealIdentity(); err != nil {
+		return nil, fmt.Errorf("reviewing a change requires a real git identity (set git config user.email): %w", err)
+	}
+	cr, err := readChange(vaultRoot, id)
+	if err != nil {
+		return nil, err
+	}
+	m, err := loadManifest(vaultRoot)
+	if err != nil {
+		return nil, err
+	}
+	if err := changeReviewDenial(m, cr, actor); err != nil {
+		return nil, err
+	}
+
+	var event string
+	switch decision {
+	case ChangeDecisionApprove:
+		if cr.Status != ChangeStatusPending {
+			return nil, fmt.Errorf("change %s is %s — only pending changes can be approved", cr.ID, cr.Status)
+		}
+		cr.Status = ChangeStatusApproved
+		event = mgmt.EventChangeApproved
+	case ChangeDecisionReject:
+		if cr.Status != ChangeStatusPending && cr.Status != ChangeStatusApproved {
+			return nil, fmt.Errorf("change %s is %s — only open changes can be rejected", cr.ID, cr.Status)
+		}
+		cr.Status = ChangeStatusRejected
+		event = mgmt.EventChangeRejected
+	default:
+		return nil, fmt.Errorf("unknown review decision %q", decision)
+	}
+
+	now := time.Now().UTC()
+	comment = strings.TrimSpace(comment)
+	cr.Reviews = append(cr.Reviews, ChangeReview{
+		Timestamp: now,
+		Actor:     mgmt.NormalizeEmail(actor.Email),
+		Decision:  decision,
+		Comment:   comment,
+	})
+	cr.UpdatedAt = now
+	if err := writeChange(vaultRoot, cr); err != nil {
+		return nil, err
+	}
+	var data map[string]any
+	if comment != "" {
+		data = map[string]any{"comment": comment}
+	}
+	ev := changeAuditEvent(event, cr, data)
+	ev.Actor = actor.Email
+	if err := mgmt.AppendAuditEvent(vaultRoot, ev); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+// commonPrepareMerge validates that actor may merge the change and returns
+// it with its zip. Read-only, so it fails fast without the write lock;
+// commonMergeChange checks again under it before publishing.
+func commonPrepareMerge(vaultRoot string, actor mgmt.Actor, id string) (*ChangeRequest, []byte, error) {
+	if err := actor.RequireRealIdentity(); err != nil {
+		return nil, nil, fmt.Errorf("merging a change requires a real git identity (set git config user.email): %w", err)
+	}
+	cr, zipData, err := commonGetChange(vaultRoot, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	m, err := loadManifest(vaultRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := changeMergeDenial(m, cr, actor); err != nil {
+		return nil, nil, err
+	}
+	return cr, zipData, nil
+}
+
+// commonMergeChange publishes a prepared change and marks it merged. The
+// caller holds the vault's write lock. The change is re-read first: if it
+// was rejected, merged, or otherwise updated since prepared was read, the
+// merge is refused before anything is published. The zip stored with the
+// change is the one published, and the one signed when key is set.
+func commonMergeChange(vaultRoot string, actor mgmt.Actor, prepared *ChangeRequest, key *signing.PrivateKey) (*ChangeRequest, error) {
+	cr, zipData, err := commonGetChange(vaultRoot, prepared.ID)
+	if err != nil {
+		return nil, err
+	}
+	if cr.Status == ChangeStatusMerged {
+		return nil, fmt.Errorf("change %s was already merged by %s", cr.ID, cr.MergedBy)
+	}
+	if !cr.UpdatedAt.Equal(prepared.UpdatedAt) {
+		return nil, fmt.Errorf("change %s was updated while merging (now %s); check it and merge again", cr.ID, cr.Status)
+	}
+	m, err := loadManifest(vaultRoot)
+	if err != nil {
+		return nil, err
+	}
+	if err := changeMergeDenial(m, cr, actor); err != nil {
+		return nil, err
+	}
+
+	l, err := detectLayout(vaultRoot)
+	if err != nil {
+		return nil, err
+	}
+	lockAsset := cr.lockAsset()
+	var sig *signing.Signature
+	var digest string
+	if key != nil {
+		if digest, err = utils.ComputeZipContentSHA256(zipData); err != nil {
+			return nil, err
+		}
+		if sig, err = writeAssetSignature(vaultRoot, l, actor, lockAsset.Name, lockAsset.Version, digest, key); err != nil {
+			return nil, err
+		}
+	}
+	if err := storeAssetVersion(vaultRoot, l, lockAsset.Name, lockAsset.Version, zipData); err != nil {
+		if sig != nil {
+			_ = os.Remove(filepath.Join(vaultRoot, l.SignaturePath(lockAsset.Name, lockAsset.Version)))
+		}
+		return nil, fmt.Errorf("failed to publish change %s: %w", cr.ID, err)
+	}
+	lockAsset.SourcePath = &lockfile.SourcePath{Path: l.SourcePathRel(lockAsset.Name, lockAsset.Version)}
+	if err := upsertAssetInheritingScopes(vaultRoot, lockAsset); err != nil {
+		return nil, fmt.Errorf("failed to publish change %s: %w", cr.ID, err)
+	}
+
+	cr.Status = ChangeStatusMerged
+	cr.MergedBy = mgmt.NormalizeEmail(actor.Email)
+	cr.UpdatedAt = time.Now().UTC()
+	if err := writeChange(vaultRoot, cr); err != nil {
+		return nil, err
+	}
+	// The version archive now holds the content
+	if _, zipPath, err := changePaths(vaultRoot, cr.ID); err == nil {
+		_ = os.Remove(zipPath)
+	}
+	ev := changeAuditEvent(mgmt.EventChangeMerged, cr, nil)
+	ev.Actor = actor.Email
+	if err := mgmt.AppendAuditEvent(vaultRoot, ev); err != nil {
+		return nil, err
+	}
+	if sig != nil {
+		if err := auditAssetSigned(vaultRoot, actor, lockAsset.Name, lockAsset.Version, digest, sig); err != nil {
+			return nil, err
+		}
+	}
+	return cr, nil
+}
+
+// ---- PathVault change requests ----
+
+func (p *PathVault) ProposeChange(ctx context.Context, a *lockfile.Asset, zipData []byte, message string) (cr *ChangeRequest, err error) {
+	err = p.withLock(ctx, func(actor mgmt.Actor) error {
+		cr, err = commonProposeChange(p.repoPath, actor, a, zipData, message)
+		return err
+	})
+	return cr, err
+}
+
+func (p *PathVault) ListChanges(ctx context.Context, status ChangeStatus) (out []ChangeRequest, err error) {
+	err = p.withReadLock(ctx, func() error {
+		out, err = commonListChanges(p.repoPath, status)
+		return err
+	})
+	return out, err
+}
+
+func (p *PathVault) GetChange(ctx context.Context, id string) (cr *ChangeRequest, zipData []byte, err error) {
+	err = p.withReadLock(ctx, func() error {
+		cr, zipData, err = commonGetChange(p.repoPath, id)
+		return err
+	})
+	return cr, zipData, err
+}
+
+func (p *PathVault) ApproveChange(ctx context.Context, id, comment string) (cr *ChangeRequest, err error) {
+	err = p.withLock(ctx, func(actor mgmt.Actor) error {
+		cr, err = commonReviewChange(p.repoPath, actor, id, ChangeDecisionApprove, comment)
+		return err
+	})
+	return cr, err
+}
+
+func (p *PathVault) RejectChange(ctx context.Context, id, comment string) (cr *ChangeRequest, err error) {
+	err = p.withLock(ctx, func(actor mgmt.Actor) error {
+		cr, err = commonReviewChange(p.repoPath, actor, id, ChangeDecisionReject, comment)
+		return err
+	})
+	return cr, err
+}
+
+// MergeChange checks the change under the read lock, then re-checks,
+// publishes and marks it merged in one write-locked step, so a reject
+// that lands in between stops the merge.
+func (p *PathVault) MergeChange(ctx context.Context, id string, key *signing.PrivateKey) (*ChangeRequest, error) {
+	actor, err := p.CurrentActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var cr *ChangeRequest
+	err = p.withReadLock(ctx, func() error {
+		cr, _, err = commonPrepareMerge(p.repoPath, actor, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	prepared := cr
+	err = p.withLock(ctx, func(actor mgmt.Actor) error {
+		cr, err = commonMergeChange(p.repoPath, actor, prepared, key)
+		return err
+	})
+	return cr, err
+}
+
+// ---- GitVault change requests ----
+
+func (g *GitVault) ProposeChange(ctx context.Context, a *lockfile.Asset, zipData []byte, message string) (cr *ChangeRequest, err error) {
+	err = g.runInVaultTx(ctx, fmt.Sprintf("Propose %s %s", a.Name, a.Version), func(root string, actor mgmt.Actor) error {
+		cr, err = commonProposeChange(root, actor, a, zipData, message)
+		return err
+	})
+	return cr, err
+}
+
+func (g *GitVault) ListChanges(ctx context.Context, status ChangeStatus) ([]ChangeRequest, error) {
+	if err := g.cloneOrUpdate(ctx); err != nil {
+		return nil, err
+	}
+	return commonListChanges(g.repoPath, status)
+}
+
+func (g *GitVault) GetChange(ctx context.Context, id string) (*ChangeRequest, []byte, error) {
+	if err := g.cloneOrUpdate(ctx); err != nil {
+		return nil, nil, err
+	}
+	return commonGetChange(g.repoPath, id)
+}
+
+func (g *GitVault) ApproveChange(ctx context.Context, id, comment string) (cr *ChangeRequest, err error) {
+	err = g.runInVaultTx(ctx, "Approve change "+id, func(root string, actor mgmt.Actor) error {
+		cr, err = commonReviewChange(root, actor, id, ChangeDecisionApprove, comment)
+		return err
+	})
+	return cr, err
+}
+
+func (g *GitVault) RejectChange(ctx context.Context, id, comment string) (cr *ChangeRequest, err error) {
+	err = g.runInVaultTx(ctx, "Reject change "+id, func(root string, actor mgmt.Actor) error {
+		cr, err = commonReviewChange(root, actor, id, ChangeDecisionReject, comment)
+		return err
+	})
+	return cr, err
+}
+
+// MergeChange checks the change against the synced clone, then publishes
+// it and records the merge in a single commit. The transaction syncs to the
+// remote head first, so a reject pushed in between stops the merge.
+func (g *GitVault) MergeChange(ctx context.Context, id string, key *signing.PrivateKey) (*ChangeRequest, error) {
+	if err := g.cloneOrUpdate(ctx); err != nil {
+		return nil, err
+	}
+	actor, err := g.CurrentActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prepared, _, err := commonPrepareMerge(g.repoPath, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	var cr *ChangeRequest
+	msg := fmt.Sprintf("Merge change %s (%s %s)", id, prepared.AssetName, prepared.Version)
+	err = g.runInVaultTx(ctx, msg, func(root string, actor mgmt.Actor) error {
+		if cr, err = commonMergeChange(root, actor, prepared, key); err != nil {
+			return err
+		}
+		// runInVaultTx only stages sx.toml and .sx/; the version lives
+		// under the asset's storage directories
+		l, err := detectLayout(root)
+		if err != nil {
+			return err
+		}
+		for _, dir := range []string{l.VersionsDir(cr.AssetName), l.AssetDir(cr.AssetName)} {
+			if err := g.gitClient.Add(ctx, root, dir); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return cr, err
+}
@@ -0,0 +1,252 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/manifest"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/signing"
+)
+
+func proposedSkill(t *testing.T, version string) (*lockfile.Asset, []byte) {
+	t.Helper()
+	zipData := storageZip(t, map[string]string{
+		"SKILL.md":      "# my-skill " + version,
+		"metadata.toml": "[asset]\nname = \"my-skill\"\nversion = \"" + version + "\"\ntype = \"skill\"\n",
+	})
+	return &lockfile.Asset{Name: "my-skill", Version: version, Type: asset.TypeSkill}, zipData
+}
+
+// TestChangeRequest_ProposeApproveMerge: the happy path. A non-admin
+// proposes, an org-admin approves, the proposer merges, and only then does
+// the new version exist in the vault.
+func TestChangeRequest_ProposeApproveMerge(t *testing.T) {
+	v := seedRBACVault(t, "bob@example.com", nil, []string{"alice@example.com"})
+	bob := mgmt.ContextWithIdentity(context.Background(), "bob@example.com")
+	alice := mgmt.ContextWithIdentity(context.Background(), "alice@example.com")
+
+	a, zipData := proposedSkill(t, "2.0.0")
+	cr, err := v.ProposeChange(bob, a, zipData, "tighten the prompt")
+	if err != nil {
+		t.Fatalf("ProposeChange: %v", err)
+	}
+	if cr.Status != ChangeStatusPending || cr.Proposer != "bob@example.com" {
+		t.Fatalf("proposed change = %+v", cr)
+	}
+	if versions, _ := v.GetVersionList(bob, "my-skill"); slices.Contains(versions, "2.0.0") {
+		t.Fatalf("proposed version must not publish before merge, got %v", versions)
+	}
+
+	if _, err := v.MergeChange(bob, cr.ID, nil); err == nil {
+		t.Fatal("merging a pending change should fail")
+	}
+	if _, err := v.ApproveChange(alice, cr.ID, "lgtm"); err != nil {
+		t.Fatalf("ApproveChange: %v", err)
+	}
+	key, err := signing.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged, err := v.MergeChange(bob, cr.ID, key)
+	if err != nil {
+		t.Fatalf("MergeChange: %v", err)
+	}
+	if merged.Status != ChangeStatusMerged || merged.MergedBy != "bob@example.com" {
+		t.Fatalf("merged change = %+v", merged)
+	}
+	if sig, err := v.AssetSignature(bob, "my-skill", "2.0.0"); err != nil || sig == nil {
+		t.Fatalf("a merge with a key must store the signature with the version: %v", err)
+	}
+	versions, err := v.GetVersionList(bob, "my-skill")
+	if err != nil {
+		t.Fatalf("GetVersionList: %v", err)
+	}
+	if !slices.Contains(versions, "2.0.0") {
+		t.Fatalf("merge should publish 2.0.0, got %v", versions)
+	}
+	m, err := loadManifest(v.repoPath)
+	if err != nil {
+		t.Fatalf("loadManifest: %v", err)
+	}
+	if a := m.FindAsset("my-skill"); a == nil || a.Version != "2.0.0" || a.SourcePath == nil {
+		t.Fatalf("manifest row after merge = %+v, want 2.0.0 with a source path", a)
+	}
+
+	events, err := mgmt.QueryAuditEvents(v.repoPath, mgmt.AuditFilter{})
+	if err != nil {
+		t.Fatalf("QueryAuditEvents: %v", err)
+	}
+	var seen []string
+	for _, e := range events {
+		if e.TargetType == mgmt.TargetTypeChange {
+			seen = append(seen, e.Event)
+		}
+	}
+	// Newest first.
+	want := []string{mgmt.EventChangeMerged, mgmt.EventChangeApproved, mgmt.EventChangeProposed}
+	if strings.Join(seen, ",") != strings.Join(want, ",") {
+		t.Fatalf("change audit events = %v, want %v", seen, want)
+	}
+	signed, err := mgmt.QueryAuditEvents(v.repoPath, mgmt.AuditFilter{EventPrefix: mgmt.EventAssetSigned})
+	if err != nil || len(signed) != 1 {
+		t.Fatalf("asset.signed events = %+v, err = %v; want exactly one", signed, err)
+	}
+}
+
+// TestChangeRequest_ReviewPermissions: the proposer can't approve their
+// own change, a random user can't approve in a governed vault, and a
+// team-admin can approve a change to an asset scoped to their team.
+func TestChangeRequest_ReviewPermissions(t *testing.T) {
+	v := seedRBACVault(t, "alice@example.com", []manifest.Team{platformTeam()}, []string{"root@example.com"})
+	ctx := context.Background()
+	if _, err := v.SetAssetInstallations(ctx, "my-skill", []InstallTarget{
+		{Kind: InstallKindTeam, Team: "platform"},
+	}, false); err != nil {
+		t.Fatalf("SetAssetInstallations: %v", err)
+	}
+
+	bob := mgmt.ContextWithIdentity(ctx, "bob@example.com")
+	a, zipData := proposedSkill(t, "2.0.0")
+	cr, err := v.ProposeChange(bob, a, zipData, "")
+	if err != nil {
+		t.Fatalf("ProposeChange: %v", err)
+	}
+
+	t.Run("proposer", func(t *testing.T) {
+		if _, err := v.ApproveChange(bob, cr.ID, ""); err == nil {
+			t.Fatal("proposer should not approve their own change")
+		}
+	})
+	t.Run("outsider", func(t *testing.T) {
+		mallory := mgmt.ContextWithIdentity(ctx, "mallory@example.com")
+		if _, err := v.ApproveChange(mallory, cr.ID, ""); err == nil {
+			t.Fatal("non-reviewer should not approve")
+		}
+	})
+	t.Run("team admin", func(t *testing.T) {
+		alice := mgmt.ContextWithIdentity(ctx, "alice@example.com")
+		got, err := v.ApproveChange(alice, cr.ID, "")
+		if err != nil {
+			t.Fatalf("team admin approve: %v", err)
+		}
+		if got.Status != ChangeStatusApproved {
+			t.Fatalf("status = %s, want approved", got.Status)
+		}
+	})
+}
+
+// TestChangeRequest_Reject: a rejected change can't be merged and drops
+// out of the pending list.
+func TestChangeRequest_Reject(t *testing.T) {
+	v := seedRBACVault(t, "bob@example.com", nil, []string{"alice@example.com"})
+	bob := mgmt.ContextWithIdentity(context.Background(), "bob@example.com")
+	alice := mgmt.ContextWithIdentity(context.Background(), "alice@example.com")
+
+	a, zipData := proposedSkill(t, "2.0.0")
+	cr, err := v.ProposeChange(bob, a, zipData, "")
+	if err != nil {
+		t.Fatalf("ProposeChange: %v", err)
+	}
+	if _, err := v.RejectChange(alice, cr.ID, "needs tests"); err != nil {
+		t.Fatalf("RejectChange: %v", err)
+	}
+	if _, err := v.MergeChange(bob, cr.ID, nil); err == nil {
+		t.Fatal("merging a rejected change should fail")
+	}
+	pending, err := v.ListChanges(bob, ChangeStatusPending)
+	if err != nil {
+		t.Fatalf("ListChanges: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("pending = %+v, want none", pending)
+	}
+	got, _, err := v.GetChange(bob, cr.ID)
+	if err != nil {
+		t.Fatalf("GetChange: %v", err)
+	}
+	if len(got.Reviews) != 1 || got.Reviews[0].Comment != "needs tests" {
+		t.Fatalf("reviews = %+v", got.Reviews)
+	}
+}
+
+// TestChangeRequest_RejectedDuringMerge: a reject that lands between the
+// merge's read-locked check and its write-locked publish stops the merge,
+// and nothing is published.
+func TestChangeRequest_RejectedDuringMerge(t *testing.T) {
+	v := seedRBACVault(t, "bob@example.com", nil, []string{"alice@example.com"})
+	bob := mgmt.ContextWithIdentity(context.Background(), "bob@example.com")
+	alice := mgmt.ContextWithIdentity(context.Background(), "alice@example.com")
+
+	a, zipData := proposedSkill(t, "2.0.0")
+	cr, err := v.ProposeChange(bob, a, zipData, "")
+	if err != nil {
+		t.Fatalf("ProposeChange: %v", err)
+	}
+	if _, err := v.ApproveChange(alice, cr.ID, ""); err != nil {
+		t.Fatalf("ApproveChange: %v", err)
+	}
+	actor, err := v.CurrentActor(bob)
+	if err != nil {
+		t.Fatalf("CurrentActor: %v", err)
+	}
+	prepared, _, err := commonPrepareMerge(v.repoPath, actor, cr.ID)
+	if err != nil {
+		t.Fatalf("commonPrepareMerge: %v", err)
+	}
+	if _, err := v.RejectChange(alice, cr.ID, "found a problem"); err != nil {
+		t.Fatalf("RejectChange: %v", err)
+	}
+
+	err = v.withLock(bob, func(actor mgmt.Actor) error {
+		_, err := commonMergeChange(v.repoPath, actor, prepared, nil)
+		return err
+	})
+	if err == nil {
+		t.Fatal("merge should fail once the change is rejected")
+	}
+	if versions, _ := v.GetVersionList(bob, "my-skill"); slices.Contains(versions, "2.0.0") {
+		t.Fatalf("rejected change must not publish, got %v", versions)
+	}
+	got, _, err := v.GetChange(bob, cr.ID)
+	if err != nil {
+		t.Fatalf("GetChange: %v", err)
+	}
+	if got.Status != ChangeStatusRejected {
+		t.Fatalf("status = %s, want rejected", got.Status)
+	}
+}
+
+// TestChangeRequest_ProposeExistingVersion: proposing a version that is
+// already published fails up front rather than at merge time.
+func TestChangeRequest_ProposeExistingVersion(t *testing.T) {
+	v := seedRBACVault(t, "bob@example.com", nil, nil)
+	ctx := context.Background()
+	a, zipData := proposedSkill(t, "1.0.0")
+	if err := v.AddAsset(ctx, a, zipData); err != nil {
+		t.Fatalf("AddAsset: %v", err)
+	}
+	_, err := v.ProposeChange(ctx, a, zipData, "")
+	var exists *ErrVersionExists
+	if !errors.As(err, &exists) {
+		t.Fatalf("err = %v, want ErrVersionExists", err)
+	}
+}
+
+// TestChangeRequest_GetUnknown: unknown and malformed IDs are errors, and
+// a malformed ID never reaches the filesystem.
+func TestChangeRequest_GetUnknown(t *testing.T) {
+	v := seedRBACVault(t, "bob@example.com", nil, nil)
+	ctx := context.Background()
+	if _, _, err := v.GetChange(ctx, "deadbeef"); !errors.Is(err, ErrChangeNotFound) {
+		t.Fatalf("err = %v, want ErrChangeNotFound", err)
+	}
+	if _, _, err := v.GetChange(ctx, "../../sx"); err == nil {
+		t.Fatal("malformed id should fail")
+	}
+}
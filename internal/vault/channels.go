@@ -0,0 +1,332 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/sleuth-io/sx/v2/internal/manifest"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+)
+
+// ---- Release channels (docs/channels.md) ----
+// A channel is a named pointer from an asset to one of its stored versions
+// (stable = "3", beta = "4"). Scope rows opt into a channel and the lock
+// resolver hands each caller, in each place, the version their most
+// specific row there follows;
+// rows that follow nothing get the asset's latest version. Channels live on
+// the manifest row, so the operations here are ordinary manifest edits.
+
+// LatestChannel is the pseudo-channel every asset has: its latest published
+// version. It can be promoted from and followed (which is the same as
+// following nothing) but never set.
+const LatestChannel = "latest"
+
+var channelNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// ChannelFollower is one scope row following a channel.
+type ChannelFollower struct {
+	Channel string
+	Target  InstallTarget
+}
+
+// AssetChannels is an asset's channel table plus who follows each channel.
+type AssetChannels struct {
+	Asset     string
+	Latest    string
+	Channels  map[string]string
+	Followers []ChannelFollower
+}
+
+// ChannelStore is implemented by vaults that support release channels. Only
+// the file-backed vaults do; skills.new has no per-scope version selection.
+// Vaults implementing it also honor InstallTarget.Pin; see SupportsVersionPins.
+type ChannelStore interface {
+	// ListChannels returns the asset's channels and their followers.
+	ListChannels(ctx context.Context, assetName string) (*AssetChannels, error)
+
+	// SetChannel points channel at a stored version of the asset.
+	SetChannel(ctx context.Context, assetName, channel, version string) error
+
+	// PromoteChannel points to at the version from currently points at and
+	// returns that version.
+	PromoteChannel(ctx context.Context, assetName, from, to string) (string, error)
+
+	// FollowChannel makes the asset's existing scope row for target follow
+	// channel. An empty channel (or LatestChannel) stops following.
+	FollowChannel(ctx context.Context, assetName string, target InstallTarget, channel string) error
+}
+
+// SupportsVersionPins reports whether v stores per-scope version pins
+// (InstallTarget.Pin). Other vaults ignore the field.
+func SupportsVersionPins(v Vault) bool {
+	_, ok := v.(ChannelStore)
+	return ok
+}
+
+// ValidateChannelName checks a channel name: lowercase letters, digits, and
+// . _ - after the first character. LatestChannel is reserved.
+func ValidateChannelName(name string) error {
+	if name == LatestChannel {
+		return fmt.Errorf("channel %q is reserved for the latest published version", LatestChannel)
+	}
+	if !channelNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid channel name %q: use lowercase letters, digits, '.', '_' or '-'", name)
+	}
+	return nil
+}
+
+// channelVersion resolves a channel name (including LatestChannel) to the
+// version it points at on a.
+func channelVersion(a *manifest.Asset, channel string) (string, error) {
+	if channel == LatestChannel {
+		return a.Version, nil
+	}
+	v, ok := a.Channels[channel]
+	if !ok {
+		return "", fmt.Errorf("asset %q has no channel %q", a.Name, channel)
+	}
+	return v, nil
+}
+
+func channelAuditEvent(event, assetName string, data map[string]any) mgmt.AuditEvent {
+	return mgmt.AuditEvent{
+		Event:      event,
+		TargetType: mgmt.TargetTypeAsset,
+		Target:     assetName,
+		Data:       data,
+	}
+}
+
+// requireChannelAsset finds the asset row a channel edit applies to and
+// enforces the asset edit gate: moving a channel changes what its followers
+// install, which is the same authority as publishing a version.
+func requireChannelAsset(m *manifest.Manifest, assetName string, actor mgmt.Actor) (*manifest.Asset, error) {
+	a := m.FindAsset(assetName)
+	if a == nil {
+		return nil, fmt.Errorf("asset %q not found", assetName)
+	}
+	if denial := assetEditDenial(m, assetName, actor); denial != nil {
+		return nil, denial
+	}
+	return a, nil
+}
+
+// setChannelTx points channel at ver on a, refusing versions that are not in
+// the vault's version archive. Returns the previous version ("" if new).
+func setChannelTx(vaultRoot string, a *manifest.Asset, channel, ver string) (string, error) {
+	if ver != a.Version {
+		l, err := detectLayout(vaultRoot)
+		if err != nil {
+			return "", err
+		}
+		versions, err := versionListForAsset(vaultRoot, l, a.Name)
+		if err != nil {
+			return "", err
+		}
+		if !slices.Contains(versions, ver) {
+			return "", fmt.Errorf("version %s of %q is not stored in this vault", ver, a.Name)
+		}
+	}
+	prev := a.Channels[channel]
+	if a.Channels == nil {
+		a.Channels = map[string]string{}
+	}
+	a.Channels[channel] = ver
+	return prev, nil
+}
+
+func commonSetChannel(vaultRoot string, actor mgmt.Actor, assetName, channel, ver string) error {
+	if err := ValidateChannelName(channel); err != nil {
+		return err
+	}
+	return withManifestEvents(vaultRoot, actor, func(m *manifest.Manifest) ([]mgmt.AuditEvent, error) {
+		a, err := requireChannelAsset(m, assetName, actor)
+		if err != nil {
+			return nil, err
+		}
+		prev, err := setChannelTx(vaultRoot, a, channel, ver)
+		if err != nil {
+			return nil, err
+		}
+		data := map[string]any{"channel": channel, "version": ver}
+		if prev != "" {
+			data["previous"] = prev
+		}
+		return []mgmt.AuditEvent{channelAuditEvent(mgmt.EventChannelSet, assetName, data)}, nil
+	})
+}
+
+func commonPromoteChannel(vaultRoot string, actor mgmt.Actor, assetName, from, to string) (string, error) {
+	if err := ValidateChannelName(to); err != nil {
+		return "", err
+	}
+	if from == to {
+		return "", errors.New("cannot promote a channel to itself")
+	}
+	var promoted string
+	err := withManifestEvents(vaultRoot, actor, func(m *manifest.Manifest) ([]mgmt.AuditEvent, error) {
+		a, err := requireChannelAsset(m, assetName, actor)
+		if err != nil {
+			return nil, err
+		}
+		if promoted, err = channelVersion(a, from); err != nil {
+			return nil, err
+		}
+		prev, err := setChannelTx(vaultRoot, a, to, promoted)
+		if err != nil {
+			return nil, err
+		}
+		data := map[string]any{"from": from, "to": to, "version": promoted}
+		if prev != "" {
+			data["previous"] = prev
+		}
+		return []mgmt.AuditEvent{channelAuditEvent(mgmt.EventChannelPromoted, assetName, data)}, nil
+	})
+	return promoted, err
+}
+
+func commonFollowChannel(vaultRoot string, actor mgmt.Actor, assetName string, target InstallTarget, channel string) error {
+	if channel == LatestChannel {
+		channel = ""
+	}
+	if target.Kind == InstallKindOrg {
+		return errors.New("an org-wide install has no scope row to follow a channel; org-wide callers get the latest version — point a team, user, bot, or repo at the channel instead")
+	}
+	return withManifestEvents(vaultRoot, actor, func(m *manifest.Manifest) ([]mgmt.AuditEvent, error) {
+		a := m.FindAsset(assetName)
+		if a == nil {
+			return nil, fmt.Errorf("asset %q not found", assetName)
+		}
+		if channel != "" {
+			if _, err := channelVersion(a, channel); err != nil {
+				return nil, err
+			}
+		}
+		s, reason := resolveSetTarget(m, target, actor)
+		if reason == "" {
+			reason = scopeSetPermissionReason(m, target, actor)
+		}
+		if reason != "" {
+			return nil, errors.New(reason)
+		}
+		// Scopes inherit across legacy same-name rows; update every copy.
+		key := scopeDedupKey(s)
+		found := false
+		for i := range m.Assets {
+			if m.Assets[i].Name != assetName {
+				continue
+			}
+			for j := range m.Assets[i].Scopes {
+				if scopeDedupKey(m.Assets[i].Scopes[j]) == key {
+					m.Assets[i].Scopes[j].Channel = channel
+					found = true
+				}
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("asset %q is not installed to %s; install it there first", assetName, target.Describe())
+		}
+		data := target.AuditData()
+		data["channel"] = channel
+		return []mgmt.AuditEvent{channelAuditEvent(mgmt.EventChannelFollowed, assetName, data)}, nil
+	})
+}
+
+func commonListChannels(vaultRoot, assetName string) (*AssetChannels, error) {
+	m, err := loadManifest(vaultRoot)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, fmt.Errorf("asset %q not found", assetName)
+	}
+	a := m.FindAsset(assetName)
+	if a == nil {
+		return nil, fmt.Errorf("asset %q not found", assetName)
+	}
+	out := &AssetChannels{Asset: a.Name, Latest: a.Version, Channels: map[string]string{}}
+	for name, v := range a.Channels {
+		out.Channels[name] = v
+	}
+	for _, s := range a.Scopes {
+		if s.Channel == "" {
+			continue
+		}
+		if t, ok := manifestScopeToTarget(s); ok {
+			out.Followers = append(out.Followers, ChannelFollower{Channel: s.Channel, Target: t})
+		}
+	}
+	sort.SliceStable(out.Followers, func(i, j int) bool {
+		return out.Followers[i].Channel < out.Followers[j].Channel
+	})
+	return out, nil
+}
+
+// ---- PathVault channels ----
+
+func (p *PathVault) ListChannels(ctx context.Context, assetName string) (out *AssetChannels, err error) {
+	err = p.withReadLock(ctx, func() error {
+		out, err = commonListChannels(p.repoPath, assetName)
+		return err
+	})
+	return out, err
+}
+
+func (p *PathVault) SetChannel(ctx context.Context, assetName, channel, version string) error {
+	return p.withLock(ctx, func(actor mgmt.Actor) error {
+		return commonSetChannel(p.repoPath, actor, assetName, channel, version)
+	})
+}
+
+func (p *PathVault) PromoteChannel(ctx context.Context, assetName, from, to string) (version string, err error) {
+	err = p.withLock(ctx, func(actor mgmt.Actor) error {
+		version, err = commonPromoteChannel(p.repoPath, actor, assetName, from, to)
+		return err
+	})
+	return version, err
+}
+
+func (p *PathVault) FollowChannel(ctx context.Context, assetName string, target InstallTarget, channel string) error {
+	return p.withLock(ctx, func(actor mgmt.Actor) error {
+		return commonFollowChannel(p.repoPath, actor, assetName, target, channel)
+	})
+}
+
+// ---- GitVault channels ----
+
+func (g *GitVault) ListChannels(ctx context.Context, assetName string) (*AssetChannels, error) {
+	if err := g.cloneOrUpdate(ctx); err != nil {
+		return nil, err
+	}
+	return commonListChannels(g.repoPath, assetName)
+}
+
+func (g *GitVault) SetChannel(ctx context.Context, assetName, channel, version string) error {
+	msg := fmt.Sprintf("Set %s channel %s to %s", assetName, channel, version)
+	return g.runInVaultTx(ctx, msg, func(root string, actor mgmt.Actor) error {
+		return commonSetChannel(root, actor, assetName, channel, version)
+	})
+}
+
+func (g *GitVault) PromoteChannel(ctx context.Context, assetName, from, to string) (version string, err error) {
+	msg := fmt.Sprintf("Promote %s %s to %s", assetName, from, to)
+	err = g.runInVaultTx(ctx, msg, func(root string, actor mgmt.Actor) error {
+		version, err = commonPromoteChannel(root, actor, assetName, from, to)
+		return err
+	})
+	return version, err
+}
+
+func (g *GitVault) FollowChannel(ctx context.Context, assetName string, target InstallTarget, channel string) error {
+	msg := fmt.Sprintf("Point %s for %s at channel %s", assetName, target.Describe(), channel)
+	if channel == "" || channel == LatestChannel {
+		msg = fmt.Sprintf("Point %s for %s at latest", assetName, target.Describe())
+	}
+	return g.runInVaultTx(ctx, msg, func(root string, actor mgmt.Actor) error {
+		return commonFollowChannel(root, actor, assetName, target, channel)
+	})
+}
@@ -0,0 +1,215 @@
+package vault
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/manifest"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+)
+
+// publishSkillVersion stores a version and advances the manifest row the
+// way a merged change or `sx add` does.
+func publishSkillVersion(t *testing.T, v *PathVault, version string) {
+	t.Helper()
+	ctx := context.Background()
+	a, zipData := proposedSkill(t, version)
+	if err := v.AddAsset(ctx, a, zipData); err != nil {
+		t.Fatalf("AddAsset %s: %v", version, err)
+	}
+	sourcePath, err := v.StorageSourcePath(a.Name, a.Version)
+	if err != nil {
+		t.Fatalf("StorageSourcePath: %v", err)
+	}
+	a.SourcePath = &lockfile.SourcePath{Path: sourcePath}
+	if err := v.InheritInstallations(ctx, a); err != nil {
+		t.Fatalf("InheritInstallations %s: %v", version, err)
+	}
+}
+
+func lockVersionFor(t *testing.T, v *PathVault, email string) lockfile.Asset {
+	t.Helper()
+	data, err := resolveLockBytesForActor(mgmt.ContextWithIdentity(context.Background(), email), v.repoPath)
+	if err != nil {
+		t.Fatalf("resolveLockBytesForActor: %v", err)
+	}
+	lf, err := lockfile.Parse(data)
+	if err != nil {
+		t.Fatalf("parse lock: %v", err)
+	}
+	for _, a := range lf.Assets {
+		if a.Name == "my-skill" {
+			return a
+		}
+	}
+	t.Fatalf("my-skill missing from %s's lock", email)
+	return lockfile.Asset{}
+}
+
+// lockVersionForBot resolves the lock as bot. The bot identity is scoped to
+// a subtest so the caller can go back to mutating the vault as a human.
+func lockVersionForBot(t *testing.T, v *PathVault, bot string) (got lockfile.Asset) {
+	t.Helper()
+	t.Run("as bot "+bot, func(t *testing.T) {
+		t.Setenv(mgmt.SXBotEnv, bot)
+		got = lockVersionFor(t, v, "")
+	})
+	return got
+}
+
+// TestChannels_FollowAndPromote: a team following stable installs the
+// stable version from the archive while everyone else gets latest, and a
+// promotion moves the team forward with an audit event.
+func TestChannels_FollowAndPromote(t *testing.T) {
+	v := seedRBACVault(t, "alice@example.com", []manifest.Team{platformTeam()}, nil)
+	ctx := context.Background()
+	publishSkillVersion(t, v, "2.0.0")
+	publishSkillVersion(t, v, "3.0.0")
+
+	if err := v.SetChannel(ctx, "my-skill", "stable", "9.9.9"); err == nil {
+		t.Fatal("a channel must not point at a version that was never stored")
+	}
+	if err := v.SetChannel(ctx, "my-skill", "stable", "2.0.0"); err != nil {
+		t.Fatalf("SetChannel: %v", err)
+	}
+	if err := v.FollowChannel(ctx, "my-skill", InstallTarget{Kind: InstallKindTeam, Team: "platform"}, "stable"); err == nil {
+		t.Fatal("following from a scope the asset isn't installed to should fail")
+	}
+	if _, err := v.SetAssetInstallations(ctx, "my-skill", []InstallTarget{
+		{Kind: InstallKindTeam, Team: "platform"},
+		{Kind: InstallKindUser, User: "alice@example.com"},
+	}, false); err != nil {
+		t.Fatalf("SetAssetInstallations: %v", err)
+	}
+	if err := v.FollowChannel(ctx, "my-skill", InstallTarget{Kind: InstallKindTeam, Team: "platform"}, "nightly"); err == nil {
+		t.Fatal("following an unknown channel should fail")
+	}
+	if err := v.FollowChannel(ctx, "my-skill", InstallTarget{Kind: InstallKindTeam, Team: "platform"}, "stable"); err != nil {
+		t.Fatalf("FollowChannel: %v", err)
+	}
+
+	// Alice's own user row outranks the team row and follows nothing.
+	if got := lockVersionFor(t, v, "alice@example.com"); got.Version != "3.0.0" {
+		t.Fatalf("alice (user row) got %s, want latest 3.0.0", got.Version)
+	}
+	if err := v.FollowChannel(ctx, "my-skill", InstallTarget{Kind: InstallKindUser, User: "alice@example.com"}, "stable"); err != nil {
+		t.Fatalf("FollowChannel user: %v", err)
+	}
+	got := lockVersionFor(t, v, "alice@example.com")
+	if got.Version != "2.0.0" || got.SourcePath == nil {
+		t.Fatalf("alice got %+v, want 2.0.0 with an archive source", got)
+	}
+	if err := got.Validate(); err != nil {
+		t.Fatalf("channel lock row is invalid: %v", err)
+	}
+
+	promoted, err := v.PromoteChannel(ctx, "my-skill", LatestChannel, "stable")
+	if err != nil {
+		t.Fatalf("PromoteChannel: %v", err)
+	}
+	if promoted != "3.0.0" {
+		t.Fatalf("promoted %s, want 3.0.0", promoted)
+	}
+	if got := lockVersionFor(t, v, "alice@example.com"); got.Version != "3.0.0" {
+		t.Fatalf("after promotion alice got %s, want 3.0.0", got.Version)
+	}
+
+	events, err := mgmt.QueryAuditEvents(v.repoPath, mgmt.AuditFilter{})
+	if err != nil {
+		t.Fatalf("QueryAuditEvents: %v", err)
+	}
+	if len(events) == 0 || events[0].Event != mgmt.EventChannelPromoted {
+		t.Fatalf("latest audit event = %+v, want %s", events[0], mgmt.EventChannelPromoted)
+	}
+	if events[0].Data["previous"] != "2.0.0" || events[0].Data["version"] != "3.0.0" {
+		t.Fatalf("promotion data = %v", events[0].Data)
+	}
+
+	info, err := v.ListChannels(ctx, "my-skill")
+	if err != nil {
+		t.Fatalf("ListChannels: %v", err)
+	}
+	if info.Latest != "3.0.0" || info.Channels["stable"] != "3.0.0" || len(info.Followers) != 2 {
+		t.Fatalf("channels = %+v", info)
+	}
+}
+
+// TestChannels_EditGate: moving a channel is an edit of the asset, so a
+// team-scoped asset's channels belong to that team's members.
+func TestChannels_EditGate(t *testing.T) {
+	v := seedRBACVault(t, "alice@example.com", []manifest.Team{platformTeam()}, nil)
+	ctx := context.Background()
+	if _, err := v.SetAssetInstallations(ctx, "my-skill", []InstallTarget{
+		{Kind: InstallKindTeam, Team: "platform"},
+	}, false); err != nil {
+		t.Fatalf("SetAssetInstallations: %v", err)
+	}
+	mallory := mgmt.ContextWithIdentity(ctx, "mallory@example.com")
+	if err := v.SetChannel(mallory, "my-skill", "stable", "1.0.0"); err == nil {
+		t.Fatal("a non-member should not move a team asset's channel")
+	}
+	if err := v.SetChannel(ctx, "my-skill", LatestChannel, "1.0.0"); err == nil {
+		t.Fatal("'latest' is reserved")
+	}
+	if err := v.SetChannel(ctx, "my-skill", "stable", "1.0.0"); err != nil {
+		t.Fatalf("member SetChannel: %v", err)
+	}
+}
+
+// TestPins_LockCarriesPinnedVersion: a bot pinned to an older version gets
+// that version from the archive, with a content hash the installer checks,
+// while everyone else stays on latest.
+func TestPins_LockCarriesPinnedVersion(t *testing.T) {
+	v := seedRBACVault(t, "alice@example.com", []manifest.Team{platformTeam()}, nil)
+	ctx := context.Background()
+	if _, err := v.CreateBot(ctx, mgmt.Bot{Name: "ci", Teams: []string{"platform"}}); err != nil {
+		t.Fatalf("CreateBot: %v", err)
+	}
+	publishSkillVersion(t, v, "2.0.0")
+	publishSkillVersion(t, v, "3.0.0")
+
+	for _, bad := range []InstallTarget{
+		{Kind: InstallKindBot, Bot: "ci", Pin: "9.9.9"},
+		{Kind: InstallKindOrg, Pin: "2.0.0"},
+	} {
+		skipped, err := v.SetAssetInstallations(ctx, "my-skill", []InstallTarget{bad}, false)
+		if err != nil || len(skipped) != 1 {
+			t.Fatalf("%s: skipped = %+v, err = %v; want it skipped", bad.Describe(), skipped, err)
+		}
+	}
+	skipped, err := v.SetAssetInstallations(ctx, "my-skill", []InstallTarget{
+		{Kind: InstallKindBot, Bot: "ci", Pin: "2.0.0"},
+		{Kind: InstallKindUser, User: "alice@example.com"},
+	}, false)
+	if err != nil || len(skipped) != 0 {
+		t.Fatalf("SetAssetInstallations: skipped = %+v, err = %v", skipped, err)
+	}
+
+	if got := lockVersionFor(t, v, "alice@example.com"); got.Version != "3.0.0" {
+		t.Fatalf("alice got %s, want latest 3.0.0", got.Version)
+	}
+
+	got := lockVersionForBot(t, v, "ci")
+	if got.Version != "2.0.0" || got.SourcePath == nil || got.SourcePath.Hashes["sha256"] == "" {
+		t.Fatalf("bot got %+v, want 2.0.0 from the archive with a sha256", got)
+	}
+	if err := got.Validate(); err != nil {
+		t.Fatalf("pinned lock row is invalid: %v", err)
+	}
+	if _, err := v.GetAsset(ctx, &got); err != nil {
+		t.Fatalf("GetAsset pinned archive: %v", err)
+	}
+	got.SourcePath.Hashes["sha256"] = "0000"
+	if _, err := v.GetAsset(ctx, &got); err == nil {
+		t.Fatal("GetAsset should reject an archive whose content hash doesn't match")
+	}
+
+	// Re-installing with "latest" unpins the existing row in place.
+	if _, err := v.SetAssetInstallations(ctx, "my-skill", []InstallTarget{{Kind: InstallKindBot, Bot: "ci", Pin: LatestChannel}}, false); err != nil {
+		t.Fatalf("unpin: %v", err)
+	}
+	if got := lockVersionForBot(t, v, "ci"); got.Version != "3.0.0" {
+		t.Fatalf("after unpin bot got %s, want 3.0.0", got.Version)
+	}
+}
@@ -42,6 +42,12 @@ func NewFromConfig(cfg Config) (Vault, error) {
 		return NewGitVaultWithOptions(cfg.GetRepositoryURL(), opts...)
 	case "path":
 		return NewPathVault(cfg.GetRepositoryURL())
+	case "s3":
+		return NewS3Vault(cfg.GetRepositoryURL(), authUsername(cfg), cfg.GetAuthToken())
+	case "oci":
+		return NewOCIVault(cfg.GetRepositoryURL(), authUsername(cfg), cfg.GetAuthToken())
+	case "http":
+		return NewHTTPVault(cfg.GetRepositoryURL(), cfg.GetAuthToken())
 	default:
 		return nil, fmt.Errorf("unsupported vault type: %s", cfg.GetType())
 	}
@@ -25,6 +25,7 @@ import (
 	"github.com/sleuth-io/sx/v2/internal/logger"
 	"github.com/sleuth-io/sx/v2/internal/manifest"
 	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
 	"github.com/sleuth-io/sx/v2/internal/utils"
 )
 
@@ -187,6 +188,33 @@ func (g *GitVault) GetAsset(ctx context.Context, asset *lockfile.Asset) ([]byte,
 
 // AddAsset uploads an asset to the Git repository
 func (g *GitVault) AddAsset(ctx context.Context, asset *lockfile.Asset, zipData []byte) error {
+	return g.addAsset(ctx, asset, func() error {
+		// Store assets exploded (not as zip) so they are easy to browse and
+		// diff in Git. The layout decides where versions and list.txt live.
+		l, err := detectLayout(g.repoPath)
+		if err != nil {
+			return err
+		}
+		if err := storeAssetVersion(g.repoPath, l, asset.Name, asset.Version, zipData); err != nil {
+			return err
+		}
+
+		// Point the asset at the stored (immutable) version directory so the
+		// manifest records a layout-correct source path.
+		asset.SourcePath = &lockfile.SourcePath{
+			Path: l.SourcePathRel(asset.Name, asset.Version),
+		}
+		return nil
+	})
+}
+
+// addAsset runs store against an up-to-date clone under the file lock,
+// then commits and pushes whatever it wrote as the asset's version. Audit
+// events store records are exported once the push lands.
+func (g *GitVault) addAsset(ctx context.Context, asset *lockfile.Asset, store func() error) (err error) {
+	release := mgmt.HoldAuditExports(g.repoPath)
+	defer func() { release(err == nil) }()
+
 	// Acquire file lock to prevent concurrent git operations
 	fileLock, err := g.acquireFileLock(ctx)
 	if err != nil {
@@ -202,21 +230,9 @@ func (g *GitVault) AddAsset(ctx context.Context, asset *lockfile.Asset, zipData
 		return err
 	}
 
-	// Store assets exploded (not as zip) so they are easy to browse and
-	// diff in Git. The layout decides where versions and list.txt live.
-	l, err := detectLayout(g.repoPath)
-	if err != nil {
+	if err := store(); err != nil {
 		return err
 	}
-	if err := storeAssetVersion(g.repoPath, l, asset.Name, asset.Version, zipData); err != nil {
-		return err
-	}
-
-	// Point the asset at the stored (immutable) version directory so the
-	// manifest records a layout-correct source path.
-	asset.SourcePath = &lockfile.SourcePath{
-		Path: l.SourcePathRel(asset.Name, asset.Version),
-	}
 
 	// Commit and push the asset to the repository
 	if err := g.commitAndPush(ctx, asset); err != nil {
@@ -38,11 +38,16 @@ var usagePushInterval = time.Hour
 //     process raced us), rebases local commits onto the new remote head
 //     and retries once. Both errors are wrapped so troubleshooting
 //     shows which leg failed.
+//  7. Exports the audit events fn recorded once the push lands, after
+//     the lock is released; a failed transaction exports none.
 //
 // Any path in the staging list that doesn't exist yet is skipped —
 // critical for empty vaults, where the very first `sx team create`
 // runs before sx.toml has been written.
-func (g *GitVault) runInVaultTx(ctx context.Context, commitMsg string, fn func(vaultRoot string, actor mgmt.Actor) error) error {
+func (g *GitVault) runInVaultTx(ctx context.Context, commitMsg string, fn func(vaultRoot string, actor mgmt.Actor) error) (err error) {
+	release := mgmt.HoldAuditExports(g.repoPath)
+	defer func() { release(err == nil) }()
+
 	fileLock, err := g.acquireFileLock(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to acquire lock: %w", err)
@@ -586,11 +591,16 @@ func parseUsageJSONL(jsonlData string) ([]mgmt.UsageEvent, error) {
 			continue
 		}
 		var raw struct {
-			AssetName    string `json:"asset_name"`
-			AssetVersion string `json:"asset_version"`
-			AssetType    string `json:"asset_type"`
-			Timestamp    string `json:"timestamp"`
-			Actor        string `json:"actor"`
+			AssetName     string `json:"asset_name"`
+			AssetVersion  string `json:"asset_version"`
+			AssetType     string `json:"asset_type"`
+			Timestamp     string `json:"timestamp"`
+			Actor         string `json:"actor"`
+			Client        string `json:"client"`
+			RepoURL       string `json:"repo_url"`
+			SessionID     string `json:"session_id"`
+			Trigger       string `json:"trigger"`
+			ContextTokens int    `json:"context_tokens"`
 		}
 		if err := json.Unmarshal([]byte(line), &raw); err != nil {
 			logger.Get().Warn("usage line malformed; dropping",
@@ -600,10 +610,15 @@ func parseUsageJSONL(jsonlData string) ([]mgmt.UsageEvent, error) {
 			continue
 		}
 		ev := mgmt.UsageEvent{
-			Actor:        raw.Actor,
-			AssetName:    raw.AssetName,
-			AssetVersion: raw.AssetVersion,
-			AssetType:    raw.AssetType,
+			Actor:         raw.Actor,
+			AssetName:     raw.AssetName,
+			AssetVersion:  raw.AssetVersion,
+			AssetType:     raw.AssetType,
+			Client:        raw.Client,
+			RepoURL:       raw.RepoURL,
+			SessionID:     raw.SessionID,
+			Trigger:       raw.Trigger,
+			ContextTokens: raw.ContextTokens,
 		}
 		if raw.Timestamp != "" {
 			parsed, err := time.Parse(time.RFC3339, raw.Timestamp)
@@ -0,0 +1,363 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/bootstrap"
+	"github.com/sleuth-io/sx/v2/internal/buildinfo"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/logger"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// errHTTPVaultReadOnly is returned by every write on an HTTPVault
+var errHTTPVaultReadOnly = fmt.Errorf("%w: http vaults are read-only; publish with sx vault export-static", ErrNotImplemented)
+
+// HTTPVault implements Vault over a static vault served by any web server
+// (see ExportStatic). It is read-only: sx.lock is fetched with
+// If-None-Match so unchanged vaults cost one 304, and index.json backs
+// listing and version lookups. Teams, bots, usage, and audit live with
+// whoever publishes the site, not here.
+type HTTPVault struct {
+	baseURL     *url.URL
+	authToken   string
+	httpClient  *http.Client
+	httpHandler *HTTPSourceHandler
+
+	indexMu sync.Mutex
+	index   *StaticIndex
+}
+
+// NewHTTPVault creates a vault for the static site at baseURL. authToken,
+// if set, is sent as a bearer token on every request.
+func NewHTTPVault(baseURL, authToken string) (*HTTPVault, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid http vault URL %q: expected http:// or https://", baseURL)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+		if u.RawPath != "" {
+			u.RawPath += "/"
+		}
+	}
+	return &HTTPVault{
+		baseURL:     u,
+		authToken:   authToken,
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		httpHandler: NewHTTPSourceHandler(authToken),
+	}, nil
+}
+
+// resolve turns a URL from sx.lock or index.json into an absolute one.
+// Relative URLs are resolved against the vault root.
+func (h *HTTPVault) resolve(ref string) (string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid asset URL %q: %w", ref, err)
+	}
+	return h.baseURL.ResolveReference(u).String(), nil
+}
+
+// get fetches one file from the vault root. A non-empty ifNoneMatch is
+// sent as If-None-Match; the caller handles 304.
+func (h *HTTPVault) get(ctx context.Context, name, ifNoneMatch string) (*http.Response, error) {
+	endpoint, err := h.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", buildinfo.GetUserAgent())
+	if h.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+h.authToken)
+	}
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", name, err)
+	}
+	return resp, nil
+}
+
+// loadIndex fetches index.json once per HTTPVault
+func (h *HTTPVault) loadIndex(ctx context.Context) (*StaticIndex, error) {
+	h.indexMu.Lock()
+	defer h.indexMu.Unlock()
+	if h.index != nil {
+		return h.index, nil
+	}
+
+	resp, err := h.get(ctx, StaticIndexFile, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to fetch %s: HTTP %d: %s", StaticIndexFile, resp.StatusCode, string(body))
+	}
+	var index StaticIndex
+	if err := json.NewDecoder(resp.Body).Decode(&index); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", StaticIndexFile, err)
+	}
+	if index.SchemaVersion > staticIndexSchemaVersion {
+		return nil, fmt.Errorf("%s schema version %d is newer than this sx supports (%d); upgrade sx", StaticIndexFile, index.SchemaVersion, staticIndexSchemaVersion)
+	}
+	h.index = &index
+	return h.index, nil
+}
+
+// indexVersion looks up one asset version in index.json
+func (h *HTTPVault) indexVersion(ctx context.Context, name, version string) (*StaticIndexVersion, error) {
+	index, err := h.loadIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entry := index.find(name)
+	if entry == nil {
+		return nil, fmt.Errorf("%w: %s", ErrAssetNotFound, name)
+	}
+	ver := entry.find(version)
+	if ver == nil {
+		return nil, fmt.Errorf("%w: %s@%s", ErrAssetNotFound, name, version)
+	}
+	return ver, nil
+}
+
+// Authenticate returns the configured token
+func (h *HTTPVault) Authenticate(ctx context.Context) (string, error) {
+	return h.authToken, nil
+}
+
+// GetLockFile fetches sx.lock, answering notModified when the server
+// returns 304 for cachedETag. Relative asset URLs are rewritten to
+// absolute ones, so the cached lock file stands on its own.
+func (h *HTTPVault) GetLockFile(ctx context.Context, cachedETag string) (content []byte, etag string, notModified bool, err error) {
+	resp, err := h.get(ctx, StaticLockFile, cachedETag)
+	if err != nil {
+		return nil, "", false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil, cachedETag, true, nil
+	case http.StatusNotFound:
+		return nil, "", false, ErrLockFileNotFound
+	case http.StatusOK:
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return nil, "", false, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to read response body: %w", err)
+	}
+	lf, err := lockfile.Parse(data)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to parse %s: %w", StaticLockFile, err)
+	}
+	for i := range lf.Assets {
+		if src := lf.Assets[i].SourceHTTP; src != nil {
+			if src.URL, err = h.resolve(src.URL); err != nil {
+				return nil, "", false, err
+			}
+		}
+	}
+	data, err = lockfile.Marshal(lf)
+	if err != nil {
+		return nil, "", false, err
+	}
+	return data, resp.Header.Get("ETag"), false, nil
+}
+
+// GetAsset downloads an asset from its source-http URL
+func (h *HTTPVault) GetAsset(ctx context.Context, a *lockfile.Asset) ([]byte, error) {
+	if a.SourceHTTP == nil {
+		return nil, fmt.Errorf("unsupported source type for http vault: %s", a.GetSourceType())
+	}
+	resolved := *a
+	src := *a.SourceHTTP
+	var err error
+	if src.URL, err = h.resolve(src.URL); err != nil {
+		return nil, err
+	}
+	resolved.SourceHTTP = &src
+	return h.httpHandler.Fetch(ctx, &resolved)
+}
+
+// AddAsset is not supported: http vaults are read-only
+func (h *HTTPVault) AddAsset(ctx context.Context, a *lockfile.Asset, zipData []byte) error {
+	return errHTTPVaultReadOnly
+}
+
+// SetInstallations is not supported: http vaults are read-only
+func (h *HTTPVault) SetInstallations(ctx context.Context, a *lockfile.Asset, scopeEntity string) error {
+	return errHTTPVaultReadOnly
+}
+
+// InheritInstallations is not supported: http vaults are read-only
+func (h *HTTPVault) InheritInstallations(ctx context.Context, a *lockfile.Asset) error {
+	return errHTTPVaultReadOnly
+}
+
+// GetVersionList returns the versions listed in index.json
+func (h *HTTPVault) GetVersionList(ctx context.Context, name string) ([]string, error) {
+	index, err := h.loadIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entry := index.find(name)
+	if entry == nil {
+		return nil, nil
+	}
+	versions := make([]string, 0, len(entry.Versions))
+	for _, v := range entry.Versions {
+		versions = append(versions, v.Version)
+	}
+	return versions, nil
+}
+
+// GetMetadata reads metadata.toml out of the version's zip
+func (h *HTTPVault) GetMetadata(ctx context.Context, name, version string) (*metadata.Metadata, error) {
+	data, err := h.GetAssetByVersion(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := utils.ReadZipFile(data, "metadata.toml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata.toml from asset zip: %w", err)
+	}
+	return metadata.Parse(raw)
+}
+
+// GetAssetByVersion downloads one version's zip and checks it against
+// the index hash
+func (h *HTTPVault) GetAssetByVersion(ctx context.Context, name, version string) ([]byte, error) {
+	ver, err := h.indexVersion(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	return h.GetAsset(ctx, &lockfile.Asset{
+		Name:       name,
+		Version:    version,
+		SourceHTTP: &lockfile.SourceHTTP{URL: ver.URL, Hashes: map[string]string{"sha256": ver.SHA256}, Size: ver.Size},
+	})
+}
+
+// VerifyIntegrity checks hashes and sizes for downloaded assets
+func (h *HTTPVault) VerifyIntegrity(data []byte, hashes map[string]string, size int64) error {
+	if size > 0 && int64(len(data)) != size {
+		return fmt.Errorf("size mismatch: expected %d bytes, got %d bytes", size, len(data))
+	}
+	return h.httpHandler.verifyHashes(data, hashes)
+}
+
+// PostUsageStats discards usage: a static site has nowhere to record it.
+// Returning nil keeps the local queue from growing without bound.
+func (h *HTTPVault) PostUsageStats(ctx context.Context, jsonlData string) error {
+	logger.Get().Debug("http vault does not record usage; dropping queued events")
+	return nil
+}
+
+// RemoveAsset is not supported: http vaults are read-only
+func (h *HTTPVault) RemoveAsset(ctx context.Context, assetName, version string, delete bool) error {
+	return errHTTPVaultReadOnly
+}
+
+// RenameAsset is not supported: http vaults are read-only
+func (h *HTTPVault) RenameAsset(ctx context.Context, oldName, newName string) error {
+	return errHTTPVaultReadOnly
+}
+
+// ListAssets lists the assets in index.json
+func (h *HTTPVault) ListAssets(ctx context.Context, opts ListAssetsOptions) (*ListAssetsResult, error) {
+	index, err := h.loadIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var assets []AssetSummary
+	for _, entry := range index.Assets {
+		if len(entry.Versions) == 0 || (opts.Type != "" && entry.Type != opts.Type) {
+			continue
+		}
+		assets = append(assets, AssetSummary{
+			Name:          entry.Name,
+			Type:          asset.FromString(entry.Type),
+			LatestVersion: entry.Versions[len(entry.Versions)-1].Version,
+			VersionsCount: len(entry.Versions),
+			Description:   entry.Description,
+			CreatedAt:     index.GeneratedAt,
+			UpdatedAt:     index.GeneratedAt,
+		})
+	}
+	slices.SortFunc(assets, func(a, b AssetSummary) int { return strings.Compare(a.Name, b.Name) })
+	if search := strings.TrimSpace(opts.Search); search != "" {
+		assets = filterBySearch(assets, search)
+	}
+	if opts.Limit > 0 && len(assets) > opts.Limit {
+		assets = assets[:opts.Limit]
+	}
+	return &ListAssetsResult{Assets: assets}, nil
+}
+
+// GetAssetDetails returns an asset's versions from index.json, with
+// metadata from the latest version's zip
+func (h *HTTPVault) GetAssetDetails(ctx context.Context, name string) (*AssetDetails, error) {
+	index, err := h.loadIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entry := index.find(name)
+	if entry == nil || len(entry.Versions) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrAssetNotFound, name)
+	}
+	details := &AssetDetails{
+		Name:        entry.Name,
+		Type:        asset.FromString(entry.Type),
+		Description: entry.Description,
+		CreatedAt:   index.GeneratedAt,
+		UpdatedAt:   index.GeneratedAt,
+	}
+	for _, v := range entry.Versions {
+		details.Versions = append(details.Versions, AssetVersion{Version: v.Version, CreatedAt: index.GeneratedAt})
+	}
+	if meta, err := h.GetMetadata(ctx, name, entry.Versions[len(entry.Versions)-1].Version); err == nil {
+		details.Metadata = meta
+	}
+	return details, nil
+}
+
+// GetMCPTools exposes the asset shim tools over index.json
+func (h *HTTPVault) GetMCPTools() any {
+	return &AssetShimRegistrar{Repo: h}
+}
+
+// GetBootstrapOptions returns nil: static vaults provide no bootstrap options
+func (h *HTTPVault) GetBootstrapOptions(ctx context.Context) []bootstrap.Option {
+	return nil
+}
+
+// CurrentActor resolves the caller from git config, as file-backed
+// vaults do
+func (h *HTTPVault) CurrentActor(ctx context.Context) (mgmt.Actor, error) {
+	return mgmt.CurrentGitActor(ctx, "")
+}
@@ -0,0 +1,112 @@
+package vault
+
+import (
+	"context"
+
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+)
+
+// The management surface of HTTPVault. A static site carries no teams,
+// bots, usage, or audit trail: reads come back empty and writes fail
+// with errHTTPVaultReadOnly.
+
+func (h *HTTPVault) ListTeams(ctx context.Context, opts ListTeamsOptions) (*ListTeamsResult, error) {
+	return &ListTeamsResult{}, nil
+}
+
+func (h *HTTPVault) GetTeam(ctx context.Context, name string) (*mgmt.Team, error) {
+	return nil, mgmt.ErrTeamNotFound
+}
+
+func (h *HTTPVault) CreateTeam(ctx context.Context, team mgmt.Team) error {
+	return errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) UpdateTeam(ctx context.Context, team mgmt.Team) error {
+	return errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) DeleteTeam(ctx context.Context, name string) error {
+	return errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) AddTeamMember(ctx context.Context, team, email string, admin bool) error {
+	return errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) RemoveTeamMember(ctx context.Context, team, email string) error {
+	return errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) SetTeamAdmin(ctx context.Context, team, email string, admin bool) error {
+	return errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) AddTeamRepository(ctx context.Context, team, repoURL string) error {
+	return errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) RemoveTeamRepository(ctx context.Context, team, repoURL string) error {
+	return errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) ListBots(ctx context.Context) ([]mgmt.Bot, error) {
+	return nil, nil
+}
+
+func (h *HTTPVault) GetBot(ctx context.Context, name string) (*mgmt.Bot, error) {
+	return nil, mgmt.ErrBotNotFound
+}
+
+func (h *HTTPVault) CreateBot(ctx context.Context, bot mgmt.Bot) (string, error) {
+	return "", errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) UpdateBot(ctx context.Context, bot mgmt.Bot) error {
+	return errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) DeleteBot(ctx context.Context, name string) error {
+	return errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) AddBotTeam(ctx context.Context, bot, team string) error {
+	return errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) RemoveBotTeam(ctx context.Context, bot, team string) error {
+	return errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) SetAssetInstallation(ctx context.Context, assetName string, target InstallTarget) error {
+	return errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) RemoveAssetInstallation(ctx context.Context, assetName string, target InstallTarget) error {
+	return errHTTPVaultReadOnly
+}
+
+func (h *HTTPVault) ClearAssetInstallations(ctx context.Context, assetName string) error {
+	return errHTTPVaultReadOnly
+}
+
+// RecordUsageEvents discards events, like PostUsageStats
+func (h *HTTPVault) RecordUsageEvents(ctx context.Context, events []mgmt.UsageEvent) error {
+	return nil
+}
+
+func (h *HTTPVault) GetUsageStats(ctx context.Context, filter mgmt.UsageFilter) (*mgmt.UsageSummary, error) {
+	return nil, ErrNotImplemented
+}
+
+func (h *HTTPVault) ReadUsageEvents(ctx context.Context, filter mgmt.UsageFilter) ([]mgmt.UsageEvent, error) {
+	return nil, ErrNotImplemented
+}
+
+func (h *HTTPVault) QueryAuditEvents(ctx context.Context, filter mgmt.AuditFilter) ([]mgmt.AuditEvent, error) {
+	return nil, ErrNotImplemented
+}
+
+func (h *HTTPVault) ImportAuditEvents(ctx context.Context, events []mgmt.AuditEvent) error {
+	return errHTTPVaultReadOnly
+}
@@ -102,6 +102,13 @@ func (l Layout) MetadataPath(name, version string) string {
 	return filepath.Join(l.VersionDir(name, version), "metadata.toml")
 }
 
+// SignaturePath is the vault-relative path of a stored version's signature
+// (docs/signing.md). It sits beside the version directory, not inside it,
+// so it is never part of the content it signs.
+func (l Layout) SignaturePath(name, version string) string {
+	return filepath.Join(l.VersionsDir(name), version+".minisig")
+}
+
 // SourcePathRel is the slash-separated vault-relative path recorded in
 // manifest and lock source-path entries for a stored version. It always
 // points at the immutable version directory, never the mutable v2 root view,
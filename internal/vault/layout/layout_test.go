@@ -35,6 +35,7 @@ func TestV1Paths(t *testing.T) {
 		{l.VersionDir("chat", "1.0"), filepath.Join("assets", "chat", "1.0")},
 		{l.VersionListPath("chat"), filepath.Join("assets", "chat", "list.txt")},
 		{l.MetadataPath("chat", "1.0"), filepath.Join("assets", "chat", "1.0", "metadata.toml")},
+		{l.SignaturePath("chat", "1.0"), filepath.Join("assets", "chat", "1.0.minisig")},
 		{l.SourcePathRel("chat", "1.0"), "assets/chat/1.0"},
 	}
 	for _, c := range cases {
@@ -52,6 +53,7 @@ func TestV2Paths(t *testing.T) {
 		{l.VersionDir("chat", "1.0"), filepath.Join(".sx", "versions", "chat", "1.0")},
 		{l.VersionListPath("chat"), filepath.Join(".sx", "versions", "chat", "list.txt")},
 		{l.MetadataPath("chat", "1.0"), filepath.Join(".sx", "versions", "chat", "1.0", "metadata.toml")},
+		{l.SignaturePath("chat", "1.0"), filepath.Join(".sx", "versions", "chat", "1.0.minisig")},
 		{l.SourcePathRel("chat", "1.0"), ".sx/versions/chat/1.0"},
 	}
 	for _, c := range cases {
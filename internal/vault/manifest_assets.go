@@ -11,6 +11,7 @@ import (
 	"github.com/sleuth-io/sx/v2/internal/lockfile"
 	"github.com/sleuth-io/sx/v2/internal/manifest"
 	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/utils"
 	"github.com/sleuth-io/sx/v2/internal/vault/layout"
 )
 
@@ -18,6 +19,11 @@ import (
 // resolved lock file bytes for the caller identified by vaultRoot's git
 // config. Every file-backed vault's GetLockFile delegates here.
 //
+// Scope rows that pin or follow conflicting versions for this caller
+// fail the whole lock with a manifest.VersionConflictError: installing
+// without the asset would uninstall it, and either version would ignore
+// a pin.
+//
 // Returns ErrLockFileNotFound only when the vault has never been
 // initialized (no manifest on disk). An initialized vault with zero
 // assets returns a valid empty lock file so the install pipeline can
@@ -34,10 +40,45 @@ func resolveLockBytesForActor(ctx context.Context, vaultRoot string) ([]byte, er
 	if err != nil {
 		return nil, err
 	}
-	lf := manifest.Resolve(m, actor)
+	l, err := detectLayout(vaultRoot)
+	if err != nil {
+		return nil, err
+	}
+	lf, err := manifest.ResolveWithVersions(m, actor, archivedVersionSource(vaultRoot, l))
+	if err != nil {
+		return nil, err
+	}
 	return lockfile.Marshal(lf)
 }
 
+// archivedVersionSource serves channel-selected and pinned versions out of
+// the vault's version archive, with a content hash of the archived files so
+// the install verifies it got exactly the version the manifest named. A
+// version that was never stored, or whose files can't be read, reports
+// not-found so the caller falls back to the latest row.
+func archivedVersionSource(vaultRoot string, l layout.Layout) manifest.VersionSource {
+	return func(name, ver string) (*lockfile.Asset, bool) {
+		versions, err := versionListForAsset(vaultRoot, l, name)
+		if err != nil || !slices.Contains(versions, ver) {
+			return nil, false
+		}
+		a, err := archivedAssetVersion(vaultRoot, l, name, ver)
+		if err != nil {
+			return nil, false
+		}
+		zipData, err := utils.CreateZip(filepath.Join(vaultRoot, a.SourcePath.Path))
+		if err != nil {
+			return nil, false
+		}
+		sum, err := utils.ComputeZipContentSHA256(zipData)
+		if err != nil {
+			return nil, false
+		}
+		a.SourcePath.Hashes = map[string]string{"sha256": sum}
+		return a, true
+	}
+}
+
 // upsertAssetInManifest inserts or replaces an asset in the vault's
 // manifest. Scopes on the incoming asset are preserved verbatim —
 // callers that want to inherit existing scopes should use
@@ -327,6 +368,12 @@ func commonCurrentInstallTargets(vaultRoot, name string) ([]InstallTarget, bool,
 // install target. Org scopes never appear as rows (org-wide is the empty scope
 // set), so an unexpected kind is reported as not-convertible.
 func manifestScopeToTarget(s manifest.Scope) (InstallTarget, bool) {
+	t, ok := manifestScopeKindToTarget(s)
+	t.Pin = s.Version
+	return t, ok
+}
+
+func manifestScopeKindToTarget(s manifest.Scope) (InstallTarget, bool) {
 	switch s.Kind {
 	case manifest.ScopeKindRepo:
 		return InstallTarget{Kind: InstallKindRepo, Repo: s.Repo}, true
@@ -12,6 +12,7 @@ import (
 	"github.com/sleuth-io/sx/v2/internal/manifest"
 	"github.com/sleuth-io/sx/v2/internal/metadata"
 	"github.com/sleuth-io/sx/v2/internal/utils"
+	"github.com/sleuth-io/sx/v2/internal/vault/layout"
 	"github.com/sleuth-io/sx/v2/internal/version"
 )
 
@@ -93,37 +94,33 @@ func assetFromStorage(vaultRoot, name string) (*lockfile.Asset, bool, error) {
 	// The install repair call site has no version in scope, so storage
 	// recovery picks the highest semver stored on disk.
 	latest := versions[len(versions)-1]
-	metaPath := filepath.Join(vaultRoot, l.MetadataPath(name, latest))
+	a, err := archivedAssetVersion(vaultRoot, l, name, latest)
+	if err != nil {
+		return nil, false, err
+	}
+	return a, true, nil
+}
+
+// archivedAssetVersion builds a lock row for one stored version of an asset
+// from its archived metadata.toml. It is intentionally lossy: the original
+// source kind and manifest-only fields are gone, so the row points at the
+// bytes stored for this version (layout-dependent location).
+func archivedAssetVersion(vaultRoot string, l layout.Layout, name, ver string) (*lockfile.Asset, error) {
+	metaPath := filepath.Join(vaultRoot, l.MetadataPath(name, ver))
 	metaBytes, err := os.ReadFile(metaPath)
 	if err != nil {
-		return nil, false, fmt.Errorf("read metadata for %q@%s: %w", name, latest, err)
+		return nil, fmt.Errorf("read metadata for %q@%s: %w", name, ver, err)
 	}
 	meta, err := metadata.Parse(metaBytes)
 	if err != nil {
-		return nil, false, fmt.Errorf("parse metadata for %q@%s: %w", name, latest, err)
+		return nil, fmt.Errorf("parse metadata for %q@%s: %w", name, ver, err)
 	}
-	if meta.Asset.Type.Key == "" {
-		return nil, false, fmt.Errorf("metadata for %q@%s has no asset type", name, latest)
-	}
-	deps := make([]lockfile.Dependency, 0, len(meta.Asset.Dependencies))
-	for _, dep := range meta.Asset.Dependencies {
-		if dep = strings.TrimSpace(dep); dep != "" {
-			deps = append(deps, lockfile.Dependency{Name: dep})
-		}
+	a, err := lockAssetFromMetadata(meta, name, ver)
+	if err != nil {
+		return nil, err
 	}
-	// Storage recovery is intentionally lossy: the original source kind and
-	// manifest-only fields are gone, so the recovered row points at the bytes
-	// currently stored for this version (layout-dependent location).
-	return &lockfile.Asset{
-		Name:         name,
-		Version:      latest,
-		Type:         meta.Asset.Type,
-		Clients:      append([]string(nil), meta.Asset.Clients...),
-		Dependencies: deps,
-		SourcePath: &lockfile.SourcePath{
-			Path: l.SourcePathRel(name, latest),
-		},
-	}, true, nil
+	a.SourcePath = &lockfile.SourcePath{Path: l.SourcePathRel(name, ver)}
+	return a, nil
 }
 
 func metadataFromAssetZip(ctx context.Context, repo Vault, asset *lockfile.Asset) (*metadata.Metadata, error) {
@@ -953,10 +953,14 @@ func commonSetAssetInstallation(vaultRoot string, actor mgmt.Actor, assetName st
 				return nil, err
 			}
 		}
+		if reason := pinnedVersionDenial(vaultRoot, assetName, target); reason != "" {
+			return nil, errors.New(reason)
+		}
 		if target.Kind == InstallKindOrg {
 			asset.Scopes = nil
-		} else if !scopeExistsOnAsset(asset.Scopes, s) {
-			asset.Scopes = append(asset.Scopes, s)
+		} else {
+			pinScopeVersion(&s, target.Pin)
+			asset.Scopes = addOrRepinScope(asset.Scopes, s, target.Pin)
 		}
 		installEvent := mgmt.AuditEvent{
 			Event:      mgmt.EventInstallSet,
@@ -1105,6 +1109,14 @@ func commonSetAssetInstallations(ctx context.Context, vaultRoot string, actor mg
 				return nil, nil
 			}
 		}
+		if orgWide {
+			for _, t := range targets {
+				if reason := pinnedVersionDenial(vaultRoot, assetName, t); t.Kind == InstallKindOrg && reason != "" {
+					skipped = append(skipped, SkippedTarget{Target: t, Reason: reason})
+					return nil, nil
+				}
+			}
+		}
 		// Resolve each non-org target against the in-transaction manifest,
 		// skipping any that can't be set (with the reason why). Org is exclusive
 		// and needs no resolution (it clears the scope set).
@@ -1116,6 +1128,9 @@ func commonSetAssetInstallations(ctx context.Context, vaultRoot string, actor mg
 		if !orgWide {
 			for _, t := range targets {
 				s, reason := resolveSetTarget(m, t, actor)
+				if reason == "" {
+					reason = pinnedVersionDenial(vaultRoot, assetName, t)
+				}
 				// Existence/format settled; now the RBAC gate (who may set it).
 				if reason == "" && enforce {
 					reason = scopeSetPermissionReason(m, t, actor)
@@ -1217,9 +1232,7 @@ func commonSetAssetInstallations(ctx context.Context, vaultRoot string, actor mg
 			})
 		} else {
 			for _, r := range resolved {
-				if !scopeExistsOnAsset(asset.Scopes, r.scope) {
-					asset.Scopes = append(asset.Scopes, r.scope)
-				}
+				asset.Scopes = addOrRepinScope(asset.Scopes, r.scope, r.target.Pin)
 				events = append(events, mgmt.AuditEvent{
 					Event:      mgmt.EventInstallSet,
 					TargetType: mgmt.TargetTypeInstallation,
@@ -1262,9 +1275,61 @@ func resolveSetTarget(m *manifest.Manifest, t InstallTarget, actor mgmt.Actor) (
 	case InstallKindOrg, InstallKindRepo, InstallKindPath, InstallKindUser:
 		// no manifest-dependent resolution beyond installTargetScope above
 	}
+	pinScopeVersion(&s, t.Pin)
 	return s, ""
 }
 
+// pinnedVersionDenial returns why t's pin can't be stored for assetName, or
+// "" when t carries no pin or the pinned version is in the vault's archive.
+// Org-wide installs have no scope row to carry a pin.
+func pinnedVersionDenial(vaultRoot, assetName string, t InstallTarget) string {
+	if t.Pin == "" || t.Pin == LatestChannel {
+		return ""
+	}
+	if t.Kind == InstallKindOrg {
+		return "an org-wide install has no scope row to pin; pin a team, user, bot, or repo instead"
+	}
+	l, err := detectLayout(vaultRoot)
+	if err != nil {
+		return err.Error()
+	}
+	versions, err := versionListForAsset(vaultRoot, l, assetName)
+	if err != nil {
+		return err.Error()
+	}
+	if !slices.Contains(versions, t.Pin) {
+		return fmt.Sprintf("version %s of %q is not stored in this vault", t.Pin, assetName)
+	}
+	return ""
+}
+
+// pinScopeVersion applies a target's pin to the scope row it resolved to:
+// a version pins the row, LatestChannel unpins it, and "" leaves it be.
+func pinScopeVersion(s *manifest.Scope, pin string) {
+	switch pin {
+	case "":
+	case LatestChannel:
+		s.Version = ""
+	default:
+		s.Version = pin
+	}
+}
+
+// addOrRepinScope appends s unless an equivalent row already exists. An
+// existing row keeps its pin unless the target named one, so re-installing
+// to a pinned scope without --pin doesn't silently move it to latest.
+func addOrRepinScope(scopes []manifest.Scope, s manifest.Scope, pin string) []manifest.Scope {
+	for i := range scopes {
+		if installScopeMatches(scopes[i], s) {
+			if pin != "" {
+				scopes[i].Version = s.Version
+			}
+			return scopes
+		}
+	}
+	return append(scopes, s)
+}
+
 // scopeRBACBypassKey carries the trusted-write opt-out (see
 // ContextWithTrustedScopeWrite). Pointer-free empty struct = standard ctx key.
 type scopeRBACBypassKey struct{}
@@ -0,0 +1,399 @@
+package vault
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gofrs/flock"
+
+	"github.com/sleuth-io/sx/v2/internal/bootstrap"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/logger"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+const (
+	// mirrorSyncTTL is how long reads trust a local mirror before checking
+	// the remote again — the mirrored-vault counterpart of gitSyncTTL.
+	mirrorSyncTTL = 2 * time.Minute
+
+	// mirrorWriteAttempts bounds how often a write is replayed after
+	// losing a race to another writer.
+	mirrorWriteAttempts = 3
+)
+
+// errMirrorConflict is returned by a mirrorRemote push that lost a race:
+// the remote changed since the mirror was pulled.
+var errMirrorConflict = errors.New("vault changed concurrently")
+
+// mirrorRemote is the store behind a mirrorVault: an S3 bucket or an OCI
+// registry. It owns how the mirror tree maps to remote objects.
+type mirrorRemote interface {
+	// pull brings the mirror tree up to date with the remote. With verify
+	// set it also discards local edits, so the tree matches the remote
+	// exactly before a write.
+	pull(ctx context.Context, verify bool) error
+
+	// push uploads what changed in the tree since the last pull, returning
+	// errMirrorConflict if another writer got there first.
+	push(ctx context.Context) error
+
+	// manifestTag returns an opaque tag that changes whenever the remote
+	// sx.toml does, or ErrLockFileNotFound when there is none.
+	manifestTag(ctx context.Context) (string, error)
+
+	// pulledManifestTag returns the tag of the sx.toml last pulled.
+	pulledManifestTag() (string, error)
+}
+
+// mirrorVault implements Vault for remotes that hold exactly what a path
+// vault directory holds — sx.toml, the assets tree and .sx/. Every
+// operation runs against a local mirror through a PathVault, so mirrored
+// vaults share all vault logic with path vaults.
+//
+// Reads pull the remote (at most once per mirrorSyncTTL). Writes pull,
+// run the PathVault operation, then push; a push that loses a race is
+// replayed on top of the other writer's change. No lock is held remotely.
+type mirrorVault struct {
+	remote    mirrorRemote
+	mirrorDir string
+	treeDir   string
+	local     *PathVault
+
+	syncMu     sync.Mutex
+	lastSynced time.Time
+}
+
+// newMirrorVault creates the mirror tree under mirrorDir. The remote is
+// set by the caller, which usually needs the tree path to build it.
+func newMirrorVault(mirrorDir string) (*mirrorVault, error) {
+	treeDir := filepath.Join(mirrorDir, "tree")
+	if err := os.MkdirAll(treeDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create vault mirror: %w", err)
+	}
+	local, err := NewPathVault(treeDir)
+	if err != nil {
+		return nil, err
+	}
+	return &mirrorVault{mirrorDir: mirrorDir, treeDir: treeDir, local: local}, nil
+}
+
+// acquireMirrorLock serializes syncs and writes of the local mirror across
+// goroutines and processes. It lives outside the mirrored tree so it is
+// never uploaded.
+func (m *mirrorVault) acquireMirrorLock(ctx context.Context) (*flock.Flock, error) {
+	fl := flock.New(filepath.Join(m.mirrorDir, ".lock"))
+	locked, err := fl.TryLockContext(ctx, 100*time.Millisecond)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	if !locked {
+		return nil, errors.New("could not acquire vault mirror lock (timeout)")
+	}
+	return fl, nil
+}
+
+// pullLocked pulls and records the sync time. The caller must hold the
+// mirror lock.
+func (m *mirrorVault) pullLocked(ctx context.Context, verify bool) error {
+	if err := m.remote.pull(ctx, verify); err != nil {
+		return err
+	}
+	m.syncMu.Lock()
+	m.lastSynced = time.Now()
+	m.syncMu.Unlock()
+	return nil
+}
+
+// read runs fn against the mirror, syncing it first unless it was synced
+// within mirrorSyncTTL.
+func (m *mirrorVault) read(ctx context.Context, fn func() error) error {
+	fl, err := m.acquireMirrorLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	m.syncMu.Lock()
+	stale := m.lastSynced.IsZero() || time.Since(m.lastSynced) >= mirrorSyncTTL
+	m.syncMu.Unlock()
+	if stale {
+		if err := m.pullLocked(ctx, false); err != nil {
+			return err
+		}
+	}
+	return fn()
+}
+
+// write runs a mutating fn against a freshly synced mirror and pushes the
+// result. If another writer got there first, the mirror is re-synced and
+// fn replayed on top of the new state. On failure the mirror is reset to
+// the remote so no half-applied change lingers locally. Audit events an
+// attempt records are exported only once its push lands.
+func (m *mirrorVault) write(ctx context.Context, fn func() error) error {
+	fl, err := m.acquireMirrorLock(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	for attempt := 1; ; attempt++ {
+		if err := m.pullLocked(ctx, true); err != nil {
+			return err
+		}
+		release := mgmt.HoldAuditExports(m.local.repoPath)
+		err := fn()
+		if err == nil {
+			err = m.remote.push(ctx)
+		}
+		release(err == nil)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, errMirrorConflict) || attempt == mirrorWriteAttempts {
+			if resetErr := m.pullLocked(ctx, true); resetErr != nil {
+				logger.Get().Warn("failed to reset vault mirror", "error", resetErr)
+			}
+			if errors.Is(err, errMirrorConflict) {
+				return fmt.Errorf("vault changed concurrently %d times in a row, please retry: %w", attempt, err)
+			}
+			return err
+		}
+		logger.Get().Debug("mirrored vault write conflicted, replaying", "attempt", attempt)
+	}
+}
+
+// mirrorRead is read for operations that return a value
+func mirrorRead[T any](ctx context.Context, m *mirrorVault, fn func() (T, error)) (T, error) {
+	var out T
+	err := m.read(ctx, func() error {
+		v, err := fn()
+		out = v
+		return err
+	})
+	return out, err
+}
+
+// mirrorWrite is write for operations that return a value
+func mirrorWrite[T any](ctx context.Context, m *mirrorVault, fn func() (T, error)) (T, error) {
+	var out T
+	err := m.write(ctx, func() error {
+		v, err := fn()
+		out = v
+		return err
+	})
+	return out, err
+}
+
+// isMirrorLocal reports whether a mirror file never leaves this machine:
+// the PathVault's own flock file.
+func isMirrorLocal(rel string) bool {
+	return rel == ".sx/.lock"
+}
+
+// mirrorFiles hashes every file in a mirror tree, keyed by slash path
+func mirrorFiles(treeDir string) (map[string]string, error) {
+	out := map[string]string{}
+	err := filepath.WalkDir(treeDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(treeDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if isMirrorLocal(rel) {
+			return nil
+		}
+		sum, err := hashFile(p)
+		if err != nil {
+			return err
+		}
+		out[rel] = sum
+		return nil
+	})
+	return out, err
+}
+
+func hashFile(p string) (string, error) {
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return "", err
+	}
+	return sha256Hex(data), nil
+}
+
+func writeMirrorFile(root, rel string, data []byte) error {
+	p := filepath.Join(root, filepath.FromSlash(rel))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(p, data, 0644)
+}
+
+// removeMirrorFile deletes a mirror file and any directories it leaves
+// empty, so removed asset versions don't linger as empty directories.
+func removeMirrorFile(root, rel string) error {
+	if err := os.Remove(filepath.Join(root, filepath.FromSlash(rel))); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for dir := path.Dir(rel); dir != "."; dir = path.Dir(dir) {
+		if os.Remove(filepath.Join(root, filepath.FromSlash(dir))) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+// Authenticate is a no-op: remotes authenticate each request themselves
+func (m *mirrorVault) Authenticate(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+// GetLockFile returns the manifest resolved for the caller's identity.
+// The ETag combines the remote sx.toml's tag with the caller's email, so
+// an unchanged vault costs one cheap remote check and the lock file is
+// served from the caller's cache.
+func (m *mirrorVault) GetLockFile(ctx context.Context, cachedETag string) (content []byte, etag string, notModified bool, err error) {
+	actor, err := m.CurrentActor(ctx)
+	if err != nil {
+		return nil, "", false, err
+	}
+	remoteTag, err := m.remote.manifestTag(ctx)
+	if err != nil {
+		return nil, "", false, err
+	}
+	if cachedETag != "" && cachedETag == mirrorLockETag(remoteTag, actor.Email) {
+		return nil, cachedETag, true, nil
+	}
+
+	fl, err := m.acquireMirrorLock(ctx)
+	if err != nil {
+		return nil, "", false, err
+	}
+	defer func() { _ = fl.Unlock() }()
+	if err := m.pullLocked(ctx, false); err != nil {
+		return nil, "", false, err
+	}
+	content, _, _, err = m.local.GetLockFile(ctx, "")
+	if err != nil {
+		return nil, "", false, err
+	}
+	// Tag what was actually pulled, which may be newer than the check.
+	pulled, err := m.remote.pulledManifestTag()
+	if err != nil {
+		return nil, "", false, err
+	}
+	return content, mirrorLockETag(pulled, actor.Email), false, nil
+}
+
+// mirrorLockETag derives the lock file ETag for one caller: the resolved
+// lock file differs per identity, so the manifest's tag alone isn't enough.
+func mirrorLockETag(manifestTag, email string) string {
+	sum := sha256.Sum256([]byte(manifestTag + "\x00" + email))
+	return hex.EncodeToString(sum[:16])
+}
+
+// GetAsset downloads an asset using its source configuration
+func (m *mirrorVault) GetAsset(ctx context.Context, asset *lockfile.Asset) ([]byte, error) {
+	return mirrorRead(ctx, m, func() ([]byte, error) { return m.local.GetAsset(ctx, asset) })
+}
+
+// AddAsset stores an asset version in the vault
+func (m *mirrorVault) AddAsset(ctx context.Context, asset *lockfile.Asset, zipData []byte) error {
+	return m.write(ctx, func() error { return m.local.AddAsset(ctx, asset, zipData) })
+}
+
+// GetVersionList retrieves available versions for an asset
+func (m *mirrorVault) GetVersionList(ctx context.Context, name string) ([]string, error) {
+	return mirrorRead(ctx, m, func() ([]string, error) { return m.local.GetVersionList(ctx, name) })
+}
+
+// ReadRootFiles reads files from the vault root. See PathVault.ReadRootFiles.
+func (m *mirrorVault) ReadRootFiles(ctx context.Context, names []string) (map[string][]byte, error) {
+	return mirrorRead(ctx, m, func() (map[string][]byte, error) { return m.local.ReadRootFiles(ctx, names) })
+}
+
+// GetMetadata retrieves metadata for a specific asset version
+func (m *mirrorVault) GetMetadata(ctx context.Context, name, version string) (*metadata.Metadata, error) {
+	return mirrorRead(ctx, m, func() (*metadata.Metadata, error) { return m.local.GetMetadata(ctx, name, version) })
+}
+
+// GetAssetByVersion retrieves an asset version as a zip
+func (m *mirrorVault) GetAssetByVersion(ctx context.Context, name, version string) ([]byte, error) {
+	return mirrorRead(ctx, m, func() ([]byte, error) { return m.local.GetAssetByVersion(ctx, name, version) })
+}
+
+// VerifyIntegrity checks hashes and sizes for downloaded assets
+func (m *mirrorVault) VerifyIntegrity(data []byte, hashes map[string]string, size int64) error {
+	return m.local.VerifyIntegrity(data, hashes, size)
+}
+
+// PostUsageStats persists a JSONL batch of usage events to .sx/usage
+func (m *mirrorVault) PostUsageStats(ctx context.Context, jsonlData string) error {
+	events, err := parseUsageJSONL(jsonlData)
+	if err != nil {
+		return err
+	}
+	return m.RecordUsageEvents(ctx, events)
+}
+
+// SetInstallations upserts an asset into the vault's manifest. See
+// PathVault.SetInstallations.
+func (m *mirrorVault) SetInstallations(ctx context.Context, asset *lockfile.Asset, scopeEntity string) error {
+	return m.write(ctx, func() error { return m.local.SetInstallations(ctx, asset, scopeEntity) })
+}
+
+// InheritInstallations upserts the asset into the manifest, keeping any
+// existing entry's scopes.
+func (m *mirrorVault) InheritInstallations(ctx context.Context, asset *lockfile.Asset) error {
+	return m.write(ctx, func() error { return m.local.InheritInstallations(ctx, asset) })
+}
+
+// RemoveAsset removes an asset from the manifest, and its files if delete
+// is set.
+func (m *mirrorVault) RemoveAsset(ctx context.Context, assetName, version string, delete bool) error {
+	return m.write(ctx, func() error { return m.local.RemoveAsset(ctx, assetName, version, delete) })
+}
+
+// RenameAsset renames an asset in the vault.
+func (m *mirrorVault) RenameAsset(ctx context.Context, oldName, newName string) error {
+	return m.write(ctx, func() error { return m.local.RenameAsset(ctx, oldName, newName) })
+}
+
+// ListAssets lists the vault's assets. See listFileVaultAssets.
+func (m *mirrorVault) ListAssets(ctx context.Context, opts ListAssetsOptions) (*ListAssetsResult, error) {
+	return mirrorRead(ctx, m, func() (*ListAssetsResult, error) { return m.local.ListAssets(ctx, opts) })
+}
+
+// GetAssetDetails returns detailed information about a specific asset.
+func (m *mirrorVault) GetAssetDetails(ctx context.Context, name string) (*AssetDetails, error) {
+	return mirrorRead(ctx, m, func() (*AssetDetails, error) { return m.local.GetAssetDetails(ctx, name) })
+}
+
+// GetMCPTools returns the asset-shim registrar, as for PathVault.
+func (m *mirrorVault) GetMCPTools() any {
+	return &AssetShimRegistrar{Repo: m}
+}
+
+// GetBootstrapOptions returns no bootstrap options for mirrored vaults
+func (m *mirrorVault) GetBootstrapOptions(ctx context.Context) []bootstrap.Option {
+	return nil
+}
+
+// CurrentActor reads the caller's git identity, falling back to $USER@host.
+func (m *mirrorVault) CurrentActor(ctx context.Context) (mgmt.Actor, error) {
+	return m.local.CurrentActor(ctx)
+}
@@ -0,0 +1,304 @@
+package vault
+
+import (
+	"context"
+
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/manifest"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/signing"
+)
+
+// The management surface of mirrorVault: every method runs the PathVault
+// implementation against the mirror, inside read or write. Change
+// requests, app plugins and the vault icon are not offered on mirrored
+// vaults.
+
+func (m *mirrorVault) ListTeams(ctx context.Context, opts ListTeamsOptions) (*ListTeamsResult, error) {
+	return mirrorRead(ctx, m, func() (*ListTeamsResult, error) { return m.local.ListTeams(ctx, opts) })
+}
+
+func (m *mirrorVault) GetTeam(ctx context.Context, name string) (*mgmt.Team, error) {
+	return mirrorRead(ctx, m, func() (*mgmt.Team, error) { return m.local.GetTeam(ctx, name) })
+}
+
+func (m *mirrorVault) CreateTeam(ctx context.Context, team mgmt.Team) error {
+	return m.write(ctx, func() error { return m.local.CreateTeam(ctx, team) })
+}
+
+func (m *mirrorVault) UpdateTeam(ctx context.Context, team mgmt.Team) error {
+	return m.write(ctx, func() error { return m.local.UpdateTeam(ctx, team) })
+}
+
+func (m *mirrorVault) DeleteTeam(ctx context.Context, name string) error {
+	return m.write(ctx, func() error { return m.local.DeleteTeam(ctx, name) })
+}
+
+func (m *mirrorVault) AddTeamMember(ctx context.Context, team, email string, admin bool) error {
+	return m.write(ctx, func() error { return m.local.AddTeamMember(ctx, team, email, admin) })
+}
+
+func (m *mirrorVault) RemoveTeamMember(ctx context.Context, team, email string) error {
+	return m.write(ctx, func() error { return m.local.RemoveTeamMember(ctx, team, email) })
+}
+
+func (m *mirrorVault) SetTeamAdmin(ctx context.Context, team, email string, admin bool) error {
+	return m.write(ctx, func() error { return m.local.SetTeamAdmin(ctx, team, email, admin) })
+}
+
+func (m *mirrorVault) AddTeamRepository(ctx context.Context, team, repoURL string) error {
+	return m.write(ctx, func() error { return m.local.AddTeamRepository(ctx, team, repoURL) })
+}
+
+func (m *mirrorVault) RemoveTeamRepository(ctx context.Context, team, repoURL string) error {
+	return m.write(ctx, func() error { return m.local.RemoveTeamRepository(ctx, team, repoURL) })
+}
+
+func (m *mirrorVault) RenameTeam(ctx context.Context, oldName, newName string) error {
+	return m.write(ctx, func() error { return m.local.RenameTeam(ctx, oldName, newName) })
+}
+
+func (m *mirrorVault) ListBots(ctx context.Context) ([]mgmt.Bot, error) {
+	return mirrorRead(ctx, m, func() ([]mgmt.Bot, error) { return m.local.ListBots(ctx) })
+}
+
+func (m *mirrorVault) GetBot(ctx context.Context, name string) (*mgmt.Bot, error) {
+	return mirrorRead(ctx, m, func() (*mgmt.Bot, error) { return m.local.GetBot(ctx, name) })
+}
+
+func (m *mirrorVault) CreateBot(ctx context.Context, bot mgmt.Bot) (string, error) {
+	return mirrorWrite(ctx, m, func() (string, error) { return m.local.CreateBot(ctx, bot) })
+}
+
+func (m *mirrorVault) UpdateBot(ctx context.Context, bot mgmt.Bot) error {
+	return m.write(ctx, func() error { return m.local.UpdateBot(ctx, bot) })
+}
+
+func (m *mirrorVault) DeleteBot(ctx context.Context, name string) error {
+	return m.write(ctx, func() error { return m.local.DeleteBot(ctx, name) })
+}
+
+func (m *mirrorVault) AddBotTeam(ctx context.Context, bot, team string) error {
+	return m.write(ctx, func() error { return m.local.AddBotTeam(ctx, bot, team) })
+}
+
+func (m *mirrorVault) RemoveBotTeam(ctx context.Context, bot, team string) error {
+	return m.write(ctx, func() error { return m.local.RemoveBotTeam(ctx, bot, team) })
+}
+
+func (m *mirrorVault) AddOrgAdmins(ctx context.Context, emails []string) (int, error) {
+	return mirrorWrite(ctx, m, func() (int, error) { return m.local.AddOrgAdmins(ctx, emails) })
+}
+
+func (m *mirrorVault) RemoveOrgAdmin(ctx context.Context, email string) error {
+	return m.write(ctx, func() error { return m.local.RemoveOrgAdmin(ctx, email) })
+}
+
+func (m *mirrorVault) ListOrgAdmins(ctx context.Context) ([]string, error) {
+	return mirrorRead(ctx, m, func() ([]string, error) { return m.local.ListOrgAdmins(ctx) })
+}
+
+func (m *mirrorVault) SetAssetInstallation(ctx context.Context, assetName string, target InstallTarget) error {
+	return m.write(ctx, func() error { return m.local.SetAssetInstallation(ctx, assetName, target) })
+}
+
+func (m *mirrorVault) RemoveAssetInstallation(ctx context.Context, assetName string, target InstallTarget) error {
+	return m.write(ctx, func() error { return m.local.RemoveAssetInstallation(ctx, assetName, target) })
+}
+
+func (m *mirrorVault) ClearAssetInstallations(ctx context.Context, assetName string) error {
+	return m.write(ctx, func() error { return m.local.ClearAssetInstallations(ctx, assetName) })
+}
+
+func (m *mirrorVault) SetAssetInstallations(ctx context.Context, assetName string, targets []InstallTarget, appendMode bool) ([]SkippedTarget, error) {
+	return mirrorWrite(ctx, m, func() ([]SkippedTarget, error) {
+		return m.local.SetAssetInstallations(ctx, assetName, targets, appendMode)
+	})
+}
+
+func (m *mirrorVault) SetPublishedAssetInstallations(ctx context.Context, asset *lockfile.Asset, targets []InstallTarget, appendMode bool) ([]SkippedTarget, error) {
+	return mirrorWrite(ctx, m, func() ([]SkippedTarget, error) {
+		return m.local.SetPublishedAssetInstallations(ctx, asset, targets, appendMode)
+	})
+}
+
+func (m *mirrorVault) UninstallAssetTargets(ctx context.Context, assetName string, targets []InstallTarget) (removed int, failures []string, err error) {
+	err = m.write(ctx, func() error {
+		var err error
+		removed, failures, err = m.local.UninstallAssetTargets(ctx, assetName, targets)
+		return err
+	})
+	return removed, failures, err
+}
+
+func (m *mirrorVault) CurrentInstallTargets(ctx context.Context, name string) (targets []InstallTarget, found bool, err error) {
+	err = m.read(ctx, func() error {
+		var err error
+		targets, found, err = m.local.CurrentInstallTargets(ctx, name)
+		return err
+	})
+	return targets, found, err
+}
+
+func (m *mirrorVault) AssetInstallScopes(ctx context.Context, name string) (scopes []manifest.Scope, found bool, err error) {
+	err = m.read(ctx, func() error {
+		var err error
+		scopes, found, err = m.local.AssetInstallScopes(ctx, name)
+		return err
+	})
+	return scopes, found, err
+}
+
+func (m *mirrorVault) CheckAssetEditPermission(ctx context.Context, name string) error {
+	return m.read(ctx, func() error { return m.local.CheckAssetEditPermission(ctx, name) })
+}
+
+func (m *mirrorVault) RetireAsset(ctx context.Context, assetName string) error {
+	return m.write(ctx, func() error { return m.local.RetireAsset(ctx, assetName) })
+}
+
+func (m *mirrorVault) RecordUsageEvents(ctx context.Context, events []mgmt.UsageEvent) error {
+	return m.write(ctx, func() error { return m.local.RecordUsageEvents(ctx, events) })
+}
+
+func (m *mirrorVault) GetUsageStats(ctx context.Context, filter mgmt.UsageFilter) (*mgmt.UsageSummary, error) {
+	return mirrorRead(ctx, m, func() (*mgmt.UsageSummary, error) { return m.local.GetUsageStats(ctx, filter) })
+}
+
+func (m *mirrorVault) ReadUsageEvents(ctx context.Context, filter mgmt.UsageFilter) ([]mgmt.UsageEvent, error) {
+	return mirrorRead(ctx, m, func() ([]mgmt.UsageEvent, error) { return m.local.ReadUsageEvents(ctx, filter) })
+}
+
+func (m *mirrorVault) QueryAuditEvents(ctx context.Context, filter mgmt.AuditFilter) ([]mgmt.AuditEvent, error) {
+	return mirrorRead(ctx, m, func() ([]mgmt.AuditEvent, error) { return m.local.QueryAuditEvents(ctx, filter) })
+}
+
+func (m *mirrorVault) ImportAuditEvents(ctx context.Context, events []mgmt.AuditEvent) error {
+	return m.write(ctx, func() error { return m.local.ImportAuditEvents(ctx, events) })
+}
+
+func (m *mirrorVault) ListRepoAssets(ctx context.Context) (map[string][]string, error) {
+	return mirrorRead(ctx, m, func() (map[string][]string, error) { return m.local.ListRepoAssets(ctx) })
+}
+
+func (m *mirrorVault) ListTeamAssets(ctx context.Context) (map[string][]string, error) {
+	return mirrorRead(ctx, m, func() (map[string][]string, error) { return m.local.ListTeamAssets(ctx) })
+}
+
+func (m *mirrorVault) ListUserAssets(ctx context.Context) (map[string][]string, error) {
+	return mirrorRead(ctx, m, func() (map[string][]string, error) { return m.local.ListUserAssets(ctx) })
+}
+
+func (m *mirrorVault) ListCollections(ctx context.Context) ([]manifest.Collection, error) {
+	return mirrorRead(ctx, m, func() ([]manifest.Collection, error) { return m.local.ListCollections(ctx) })
+}
+
+func (m *mirrorVault) SaveCollection(ctx context.Context, c manifest.Collection) error {
+	return m.write(ctx, func() error { return m.local.SaveCollection(ctx, c) })
+}
+
+func (m *mirrorVault) DeleteCollection(ctx context.Context, name string) error {
+	return m.write(ctx, func() error { return m.local.DeleteCollection(ctx, name) })
+}
+
+func (m *mirrorVault) RenameCollection(ctx context.Context, oldName, newName string) error {
+	return m.write(ctx, func() error { return m.local.RenameCollection(ctx, oldName, newName) })
+}
+
+func (m *mirrorVault) SetCollectionInstallation(ctx context.Context, name string, target InstallTarget) error {
+	return m.write(ctx, func() error { return m.local.SetCollectionInstallation(ctx, name, target) })
+}
+
+func (m *mirrorVault) RemoveCollectionInstallation(ctx context.Context, name string, target InstallTarget) error {
+	return m.write(ctx, func() error { return m.local.RemoveCollectionInstallation(ctx, name, target) })
+}
+
+func (m *mirrorVault) CurrentCollectionInstallTargets(ctx context.Context, name string) (targets []InstallTarget, found bool, err error) {
+	err = m.read(ctx, func() error {
+		var err error
+		targets, found, err = m.local.CurrentCollectionInstallTargets(ctx, name)
+		return err
+	})
+	return targets, found, err
+}
+
+func (m *mirrorVault) ListChannels(ctx context.Context, assetName string) (*AssetChannels, error) {
+	return mirrorRead(ctx, m, func() (*AssetChannels, error) { return m.local.ListChannels(ctx, assetName) })
+}
+
+func (m *mirrorVault) SetChannel(ctx context.Context, assetName, channel, version string) error {
+	return m.write(ctx, func() error { return m.local.SetChannel(ctx, assetName, channel, version) })
+}
+
+func (m *mirrorVault) PromoteChannel(ctx context.Context, assetName, from, to string) (string, error) {
+	return mirrorWrite(ctx, m, func() (string, error) { return m.local.PromoteChannel(ctx, assetName, from, to) })
+}
+
+func (m *mirrorVault) FollowChannel(ctx context.Context, assetName string, target InstallTarget, channel string) error {
+	return m.write(ctx, func() error { return m.local.FollowChannel(ctx, assetName, target, channel) })
+}
+
+func (m *mirrorVault) GetQuality(ctx context.Context, asset string) (string, error) {
+	return mirrorRead(ctx, m, func() (string, error) { return m.local.GetQuality(ctx, asset) })
+}
+
+func (m *mirrorVault) AddQuality(ctx context.Context, asset, record string) error {
+	return m.write(ctx, func() error { return m.local.AddQuality(ctx, asset, record) })
+}
+
+func (m *mirrorVault) LatestQuality(ctx context.Context) (string, error) {
+	return mirrorRead(ctx, m, func() (string, error) { return m.local.LatestQuality(ctx) })
+}
+
+// ReevaluateQuality on a mirrored vault is the caller's job, as on a path vault.
+func (m *mirrorVault) ReevaluateQuality(ctx context.Context, asset string) (string, error) {
+	return QualityEvalLocal, nil
+}
+
+func (m *mirrorVault) ListBenchmarks(ctx context.Context, asset string) (string, error) {
+	return mirrorRead(ctx, m, func() (string, error) { return m.local.ListBenchmarks(ctx, asset) })
+}
+
+func (m *mirrorVault) AddBenchmark(ctx context.Context, asset, record string) error {
+	return m.write(ctx, func() error { return m.local.AddBenchmark(ctx, asset, record) })
+}
+
+func (m *mirrorVault) LatestBenchmarks(ctx context.Context) (string, error) {
+	return mirrorRead(ctx, m, func() (string, error) { return m.local.LatestBenchmarks(ctx) })
+}
+
+func (m *mirrorVault) SigningConfig(ctx context.Context) (*manifest.Signing, error) {
+	return mirrorRead(ctx, m, func() (*manifest.Signing, error) { return m.local.SigningConfig(ctx) })
+}
+
+func (m *mirrorVault) SetSigningPolicy(ctx context.Context, policy string) error {
+	return m.write(ctx, func() error { return m.local.SetSigningPolicy(ctx, policy) })
+}
+
+func (m *mirrorVault) TrustSigningKey(ctx context.Context, name, publicKey string) error {
+	return m.write(ctx, func() error { return m.local.TrustSigningKey(ctx, name, publicKey) })
+}
+
+func (m *mirrorVault) UntrustSigningKey(ctx context.Context, name string) error {
+	return m.write(ctx, func() error { return m.local.UntrustSigningKey(ctx, name) })
+}
+
+func (m *mirrorVault) AddSignedAsset(ctx context.Context, asset *lockfile.Asset, zipData []byte, key *signing.PrivateKey) (*signing.Signature, error) {
+	return mirrorWrite(ctx, m, func() (*signing.Signature, error) { return m.local.AddSignedAsset(ctx, asset, zipData, key) })
+}
+
+func (m *mirrorVault) SignAsset(ctx context.Context, name, version string, key *signing.PrivateKey) (*signing.Signature, error) {
+	return mirrorWrite(ctx, m, func() (*signing.Signature, error) { return m.local.SignAsset(ctx, name, version, key) })
+}
+
+func (m *mirrorVault) AssetSignature(ctx context.Context, name, version string) ([]byte, error) {
+	return mirrorRead(ctx, m, func() ([]byte, error) { return m.local.AssetSignature(ctx, name, version) })
+}
+
+func (m *mirrorVault) MigrateStorage(ctx context.Context) (*MigrationResult, error) {
+	return mirrorWrite(ctx, m, func() (*MigrationResult, error) { return m.local.MigrateStorage(ctx) })
+}
+
+func (m *mirrorVault) PlanStorageMigration(ctx context.Context) (*MigrationPlan, error) {
+	return mirrorRead(ctx, m, func() (*MigrationPlan, error) { return m.local.PlanStorageMigration(ctx) })
+}
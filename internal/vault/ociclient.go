@@ -0,0 +1,494 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Media types of the artifacts an OCI vault stores
+const (
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	ociEmptyMediaType    = "application/vnd.oci.empty.v1+json"
+
+	ociAssetArtifactType    = "application/vnd.sx.asset.v1"
+	ociAssetConfigMediaType = "application/vnd.sx.asset.config.v1+toml"
+	ociAssetLayerMediaType  = "application/vnd.sx.asset.layer.v1+zip"
+
+	ociVaultArtifactType  = "application/vnd.sx.vault.v1"
+	ociVaultFileMediaType = "application/vnd.sx.vault.file.v1"
+
+	ociTitleAnnotation   = "org.opencontainers.image.title"
+	ociVersionAnnotation = "org.opencontainers.image.version"
+)
+
+// ociEmptyConfig is the OCI empty descriptor's content
+var ociEmptyConfig = []byte("{}")
+
+// errOCINotFound is returned for a missing manifest, blob, or repository
+var errOCINotFound = errors.New("oci object not found")
+
+// errOCIUnsupported is returned when the registry refuses an operation
+// outright, e.g. deletes on a registry with deletion disabled
+var errOCIUnsupported = errors.New("operation not supported by the registry")
+
+var (
+	// ociRepoComponent is one path component of a repository name
+	ociRepoComponent = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*$`)
+	ociTagPattern    = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._-]{0,127}$`)
+)
+
+// ociDescriptor references a blob by digest
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ociManifest is an OCI image manifest used as an artifact manifest
+type ociManifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        ociDescriptor     `json:"config"`
+	Layers        []ociDescriptor   `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// ociLocation is a parsed oci://registry/repository URL
+type ociLocation struct {
+	Scheme     string // "https", or "http" for local and ?insecure=true registries
+	Registry   string // host[:port]
+	Repository string
+}
+
+// parseOCIURL parses an oci:// vault URL. Registries on localhost, and
+// any registry with ?insecure=true, are reached over plain HTTP.
+func parseOCIURL(raw string) (ociLocation, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ociLocation{}, fmt.Errorf("invalid OCI URL: %w", err)
+	}
+	repo := strings.Trim(u.Path, "/")
+	if u.Scheme != "oci" || u.Host == "" || repo == "" {
+		return ociLocation{}, fmt.Errorf("invalid OCI URL %q: expected oci://registry/repository", raw)
+	}
+	for part := range strings.SplitSeq(repo, "/") {
+		if !ociRepoComponent.MatchString(part) {
+			return ociLocation{}, fmt.Errorf("invalid OCI URL %q: %q is not a valid repository name component (lowercase letters, digits, and separators)", raw, part)
+		}
+	}
+	loc := ociLocation{Scheme: "https", Registry: u.Host, Repository: repo}
+	if u.Query().Get("insecure") == "true" || isLoopbackHost(u.Hostname()) {
+		loc.Scheme = "http"
+	}
+	return loc, nil
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// ociClient speaks the OCI distribution API to one registry. It answers
+// 401 challenges itself: Basic with the configured credentials, or Bearer
+// by fetching a token from the challenge's realm.
+type ociClient struct {
+	loc      ociLocation
+	username string
+	password string
+	http     *http.Client
+
+	mu     sync.Mutex
+	basic  bool
+	tokens map[string]string // repository → bearer token
+}
+
+func newOCIClient(loc ociLocation, username, password string) *ociClient {
+	return &ociClient{
+		loc:      loc,
+		username: username,
+		password: password,
+		http:     &http.Client{Timeout: 60 * time.Second},
+		tokens:   map[string]string{},
+	}
+}
+
+// endpoint returns the API URL for path under a repository
+func (c *ociClient) endpoint(repo, path string) string {
+	return fmt.Sprintf("%s://%s/v2/%s/%s", c.loc.Scheme, c.loc.Registry, repo, path)
+}
+
+// send performs a request, authenticating and retrying once on 401
+func (c *ociClient) send(ctx context.Context, method, repo, endpoint string, header http.Header, body []byte) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		req.ContentLength = int64(len(body))
+		c.authorize(req, repo)
+
+		resp, err := c.http.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("registry request failed: %w", err)
+		}
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			return resp, nil
+		}
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+		if err := c.login(ctx, repo, challenge); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *ociClient) authorize(req *http.Request, repo string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if token := c.tokens[repo]; token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else if c.basic {
+		req.SetBasicAuth(c.username, c.password)
+	}
+}
+
+// login answers a WWW-Authenticate challenge
+func (c *ociClient) login(ctx context.Context, repo, challenge string) error {
+	scheme, params := parseAuthChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.username == "" && c.password == "" {
+			return errors.New("registry requires credentials: set the profile's authUsername and authToken")
+		}
+		c.mu.Lock()
+		c.basic = true
+		c.mu.Unlock()
+		return nil
+	case "bearer":
+		token, err := c.fetchToken(ctx, params)
+		if err != nil {
+			return err
+		}
+		c.mu.Lock()
+		c.tokens[repo] = token
+		c.mu.Unlock()
+		return nil
+	default:
+		return fmt.Errorf("registry returned 401 with an unsupported challenge %q", challenge)
+	}
+}
+
+// fetchToken gets a bearer token from a token service (the Docker
+// registry token protocol), authenticating with the credentials if set
+func (c *ociClient) fetchToken(ctx context.Context, params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme == "" {
+		return "", fmt.Errorf("registry token challenge has an invalid realm %q", params["realm"])
+	}
+	q := realm.Query()
+	if service := params["service"]; service != "" {
+		q.Set("service", service)
+	}
+	if scope := params["scope"]; scope != "" {
+		q.Set("scope", scope)
+	}
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch registry token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("registry token request failed: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	var out struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("failed to parse registry token: %w", err)
+	}
+	if out.Token == "" {
+		out.Token = out.AccessToken
+	}
+	if out.Token == "" {
+		return "", errors.New("registry token service returned no token")
+	}
+	return out.Token, nil
+}
+
+// parseAuthChallenge splits `Bearer realm="…",service="…",scope="…"`
+// into its scheme and parameters
+func parseAuthChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := map[string]string{}
+	for rest = strings.TrimSpace(rest); rest != ""; {
+		key, after, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		var value string
+		if strings.HasPrefix(after, `"`) {
+			end := strings.Index(after[1:], `"`)
+			if end < 0 {
+				break
+			}
+			value, rest = after[1:end+1], after[end+2:]
+		} else {
+			value, rest, _ = strings.Cut(after, ",")
+		}
+		params[key] = strings.TrimSpace(value)
+		rest = strings.TrimLeft(rest, ", ")
+	}
+	return scheme, params
+}
+
+// ociResponseError turns a failed response into an error, reading the
+// registry's error body
+func ociResponseError(resp *http.Response) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return errOCINotFound
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var out struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(body, &out) == nil && len(out.Errors) > 0 {
+		e := out.Errors[0]
+		if e.Code == "UNSUPPORTED" || resp.StatusCode == http.StatusMethodNotAllowed {
+			return fmt.Errorf("%w: %s", errOCIUnsupported, e.Message)
+		}
+		return fmt.Errorf("registry error %s: %s", e.Code, e.Message)
+	}
+	if resp.StatusCode == http.StatusMethodNotAllowed {
+		return errOCIUnsupported
+	}
+	return fmt.Errorf("registry returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// ociDigest returns the sha256 digest of data in OCI form
+func ociDigest(data []byte) string {
+	return "sha256:" + sha256Hex(data)
+}
+
+// getManifest fetches a manifest by tag or digest. The returned digest
+// is computed from the bytes received, and must match ref when ref is a
+// digest.
+func (c *ociClient) getManifest(ctx context.Context, repo, ref string) (*ociManifest, string, error) {
+	header := http.Header{"Accept": {ociManifestMediaType}}
+	resp, err := c.send(ctx, http.MethodGet, repo, c.endpoint(repo, "manifests/"+ref), header, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", ociResponseError(resp)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read manifest: %w", err)
+	}
+	digest := ociDigest(data)
+	if strings.HasPrefix(ref, "sha256:") && ref != digest {
+		return nil, "", fmt.Errorf("manifest %s@%s failed digest verification (got %s)", repo, ref, digest)
+	}
+	var m ociManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, "", fmt.Errorf("failed to parse manifest %s:%s: %w", repo, ref, err)
+	}
+	return &m, digest, nil
+}
+
+// headManifest returns a manifest's digest without downloading it
+func (c *ociClient) headManifest(ctx context.Context, repo, ref string) (string, error) {
+	header := http.Header{"Accept": {ociManifestMediaType}}
+	resp, err := c.send(ctx, http.MethodHead, repo, c.endpoint(repo, "manifests/"+ref), header, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", ociResponseError(resp)
+	}
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+	_, digest, err := c.getManifest(ctx, repo, ref)
+	return digest, err
+}
+
+// putManifest uploads a manifest under a tag and returns its digest
+func (c *ociClient) putManifest(ctx context.Context, repo, tag string, m *ociManifest) (string, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	header := http.Header{"Content-Type": {ociManifestMediaType}}
+	resp, err := c.send(ctx, http.MethodPut, repo, c.endpoint(repo, "manifests/"+tag), header, data)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", ociResponseError(resp)
+	}
+	return ociDigest(data), nil
+}
+
+// deleteManifest deletes a manifest by digest, which removes its tags.
+// A manifest that's already gone is not an error.
+func (c *ociClient) deleteManifest(ctx context.Context, repo, digest string) error {
+	resp, err := c.send(ctx, http.MethodDelete, repo, c.endpoint(repo, "manifests/"+digest), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusAccepted, http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	}
+	return ociResponseError(resp)
+}
+
+// getBlob downloads a blob and verifies it against its digest
+func (c *ociClient) getBlob(ctx context.Context, repo, digest string) ([]byte, error) {
+	resp, err := c.send(ctx, http.MethodGet, repo, c.endpoint(repo, "blobs/"+digest), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, ociResponseError(resp)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	if got := ociDigest(data); got != digest {
+		return nil, fmt.Errorf("blob %s@%s failed digest verification (got %s)", repo, digest, got)
+	}
+	return data, nil
+}
+
+// pushBlob uploads data unless the repository already has it, and
+// returns its descriptor (without media type)
+func (c *ociClient) pushBlob(ctx context.Context, repo string, data []byte) (ociDescriptor, error) {
+	desc := ociDescriptor{Digest: ociDigest(data), Size: int64(len(data))}
+
+	resp, err := c.send(ctx, http.MethodHead, repo, c.endpoint(repo, "blobs/"+desc.Digest), nil, nil)
+	if err != nil {
+		return desc, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return desc, nil
+	}
+
+	resp, err = c.send(ctx, http.MethodPost, repo, c.endpoint(repo, "blobs/uploads/"), nil, nil)
+	if err != nil {
+		return desc, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return desc, ociResponseError(resp)
+	}
+	location, err := resp.Location()
+	if err != nil {
+		return desc, fmt.Errorf("registry upload response has no Location: %w", err)
+	}
+	q := location.Query()
+	q.Set("digest", desc.Digest)
+	location.RawQuery = q.Encode()
+
+	header := http.Header{"Content-Type": {"application/octet-stream"}}
+	put, err := c.send(ctx, http.MethodPut, repo, location.String(), header, data)
+	if err != nil {
+		return desc, err
+	}
+	defer put.Body.Close()
+	if put.StatusCode != http.StatusCreated {
+		return desc, ociResponseError(put)
+	}
+	return desc, nil
+}
+
+// listTags lists a repository's tags, following pagination. A missing
+// repository has no tags.
+func (c *ociClient) listTags(ctx context.Context, repo string) ([]string, error) {
+	var tags []string
+	endpoint := c.endpoint(repo, "tags/list")
+	for endpoint != "" {
+		resp, err := c.send(ctx, http.MethodGet, repo, endpoint, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			_ = resp.Body.Close()
+			return nil, nil
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := ociResponseError(resp)
+			_ = resp.Body.Close()
+			return nil, err
+		}
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse tag list: %w", err)
+		}
+		tags = append(tags, page.Tags...)
+		endpoint = nextLink(resp)
+	}
+	return tags, nil
+}
+
+// nextLink returns the absolute URL of a response's rel="next" Link
+func nextLink(resp *http.Response) string {
+	for link := range strings.SplitSeq(resp.Header.Get("Link"), ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+		if !ok || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+		ref, err := url.Parse(strings.Trim(strings.TrimSpace(target), "<>"))
+		if err != nil {
+			return ""
+		}
+		return resp.Request.URL.ResolveReference(ref).String()
+	}
+	return ""
+}
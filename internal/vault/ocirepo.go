@@ -0,0 +1,698 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/cache"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/logger"
+	"github.com/sleuth-io/sx/v2/internal/metadata"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+	"github.com/sleuth-io/sx/v2/internal/version"
+)
+
+const (
+	// ociVaultTag tags the vault artifact: sx.toml and the rest of the
+	// vault's state, one layer per file
+	ociVaultTag = "sx.toml"
+
+	ociStateFile = "oci-state.json"
+)
+
+// OCIVault implements Vault on an OCI registry (distribution, Harbor,
+// GHCR, ECR, Artifact Registry). Each stored asset version is an artifact
+// in its own repository — {repository}/{asset}:{version}, with
+// metadata.toml as the config blob and the version's zip as the single
+// layer — so the registry's tag listing is the version list. Everything
+// else a path vault holds (sx.toml, the latest-version views, .sx/ logs)
+// is the vault artifact, tagged sx.toml in {repository}.
+//
+// All vault logic runs on a local mirror (see mirrorVault). Every blob and
+// manifest pulled into it is checked against its registry digest, so
+// integrity rests on the registry's content addressing rather than on
+// hashes in the lock file. Reads fetch a version's artifact only when that
+// version is resolved or installed, and serve it as the layer the registry
+// digest names.
+//
+// Registries have no conditional PUT: a write re-checks the vault
+// artifact's digest just before retagging it and replays on a mismatch,
+// which narrows but cannot fully close the window for concurrent writers.
+type OCIVault struct {
+	*mirrorVault
+	repoURL string
+	remote  *ociRemote
+}
+
+// ociRemote syncs a mirror tree with a registry repository
+type ociRemote struct {
+	client    *ociClient
+	mirrorDir string
+	treeDir   string
+}
+
+// ociMirrorState records what the mirror was pulled from
+type ociMirrorState struct {
+	// Manifest is the digest of the vault artifact last pulled or pushed
+	Manifest string `json:"manifest"`
+	// Files maps each vault artifact file to its layer digest
+	Files map[string]string `json:"files"`
+	// Versions maps "{name}/{version}" to its asset artifact digest
+	Versions map[string]string `json:"versions"`
+	// Layers maps "{name}/{version}" to the digest of its zip layer, kept
+	// in the mirror's blob store
+	Layers map[string]string `json:"layers"`
+}
+
+// NewOCIVault creates a vault for an oci://registry/repository URL.
+// Credentials fall back to SX_OCI_USERNAME / SX_OCI_PASSWORD; with none,
+// the registry is accessed anonymously.
+func NewOCIVault(repoURL, username, password string) (*OCIVault, error) {
+	loc, err := parseOCIURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	if username == "" && password == "" {
+		username, password = os.Getenv("SX_OCI_USERNAME"), os.Getenv("SX_OCI_PASSWORD")
+	}
+
+	mirrorDir, err := cache.GetOCIVaultCachePath(repoURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache path: %w", err)
+	}
+	mirror, err := newMirrorVault(mirrorDir)
+	if err != nil {
+		return nil, err
+	}
+	remote := &ociRemote{
+		client:    newOCIClient(loc, username, password),
+		mirrorDir: mirrorDir,
+		treeDir:   mirror.treeDir,
+	}
+	mirror.remote = remote
+	return &OCIVault{mirrorVault: mirror, repoURL: repoURL, remote: remote}, nil
+}
+
+// GetVersionList lists the asset repository's tags, straight from the
+// registry
+func (o *OCIVault) GetVersionList(ctx context.Context, name string) ([]string, error) {
+	repo, err := o.remote.assetRepo(name)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := o.remote.client.listTags(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list versions of %s: %w", name, err)
+	}
+	return version.Sort(tags), nil
+}
+
+// GetAsset serves a version stored in the vault as its registry layer,
+// checked against the layer digest. Other sources go through the mirror.
+func (o *OCIVault) GetAsset(ctx context.Context, asset *lockfile.Asset) ([]byte, error) {
+	return mirrorRead(ctx, o.mirrorVault, func() ([]byte, error) {
+		if asset.GetSourceType() == "path" {
+			if data, ok, err := o.remote.versionBlob(ctx, asset.Name, asset.Version); ok || err != nil {
+				return data, err
+			}
+		}
+		return o.local.GetAsset(ctx, asset)
+	})
+}
+
+// GetAssetByVersion retrieves a stored version as its registry layer
+func (o *OCIVault) GetAssetByVersion(ctx context.Context, name, version string) ([]byte, error) {
+	return mirrorRead(ctx, o.mirrorVault, func() ([]byte, error) {
+		if data, ok, err := o.remote.versionBlob(ctx, name, version); ok || err != nil {
+			return data, err
+		}
+		return o.local.GetAssetByVersion(ctx, name, version)
+	})
+}
+
+// GetMetadata retrieves metadata for a specific asset version, fetching
+// the version first
+func (o *OCIVault) GetMetadata(ctx context.Context, name, version string) (*metadata.Metadata, error) {
+	return mirrorRead(ctx, o.mirrorVault, func() (*metadata.Metadata, error) {
+		if err := o.remote.ensureVersion(ctx, name, version); err != nil {
+			return nil, err
+		}
+		return o.local.GetMetadata(ctx, name, version)
+	})
+}
+
+// LockAssetVersion describes a stored version, fetching it first
+func (o *OCIVault) LockAssetVersion(ctx context.Context, name, version string) (*lockfile.Asset, error) {
+	return mirrorRead(ctx, o.mirrorVault, func() (*lockfile.Asset, error) {
+		if err := o.remote.ensureVersion(ctx, name, version); err != nil {
+			return nil, err
+		}
+		return o.local.LockAssetVersion(ctx, name, version)
+	})
+}
+
+// VerifyIntegrity checks data against the registry rather than the lock
+// file: it must be the exact zip layer of a version artifact pulled into
+// the mirror.
+func (o *OCIVault) VerifyIntegrity(data []byte, hashes map[string]string, size int64) error {
+	state, err := o.remote.loadState()
+	if err != nil {
+		return err
+	}
+	digest := ociDigest(data)
+	for _, layer := range state.Layers {
+		if layer == digest {
+			return nil
+		}
+	}
+	return fmt.Errorf("content digest %s matches no asset artifact in the registry", digest)
+}
+
+// assetRepo is the repository holding an asset's version artifacts
+func (r *ociRemote) assetRepo(name string) (string, error) {
+	if !ociRepoComponent.MatchString(name) {
+		return "", fmt.Errorf("asset name %q can't be stored in an OCI vault: repository names allow lowercase letters, digits, and separators", name)
+	}
+	return r.client.loc.Repository + "/" + name, nil
+}
+
+// ociVersionOf reports whether a mirror path lies inside a stored
+// version's directory, .sx/versions/{name}/{version}/…, which is carried
+// by that version's artifact rather than the vault artifact
+func ociVersionOf(rel string) (name, ver string, ok bool) {
+	parts := strings.SplitN(rel, "/", 5)
+	if len(parts) < 5 || parts[0] != ".sx" || parts[1] != "versions" {
+		return "", "", false
+	}
+	return parts[2], parts[3], true
+}
+
+func (r *ociRemote) versionDir(name, ver string) string {
+	return filepath.Join(r.treeDir, ".sx", "versions", name, ver)
+}
+
+func (r *ociRemote) manifestTag(ctx context.Context) (string, error) {
+	digest, err := r.client.headManifest(ctx, r.client.loc.Repository, ociVaultTag)
+	if errors.Is(err, errOCINotFound) {
+		return "", ErrLockFileNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check vault manifest: %w", err)
+	}
+	return digest, nil
+}
+
+func (r *ociRemote) pulledManifestTag() (string, error) {
+	state, err := r.loadState()
+	if err != nil {
+		return "", err
+	}
+	return state.Manifest, nil
+}
+
+// pull brings the mirror up to date: vault artifact files whose digest
+// changed are downloaded, and versions no list.txt names any more are
+// dropped. Reads fetch versions one at a time (see ensureVersion); with
+// verify set, before a write, every listed version that isn't materialized
+// yet is extracted from its artifact, so vault-wide edits see all of them,
+// and local edits are discarded. Versions are immutable, so each is
+// downloaded once.
+func (r *ociRemote) pull(ctx context.Context, verify bool) error {
+	state, err := r.loadState()
+	if err != nil {
+		return err
+	}
+
+	remote := map[string]string{}
+	m, digest, err := r.client.getManifest(ctx, r.client.loc.Repository, ociVaultTag)
+	switch {
+	case errors.Is(err, errOCINotFound):
+		digest = ""
+	case err != nil:
+		return fmt.Errorf("failed to fetch vault manifest: %w", err)
+	default:
+		for _, layer := range m.Layers {
+			rel := layer.Annotations[ociTitleAnnotation]
+			if _, _, inVersion := ociVersionOf(rel); rel == "" || inVersion || isMirrorLocal(rel) || !filepath.IsLocal(filepath.FromSlash(rel)) {
+				continue
+			}
+			remote[rel] = layer.Digest
+		}
+	}
+
+	for rel, layerDigest := range remote {
+		fresh := state.Files[rel] == layerDigest
+		if fresh && verify {
+			sum, err := hashFile(filepath.Join(r.treeDir, filepath.FromSlash(rel)))
+			fresh = err == nil && "sha256:"+sum == layerDigest
+		}
+		if fresh {
+			continue
+		}
+		data, err := r.client.getBlob(ctx, r.client.loc.Repository, layerDigest)
+		if err != nil {
+			return fmt.Errorf("failed to download %s: %w", rel, err)
+		}
+		if err := writeMirrorFile(r.treeDir, rel, data); err != nil {
+			return err
+		}
+		state.Files[rel] = layerDigest
+	}
+	for rel := range state.Files {
+		if _, ok := remote[rel]; !ok {
+			if err := removeMirrorFile(r.treeDir, rel); err != nil {
+				return err
+			}
+			delete(state.Files, rel)
+		}
+	}
+	local, err := mirrorFiles(r.treeDir)
+	if err != nil {
+		return err
+	}
+	if verify {
+		for rel := range local {
+			if _, _, inVersion := ociVersionOf(rel); !inVersion && state.Files[rel] == "" {
+				if err := removeMirrorFile(r.treeDir, rel); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	state.Manifest = digest
+
+	listed, err := r.listedVersions()
+	if err != nil {
+		return err
+	}
+	for key := range listed {
+		if !verify {
+			break
+		}
+		name, ver, _ := strings.Cut(key, "/")
+		if r.materialized(state, name, ver) {
+			continue
+		}
+		err := r.fetchVersion(ctx, state, name, ver)
+		if errors.Is(err, errOCINotFound) {
+			logger.Get().Warn("version listed in OCI vault has no artifact", "asset", name, "version", ver)
+			continue
+		}
+		if err != nil {
+			_ = r.saveState(state)
+			return err
+		}
+	}
+	// Drop versions the vault no longer lists, and on verify any a failed
+	// write left behind.
+	for key := range state.Versions {
+		if !listed[key] {
+			r.forgetVersion(state, key)
+		}
+	}
+	for key := range r.localVersions(local) {
+		if !listed[key] {
+			name, ver, _ := strings.Cut(key, "/")
+			if err := os.RemoveAll(r.versionDir(name, ver)); err != nil {
+				return err
+			}
+		}
+	}
+	return r.saveState(state)
+}
+
+// ensureVersion fetches one listed version into the mirror unless it is
+// already there. Versions the vault doesn't list are left for the caller
+// to report missing. The caller must hold the mirror lock.
+func (r *ociRemote) ensureVersion(ctx context.Context, name, ver string) error {
+	state, err := r.loadState()
+	if err != nil {
+		return err
+	}
+	if r.materialized(state, name, ver) {
+		return nil
+	}
+	listed, err := r.listedVersions()
+	if err != nil {
+		return err
+	}
+	if !listed[path.Join(name, ver)] {
+		return nil
+	}
+	if err := r.fetchVersion(ctx, state, name, ver); err != nil {
+		if errors.Is(err, errOCINotFound) {
+			return fmt.Errorf("%w: %s@%s has no artifact in the registry", ErrAssetNotFound, name, ver)
+		}
+		return err
+	}
+	return r.saveState(state)
+}
+
+// versionBlob returns a listed version's zip layer, fetching it if needed
+// and re-checking the stored copy against the layer digest. ok is false
+// when the vault doesn't list the version.
+func (r *ociRemote) versionBlob(ctx context.Context, name, ver string) (data []byte, ok bool, err error) {
+	if err := r.ensureVersion(ctx, name, ver); err != nil {
+		return nil, false, err
+	}
+	state, err := r.loadState()
+	if err != nil {
+		return nil, false, err
+	}
+	layer, ok := state.Layers[path.Join(name, ver)]
+	if !ok {
+		return nil, false, nil
+	}
+	data, err = os.ReadFile(r.blobPath(layer))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s@%s from the mirror: %w", name, ver, err)
+	}
+	if got := ociDigest(data); got != layer {
+		return nil, false, fmt.Errorf("%s@%s in the mirror is %s, but the registry digest is %s", name, ver, got, layer)
+	}
+	return data, true, nil
+}
+
+// materialized reports whether a version's tree and layer are both in the
+// mirror
+func (r *ociRemote) materialized(state *ociMirrorState, name, ver string) bool {
+	layer, ok := state.Layers[path.Join(name, ver)]
+	return ok && utils.IsDirectory(r.versionDir(name, ver)) && utils.FileExists(r.blobPath(layer))
+}
+
+// fetchVersion extracts a version's artifact into the mirror, keeps its
+// verified layer in the blob store, and records both digests in state
+func (r *ociRemote) fetchVersion(ctx context.Context, state *ociMirrorState, name, ver string) error {
+	repo, err := r.assetRepo(name)
+	if err != nil {
+		return err
+	}
+	m, digest, err := r.client.getManifest(ctx, repo, ver)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(m.Layers, func(l ociDescriptor) bool { return l.MediaType == ociAssetLayerMediaType })
+	if i < 0 {
+		return fmt.Errorf("%s:%s is not an sx asset artifact", repo, ver)
+	}
+	layer := m.Layers[i].Digest
+	zipData, err := r.client.getBlob(ctx, repo, layer)
+	if err != nil {
+		return fmt.Errorf("failed to download %s@%s: %w", name, ver, err)
+	}
+	dir := r.versionDir(name, ver)
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := utils.ExtractZip(zipData, dir); err != nil {
+		return fmt.Errorf("failed to extract %s@%s: %w", name, ver, err)
+	}
+	if err := r.storeBlob(layer, zipData); err != nil {
+		return err
+	}
+	key := path.Join(name, ver)
+	state.Versions[key], state.Layers[key] = digest, layer
+	return nil
+}
+
+// blobPath is where the mirror keeps a version's zip layer, outside the
+// tree so it is never uploaded as a vault file
+func (r *ociRemote) blobPath(digest string) string {
+	return filepath.Join(r.mirrorDir, "blobs", strings.ReplaceAll(digest, ":", "-"))
+}
+
+func (r *ociRemote) storeBlob(digest string, data []byte) error {
+	if err := os.MkdirAll(filepath.Join(r.mirrorDir, "blobs"), 0755); err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(r.blobPath(digest), data, 0644)
+}
+
+// forgetVersion drops a version from state along with its stored layer
+func (r *ociRemote) forgetVersion(state *ociMirrorState, key string) {
+	if layer, ok := state.Layers[key]; ok {
+		_ = os.Remove(r.blobPath(layer))
+	}
+	delete(state.Versions, key)
+	delete(state.Layers, key)
+}
+
+// push uploads new versions as artifacts, then retags the vault artifact
+// if any of its files changed, then deletes the artifacts of removed
+// versions — so the vault never lists a version whose artifact is missing.
+func (r *ociRemote) push(ctx context.Context) error {
+	state, err := r.loadState()
+	if err != nil {
+		return err
+	}
+	if err := r.checkUnchanged(ctx, state); err != nil {
+		return err
+	}
+	local, err := mirrorFiles(r.treeDir)
+	if err != nil {
+		return err
+	}
+
+	versions := r.localVersions(local)
+	for _, key := range slices.Sorted(maps.Keys(versions)) {
+		if _, ok := state.Versions[key]; ok {
+			continue
+		}
+		name, ver, _ := strings.Cut(key, "/")
+		digest, layer, err := r.pushVersion(ctx, name, ver)
+		if err != nil {
+			_ = r.saveState(state)
+			return err
+		}
+		state.Versions[key], state.Layers[key] = digest, layer
+	}
+
+	files := map[string]string{}
+	for rel, sum := range local {
+		if _, _, inVersion := ociVersionOf(rel); !inVersion {
+			files[rel] = "sha256:" + sum
+		}
+	}
+	if !maps.Equal(files, state.Files) {
+		digest, err := r.pushVaultArtifact(ctx, state, files)
+		if err != nil {
+			_ = r.saveState(state)
+			return err
+		}
+		state.Manifest, state.Files = digest, files
+	}
+
+	for key, digest := range state.Versions {
+		if versions[key] {
+			continue
+		}
+		name, _, _ := strings.Cut(key, "/")
+		repo, err := r.assetRepo(name)
+		if err == nil {
+			err = r.client.deleteManifest(ctx, repo, digest)
+		}
+		if errors.Is(err, errOCIUnsupported) {
+			logger.Get().Warn("registry does not allow deletes; the removed version's tag stays until an admin deletes it", "version", key)
+		} else if err != nil {
+			_ = r.saveState(state)
+			return fmt.Errorf("failed to delete %s: %w", key, err)
+		}
+		r.forgetVersion(state, key)
+	}
+	return r.saveState(state)
+}
+
+// checkUnchanged returns errMirrorConflict if the vault artifact moved
+// since it was pulled
+func (r *ociRemote) checkUnchanged(ctx context.Context, state *ociMirrorState) error {
+	current, err := r.client.headManifest(ctx, r.client.loc.Repository, ociVaultTag)
+	if errors.Is(err, errOCINotFound) {
+		current, err = "", nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check vault manifest: %w", err)
+	}
+	if current != state.Manifest {
+		return fmt.Errorf("%w: vault artifact is now %s", errMirrorConflict, current)
+	}
+	return nil
+}
+
+// pushVersion uploads one stored version as an asset artifact, keeping
+// its layer in the blob store, and returns the artifact and layer digests
+func (r *ociRemote) pushVersion(ctx context.Context, name, ver string) (digest, layerDigest string, err error) {
+	repo, err := r.assetRepo(name)
+	if err != nil {
+		return "", "", err
+	}
+	if !ociTagPattern.MatchString(ver) {
+		return "", "", fmt.Errorf("version %q of %s can't be an OCI tag: tags allow letters, digits, '.', '_' and '-'", ver, name)
+	}
+	dir := r.versionDir(name, ver)
+	zipData, err := utils.CreateZip(dir)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to package %s@%s: %w", name, ver, err)
+	}
+	config, configType := ociEmptyConfig, ociEmptyMediaType
+	if meta, err := os.ReadFile(filepath.Join(dir, "metadata.toml")); err == nil {
+		config, configType = meta, ociAssetConfigMediaType
+	}
+
+	configDesc, err := r.client.pushBlob(ctx, repo, config)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to upload %s@%s: %w", name, ver, err)
+	}
+	configDesc.MediaType = configType
+	layer, err := r.client.pushBlob(ctx, repo, zipData)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to upload %s@%s: %w", name, ver, err)
+	}
+	if err := r.storeBlob(layer.Digest, zipData); err != nil {
+		return "", "", err
+	}
+	layer.MediaType = ociAssetLayerMediaType
+	layer.Annotations = map[string]string{ociTitleAnnotation: name + "-" + ver + ".zip"}
+
+	digest, err = r.client.putManifest(ctx, repo, ver, &ociManifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		ArtifactType:  ociAssetArtifactType,
+		Config:        configDesc,
+		Layers:        []ociDescriptor{layer},
+		Annotations:   map[string]string{ociTitleAnnotation: name, ociVersionAnnotation: ver},
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to tag %s@%s: %w", name, ver, err)
+	}
+	return digest, layer.Digest, nil
+}
+
+// pushVaultArtifact uploads changed vault files and retags the vault
+// artifact, re-checking for a concurrent writer just before the retag
+func (r *ociRemote) pushVaultArtifact(ctx context.Context, state *ociMirrorState, files map[string]string) (string, error) {
+	repo := r.client.loc.Repository
+	configDesc, err := r.client.pushBlob(ctx, repo, ociEmptyConfig)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload vault config: %w", err)
+	}
+	configDesc.MediaType = ociEmptyMediaType
+
+	var layers []ociDescriptor
+	for _, rel := range slices.Sorted(maps.Keys(files)) {
+		data, err := os.ReadFile(filepath.Join(r.treeDir, filepath.FromSlash(rel)))
+		if err != nil {
+			return "", err
+		}
+		layer, err := r.client.pushBlob(ctx, repo, data)
+		if err != nil {
+			return "", fmt.Errorf("failed to upload %s: %w", rel, err)
+		}
+		layer.MediaType = ociVaultFileMediaType
+		layer.Annotations = map[string]string{ociTitleAnnotation: rel}
+		layers = append(layers, layer)
+	}
+
+	if err := r.checkUnchanged(ctx, state); err != nil {
+		return "", err
+	}
+	digest, err := r.client.putManifest(ctx, repo, ociVaultTag, &ociManifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		ArtifactType:  ociVaultArtifactType,
+		Config:        configDesc,
+		Layers:        layers,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to tag vault manifest: %w", err)
+	}
+	return digest, nil
+}
+
+// listedVersions reads every .sx/versions/*/list.txt in the mirror
+func (r *ociRemote) listedVersions() (map[string]bool, error) {
+	lists, err := filepath.Glob(filepath.Join(r.treeDir, ".sx", "versions", "*", "list.txt"))
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]bool{}
+	for _, list := range lists {
+		versions, err := readVersionListFile(list)
+		if err != nil {
+			return nil, err
+		}
+		name := filepath.Base(filepath.Dir(list))
+		for _, ver := range versions {
+			out[path.Join(name, ver)] = true
+		}
+	}
+	return out, nil
+}
+
+// localVersions returns the "{name}/{version}" keys of the version
+// directories among the mirror's files
+func (r *ociRemote) localVersions(local map[string]string) map[string]bool {
+	out := map[string]bool{}
+	for rel := range local {
+		if name, ver, ok := ociVersionOf(rel); ok {
+			out[name+"/"+ver] = true
+		}
+	}
+	return out
+}
+
+func (r *ociRemote) loadState() (*ociMirrorState, error) {
+	state := &ociMirrorState{}
+	data, err := os.ReadFile(filepath.Join(r.mirrorDir, ociStateFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read OCI mirror state: %w", err)
+	default:
+		if err := json.Unmarshal(data, state); err != nil {
+			// A corrupt state file only costs a full re-download.
+			logger.Get().Warn("discarding corrupt OCI mirror state", "error", err)
+			state = &ociMirrorState{}
+		}
+	}
+	if state.Files == nil {
+		state.Files = map[string]string{}
+	}
+	if state.Versions == nil {
+		state.Versions = map[string]string{}
+	}
+	if state.Layers == nil {
+		state.Layers = map[string]string{}
+	}
+	return state, nil
+}
+
+func (r *ociRemote) saveState(state *ociMirrorState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(filepath.Join(r.mirrorDir, ociStateFile), data, 0644)
+}
+
+var (
+	_ Vault               = (*OCIVault)(nil)
+	_ QualityStore        = (*OCIVault)(nil)
+	_ BenchmarkStore      = (*OCIVault)(nil)
+	_ SigningStore        = (*OCIVault)(nil)
+	_ ChannelStore        = (*OCIVault)(nil)
+	_ CollectionStore     = (*OCIVault)(nil)
+	_ RepoAssetLister     = (*OCIVault)(nil)
+	_ TeamAssetLister     = (*OCIVault)(nil)
+	_ UserAssetLister     = (*OCIVault)(nil)
+	_ TeamRenamer         = (*OCIVault)(nil)
+	_ CollectionRenamer   = (*OCIVault)(nil)
+	_ CollectionInstaller = (*OCIVault)(nil)
+)
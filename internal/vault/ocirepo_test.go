@@ -0,0 +1,352 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// fakeRegistry is an in-process OCI distribution registry: token auth,
+// monolithic blob uploads, manifests by tag or digest, paginated tag
+// lists, and deletes. beforeUpload, if set, runs (outside the lock) before
+// each blob upload completes.
+type fakeRegistry struct {
+	srv          *httptest.Server
+	beforeUpload func(repo string)
+
+	mu        sync.Mutex
+	blobs     map[string][]byte            // digest → content
+	manifests map[string]map[string]string // repo → tag or digest → digest
+	uploads   int
+}
+
+func newFakeRegistry(t *testing.T) *fakeRegistry {
+	t.Helper()
+	f := &fakeRegistry{blobs: map[string][]byte{}, manifests: map[string]map[string]string{}}
+	f.srv = httptest.NewServer(f)
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func (f *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/token" {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "alice" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "tok-" + r.URL.Query().Get("scope")})
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, "/v2/")
+	var repo, kind, ref string
+	for _, k := range []string{"/manifests/", "/blobs/uploads/", "/blobs/", "/tags/list"} {
+		if i := strings.LastIndex(path, k); i >= 0 {
+			repo, kind, ref = path[:i], strings.Trim(k, "/"), path[i+len(k):]
+			break
+		}
+	}
+	if want := "Bearer tok-repository:" + repo + ":pull,push"; r.Header.Get("Authorization") != want {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake",scope="repository:%s:pull,push"`, f.srv.URL, repo))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if kind == "blobs/uploads" && r.Method == http.MethodPut && f.beforeUpload != nil {
+		f.beforeUpload(repo)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	switch {
+	case kind == "blobs/uploads" && r.Method == http.MethodPost:
+		w.Header().Set("Location", "/v2/"+repo+"/blobs/uploads/session")
+		w.WriteHeader(http.StatusAccepted)
+	case kind == "blobs/uploads" && r.Method == http.MethodPut:
+		digest := r.URL.Query().Get("digest")
+		if ociDigest(body) != digest {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.blobs[digest] = body
+		f.uploads++
+		w.WriteHeader(http.StatusCreated)
+	case kind == "blobs":
+		data, ok := f.blobs[ref]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	case kind == "manifests" && r.Method == http.MethodPut:
+		digest := ociDigest(body)
+		f.blobs[digest] = body
+		if f.manifests[repo] == nil {
+			f.manifests[repo] = map[string]string{}
+		}
+		f.manifests[repo][ref] = digest
+		f.manifests[repo][digest] = digest
+		w.WriteHeader(http.StatusCreated)
+	case kind == "manifests" && r.Method == http.MethodDelete:
+		for k, d := range f.manifests[repo] {
+			if d == ref {
+				delete(f.manifests[repo], k)
+			}
+		}
+		w.WriteHeader(http.StatusAccepted)
+	case kind == "manifests":
+		digest, ok := f.manifests[repo][ref]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.Header().Set("Content-Type", ociManifestMediaType)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(f.blobs[digest])
+		}
+	case kind == "tags/list":
+		f.listTags(w, r, repo)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// listTags serves two tags per page, to exercise Link pagination
+func (f *fakeRegistry) listTags(w http.ResponseWriter, r *http.Request, repo string) {
+	var tags []string
+	for k := range f.manifests[repo] {
+		if !strings.HasPrefix(k, "sha256:") && k > r.URL.Query().Get("last") {
+			tags = append(tags, k)
+		}
+	}
+	if len(tags) == 0 && f.manifests[repo] == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	sort.Strings(tags)
+	if len(tags) > 2 {
+		tags = tags[:2]
+		w.Header().Set("Link", fmt.Sprintf(`</v2/%s/tags/list?n=2&last=%s>; rel="next"`, repo, url.QueryEscape(tags[1])))
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"name": repo, "tags": tags})
+}
+
+func (f *fakeRegistry) manifest(t *testing.T, repo, ref string) *ociManifest {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	digest, ok := f.manifests[repo][ref]
+	if !ok {
+		return nil
+	}
+	var m ociManifest
+	if err := json.Unmarshal(f.blobs[digest], &m); err != nil {
+		t.Fatal(err)
+	}
+	return &m
+}
+
+// newTestOCIVault opens the fake registry with its own mirror directory,
+// as a second machine would.
+func newTestOCIVault(t *testing.T, f *fakeRegistry) *OCIVault {
+	t.Helper()
+	t.Setenv("SX_CACHE_DIR", t.TempDir())
+	v, err := NewOCIVault("oci://"+strings.TrimPrefix(f.srv.URL, "http://")+"/team/sx", "alice", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestOCIVault_PublishAndReadAcrossMirrors(t *testing.T) {
+	reg := newFakeRegistry(t)
+	alice := newTestOCIVault(t, reg)
+	bob := newTestOCIVault(t, reg)
+	ctx := mgmt.ContextWithIdentity(context.Background(), "alice@example.com")
+
+	for i, ver := range []string{"1.0", "2.0", "3.0"} {
+		a, zipData := proposedSkill(t, ver)
+		if err := alice.AddAsset(ctx, a, zipData); err != nil {
+			t.Fatalf("AddAsset %s: %v", ver, err)
+		}
+		install := alice.InheritInstallations
+		if i == 0 {
+			install = func(ctx context.Context, a *lockfile.Asset) error { return alice.SetInstallations(ctx, a, "") }
+		}
+		if err := install(ctx, a); err != nil {
+			t.Fatalf("install %s: %v", ver, err)
+		}
+	}
+
+	m := reg.manifest(t, "team/sx/my-skill", "2.0")
+	if m == nil || m.ArtifactType != ociAssetArtifactType || m.Config.MediaType != ociAssetConfigMediaType ||
+		len(m.Layers) != 1 || m.Layers[0].MediaType != ociAssetLayerMediaType {
+		t.Fatalf("asset artifact = %+v, want metadata.toml config and one zip layer", m)
+	}
+	vaultManifest := reg.manifest(t, "team/sx", ociVaultTag)
+	if vaultManifest == nil || !slices.ContainsFunc(vaultManifest.Layers, func(l ociDescriptor) bool {
+		return l.Annotations[ociTitleAnnotation] == "sx.toml"
+	}) {
+		t.Fatalf("vault artifact = %+v, want an sx.toml layer", vaultManifest)
+	}
+
+	versions, err := bob.GetVersionList(ctx, "my-skill")
+	if err != nil || !slices.Equal(versions, []string{"1.0", "2.0", "3.0"}) {
+		t.Fatalf("GetVersionList = %v, %v; want [1.0 2.0 3.0]", versions, err)
+	}
+	if _, err := bob.GetVersionList(ctx, "My_Skill"); err == nil {
+		t.Error("GetVersionList of a name no OCI repository can hold should fail, not report no versions")
+	}
+	data, etag, _, err := bob.GetLockFile(ctx, "")
+	if err != nil {
+		t.Fatalf("GetLockFile: %v", err)
+	}
+	lf, err := lockfile.Parse(data)
+	if err != nil || len(lf.Assets) != 1 || lf.Assets[0].Version != "3.0" {
+		t.Fatalf("lock file = %+v, %v; want my-skill 3.0", lf, err)
+	}
+	if utils.IsDirectory(bob.remote.versionDir("my-skill", "3.0")) {
+		t.Error("reading the lock file downloaded a version nobody installed yet")
+	}
+	if _, err := bob.GetAsset(ctx, &lf.Assets[0]); err != nil {
+		t.Errorf("GetAsset: %v", err)
+	}
+	if !utils.IsDirectory(bob.remote.versionDir("my-skill", "3.0")) || utils.IsDirectory(bob.remote.versionDir("my-skill", "1.0")) {
+		t.Error("installing 3.0 should fetch exactly that version")
+	}
+	if _, _, notModified, err := bob.GetLockFile(ctx, etag); err != nil || !notModified {
+		t.Errorf("unchanged vault: notModified=%v err=%v; want notModified", notModified, err)
+	}
+
+	if err := bob.RemoveAsset(ctx, "my-skill", "", true); err != nil {
+		t.Fatalf("RemoveAsset: %v", err)
+	}
+	if reg.manifest(t, "team/sx/my-skill", "1.0") != nil {
+		t.Error("removed version is still tagged in the registry")
+	}
+	if versions, err := alice.GetVersionList(ctx, "my-skill"); err != nil || len(versions) != 0 {
+		t.Errorf("versions after removal = %v, %v; want none", versions, err)
+	}
+}
+
+// A blob that doesn't match its digest must never reach the mirror.
+func TestOCIVault_RejectsTamperedBlob(t *testing.T) {
+	reg := newFakeRegistry(t)
+	alice := newTestOCIVault(t, reg)
+	ctx := mgmt.ContextWithIdentity(context.Background(), "alice@example.com")
+
+	a, zipData := proposedSkill(t, "1.0")
+	if err := alice.AddAsset(ctx, a, zipData); err != nil {
+		t.Fatal(err)
+	}
+	layer := reg.manifest(t, "team/sx/my-skill", "1.0").Layers[0]
+	_, other := proposedSkill(t, "6.6.6")
+	reg.mu.Lock()
+	reg.blobs[layer.Digest] = other
+	reg.mu.Unlock()
+
+	_, err := newTestOCIVault(t, reg).GetAssetByVersion(ctx, "my-skill", "1.0")
+	if err == nil || !strings.Contains(err.Error(), "digest verification") {
+		t.Fatalf("err = %v, want a digest verification failure", err)
+	}
+}
+
+// Mirror content that no longer matches the registry digest is refused,
+// whatever the lock file says.
+func TestOCIVault_MirrorMustMatchRegistryDigest(t *testing.T) {
+	reg := newFakeRegistry(t)
+	alice := newTestOCIVault(t, reg)
+	bob := newTestOCIVault(t, reg)
+	ctx := mgmt.ContextWithIdentity(context.Background(), "alice@example.com")
+
+	a, zipData := proposedSkill(t, "1.0")
+	if err := alice.AddAsset(ctx, a, zipData); err != nil {
+		t.Fatal(err)
+	}
+	data, err := bob.GetAssetByVersion(ctx, "my-skill", "1.0")
+	if err != nil {
+		t.Fatalf("GetAssetByVersion: %v", err)
+	}
+	if err := bob.VerifyIntegrity(data, nil, 0); err != nil {
+		t.Errorf("VerifyIntegrity of the registry layer: %v", err)
+	}
+
+	layer := reg.manifest(t, "team/sx/my-skill", "1.0").Layers[0]
+	_, other := proposedSkill(t, "6.6.6")
+	if err := os.WriteFile(bob.remote.blobPath(layer.Digest), other, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bob.GetAssetByVersion(ctx, "my-skill", "1.0"); err == nil || !strings.Contains(err.Error(), "registry digest") {
+		t.Errorf("GetAssetByVersion of tampered mirror content: err = %v, want a registry digest mismatch", err)
+	}
+	hashes := map[string]string{"sha256": sha256Hex(other)}
+	if err := bob.VerifyIntegrity(other, hashes, int64(len(other))); err == nil {
+		t.Error("VerifyIntegrity accepted content the registry never served, because the lock file's hashes matched")
+	}
+}
+
+// Two writers race on the vault artifact: the one that finds it moved
+// just before retagging replays its change on top of the winner's.
+func TestOCIVault_ConflictingWritesReplay(t *testing.T) {
+	reg := newFakeRegistry(t)
+	alice := newTestOCIVault(t, reg)
+	bob := newTestOCIVault(t, reg)
+	ctx := mgmt.ContextWithIdentity(context.Background(), "alice@example.com")
+
+	if err := alice.CreateTeam(ctx, mgmt.Team{Name: "platform", Members: []string{"alice@example.com"}, Admins: []string{"alice@example.com"}}); err != nil {
+		t.Fatalf("CreateTeam: %v", err)
+	}
+
+	raced := false
+	reg.beforeUpload = func(repo string) {
+		if repo != "team/sx" || raced {
+			return
+		}
+		raced = true
+		// Bob's write lands while Alice is still uploading her layers.
+		if err := bob.AddTeamMember(ctx, "platform", "bob@example.com", false); err != nil {
+			t.Errorf("concurrent AddTeamMember: %v", err)
+		}
+	}
+	if err := alice.AddTeamMember(ctx, "platform", "carol@example.com", false); err != nil {
+		t.Fatalf("AddTeamMember: %v", err)
+	}
+	reg.beforeUpload = nil
+	if !raced {
+		t.Fatal("the concurrent write never ran")
+	}
+
+	team, err := newTestOCIVault(t, reg).GetTeam(ctx, "platform")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, member := range []string{"bob@example.com", "carol@example.com"} {
+		if !slices.Contains(team.Members, member) {
+			t.Errorf("members = %v, missing %s", team.Members, member)
+		}
+	}
+}
+
+func TestParseAuthChallenge(t *testing.T) {
+	scheme, params := parseAuthChallenge(`Bearer realm="https://auth.example.com/token",service="registry",scope="repository:a/b:pull,push"`)
+	if scheme != "Bearer" || params["realm"] != "https://auth.example.com/token" ||
+		params["service"] != "registry" || params["scope"] != "repository:a/b:pull,push" {
+		t.Errorf("got %q %v", scheme, params)
+	}
+}
@@ -62,24 +62,34 @@ func (p *PathSourceHandler) Fetch(ctx context.Context, asset *lockfile.Asset) ([
 		return nil, fmt.Errorf("path not found: %s", resolvedPath)
 	}
 
-	// If it's a directory, create a zip from it
+	var data []byte
 	if info.IsDir() {
-		data, err := utils.CreateZip(resolvedPath)
+		// If it's a directory, create a zip from it
+		data, err = utils.CreateZip(resolvedPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create zip from directory: %w", err)
 		}
-		return data, nil
-	}
+	} else {
+		// It's a file - read it
+		data, err = os.ReadFile(resolvedPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
 
-	// It's a file - read it
-	data, err := os.ReadFile(resolvedPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
+		// Verify it's a valid zip file
+		if !utils.IsZipFile(data) {
+			return nil, fmt.Errorf("file is not a valid zip archive: %s", resolvedPath)
+		}
 	}
 
-	// Verify it's a valid zip file
-	if !utils.IsZipFile(data) {
-		return nil, fmt.Errorf("file is not a valid zip archive: %s", resolvedPath)
+	if expected, ok := source.Hashes["sha256"]; ok {
+		actual, err := utils.ComputeZipContentSHA256(data)
+		if err != nil {
+			return nil, err
+		}
+		if actual != expected {
+			return nil, fmt.Errorf("hash mismatch for %s: expected %s, got %s", resolvedPath, expected, actual)
+		}
 	}
 
 	return data, nil
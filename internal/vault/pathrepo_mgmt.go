@@ -316,8 +316,12 @@ func (p *PathVault) QueryAuditEvents(ctx context.Context, filter mgmt.AuditFilte
 }
 
 // withLock wraps a mutating op: acquire exclusive flock, resolve actor,
-// migrate the storage format if pending, run fn.
-func (p *PathVault) withLock(ctx context.Context, fn func(actor mgmt.Actor) error) error {
+// migrate the storage format if pending, run fn. Audit events fn records
+// are exported after the lock is released, and only if fn succeeds.
+func (p *PathVault) withLock(ctx context.Context, fn func(actor mgmt.Actor) error) (err error) {
+	release := mgmt.HoldAuditExports(p.repoPath)
+	defer func() { release(err == nil) }()
+
 	fl, err := p.acquirePathLock(ctx)
 	if err != nil {
 		return err
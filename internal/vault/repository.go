@@ -242,6 +242,11 @@ type InstallTarget struct {
 	User  string   // User (email)
 	Bot   string   // Bot (name)
 
+	// Pin, when set, pins this target to one stored version of the asset
+	// instead of the latest (docs/channels.md#pinning). LatestChannel
+	// removes an existing pin. File-backed vaults only.
+	Pin string
+
 	// EntityID is the server GID of the installed entity, populated when a
 	// target is read back from the server (the current-installation view). It
 	// lets a removal target the exact installation via uninstallAssetTargets
@@ -281,12 +286,23 @@ func (t InstallTarget) AuditData() map[string]any {
 	case InstallKindBot:
 		data["bot"] = t.Bot
 	}
+	if t.Pin != "" {
+		data["pin"] = t.Pin
+	}
 	return data
 }
 
 // Describe returns a short human-readable summary of the target, suitable
 // for commit messages and CLI output.
 func (t InstallTarget) Describe() string {
+	if t.Pin != "" {
+		pin := t
+		pin.Pin = ""
+		if t.Pin == LatestChannel {
+			return pin.Describe() + " (unpinned)"
+		}
+		return fmt.Sprintf("%s (pinned to %s)", pin.Describe(), t.Pin)
+	}
 	switch t.Kind {
 	case InstallKindOrg:
 		return "org (global)"
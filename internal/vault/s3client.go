@@ -0,0 +1,374 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// errS3Conflict is returned when a conditional request loses a race: the
+// object changed (If-Match) or appeared (If-None-Match) since we last saw it.
+var errS3Conflict = fmt.Errorf("s3 object changed: %w", errMirrorConflict)
+
+// errS3NotFound is returned for a missing object
+var errS3NotFound = errors.New("s3 object not found")
+
+// s3Location is a parsed s3://bucket/prefix?endpoint=…&region=… URL
+type s3Location struct {
+	Bucket   string
+	Prefix   string // "" or ending in "/"
+	Region   string
+	Endpoint string // custom endpoint (MinIO etc.), "" for AWS
+}
+
+// parseS3URL parses an s3:// vault URL. The region and endpoint fall back
+// to the standard AWS environment variables.
+func parseS3URL(raw string) (s3Location, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return s3Location{}, fmt.Errorf("invalid S3 URL: %w", err)
+	}
+	if u.Scheme != "s3" || u.Host == "" {
+		return s3Location{}, fmt.Errorf("invalid S3 URL %q: expected s3://bucket[/prefix]", raw)
+	}
+	loc := s3Location{
+		Bucket:   u.Host,
+		Prefix:   strings.Trim(u.Path, "/"),
+		Region:   u.Query().Get("region"),
+		Endpoint: strings.TrimSuffix(u.Query().Get("endpoint"), "/"),
+	}
+	if loc.Prefix != "" {
+		loc.Prefix += "/"
+	}
+	if loc.Region == "" {
+		loc.Region = firstEnv("AWS_REGION", "AWS_DEFAULT_REGION")
+	}
+	if loc.Region == "" {
+		loc.Region = "us-east-1"
+	}
+	if loc.Endpoint == "" {
+		loc.Endpoint = strings.TrimSuffix(firstEnv("AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL"), "/")
+	}
+	return loc, nil
+}
+
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// s3Credentials are static AWS-style credentials. The zero value sends
+// unsigned (anonymous) requests.
+type s3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// s3CredentialsFromEnv reads the standard AWS credential variables
+func s3CredentialsFromEnv() s3Credentials {
+	return s3Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+}
+
+// s3Object is one entry of a bucket listing
+type s3Object struct {
+	Key  string
+	ETag string
+	Size int64
+}
+
+// s3Client is a minimal S3 REST client: just the object calls the S3
+// vault needs, signed with SigV4. It works against AWS and S3-compatible
+// stores (MinIO, R2, Ceph); custom endpoints use path-style addressing.
+type s3Client struct {
+	loc   s3Location
+	creds s3Credentials
+	http  *http.Client
+	now   func() time.Time
+}
+
+func newS3Client(loc s3Location, creds s3Credentials) *s3Client {
+	return &s3Client{
+		loc:   loc,
+		creds: creds,
+		http:  &http.Client{Timeout: 60 * time.Second},
+		now:   time.Now,
+	}
+}
+
+// objectURL returns the request URL for key ("" for the bucket itself)
+func (c *s3Client) objectURL(key string, query url.Values) *url.URL {
+	var u *url.URL
+	if c.loc.Endpoint != "" {
+		u, _ = url.Parse(c.loc.Endpoint)
+		u.Path = "/" + c.loc.Bucket + "/" + key
+	} else {
+		host := "s3." + c.loc.Region + ".amazonaws.com"
+		u = &url.URL{Scheme: "https", Host: c.loc.Bucket + "." + host, Path: "/" + key}
+	}
+	// Send the path exactly as it is signed
+	u.RawPath = s3EscapePath(u.Path)
+	u.RawQuery = s3CanonicalQuery(query)
+	return u
+}
+
+// do sends a signed request and returns the response for 2xx statuses.
+// Other statuses are mapped to errS3NotFound, errS3Conflict, or an error
+// carrying S3's error code and message.
+func (c *s3Client) do(ctx context.Context, method, key string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.objectURL(key, query).String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.ContentLength = int64(len(body))
+	c.sign(req, body)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 %s %s: %w", method, key, err)
+	}
+	if resp.StatusCode/100 == 2 {
+		return resp, nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		if key != "" {
+			return nil, errS3NotFound
+		}
+	case http.StatusPreconditionFailed, http.StatusConflict:
+		return nil, errS3Conflict
+	}
+	var s3Err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if xml.Unmarshal(data, &s3Err) == nil && s3Err.Code != "" {
+		return nil, fmt.Errorf("s3 %s %s: %s: %s", method, key, s3Err.Code, s3Err.Message)
+	}
+	return nil, fmt.Errorf("s3 %s %s: HTTP %d", method, key, resp.StatusCode)
+}
+
+// listObjects lists every object under prefix, following continuation tokens
+func (c *s3Client) listObjects(ctx context.Context, prefix string) ([]s3Object, error) {
+	var out []s3Object
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := c.do(ctx, http.MethodGet, "", query, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Contents []struct {
+				Key  string `xml:"Key"`
+				ETag string `xml:"ETag"`
+				Size int64  `xml:"Size"`
+			} `xml:"Contents"`
+			IsTruncated           bool   `xml:"IsTruncated"`
+			NextContinuationToken string `xml:"NextContinuationToken"`
+		}
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse S3 listing: %w", err)
+		}
+		for _, obj := range page.Contents {
+			out = append(out, s3Object{Key: obj.Key, ETag: obj.ETag, Size: obj.Size})
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return out, nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// getObject downloads an object, returning its content and ETag
+func (c *s3Client) getObject(ctx context.Context, key string) ([]byte, string, error) {
+	resp, err := c.do(ctx, http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("s3 GET %s: %w", key, err)
+	}
+	return data, resp.Header.Get("ETag"), nil
+}
+
+// headObject returns an object's ETag without downloading it
+func (c *s3Client) headObject(ctx context.Context, key string) (string, error) {
+	resp, err := c.do(ctx, http.MethodHead, key, nil, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+	return resp.Header.Get("ETag"), nil
+}
+
+// putObject uploads an object conditionally: with ifMatch set the write
+// only succeeds if the object still has that ETag; with ifMatch empty it
+// only succeeds if the object doesn't exist yet. A lost race returns
+// errS3Conflict. Returns the new ETag.
+func (c *s3Client) putObject(ctx context.Context, key string, data []byte, ifMatch string) (string, error) {
+	header := http.Header{}
+	if ifMatch != "" {
+		header.Set("If-Match", ifMatch)
+	} else {
+		header.Set("If-None-Match", "*")
+	}
+	resp, err := c.do(ctx, http.MethodPut, key, nil, header, data)
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+	return resp.Header.Get("ETag"), nil
+}
+
+// deleteObject removes an object; deleting a missing object succeeds
+func (c *s3Client) deleteObject(ctx context.Context, key string) error {
+	resp, err := c.do(ctx, http.MethodDelete, key, nil, nil, nil)
+	if errors.Is(err, errS3NotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// sign adds AWS Signature Version 4 headers to req. Requests are left
+// unsigned when no credentials are configured (public buckets).
+func (c *s3Client) sign(req *http.Request, body []byte) {
+	if c.creds.AccessKeyID == "" {
+		return
+	}
+	now := c.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if c.creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", c.creds.SessionToken)
+	}
+
+	// Sign the host and every header we set ourselves; transport-level
+	// headers (User-Agent, Accept-Encoding) are added later and excluded.
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := day + "/" + c.loc.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	key := hmacSHA256([]byte("AWS4"+c.creds.SecretAccessKey), day)
+	key = hmacSHA256(key, c.loc.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// s3CanonicalQuery encodes query the way SigV4 expects: sorted by key,
+// with RFC 3986 escaping (url.Values.Encode escapes spaces as '+').
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		for _, v := range query[k] {
+			parts = append(parts, s3Escape(k, true)+"="+s3Escape(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3EscapePath escapes a URL path for the canonical request, keeping '/'
+func s3EscapePath(path string) string {
+	return s3Escape(path, false)
+}
+
+// s3Escape percent-encodes everything but RFC 3986 unreserved characters
+// (and '/', unless encodeSlash)
+func s3Escape(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case 'A' <= ch && ch <= 'Z', 'a' <= ch && ch <= 'z', '0' <= ch && ch <= '9',
+			ch == '-', ch == '_', ch == '.', ch == '~':
+			b.WriteByte(ch)
+		case ch == '/' && !encodeSlash:
+			b.WriteByte(ch)
+		default:
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
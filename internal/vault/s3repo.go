@@ -0,0 +1,288 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sleuth-io/sx/v2/internal/cache"
+	"github.com/sleuth-io/sx/v2/internal/logger"
+	"github.com/sleuth-io/sx/v2/internal/manifest"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+const s3StateFile = "state.json"
+
+// S3Vault implements Vault on an S3-compatible bucket (AWS S3, MinIO, R2).
+// The bucket holds exactly what a path vault directory holds, and all
+// vault logic runs on a local mirror of it (see mirrorVault).
+//
+// Reads list the bucket and download changed objects. Writes upload what
+// changed with conditional PUTs: If-Match on the ETag each object had when
+// it was downloaded, If-None-Match for new objects. sx.toml goes last, so a
+// concurrent writer loses the race on the manifest before it can publish
+// a half-merged state.
+type S3Vault struct {
+	*mirrorVault
+	repoURL string
+}
+
+// s3Remote syncs a mirror tree with a bucket prefix
+type s3Remote struct {
+	client    *s3Client
+	mirrorDir string
+	treeDir   string
+}
+
+// s3MirrorEntry records the remote object a mirrored file came from
+type s3MirrorEntry struct {
+	ETag   string `json:"etag"`
+	SHA256 string `json:"sha256"`
+}
+
+// NewS3Vault creates a vault for an s3://bucket[/prefix] URL. The access
+// key pair falls back to AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY (and
+// AWS_SESSION_TOKEN); with no credentials at all, requests are unsigned.
+func NewS3Vault(repoURL, accessKeyID, secretAccessKey string) (*S3Vault, error) {
+	loc, err := parseS3URL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	creds := s3Credentials{AccessKeyID: accessKeyID, SecretAccessKey: secretAccessKey}
+	if accessKeyID == "" && secretAccessKey == "" {
+		creds = s3CredentialsFromEnv()
+	}
+
+	mirrorDir, err := cache.GetS3VaultCachePath(repoURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cache path: %w", err)
+	}
+	mirror, err := newMirrorVault(mirrorDir)
+	if err != nil {
+		return nil, err
+	}
+	mirror.remote = &s3Remote{
+		client:    newS3Client(loc, creds),
+		mirrorDir: mirrorDir,
+		treeDir:   mirror.treeDir,
+	}
+	return &S3Vault{mirrorVault: mirror, repoURL: repoURL}, nil
+}
+
+// key maps a mirror-relative slash path to its object key
+func (r *s3Remote) key(rel string) string {
+	return r.client.loc.Prefix + rel
+}
+
+// manifestTag is sx.toml's object ETag, from a single HEAD request
+func (r *s3Remote) manifestTag(ctx context.Context) (string, error) {
+	etag, err := r.client.headObject(ctx, r.key(manifest.FileName))
+	if errors.Is(err, errS3NotFound) {
+		return "", ErrLockFileNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to check vault manifest: %w", err)
+	}
+	return etag, nil
+}
+
+// pulledManifestTag is the ETag of the sx.toml last downloaded
+func (r *s3Remote) pulledManifestTag() (string, error) {
+	state, err := r.loadState()
+	if err != nil {
+		return "", err
+	}
+	return state[manifest.FileName].ETag, nil
+}
+
+// pull brings the mirror up to date with the bucket: downloads
+// objects whose ETag changed and removes files whose object is gone. With
+// verify set it also re-downloads files edited locally and removes local
+// files the bucket doesn't have, so the mirror matches the bucket exactly
+// before a write.
+func (r *s3Remote) pull(ctx context.Context, verify bool) error {
+	objects, err := r.client.listObjects(ctx, r.client.loc.Prefix)
+	if err != nil {
+		return fmt.Errorf("failed to list S3 vault: %w", err)
+	}
+	state, err := r.loadState()
+	if err != nil {
+		return err
+	}
+
+	remote := make(map[string]string, len(objects))
+	for _, obj := range objects {
+		rel := strings.TrimPrefix(obj.Key, r.client.loc.Prefix)
+		if rel == "" || strings.HasSuffix(rel, "/") || !filepath.IsLocal(filepath.FromSlash(rel)) || isMirrorLocal(rel) {
+			continue
+		}
+		remote[rel] = obj.ETag
+	}
+
+	for rel, etag := range remote {
+		entry, known := state[rel]
+		fresh := known && entry.ETag == etag
+		if fresh && verify {
+			sum, err := hashFile(filepath.Join(r.treeDir, filepath.FromSlash(rel)))
+			fresh = err == nil && sum == entry.SHA256
+		}
+		if fresh {
+			continue
+		}
+		data, gotETag, err := r.client.getObject(ctx, r.key(rel))
+		if errors.Is(err, errS3NotFound) {
+			// Deleted since the listing; the next sync drops it.
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to download %s: %w", rel, err)
+		}
+		if gotETag == "" {
+			gotETag = etag
+		}
+		if err := writeMirrorFile(r.treeDir, rel, data); err != nil {
+			return err
+		}
+		state[rel] = s3MirrorEntry{ETag: gotETag, SHA256: sha256Hex(data)}
+	}
+
+	for rel := range state {
+		if _, ok := remote[rel]; !ok {
+			if err := removeMirrorFile(r.treeDir, rel); err != nil {
+				return err
+			}
+			delete(state, rel)
+		}
+	}
+	if verify {
+		local, err := mirrorFiles(r.treeDir)
+		if err != nil {
+			return err
+		}
+		for rel := range local {
+			if _, ok := state[rel]; !ok {
+				if err := removeMirrorFile(r.treeDir, rel); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return r.saveState(state)
+}
+
+// push uploads every mirror file that differs from what was pulled
+// and deletes objects whose file was removed. Asset files go first, then
+// .sx/, then sx.toml — the manifest is the commit point, so a reader never
+// sees it reference files that aren't uploaded yet — and deletions last,
+// once the manifest no longer references them.
+func (r *s3Remote) push(ctx context.Context) error {
+	state, err := r.loadState()
+	if err != nil {
+		return err
+	}
+	local, err := mirrorFiles(r.treeDir)
+	if err != nil {
+		return err
+	}
+
+	var changed []string
+	for rel, sum := range local {
+		if entry, ok := state[rel]; !ok || entry.SHA256 != sum {
+			changed = append(changed, rel)
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool {
+		ri, rj := s3UploadRank(changed[i]), s3UploadRank(changed[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return changed[i] < changed[j]
+	})
+
+	for _, rel := range changed {
+		data, err := os.ReadFile(filepath.Join(r.treeDir, filepath.FromSlash(rel)))
+		if err != nil {
+			return err
+		}
+		etag, err := r.client.putObject(ctx, r.key(rel), data, state[rel].ETag)
+		if err == nil && etag == "" {
+			etag, err = r.client.headObject(ctx, r.key(rel))
+		}
+		if err != nil {
+			// Keep what already landed so the replay doesn't re-upload it.
+			_ = r.saveState(state)
+			return fmt.Errorf("failed to upload %s: %w", rel, err)
+		}
+		state[rel] = s3MirrorEntry{ETag: etag, SHA256: local[rel]}
+	}
+
+	for rel := range state {
+		if _, ok := local[rel]; ok {
+			continue
+		}
+		if err := r.client.deleteObject(ctx, r.key(rel)); err != nil {
+			_ = r.saveState(state)
+			return fmt.Errorf("failed to delete %s: %w", rel, err)
+		}
+		delete(state, rel)
+	}
+	return r.saveState(state)
+}
+
+// s3UploadRank orders uploads: asset files, then .sx/, then the manifest
+func s3UploadRank(rel string) int {
+	switch {
+	case rel == manifest.FileName:
+		return 2
+	case strings.HasPrefix(rel, ".sx/"):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (r *s3Remote) loadState() (map[string]s3MirrorEntry, error) {
+	state := map[string]s3MirrorEntry{}
+	data, err := os.ReadFile(filepath.Join(r.mirrorDir, s3StateFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read S3 mirror state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		// A corrupt state file only costs a full re-download.
+		logger.Get().Warn("discarding corrupt S3 mirror state", "error", err)
+		return map[string]s3MirrorEntry{}, nil
+	}
+	return state, nil
+}
+
+func (r *s3Remote) saveState(state map[string]s3MirrorEntry) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return utils.WriteFileAtomic(filepath.Join(r.mirrorDir, s3StateFile), data, 0644)
+}
+
+var (
+	_ Vault               = (*S3Vault)(nil)
+	_ QualityStore        = (*S3Vault)(nil)
+	_ BenchmarkStore      = (*S3Vault)(nil)
+	_ SigningStore        = (*S3Vault)(nil)
+	_ ChannelStore        = (*S3Vault)(nil)
+	_ CollectionStore     = (*S3Vault)(nil)
+	_ RepoAssetLister     = (*S3Vault)(nil)
+	_ TeamAssetLister     = (*S3Vault)(nil)
+	_ UserAssetLister     = (*S3Vault)(nil)
+	_ TeamRenamer         = (*S3Vault)(nil)
+	_ CollectionRenamer   = (*S3Vault)(nil)
+	_ CollectionInstaller = (*S3Vault)(nil)
+)
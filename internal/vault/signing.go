@@ -0,0 +1,434 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/manifest"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/signing"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+	"github.com/sleuth-io/sx/v2/internal/vault/layout"
+)
+
+// ---- Asset signing (docs/signing.md) ----
+// `sx add` signs each version it publishes with the publisher's key; the
+// signature sits beside the version in the archive. Installs check fetched
+// content against the manifest's [signing] trusted keys, so a swapped file
+// in a synced folder no longer passes as long as the attacker doesn't hold
+// a trusted key. Policy and keys are org-admin gated like the rest of the
+// vault's governance.
+
+// ErrAssetUnsigned is returned by VerifyAssetSignature for a version with
+// no stored signature.
+var ErrAssetUnsigned = errors.New("asset is not signed")
+
+// SigningStore is implemented by vaults that store asset signatures. Only
+// the file-backed vaults do; skills.new verifies uploads server-side.
+type SigningStore interface {
+	// SigningConfig returns the manifest's [signing] table (nil when unset).
+	SigningConfig(ctx context.Context) (*manifest.Signing, error)
+
+	// SetSigningPolicy sets the verification policy: off, warn, or enforce.
+	SetSigningPolicy(ctx context.Context, policy string) error
+
+	// TrustSigningKey adds (or replaces) the named trusted public key.
+	TrustSigningKey(ctx context.Context, name, publicKey string) error
+
+	// UntrustSigningKey removes the named trusted key.
+	UntrustSigningKey(ctx context.Context, name string) error
+
+	// AddSignedAsset is AddAsset that also signs the version with key. The
+	// signature covers zipData itself and is stored in the same write as
+	// the version, so the vault never lists it unsigned.
+	AddSignedAsset(ctx context.Context, asset *lockfile.Asset, zipData []byte, key *signing.PrivateKey) (*signing.Signature, error)
+
+	// SignAsset signs a stored version with key, records the signature in
+	// the version archive, and audits it with the caller as signer. It is
+	// for versions published unsigned.
+	SignAsset(ctx context.Context, name, version string, key *signing.PrivateKey) (*signing.Signature, error)
+
+	// AssetSignature returns the stored signature file for a version, or
+	// nil when it has none.
+	AssetSignature(ctx context.Context, name, version string) ([]byte, error)
+}
+
+// VerifiedSignature is a signature that checked out against a trusted key.
+type VerifiedSignature struct {
+	signing.Signature
+	// KeyName is the trusted key's name in the manifest.
+	KeyName string
+}
+
+// SigningPolicyOf returns cfg's effective policy.
+func SigningPolicyOf(cfg *manifest.Signing) string {
+	if cfg == nil || cfg.Policy == "" {
+		return signing.PolicyOff
+	}
+	return cfg.Policy
+}
+
+// VerifyAssetSignature checks zipData, as fetched for name@version, against
+// the trusted keys in cfg. It does not apply the policy; callers decide
+// whether a failure warns or blocks.
+func VerifyAssetSignature(ctx context.Context, store SigningStore, cfg *manifest.Signing, name, version string, zipData []byte) (*VerifiedSignature, error) {
+	data, err := store.AssetSignature(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, ErrAssetUnsigned
+	}
+	digest, err := utils.ComputeZipContentSHA256(zipData)
+	if err != nil {
+		return nil, err
+	}
+	var keys []*signing.PublicKey
+	names := map[string]string{}
+	if cfg != nil {
+		for _, tk := range cfg.TrustedKeys {
+			k, err := signing.ParsePublicKey(tk.PublicKey)
+			if err != nil {
+				return nil, fmt.Errorf("trusted key %q: %w", tk.Name, err)
+			}
+			keys = append(keys, k)
+			names[k.ID()] = tk.Name
+		}
+	}
+	sig, err := signing.Verify(data, keys, name, version, digest)
+	if err != nil {
+		return nil, err
+	}
+	return &VerifiedSignature{Signature: *sig, KeyName: names[sig.KeyID]}, nil
+}
+
+// requireSigningAdmin applies the governance rule every vault-wide policy
+// shares: org-admins only on a governed vault, anyone on an ungoverned one.
+func requireSigningAdmin(m *manifest.Manifest, actor mgmt.Actor) error {
+	if m.HasOrgAdmins() && !m.IsOrgAdmin(actor.Email) {
+		return errors.New("only org-admins can change signing settings")
+	}
+	return nil
+}
+
+func signingAuditEvent(event, target string, data map[string]any) *mgmt.AuditEvent {
+	return &mgmt.AuditEvent{
+		Event:      event,
+		TargetType: mgmt.TargetTypeSigning,
+		Target:     target,
+		Data:       data,
+	}
+}
+
+func commonSigningConfig(vaultRoot string) (*manifest.Signing, error) {
+	m, err := loadManifest(vaultRoot)
+	if err != nil || m == nil {
+		return nil, err
+	}
+	return m.Signing, nil
+}
+
+func commonSetSigningPolicy(vaultRoot string, actor mgmt.Actor, policy string) error {
+	if err := signing.ValidatePolicy(policy); err != nil {
+		return err
+	}
+	return withManifest(vaultRoot, actor, func(m *manifest.Manifest) (*mgmt.AuditEvent, error) {
+		if err := requireSigningAdmin(m, actor); err != nil {
+			return nil, err
+		}
+		if policy == signing.PolicyEnforce && (m.Signing == nil || len(m.Signing.TrustedKeys) == 0) {
+			return nil, errors.New("trust at least one key before enforcing signatures; see 'sx signing trust'")
+		}
+		if m.Signing == nil {
+			m.Signing = &manifest.Signing{}
+		}
+		previous := SigningPolicyOf(m.Signing)
+		m.Signing.Policy = policy
+		if policy == signing.PolicyOff {
+			m.Signing.Policy = ""
+		}
+		if m.Signing.Policy == "" && len(m.Signing.TrustedKeys) == 0 {
+			m.Signing = nil
+		}
+		return signingAuditEvent(mgmt.EventSigningPolicySet, "policy", map[string]any{
+			"policy":   policy,
+			"previous": previous,
+		}), nil
+	})
+}
+
+func commonTrustSigningKey(vaultRoot string, actor mgmt.Actor, name, publicKey string) error {
+	if name == "" {
+		return errors.New("trusted key name cannot be empty")
+	}
+	key, err := signing.ParsePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	return withManifest(vaultRoot, actor, func(m *manifest.Manifest) (*mgmt.AuditEvent, error) {
+		if err := requireSigningAdmin(m, actor); err != nil {
+			return nil, err
+		}
+		if m.Signing == nil {
+			m.Signing = &manifest.Signing{}
+		}
+		entry := manifest.TrustedKey{Name: name, PublicKey: key.String()}
+		if i := slices.IndexFunc(m.Signing.TrustedKeys, func(k manifest.TrustedKey) bool { return k.Name == name }); i >= 0 {
+			m.Signing.TrustedKeys[i] = entry
+		} else {
+			m.Signing.TrustedKeys = append(m.Signing.TrustedKeys, entry)
+		}
+		return signingAuditEvent(mgmt.EventSigningKeyTrusted, name, map[string]any{"key_id": key.ID()}), nil
+	})
+}
+
+func commonUntrustSigningKey(vaultRoot string, actor mgmt.Actor, name string) error {
+	return withManifest(vaultRoot, actor, func(m *manifest.Manifest) (*mgmt.AuditEvent, error) {
+		if err := requireSigningAdmin(m, actor); err != nil {
+			return nil, err
+		}
+		var i = -1
+		if m.Signing != nil {
+			i = slices.IndexFunc(m.Signing.TrustedKeys, func(k manifest.TrustedKey) bool { return k.Name == name })
+		}
+		if i < 0 {
+			return nil, fmt.Errorf("no trusted key named %q", name)
+		}
+		if m.Signing.Policy == signing.PolicyEnforce && len(m.Signing.TrustedKeys) == 1 {
+			return nil, errors.New("cannot remove the last trusted key while signatures are enforced; set the policy to warn or off first")
+		}
+		m.Signing.TrustedKeys = slices.Delete(m.Signing.TrustedKeys, i, i+1)
+		if m.Signing.Policy == "" && len(m.Signing.TrustedKeys) == 0 {
+			m.Signing = nil
+		}
+		return signingAuditEvent(mgmt.EventSigningKeyUntrusted, name, nil), nil
+	})
+}
+
+// storedVersionDigest hashes a stored version's files the way installs
+// will hash the zip they fetch.
+func storedVersionDigest(vaultRoot string, l layout.Layout, name, ver string) (string, error) {
+	versions, err := versionListForAsset(vaultRoot, l, name)
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains(versions, ver) {
+		return "", fmt.Errorf("version %s of %q is not stored in this vault", ver, name)
+	}
+	data, err := utils.CreateZip(filepath.Join(vaultRoot, l.VersionDir(name, ver)))
+	if err != nil {
+		return "", err
+	}
+	return utils.ComputeZipContentSHA256(data)
+}
+
+// requireSigner checks that actor may sign versions of name.
+func requireSigner(vaultRoot string, actor mgmt.Actor, name string) error {
+	if err := actor.RequireRealIdentity(); err != nil {
+		return fmt.Errorf("signing requires a real git identity (set git config user.email): %w", err)
+	}
+	m, err := loadManifest(vaultRoot)
+	if err != nil {
+		return err
+	}
+	if m != nil {
+		if denial := assetEditDenial(m, name, actor); denial != nil {
+			return denial
+		}
+	}
+	return nil
+}
+
+func commonSignAsset(vaultRoot string, actor mgmt.Actor, name, ver string, key *signing.PrivateKey) (*signing.Signature, error) {
+	if err := requireSigner(vaultRoot, actor, name); err != nil {
+		return nil, err
+	}
+	l, err := detectLayout(vaultRoot)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := storedVersionDigest(vaultRoot, l, name, ver)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := writeAssetSignature(vaultRoot, l, actor, name, ver, digest, key)
+	if err != nil {
+		return nil, err
+	}
+	return sig, auditAssetSigned(vaultRoot, actor, name, ver, digest, sig)
+}
+
+// commonAddSignedAsset stores a new version with a signature over zipData,
+// the bytes being published. The signature is written before the version
+// is added to list.txt, so no reader sees the version listed without it.
+func commonAddSignedAsset(vaultRoot string, actor mgmt.Actor, asset *lockfile.Asset, zipData []byte, key *signing.PrivateKey) (*signing.Signature, error) {
+	if err := requireSigner(vaultRoot, actor, asset.Name); err != nil {
+		return nil, err
+	}
+	l, err := detectLayout(vaultRoot)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := utils.ComputeZipContentSHA256(zipData)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := writeAssetSignature(vaultRoot, l, actor, asset.Name, asset.Version, digest, key)
+	if err != nil {
+		return nil, err
+	}
+	if err := storeAssetVersion(vaultRoot, l, asset.Name, asset.Version, zipData); err != nil {
+		_ = os.Remove(filepath.Join(vaultRoot, l.SignaturePath(asset.Name, asset.Version)))
+		return nil, err
+	}
+	asset.SourcePath = &lockfile.SourcePath{Path: l.SourcePathRel(asset.Name, asset.Version)}
+	return sig, auditAssetSigned(vaultRoot, actor, asset.Name, asset.Version, digest, sig)
+}
+
+// writeAssetSignature signs digest for name@ver and stores the signature
+// in the version archive.
+func writeAssetSignature(vaultRoot string, l layout.Layout, actor mgmt.Actor, name, ver, digest string, key *signing.PrivateKey) (*signing.Signature, error) {
+	now := time.Now()
+	data := signing.Sign(key, name, ver, digest, actor.Email, now)
+	sigPath := filepath.Join(vaultRoot, l.SignaturePath(name, ver))
+	if err := os.MkdirAll(filepath.Dir(sigPath), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to store signature: %w", err)
+	}
+	if err := utils.WriteFileAtomic(sigPath, data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to store signature: %w", err)
+	}
+	return &signing.Signature{KeyID: key.Public().ID(), Signer: actor.Email, SignedAt: now.UTC().Truncate(time.Second)}, nil
+}
+
+func auditAssetSigned(vaultRoot string, actor mgmt.Actor, name, ver, digest string, sig *signing.Signature) error {
+	return mgmt.AppendAuditEvent(vaultRoot, mgmt.AuditEvent{
+		Actor:      actor.Email,
+		Event:      mgmt.EventAssetSigned,
+		TargetType: mgmt.TargetTypeAsset,
+		Target:     name,
+		Data: map[string]any{
+			"version": ver,
+			"key_id":  sig.KeyID,
+			"signer":  sig.Signer,
+			"sha256":  digest,
+		},
+	})
+}
+
+func commonAssetSignature(vaultRoot, name, ver string) ([]byte, error) {
+	l, err := detectLayout(vaultRoot)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(vaultRoot, l.SignaturePath(name, ver))) // #nosec G304 -- layout path under the vault root
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// ---- PathVault signing ----
+
+func (p *PathVault) SigningConfig(ctx context.Context) (*manifest.Signing, error) {
+	return commonSigningConfig(p.repoPath)
+}
+
+func (p *PathVault) SetSigningPolicy(ctx context.Context, policy string) error {
+	return p.withLock(ctx, func(actor mgmt.Actor) error {
+		return commonSetSigningPolicy(p.repoPath, actor, policy)
+	})
+}
+
+func (p *PathVault) TrustSigningKey(ctx context.Context, name, publicKey string) error {
+	return p.withLock(ctx, func(actor mgmt.Actor) error {
+		return commonTrustSigningKey(p.repoPath, actor, name, publicKey)
+	})
+}
+
+func (p *PathVault) UntrustSigningKey(ctx context.Context, name string) error {
+	return p.withLock(ctx, func(actor mgmt.Actor) error {
+		return commonUntrustSigningKey(p.repoPath, actor, name)
+	})
+}
+
+func (p *PathVault) AddSignedAsset(ctx context.Context, asset *lockfile.Asset, zipData []byte, key *signing.PrivateKey) (sig *signing.Signature, err error) {
+	err = p.withLock(ctx, func(actor mgmt.Actor) error {
+		sig, err = commonAddSignedAsset(p.repoPath, actor, asset, zipData, key)
+		return err
+	})
+	return sig, err
+}
+
+func (p *PathVault) SignAsset(ctx context.Context, name, version string, key *signing.PrivateKey) (sig *signing.Signature, err error) {
+	err = p.withLock(ctx, func(actor mgmt.Actor) error {
+		sig, err = commonSignAsset(p.repoPath, actor, name, version, key)
+		return err
+	})
+	return sig, err
+}
+
+func (p *PathVault) AssetSignature(ctx context.Context, name, version string) ([]byte, error) {
+	return commonAssetSignature(p.repoPath, name, version)
+}
+
+// ---- GitVault signing ----
+
+func (g *GitVault) SigningConfig(ctx context.Context) (*manifest.Signing, error) {
+	if err := g.cloneOrUpdate(ctx); err != nil {
+		return nil, err
+	}
+	return commonSigningConfig(g.repoPath)
+}
+
+func (g *GitVault) SetSigningPolicy(ctx context.Context, policy string) error {
+	return g.runInVaultTx(ctx, "Set signing policy to "+policy, func(root string, actor mgmt.Actor) error {
+		return commonSetSigningPolicy(root, actor, policy)
+	})
+}
+
+func (g *GitVault) TrustSigningKey(ctx context.Context, name, publicKey string) error {
+	return g.runInVaultTx(ctx, "Trust signing key "+name, func(root string, actor mgmt.Actor) error {
+		return commonTrustSigningKey(root, actor, name, publicKey)
+	})
+}
+
+func (g *GitVault) UntrustSigningKey(ctx context.Context, name string) error {
+	return g.runInVaultTx(ctx, "Remove trusted signing key "+name, func(root string, actor mgmt.Actor) error {
+		return commonUntrustSigningKey(root, actor, name)
+	})
+}
+
+// AddSignedAsset publishes and signs a version in one commit.
+func (g *GitVault) AddSignedAsset(ctx context.Context, asset *lockfile.Asset, zipData []byte, key *signing.PrivateKey) (sig *signing.Signature, err error) {
+	err = g.addAsset(ctx, asset, func() error {
+		actor, err := mgmt.CurrentGitActor(ctx, g.repoPath)
+		if err != nil {
+			return err
+		}
+		sig, err = commonAddSignedAsset(g.repoPath, actor, asset, zipData, key)
+		return err
+	})
+	return sig, err
+}
+
+func (g *GitVault) SignAsset(ctx context.Context, name, version string, key *signing.PrivateKey) (sig *signing.Signature, err error) {
+	msg := fmt.Sprintf("Sign %s@%s", name, version)
+	err = g.runInVaultTx(ctx, msg, func(root string, actor mgmt.Actor) error {
+		sig, err = commonSignAsset(root, actor, name, version, key)
+		return err
+	})
+	return sig, err
+}
+
+func (g *GitVault) AssetSignature(ctx context.Context, name, version string) ([]byte, error) {
+	if err := g.cloneOrUpdate(ctx); err != nil {
+		return nil, err
+	}
+	return commonAssetSignature(g.repoPath, name, version)
+}
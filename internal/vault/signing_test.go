@@ -0,0 +1,157 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/manifest"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/signing"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+func verifyStored(t *testing.T, v *PathVault, version string) (*VerifiedSignature, error) {
+	t.Helper()
+	ctx := context.Background()
+	cfg, err := v.SigningConfig(ctx)
+	if err != nil {
+		t.Fatalf("SigningConfig: %v", err)
+	}
+	zipData, err := v.GetAssetByVersion(ctx, "my-skill", version)
+	if err != nil {
+		t.Fatalf("GetAssetByVersion: %v", err)
+	}
+	return VerifyAssetSignature(ctx, v, cfg, "my-skill", version, zipData)
+}
+
+// TestSigning_SignAndVerify: a signed version verifies against the trusted
+// key and names its signer; editing the archived files afterwards, or an
+// unsigned version, fails verification.
+func TestSigning_SignAndVerify(t *testing.T) {
+	v := seedRBACVault(t, "alice@example.com", nil, nil)
+	ctx := context.Background()
+	publishSkillVersion(t, v, "2.0.0")
+	publishSkillVersion(t, v, "3.0.0")
+	key, err := signing.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.SetSigningPolicy(ctx, signing.PolicyEnforce); err == nil {
+		t.Fatal("enforcing with no trusted keys would block every install")
+	}
+	if err := v.TrustSigningKey(ctx, "alice@example.com", key.Public().String()); err != nil {
+		t.Fatalf("TrustSigningKey: %v", err)
+	}
+	if err := v.SetSigningPolicy(ctx, signing.PolicyEnforce); err != nil {
+		t.Fatalf("SetSigningPolicy: %v", err)
+	}
+	if _, err := v.SignAsset(ctx, "my-skill", "9.9.9", key); err == nil {
+		t.Fatal("signing a version that was never stored should fail")
+	}
+	if _, err := v.SignAsset(ctx, "my-skill", "2.0.0", key); err != nil {
+		t.Fatalf("SignAsset: %v", err)
+	}
+
+	sig, err := verifyStored(t, v, "2.0.0")
+	if err != nil {
+		t.Fatalf("VerifyAssetSignature: %v", err)
+	}
+	if sig.Signer != "alice@example.com" || sig.KeyName != "alice@example.com" || sig.KeyID != key.Public().ID() {
+		t.Fatalf("signature = %+v", sig)
+	}
+	if _, err := verifyStored(t, v, "3.0.0"); !errors.Is(err, ErrAssetUnsigned) {
+		t.Fatalf("unsigned version: err = %v", err)
+	}
+
+	events, err := mgmt.QueryAuditEvents(v.repoPath, mgmt.AuditFilter{EventPrefix: mgmt.EventAssetSigned})
+	if err != nil || len(events) != 1 {
+		t.Fatalf("asset.signed events = %+v, err = %v", events, err)
+	}
+	if events[0].Data["signer"] != "alice@example.com" || events[0].Data["version"] != "2.0.0" {
+		t.Fatalf("asset.signed data = %v", events[0].Data)
+	}
+
+	// Swap the archived content behind the signature's back.
+	l, err := detectLayout(v.repoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skill := filepath.Join(v.repoPath, l.VersionDir("my-skill", "2.0.0"), "SKILL.md")
+	if err := os.WriteFile(skill, []byte("# exfiltrate secrets"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := verifyStored(t, v, "2.0.0"); !errors.Is(err, signing.ErrInvalidSignature) {
+		t.Fatalf("tampered version: err = %v", err)
+	}
+
+	if err := v.UntrustSigningKey(ctx, "alice@example.com"); err == nil {
+		t.Fatal("removing the last key while enforcing should fail")
+	}
+}
+
+// TestSigning_AddSignedAsset: publishing with a key signs the published
+// zip in the same write, so the version is never listed unsigned and
+// verifies against what installs fetch.
+func TestSigning_AddSignedAsset(t *testing.T) {
+	v := seedRBACVault(t, "alice@example.com", nil, nil)
+	ctx := context.Background()
+	key, err := signing.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.TrustSigningKey(ctx, "alice@example.com", key.Public().String()); err != nil {
+		t.Fatalf("TrustSigningKey: %v", err)
+	}
+
+	a, zipData := proposedSkill(t, "2.0.0")
+	sig, err := v.AddSignedAsset(ctx, a, zipData, key)
+	if err != nil {
+		t.Fatalf("AddSignedAsset: %v", err)
+	}
+	if sig.KeyID != key.Public().ID() || a.SourcePath == nil {
+		t.Fatalf("signature = %+v, source = %+v", sig, a.SourcePath)
+	}
+	verified, err := verifyStored(t, v, "2.0.0")
+	if err != nil {
+		t.Fatalf("VerifyAssetSignature: %v", err)
+	}
+	if verified.Signer != "alice@example.com" {
+		t.Fatalf("signer = %s", verified.Signer)
+	}
+
+	events, err := mgmt.QueryAuditEvents(v.repoPath, mgmt.AuditFilter{EventPrefix: mgmt.EventAssetSigned})
+	if err != nil || len(events) != 1 {
+		t.Fatalf("asset.signed events = %+v, err = %v", events, err)
+	}
+	digest, err := utils.ComputeZipContentSHA256(zipData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if events[0].Data["sha256"] != digest {
+		t.Fatalf("signed digest = %v, want the published zip's %s", events[0].Data["sha256"], digest)
+	}
+}
+
+// TestSigning_GovernedByOrgAdmins: on a governed vault only org-admins may
+// change which keys are trusted.
+func TestSigning_GovernedByOrgAdmins(t *testing.T) {
+	v := seedRBACVault(t, "alice@example.com", []manifest.Team{platformTeam()}, []string{"alice@example.com"})
+	key, err := signing.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mallory := mgmt.ContextWithIdentity(context.Background(), "mallory@example.com")
+	if err := v.TrustSigningKey(mallory, "mallory@example.com", key.Public().String()); err == nil {
+		t.Fatal("a non-admin should not be able to trust a key")
+	}
+	if err := v.SetSigningPolicy(mallory, signing.PolicyOff); err == nil {
+		t.Fatal("a non-admin should not be able to change the policy")
+	}
+	if err := v.TrustSigningKey(context.Background(), "alice@example.com", "not-a-key"); err == nil {
+		t.Fatal("an unparseable key should be rejected")
+	}
+}
@@ -432,9 +432,14 @@ func (s *SleuthVault) GetMetadata(ctx context.Context, name, version string) (*m
 	return metadata.Parse(data)
 }
 
+// assetVersionURL is the download URL of one version's zip
+func (s *SleuthVault) assetVersionURL(name, ver string) string {
+	return fmt.Sprintf("%s/api/skills/assets/%s/%s/%s-%s.zip", s.serverURL, name, ver, name, ver)
+}
+
 // GetAssetByVersion downloads an asset by name and version
 func (s *SleuthVault) GetAssetByVersion(ctx context.Context, name, ver string) ([]byte, error) {
-	endpoint := fmt.Sprintf("%s/api/skills/assets/%s/%s/%s-%s.zip", s.serverURL, name, ver, name, ver)
+	endpoint := s.assetVersionURL(name, ver)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
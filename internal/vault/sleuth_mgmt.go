@@ -1572,6 +1572,19 @@ func (s *SleuthVault) RecordUsageEvents(ctx context.Context, events []mgmt.Usage
 			if ev.Actor != "" {
 				payload["actor"] = ev.Actor
 			}
+			for key, value := range map[string]string{
+				"client":     ev.Client,
+				"repo_url":   ev.RepoURL,
+				"session_id": ev.SessionID,
+				"trigger":    ev.Trigger,
+			} {
+				if value != "" {
+					payload[key] = value
+				}
+			}
+			if ev.ContextTokens > 0 {
+				payload["context_tokens"] = ev.ContextTokens
+			}
 			line, err := json.Marshal(payload)
 			if err != nil {
 				return err
@@ -1645,6 +1658,11 @@ func (s *SleuthVault) ReadUsageEvents(ctx context.Context, filter mgmt.UsageFilt
 		}
 		after = conn.PageInfo.EndCursor
 	}
+	// The export has no client, repository or trigger arguments; apply
+	// those parts of the filter here so they never silently widen a query.
+	if filter.Client != "" || filter.RepoURL != "" || filter.Trigger != "" {
+		events = mgmt.FilterUsageEvents(events, filter)
+	}
 	return events, nil
 }
 
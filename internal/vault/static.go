@@ -0,0 +1,228 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/sleuth-io/sx/v2/internal/asset"
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+	"github.com/sleuth-io/sx/v2/internal/manifest"
+	"github.com/sleuth-io/sx/v2/internal/mgmt"
+	"github.com/sleuth-io/sx/v2/internal/utils"
+)
+
+// Files of a static vault, as written by ExportStatic and read by HTTPVault.
+const (
+	StaticLockFile  = "sx.lock"
+	StaticIndexFile = "index.json"
+
+	staticIndexSchemaVersion = 1
+)
+
+// StaticIndex is the index.json of a static vault: every exported asset
+// with its versions, so a read-only HTTP vault can list and fetch them
+// without a directory listing.
+type StaticIndex struct {
+	SchemaVersion int                `json:"schema_version"`
+	GeneratedAt   time.Time          `json:"generated_at"`
+	Assets        []StaticIndexAsset `json:"assets"`
+}
+
+// StaticIndexAsset is one asset in a static vault index
+type StaticIndexAsset struct {
+	Name        string               `json:"name"`
+	Type        string               `json:"type"`
+	Description string               `json:"description,omitempty"`
+	Versions    []StaticIndexVersion `json:"versions"`
+}
+
+// StaticIndexVersion is one downloadable asset version. URL is relative
+// to the vault root.
+type StaticIndexVersion struct {
+	Version string `json:"version"`
+	URL     string `json:"url"`
+	SHA256  string `json:"sha256"`
+	Size    int64  `json:"size"`
+}
+
+// find returns the named asset, or nil
+func (idx *StaticIndex) find(name string) *StaticIndexAsset {
+	for i := range idx.Assets {
+		if idx.Assets[i].Name == name {
+			return &idx.Assets[i]
+		}
+	}
+	return nil
+}
+
+// find returns the given version, or nil
+func (a *StaticIndexAsset) find(version string) *StaticIndexVersion {
+	for i := range a.Versions {
+		if a.Versions[i].Version == version {
+			return &a.Versions[i]
+		}
+	}
+	return nil
+}
+
+// StaticExportResult summarizes an ExportStatic run
+type StaticExportResult struct {
+	Assets   int
+	Versions int
+	// Dropped counts assets left out of sx.lock because every install
+	// scope was team-, user-, or bot-specific.
+	Dropped int
+}
+
+// rootFileReader is implemented by file-backed vaults (path, git, s3, oci)
+type rootFileReader interface {
+	ReadRootFiles(ctx context.Context, names []string) (map[string][]byte, error)
+}
+
+// ExportStatic renders a file-backed vault into dir as a static vault that
+// any web server can host:
+//
+//	sx.lock                      the manifest resolved to a lock file
+//	index.json                   assets and versions (see StaticIndex)
+//	assets/{name}/{version}.zip  one zip per version
+//
+// The lock file is resolved without an identity: org-wide, repo, and path
+// installs carry over; team, user, and bot installs need an identity the
+// static host can't check, so they are left out. Every lock entry points
+// at its zip by relative URL with a sha256 hash.
+func ExportStatic(ctx context.Context, v Vault, dir string) (*StaticExportResult, error) {
+	reader, ok := v.(rootFileReader)
+	if !ok {
+		return nil, fmt.Errorf("%w: only path, git, s3, and oci vaults can be exported", ErrNotImplemented)
+	}
+	files, err := reader.ReadRootFiles(ctx, []string{manifest.FileName})
+	if err != nil {
+		return nil, err
+	}
+	data, ok := files[manifest.FileName]
+	if !ok {
+		return nil, ErrLockFileNotFound
+	}
+	m, err := manifest.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	result := &StaticExportResult{}
+	index := &StaticIndex{SchemaVersion: staticIndexSchemaVersion, GeneratedAt: time.Now().UTC()}
+
+	// Stored versions first, so the index can serve version history.
+	listed, err := v.ListAssets(ctx, ListAssetsOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range listed.Assets {
+		entry := StaticIndexAsset{Name: summary.Name, Type: summary.Type.Key, Description: summary.Description}
+		versions, err := v.GetVersionList(ctx, summary.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list versions of %s: %w", summary.Name, err)
+		}
+		for _, version := range versions {
+			zipData, err := v.GetAssetByVersion(ctx, summary.Name, version)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s@%s: %w", summary.Name, version, err)
+			}
+			ver, err := writeStaticZip(dir, summary.Name, version, zipData)
+			if err != nil {
+				return nil, err
+			}
+			entry.Versions = append(entry.Versions, ver)
+		}
+		index.Assets = append(index.Assets, entry)
+	}
+
+	// Then the lock file. Entries whose version wasn't stored (source-http
+	// or source-git rows) are fetched and added to the index.
+	lf := manifest.Resolve(m, mgmt.Actor{})
+	for _, a := range m.Assets {
+		if a.Type.Key != asset.TypeAppPlugin.Key && !slices.ContainsFunc(lf.Assets, func(r lockfile.Asset) bool { return r.Name == a.Name }) {
+			result.Dropped++
+		}
+	}
+	resolved := make([]lockfile.Asset, 0, len(lf.Assets))
+	for _, a := range lf.Assets {
+		entry := index.find(a.Name)
+		if entry == nil {
+			index.Assets = append(index.Assets, StaticIndexAsset{Name: a.Name, Type: a.Type.Key})
+			entry = &index.Assets[len(index.Assets)-1]
+		}
+		ver := entry.find(a.Version)
+		if ver == nil {
+			zipData, err := v.GetAsset(ctx, &a)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch %s@%s: %w", a.Name, a.Version, err)
+			}
+			written, err := writeStaticZip(dir, a.Name, a.Version, zipData)
+			if err != nil {
+				return nil, err
+			}
+			entry.Versions = append(entry.Versions, written)
+			ver = &entry.Versions[len(entry.Versions)-1]
+		}
+		a.SourcePath, a.SourceGit = nil, nil
+		a.SourceHTTP = &lockfile.SourceHTTP{
+			URL:    ver.URL,
+			Hashes: map[string]string{"sha256": ver.SHA256},
+			Size:   ver.Size,
+		}
+		resolved = append(resolved, a)
+	}
+	lf.Assets = resolved
+
+	for _, entry := range index.Assets {
+		result.Versions += len(entry.Versions)
+	}
+	result.Assets = len(index.Assets)
+
+	lockData, err := lockfile.Marshal(lf)
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.WriteFileAtomic(filepath.Join(dir, StaticLockFile), lockData, 0644); err != nil {
+		return nil, err
+	}
+	indexData, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := utils.WriteFileAtomic(filepath.Join(dir, StaticIndexFile), append(indexData, '\n'), 0644); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// writeStaticZip writes one version's zip and returns its index entry
+func writeStaticZip(dir, name, version string, zipData []byte) (StaticIndexVersion, error) {
+	rel := "assets/" + url.PathEscape(name) + "/" + url.PathEscape(version) + ".zip"
+	p := filepath.Join(dir, "assets", name, version+".zip")
+	if !filepath.IsLocal(filepath.Join("assets", name, version+".zip")) {
+		return StaticIndexVersion{}, errors.New("invalid asset name or version: " + name + "@" + version)
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return StaticIndexVersion{}, err
+	}
+	if err := utils.WriteFileAtomic(p, zipData, 0644); err != nil {
+		return StaticIndexVersion{}, err
+	}
+	return StaticIndexVersion{
+		Version: version,
+		URL:     rel,
+		SHA256:  sha256Hex(zipData),
+		Size:    int64(len(zipData)),
+	}, nil
+}
@@ -0,0 +1,136 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/sx/v2/internal/lockfile"
+)
+
+// serveStatic serves dir the way a plain web server would, with a
+// content-hash ETag so If-None-Match gets a 304.
+func serveStatic(t *testing.T, dir string) *httptest.Server {
+	t.Helper()
+	files := http.FileServer(http.Dir(dir))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(r.URL.Path))); err == nil {
+			w.Header().Set("ETag", `"`+sha256Hex(data)+`"`)
+		}
+		files.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestExportStatic_ServedAsHTTPVault(t *testing.T) {
+	ctx := context.Background()
+	src, err := NewPathVault("file://" + t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, zipData := proposedSkill(t, "1.0")
+	if err := src.AddAsset(ctx, a, zipData); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.SetInstallations(ctx, a, ""); err != nil {
+		t.Fatal(err)
+	}
+	publishSkillVersion(t, src, "2.0")
+
+	site := t.TempDir()
+	result, err := ExportStatic(ctx, src, site)
+	if err != nil {
+		t.Fatalf("ExportStatic: %v", err)
+	}
+	if result.Assets != 1 || result.Versions != 2 || result.Dropped != 0 {
+		t.Errorf("result = %+v, want 1 asset, 2 versions, none dropped", result)
+	}
+
+	srv := serveStatic(t, site)
+	v, err := NewHTTPVault(srv.URL+"/", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, etag, notModified, err := v.GetLockFile(ctx, "")
+	if err != nil || etag == "" || notModified {
+		t.Fatalf("GetLockFile: etag=%q notModified=%v err=%v", etag, notModified, err)
+	}
+	lf, err := lockfile.Parse(data)
+	if err != nil || len(lf.Assets) != 1 {
+		t.Fatalf("lock file = %+v, %v; want one asset", lf, err)
+	}
+	got := lf.Assets[0]
+	if got.Version != "2.0" || got.SourceHTTP == nil || !strings.HasPrefix(got.SourceHTTP.URL, srv.URL+"/assets/") {
+		t.Fatalf("lock entry = %+v, want my-skill 2.0 at an absolute URL", got)
+	}
+	if _, err := v.GetAsset(ctx, &got); err != nil {
+		t.Errorf("GetAsset: %v", err)
+	}
+	if _, again, notModified, err := v.GetLockFile(ctx, etag); err != nil || !notModified || again != etag {
+		t.Errorf("unchanged site: etag=%q notModified=%v err=%v; want notModified", again, notModified, err)
+	}
+
+	versions, err := v.GetVersionList(ctx, "my-skill")
+	if err != nil || !slices.Equal(versions, []string{"1.0", "2.0"}) {
+		t.Errorf("GetVersionList = %v, %v; want [1.0 2.0]", versions, err)
+	}
+	if _, err := v.GetAssetByVersion(ctx, "my-skill", "1.0"); err != nil {
+		t.Errorf("GetAssetByVersion 1.0: %v", err)
+	}
+	listed, err := v.ListAssets(ctx, ListAssetsOptions{})
+	if err != nil || len(listed.Assets) != 1 || listed.Assets[0].LatestVersion != "2.0" {
+		t.Errorf("ListAssets = %+v, %v", listed, err)
+	}
+
+	if err := v.AddAsset(ctx, a, zipData); !errors.Is(err, ErrNotImplemented) {
+		t.Errorf("AddAsset err = %v, want ErrNotImplemented", err)
+	}
+}
+
+// A tampered zip must fail the sha256 pinned in sx.lock.
+func TestHTTPVault_RejectsTamperedAsset(t *testing.T) {
+	ctx := context.Background()
+	src, err := NewPathVault("file://" + t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, zipData := proposedSkill(t, "1.0")
+	if err := src.AddAsset(ctx, a, zipData); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.SetInstallations(ctx, a, ""); err != nil {
+		t.Fatal(err)
+	}
+	site := t.TempDir()
+	if _, err := ExportStatic(ctx, src, site); err != nil {
+		t.Fatal(err)
+	}
+	_, other := proposedSkill(t, "9.9")
+	if err := os.WriteFile(filepath.Join(site, "assets", "my-skill", "1.0.zip"), other, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := NewHTTPVault(serveStatic(t, site).URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _, _, err := v.GetLockFile(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lf, err := lockfile.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.GetAsset(ctx, &lf.Assets[0]); err == nil {
+		t.Error("GetAsset accepted a zip that doesn't match its pinned hash")
+	}
+}
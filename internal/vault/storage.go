@@ -130,6 +130,9 @@ func deleteAssetStorage(vaultRoot string, l layout.Layout, name, ver string) err
 	if err := os.RemoveAll(filepath.Join(vaultRoot, l.VersionDir(name, ver))); err != nil {
 		return err
 	}
+	if err := os.Remove(filepath.Join(vaultRoot, l.SignaturePath(name, ver))); err != nil && !os.IsNotExist(err) {
+		return err
+	}
 	listPath := filepath.Join(vaultRoot, l.VersionListPath(name))
 	if err := removeFromVersionListFile(listPath, ver); err != nil {
 		return err
@@ -277,6 +277,49 @@ func FilterByMultiple(versions []string, specifiers []*Specifier) ([]string, err
 	return result, nil
 }
 
+// IsRange reports whether spec is a semver range ("^1.2", ">=2.0 <3")
+// rather than one exact version. A bare version is exact: "3" means 3,
+// not 3.x.
+func IsRange(spec string) bool {
+	if spec == "" {
+		return false
+	}
+	_, err := semver.NewVersion(spec)
+	return err != nil
+}
+
+// ParseRange parses spec as a semver range
+func ParseRange(spec string) (*semver.Constraints, error) {
+	c, err := semver.NewConstraint(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version range %q: %w", spec, err)
+	}
+	return c, nil
+}
+
+// Satisfies reports whether v satisfies spec: an exact version or a
+// semver range. An empty spec matches anything; an unparsable version or
+// spec matches only by string equality. Exact versions are compared
+// directly, since semver reads a partial version such as "3" as 3.x.
+func Satisfies(spec, v string) bool {
+	if spec == "" || spec == v {
+		return true
+	}
+	parsed, err := semver.NewVersion(v)
+	if err != nil {
+		return false
+	}
+	if !IsRange(spec) {
+		want, _ := semver.NewVersion(spec)
+		return want.Equal(parsed)
+	}
+	c, err := ParseRange(spec)
+	if err != nil {
+		return false
+	}
+	return c.Check(parsed)
+}
+
 // Sort sorts a list of version strings in ascending order (oldest first) using semantic versioning rules.
 // Invalid versions are placed at the end in their original order.
 func Sort(versions []string) []string {